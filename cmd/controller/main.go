@@ -49,6 +49,7 @@ var (
 	buildGCSFetcherImage     = flag.String("build-gcs-fetcher-image", "", "The container image containing our GCS fetcher binary.")
 	prImage                  = flag.String("pr-image", "", "The container image containing our PR binary.")
 	imageDigestExporterImage = flag.String("imagedigest-exporter-image", "", "The container image containing our image digest exporter binary.")
+	sidecarLogResultsImage   = flag.String("sidecarlogresults-image", "", "The container image containing the binary for accessing results.")
 	namespace                = flag.String("namespace", corev1.NamespaceAll, "Namespace to restrict informer to. Optional, defaults to all namespaces.")
 	versionGiven             = flag.String("version", "devel", "Version of Tekton running")
 	threadsPerController     = flag.Int("threads-per-controller", controller.DefaultThreadsPerController, "Threads (goroutines) to create per controller")
@@ -71,6 +72,7 @@ func main() {
 		BuildGCSFetcherImage:     *buildGCSFetcherImage,
 		PRImage:                  *prImage,
 		ImageDigestExporterImage: *imageDigestExporterImage,
+		SidecarLogResultsImage:   *sidecarLogResultsImage,
 	}
 	if err := images.Validate(); err != nil {
 		log.Fatal(err)
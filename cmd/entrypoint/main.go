@@ -27,23 +27,39 @@ import (
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/credentials"
-	"github.com/tektoncd/pipeline/pkg/credentials/dockercreds"
-	"github.com/tektoncd/pipeline/pkg/credentials/gitcreds"
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
 	"github.com/tektoncd/pipeline/pkg/termination"
+
+	// Register the credential builders.
+	_ "github.com/tektoncd/pipeline/pkg/credentials/dockercreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/filecreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/gitcreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/mavencreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/netrccreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/npmcreds"
 )
 
 var (
-	ep              = flag.String("entrypoint", "", "Original specified entrypoint to execute")
-	waitFiles       = flag.String("wait_file", "", "Comma-separated list of paths to wait for")
-	waitFileContent = flag.Bool("wait_file_content", false, "If specified, expect wait_file to have content")
-	postFile        = flag.String("post_file", "", "If specified, file to write upon completion")
-	terminationPath = flag.String("termination_path", "/tekton/termination", "If specified, file to write upon termination")
-	results         = flag.String("results", "", "If specified, list of file names that might contain task results")
-	timeout         = flag.Duration("timeout", time.Duration(0), "If specified, sets timeout for step")
+	ep                  = flag.String("entrypoint", "", "Original specified entrypoint to execute")
+	waitFiles           = flag.String("wait_file", "", "Comma-separated list of paths to wait for")
+	waitFileContent     = flag.Bool("wait_file_content", false, "If specified, expect wait_file to have content")
+	postFile            = flag.String("post_file", "", "If specified, file to write upon completion")
+	terminationPath     = flag.String("termination_path", "/tekton/termination", "If specified, file to write upon termination")
+	results             = flag.String("results", "", "If specified, list of file names that might contain task results")
+	timeout             = flag.Duration("timeout", time.Duration(0), "If specified, sets timeout for step")
+	onError             = flag.String("on_error", "", "Set to \"continue\" to ignore an error and continue when a container terminates with a non-zero exit code, or \"stopAndFail\" to stop executing the remaining steps")
+	breakpointOnFailure = flag.Bool("breakpoint_on_failure", false, "If specified, pause the step after it fails until it is resumed with the debug scripts")
+	checkBreakpoint     = flag.String("check_breakpoint", "", "If specified, only check whether the step is paused at the given breakpoint file, and exit with a non-zero code otherwise")
+	dropNetworkingFlag  = flag.Bool("drop_networking", false, "If specified, execute the step in new namespaces without network access")
+	stepResults         = flag.String("step_results", "", "If specified, list of file names that might contain results of the step")
+	stepResultsDir      = flag.String("step_results_dir", "", "If specified, directory in which the step writes its results")
+	reportResourceUsage = flag.Bool("report_resource_usage", false, "If specified, report the resource usage of the step in the termination message, if there is room left for it")
 )
 
-const defaultWaitPollingInterval = time.Second
+const (
+	defaultWaitPollingInterval = time.Second
+	debugInfoDir               = "/tekton/debug/info"
+)
 
 func cp(src, dst string) error {
 	s, err := os.Open(src)
@@ -65,10 +81,9 @@ func cp(src, dst string) error {
 }
 
 func main() {
-	// Add credential flags originally introduced with our legacy credentials helper
-	// image (creds-init).
-	gitcreds.AddFlags(flag.CommandLine)
-	dockercreds.AddFlags(flag.CommandLine)
+	// Add the flags of the credential builders, originally introduced with our
+	// legacy credentials helper image (creds-init).
+	credentials.AddFlags(flag.CommandLine)
 
 	flag.Parse()
 
@@ -85,34 +100,69 @@ func main() {
 		return
 	}
 
+	// If invoked in "decode-scripts mode" (`entrypoint decode-scripts
+	// <path>=<base64>...`), write the decoded scripts to their path. If
+	// invoked in "mkdirs mode" (`entrypoint mkdirs <dir>...`), create the
+	// directories. These are used by init containers running the entrypoint
+	// image, which contains no shell, and never by the steps, which are
+	// always given an entrypoint.
+	if *ep == "" && len(flag.Args()) > 0 {
+		switch flag.Args()[0] {
+		case "decode-scripts":
+			if err := decodeScripts(flag.Args()[1:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "mkdirs":
+			if err := mkdirs(flag.Args()[1:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
+
+	// If invoked in "check breakpoint mode", e.g. by the readiness probe of
+	// a step, exit successfully only if the step is paused at the breakpoint.
+	if *checkBreakpoint != "" {
+		if _, err := os.Stat(*checkBreakpoint); err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Copy credentials we're expecting from the legacy credentials helper (creds-init)
 	// from secret volume mounts to /tekton/creds. This is done to support the expansion
 	// of a variable, $(credentials.path), that resolves to a single place with all the
 	// stored credentials.
-	builders := []credentials.Builder{dockercreds.NewBuilder(), gitcreds.NewBuilder()}
-	for _, c := range builders {
+	for _, c := range credentials.Builders() {
 		if err := c.Write("/tekton/creds"); err != nil {
 			log.Printf("Error initializing credentials: %s", err)
 		}
 	}
 
 	e := entrypoint.Entrypointer{
-		Entrypoint:      *ep,
-		WaitFiles:       strings.Split(*waitFiles, ","),
-		WaitFileContent: *waitFileContent,
-		PostFile:        *postFile,
-		TerminationPath: *terminationPath,
-		Args:            flag.Args(),
-		Waiter:          &realWaiter{waitPollingInterval: defaultWaitPollingInterval},
-		Runner:          &realRunner{},
-		PostWriter:      &realPostWriter{},
-		Results:         strings.Split(*results, ","),
-		Timeout:         timeout,
+		Entrypoint:          *ep,
+		WaitFiles:           strings.Split(*waitFiles, ","),
+		WaitFileContent:     *waitFileContent,
+		PostFile:            *postFile,
+		TerminationPath:     *terminationPath,
+		Args:                flag.Args(),
+		Waiter:              &realWaiter{waitPollingInterval: defaultWaitPollingInterval},
+		Runner:              &realRunner{dropNetworking: *dropNetworkingFlag},
+		PostWriter:          &realPostWriter{},
+		Results:             strings.Split(*results, ","),
+		Timeout:             timeout,
+		OnError:             *onError,
+		BreakpointOnFailure: *breakpointOnFailure,
+		DebugInfoDir:        debugInfoDir,
+		StepResults:         strings.Split(*stepResults, ","),
+		StepResultsDir:      *stepResultsDir,
+		ReportResourceUsage: *reportResourceUsage,
 	}
 
 	// Copy any creds injected by the controller into the $HOME directory of the current
-	// user so that they're discoverable by git / ssh.
-	if err := credentials.CopyCredsToHome(credentials.CredsInitCredentials); err != nil {
+	// user so that they're discoverable by git / ssh and the other tools.
+	if err := credentials.CopyCredsToHome(credentials.CredentialPaths()); err != nil {
 		log.Printf("non-fatal error copying credentials: %q", err)
 	}
 
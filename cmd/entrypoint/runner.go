@@ -5,8 +5,11 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
 )
 
@@ -16,9 +19,15 @@ import (
 // realRunner actually runs commands.
 type realRunner struct {
 	signals chan os.Signal
+	// dropNetworking executes the command in new namespaces without network access
+	dropNetworking bool
+	// resourceUsage is the resource usage of the last command run, as
+	// internal results
+	resourceUsage []v1beta1.PipelineResourceResult
 }
 
 var _ entrypoint.Runner = (*realRunner)(nil)
+var _ entrypoint.ResourceUsageReporter = (*realRunner)(nil)
 
 func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	if len(args) == 0 {
@@ -40,8 +49,12 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	// dedicated PID group used to forward signals to
 	// main process and all children
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if rr.dropNetworking {
+		dropNetworking(cmd)
+	}
 
 	// Start defined command
+	start := time.Now()
 	if err := cmd.Start(); err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
 			return context.DeadlineExceeded
@@ -60,7 +73,11 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	}()
 
 	// Wait for command to exit
-	if err := cmd.Wait(); err != nil {
+	err := cmd.Wait()
+	if cmd.ProcessState != nil {
+		rr.resourceUsage = resourceUsage(cmd.ProcessState, time.Since(start))
+	}
+	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
 			return context.DeadlineExceeded
 		}
@@ -69,3 +86,37 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 
 	return nil
 }
+
+// ResourceUsage returns the resource usage of the last command run.
+func (rr *realRunner) ResourceUsage() []v1beta1.PipelineResourceResult {
+	return rr.resourceUsage
+}
+
+// resourceUsage returns the resource usage of the exited command, as internal
+// results. The usage includes the one of the descendants of the command which
+// it waited for.
+func resourceUsage(state *os.ProcessState, wallTime time.Duration) []v1beta1.PipelineResourceResult {
+	rusage, ok := state.SysUsage().(*syscall.Rusage)
+	if !ok {
+		return nil
+	}
+	// On Linux, the maximum resident set size is reported in kilobytes.
+	maxRSS := int64(rusage.Maxrss) * 1024
+	return []v1beta1.PipelineResourceResult{{
+		Key:        "MaxRSS",
+		Value:      strconv.FormatInt(maxRSS, 10),
+		ResultType: v1beta1.InternalTektonResultType,
+	}, {
+		Key:        "UserCPUTime",
+		Value:      state.UserTime().String(),
+		ResultType: v1beta1.InternalTektonResultType,
+	}, {
+		Key:        "SystemCPUTime",
+		Value:      state.SystemTime().String(),
+		ResultType: v1beta1.InternalTektonResultType,
+	}, {
+		Key:        "WallTime",
+		Value:      wallTime.String(),
+		ResultType: v1beta1.InternalTektonResultType,
+	}}
+}
@@ -3,9 +3,12 @@ package main
 import (
 	"context"
 	"os"
+	"strconv"
 	"syscall"
 	"testing"
 	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 )
 
 // TestRealRunnerSignalForwarding will artificially put an interrupt signal (SIGINT) in the rr.signals chan.
@@ -37,3 +40,25 @@ func TestRealRunnerTimeout(t *testing.T) {
 		t.Fatalf("step didn't timeout")
 	}
 }
+
+func TestRealRunnerResourceUsage(t *testing.T) {
+	rr := realRunner{}
+	if err := rr.Run(context.Background(), "sh", "-c", "exit 1"); err == nil {
+		t.Fatal("expected the command to fail")
+	}
+	got := map[string]string{}
+	for _, r := range rr.ResourceUsage() {
+		if r.ResultType != v1beta1.InternalTektonResultType {
+			t.Errorf("expected result %q to be internal, got type %q", r.Key, r.ResultType)
+		}
+		got[r.Key] = r.Value
+	}
+	if maxRSS, err := strconv.ParseInt(got["MaxRSS"], 10, 64); err != nil || maxRSS <= 0 {
+		t.Errorf("expected a positive MaxRSS, got %q", got["MaxRSS"])
+	}
+	for _, key := range []string{"UserCPUTime", "SystemCPUTime", "WallTime"} {
+		if _, err := time.ParseDuration(got[key]); err != nil {
+			t.Errorf("expected %s to be a duration, got %q", key, got[key])
+		}
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// decodeScripts writes the scripts given as path=base64 args to their path,
+// as executable files. This is used to place the scripts of the steps and
+// sidecars, without requiring a shell to exist in the image placing them.
+func decodeScripts(args []string) error {
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("expected a script of the form path=base64, got: %q", arg)
+		}
+		script, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil {
+			return fmt.Errorf("decoding script %s: %w", parts[0], err)
+		}
+		if err := ioutil.WriteFile(parts[0], script, 0755); err != nil {
+			return err
+		}
+		// The mode given to WriteFile is subject to the umask, which could
+		// make the script non-executable.
+		if err := os.Chmod(parts[0], 0755); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mkdirs creates the given directories along with any necessary parents. This
+// is used to create the working directories of the steps, without requiring
+// the mkdir command to exist in the image creating them.
+func mkdirs(dirs []string) error {
+	for _, dir := range dirs {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDecodeScripts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir() = %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	scripts := map[string]string{
+		filepath.Join(dir, "script-0"): "#!/bin/sh\necho \"$HOME\" << 'EOF'\n",
+		filepath.Join(dir, "script-1"): "#!/usr/bin/env python3\nprint('=')",
+	}
+	var args []string
+	for path, script := range scripts {
+		args = append(args, path+"="+base64.StdEncoding.EncodeToString([]byte(script)))
+	}
+	if err := decodeScripts(args); err != nil {
+		t.Fatalf("decodeScripts() = %v", err)
+	}
+
+	for path, want := range scripts {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ioutil.ReadFile(%s) = %v", path, err)
+		}
+		if string(b) != want {
+			t.Errorf("got: %q, wanted: %q", string(b), want)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("os.Stat(%s) = %v", path, err)
+		}
+		if info.Mode().Perm() != 0755 {
+			t.Errorf("%s has mode %v, wanted it executable", path, info.Mode().Perm())
+		}
+	}
+}
+
+func TestDecodeScriptsErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir() = %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, arg := range []string{
+		filepath.Join(dir, "script"),
+		"=ZWNobw==",
+		filepath.Join(dir, "script") + "=not base64",
+	} {
+		if err := decodeScripts([]string{arg}); err == nil {
+			t.Errorf("decodeScripts(%q); got success, wanted error.", arg)
+		}
+	}
+}
+
+func TestMkdirs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir() = %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	dirs := []string{filepath.Join(dir, "a", "b"), filepath.Join(dir, "c")}
+	if err := mkdirs(dirs); err != nil {
+		t.Fatalf("mkdirs() = %v", err)
+	}
+	for _, d := range dirs {
+		if info, err := os.Stat(d); err != nil || !info.IsDir() {
+			t.Errorf("expected directory %s to exist: %v", d, err)
+		}
+	}
+}
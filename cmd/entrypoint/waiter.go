@@ -3,14 +3,19 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
 )
 
-// realWaiter actually waits for files, by polling.
+// realWaiter actually waits for files, by watching the directory they are
+// written to for changes, or by polling if it cannot be watched.
 type realWaiter struct {
 	waitPollingInterval time.Duration
+	// pollOnly disables watching the directory of the waited for file, so
+	// that the waiter only relies on polling.
+	pollOnly bool
 }
 
 var _ entrypoint.Waiter = (*realWaiter)(nil)
@@ -21,6 +26,27 @@ func (rw *realWaiter) setWaitPollingInterval(pollingInterval time.Duration) *rea
 	return rw
 }
 
+// watcher notifies of changes to the entries of a directory.
+type watcher interface {
+	// wait blocks until an entry of the directory changes or the timeout
+	// expires, whichever happens first.
+	wait(timeout time.Duration) error
+	close() error
+}
+
+// pollingWatcher is the watcher used when the directory cannot be watched: it
+// never notifies of changes and always waits for the timeout to expire.
+type pollingWatcher struct{}
+
+func (pollingWatcher) wait(timeout time.Duration) error {
+	time.Sleep(timeout)
+	return nil
+}
+
+func (pollingWatcher) close() error {
+	return nil
+}
+
 // Wait watches a file and returns when either a) the file exists and, if
 // the expectContent argument is true, the file has non-zero size or b) there
 // is an error polling the file.
@@ -30,11 +56,26 @@ func (rw *realWaiter) setWaitPollingInterval(pollingInterval time.Duration) *rea
 //
 // If a file of the same name with a ".err" extension exists then this Wait
 // will end with a skipError.
+//
+// The file is checked every time an entry of its directory changes, which
+// also covers files such as the ones provided by the Downward API that are
+// updated by atomically swapping symlinks. It is also checked every
+// waitPollingInterval in case changes are missed, and only polled if the
+// directory cannot be watched.
 func (rw *realWaiter) Wait(file string, expectContent bool) error {
 	if file == "" {
 		return nil
 	}
-	for ; ; time.Sleep(rw.waitPollingInterval) {
+	var w watcher = pollingWatcher{}
+	if !rw.pollOnly {
+		// The watch is set up before checking the file for the first time,
+		// so that the file cannot be written in between unnoticed.
+		if dw, err := newWatcher(filepath.Dir(file)); err == nil {
+			w = dw
+		}
+	}
+	defer func() { w.close() }()
+	for {
 		if info, err := os.Stat(file); err == nil {
 			if !expectContent || info.Size() > 0 {
 				return nil
@@ -45,6 +86,11 @@ func (rw *realWaiter) Wait(file string, expectContent bool) error {
 		if _, err := os.Stat(file + ".err"); err == nil {
 			return skipError("error file present, bail and skip the step")
 		}
+		if err := w.wait(rw.waitPollingInterval); err != nil {
+			// The watcher is broken, fall back to polling.
+			w.close()
+			w = pollingWatcher{}
+		}
 	}
 }
 
@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// The polling interval is long enough for these tests to fail if the waiter
+// did not notice the changes to the watched directory.
+const testWatchPollingInterval = time.Hour
+
+func TestRealWaiterWaitWatching(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		expectContent bool
+		// downward lays out the directory like a Downward API volume.
+		downward bool
+		// write writes the waited for file, or its error file, to dir.
+		write   func(dir, file string) error
+		wantErr bool
+	}{{
+		name: "file created",
+		write: func(dir, file string) error {
+			return ioutil.WriteFile(file, nil, 0700)
+		},
+	}, {
+		name:          "content written",
+		expectContent: true,
+		write: func(dir, file string) error {
+			return ioutil.WriteFile(file, []byte("😺"), 0700)
+		},
+	}, {
+		name: "error file created",
+		write: func(dir, file string) error {
+			return ioutil.WriteFile(file+".err", nil, 0700)
+		},
+		wantErr: true,
+	}, {
+		// This is how the Downward API updates the files of its volumes.
+		name:          "symlink swapped",
+		expectContent: true,
+		downward:      true,
+		write: func(dir, file string) error {
+			if err := os.Mkdir(filepath.Join(dir, "..2"), 0700); err != nil {
+				return err
+			}
+			if err := ioutil.WriteFile(filepath.Join(dir, "..2", "ready"), []byte("READY"), 0700); err != nil {
+				return err
+			}
+			if err := os.Symlink("..2", filepath.Join(dir, "..data_tmp")); err != nil {
+				return err
+			}
+			return os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data"))
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "real_waiter_test_dir")
+			if err != nil {
+				t.Fatalf("error creating temp dir: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			file := filepath.Join(dir, "file")
+			if tc.downward {
+				// The file of a Downward API volume always exists, but is
+				// empty until the annotation is set.
+				if err := os.Mkdir(filepath.Join(dir, "..1"), 0700); err != nil {
+					t.Fatalf("error creating dir: %v", err)
+				}
+				if err := ioutil.WriteFile(filepath.Join(dir, "..1", "ready"), nil, 0700); err != nil {
+					t.Fatalf("error creating file: %v", err)
+				}
+				if err := os.Symlink("..1", filepath.Join(dir, "..data")); err != nil {
+					t.Fatalf("error creating symlink: %v", err)
+				}
+				file = filepath.Join(dir, "ready")
+				if err := os.Symlink(filepath.Join("..data", "ready"), file); err != nil {
+					t.Fatalf("error creating symlink: %v", err)
+				}
+			}
+
+			rw := realWaiter{}
+			errCh := make(chan error)
+			go func() {
+				errCh <- rw.setWaitPollingInterval(testWatchPollingInterval).Wait(file, tc.expectContent)
+			}()
+			select {
+			case err := <-errCh:
+				t.Fatalf("did not expect Wait() to return before the file was written, got %v", err)
+			case <-time.After(testWaitPollingInterval):
+			}
+			if err := tc.write(dir, file); err != nil {
+				t.Fatalf("error writing file: %v", err)
+			}
+			select {
+			case err := <-errCh:
+				if _, ok := err.(skipError); ok != tc.wantErr {
+					t.Errorf("Wait() = %v, wantErr %t", err, tc.wantErr)
+				} else if !ok && err != nil {
+					t.Errorf("error waiting on file %q: %v", file, err)
+				}
+			case <-time.After(time.Second):
+				t.Errorf("expected Wait() to have noticed the file being written by now")
+			}
+		})
+	}
+}
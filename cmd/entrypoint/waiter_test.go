@@ -17,8 +17,10 @@ limitations under the License.
 package main
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -122,3 +124,80 @@ func TestRealWaiterWaitWithContent(t *testing.T) {
 		t.Errorf("expected Wait() to have detected a non-zero file size by now")
 	}
 }
+
+func TestRealWaiterWaitWithErrorFile(t *testing.T) {
+	tmp, err := ioutil.TempFile("", "real_waiter_test_file")
+	if err != nil {
+		t.Errorf("error creating temp file: %v", err)
+	}
+	os.Remove(tmp.Name())
+	defer os.Remove(tmp.Name() + ".err")
+	rw := realWaiter{}
+	doneCh := make(chan struct{})
+	go func() {
+		err := rw.setWaitPollingInterval(testWaitPollingInterval).Wait(tmp.Name(), false)
+		if _, ok := err.(skipError); !ok {
+			t.Errorf("expected a skipError waiting on tmp file %q, got %v", tmp.Name(), err)
+		}
+		close(doneCh)
+	}()
+	if err := ioutil.WriteFile(tmp.Name()+".err", nil, 0700); err != nil {
+		t.Errorf("error writing error file: %v", err)
+	}
+	select {
+	case <-doneCh:
+		// Success
+	case <-time.After(2 * testWaitPollingInterval):
+		t.Errorf("expected Wait() to have detected the error file by now")
+	}
+}
+
+// benchmarkHandoff measures the latency of handing off between steps: each
+// operation is a round trip between two waiters, each one waiting for the
+// file written by the other.
+func benchmarkHandoff(b *testing.B, rw *realWaiter) {
+	dir, err := ioutil.TempDir("", "real_waiter_bench")
+	if err != nil {
+		b.Fatalf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	ping := func(i int) string { return filepath.Join(dir, fmt.Sprintf("ping-%d", i)) }
+	pong := func(i int) string { return filepath.Join(dir, fmt.Sprintf("pong-%d", i)) }
+
+	errCh := make(chan error, 1)
+	go func() {
+		for i := 0; i < b.N; i++ {
+			if err := rw.Wait(ping(i), false); err != nil {
+				errCh <- err
+				return
+			}
+			if err := ioutil.WriteFile(pong(i), nil, 0700); err != nil {
+				errCh <- err
+				return
+			}
+		}
+		errCh <- nil
+	}()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := ioutil.WriteFile(ping(i), nil, 0700); err != nil {
+			b.Fatalf("error writing file: %v", err)
+		}
+		if err := rw.Wait(pong(i), false); err != nil {
+			b.Fatalf("error waiting on file: %v", err)
+		}
+	}
+	b.StopTimer()
+	if err := <-errCh; err != nil {
+		b.Fatalf("error waiting on file: %v", err)
+	}
+}
+
+func BenchmarkRealWaiterHandoff(b *testing.B) {
+	b.Run("watching", func(b *testing.B) {
+		benchmarkHandoff(b, &realWaiter{waitPollingInterval: defaultWaitPollingInterval})
+	})
+	b.Run("polling", func(b *testing.B) {
+		benchmarkHandoff(b, &realWaiter{waitPollingInterval: defaultWaitPollingInterval, pollOnly: true})
+	})
+}
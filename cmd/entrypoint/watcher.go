@@ -0,0 +1,11 @@
+// +build !linux
+
+package main
+
+import "errors"
+
+// Watching directories is currently only implemented on Linux, the waiter
+// falls back to polling on other platforms.
+func newWatcher(dir string) (watcher, error) {
+	return nil, errors.New("watching directories is only implemented on linux")
+}
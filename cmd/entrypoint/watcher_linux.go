@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// inotifyMask selects the events signaling that an entry of the watched
+// directory may have been created or written to. IN_MOVED_TO notably covers
+// the files of Downward API volumes, which are updated by renaming a symlink.
+const inotifyMask = syscall.IN_CREATE | syscall.IN_MOVED_TO | syscall.IN_MODIFY | syscall.IN_CLOSE_WRITE | syscall.IN_ATTRIB
+
+// inotifyWatcher watches a directory with inotify.
+type inotifyWatcher struct {
+	f   *os.File
+	buf []byte
+}
+
+// newWatcher returns a watcher notifying of changes to the entries of dir.
+func newWatcher(dir string) (watcher, error) {
+	// The inotify instance is non-blocking so that reading it goes through
+	// the runtime poller, which supports read deadlines.
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+	if _, err := syscall.InotifyAddWatch(fd, dir, inotifyMask); err != nil {
+		syscall.Close(fd)
+		return nil, os.NewSyscallError("inotify_add_watch", err)
+	}
+	return &inotifyWatcher{
+		f:   os.NewFile(uintptr(fd), "inotify"),
+		buf: make([]byte, 4096),
+	}, nil
+}
+
+// wait blocks until events are available or the timeout expires. The events
+// themselves are discarded, since the waiter checks the file after any of
+// them.
+func (w *inotifyWatcher) wait(timeout time.Duration) error {
+	if err := w.f.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+	if _, err := w.f.Read(w.buf); err != nil && !os.IsTimeout(err) {
+		return err
+	}
+	return nil
+}
+
+func (w *inotifyWatcher) close() error {
+	return w.f.Close()
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/sidecarlogresults"
+)
+
+var (
+	resultsDir  = flag.String("results_dir", pipeline.DefaultResultPath, "Path to the directory where Task results are written")
+	resultNames = flag.String("result_names", "", "Comma-separated list of the names of the results to print")
+	waitFile    = flag.String("wait_file", "", "Post file written by the last step; results are printed once it, or its .err counterpart, exists")
+)
+
+// The results sidecar prints the Task results found in the results directory
+// to its stdout, one JSON object per line, once the last step is done. The
+// controller then reads them from the logs of this container.
+func main() {
+	flag.Parse()
+	if err := sidecarlogresults.LookForResults(os.Stdout, *waitFile, *resultsDir, strings.Split(*resultNames, ",")); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -8,6 +8,8 @@ require (
 	github.com/cloudevents/sdk-go/v2 v2.1.0
 	github.com/ghodss/yaml v1.0.0
 	github.com/go-openapi/spec v0.19.6
+	github.com/golang/protobuf v1.4.3
+	github.com/google/cel-go v0.6.0
 	github.com/google/go-cmp v0.5.4
 	github.com/google/go-containerregistry v0.2.1
 	github.com/google/uuid v1.1.2
@@ -120,6 +120,11 @@ func PipelineRunCancelled(spec *v1beta1.PipelineRunSpec) {
 	spec.Status = v1beta1.PipelineRunSpecStatusCancelled
 }
 
+// PipelineRunPending sets the status to pending to the PipelineRunSpec.
+func PipelineRunPending(spec *v1beta1.PipelineRunSpec) {
+	spec.Status = v1beta1.PipelineRunSpecStatusPending
+}
+
 // PipelineDeclaredResource adds a resource declaration to the Pipeline Spec,
 // with the specified name and type.
 func PipelineDeclaredResource(name string, t v1beta1.PipelineResourceType) PipelineSpecOp {
@@ -519,6 +524,15 @@ func PipelineRunTimeout(duration time.Duration) PipelineRunSpecOp {
 	}
 }
 
+// PipelineRunTimeouts sets the timeouts of the tasks and finally tasks to the
+// PipelineRunSpec, in place of its timeout.
+func PipelineRunTimeouts(timeouts v1beta1.TimeoutFields) PipelineRunSpecOp {
+	return func(prs *v1beta1.PipelineRunSpec) {
+		prs.Timeout = nil
+		prs.Timeouts = &timeouts
+	}
+}
+
 // PipelineRunNilTimeout sets the timeout to nil on the PipelineRunSpec
 func PipelineRunNilTimeout(prs *v1beta1.PipelineRunSpec) {
 	prs.Timeout = nil
@@ -370,7 +370,7 @@ func TaskRunResult(name, value string) TaskRunStatusOp {
 	return func(s *v1beta1.TaskRunStatus) {
 		s.TaskRunResults = append(s.TaskRunResults, v1beta1.TaskRunResult{
 			Name:  name,
-			Value: value,
+			Value: *v1beta1.NewArrayOrString(value),
 		})
 	}
 }
@@ -579,6 +579,11 @@ func TaskRunCancelled(spec *v1beta1.TaskRunSpec) {
 	spec.Status = v1beta1.TaskRunSpecStatusCancelled
 }
 
+// TaskRunPending sets the status to pending to the TaskRunSpec.
+func TaskRunPending(spec *v1beta1.TaskRunSpec) {
+	spec.Status = v1beta1.TaskRunSpecStatusPending
+}
+
 // TaskRunTaskRef sets the specified Task reference to the TaskRunSpec.
 // Any number of TaskRef modifier can be passed to transform it.
 func TaskRunTaskRef(name string, ops ...TaskRefOp) TaskRunSpecOp {
@@ -727,6 +732,27 @@ func TaskRunPodTemplate(podTemplate *pod.Template) TaskRunSpecOp {
 	}
 }
 
+// TaskRunComputeResources sets the compute resources of the whole Task of the TaskRun
+func TaskRunComputeResources(resources *corev1.ResourceRequirements) TaskRunSpecOp {
+	return func(spec *v1beta1.TaskRunSpec) {
+		spec.ComputeResources = resources
+	}
+}
+
+// TaskRunStepOverrides sets the step overrides of the TaskRun
+func TaskRunStepOverrides(overrides ...v1beta1.TaskRunStepOverride) TaskRunSpecOp {
+	return func(spec *v1beta1.TaskRunSpec) {
+		spec.StepOverrides = overrides
+	}
+}
+
+// TaskRunSidecarOverrides sets the sidecar overrides of the TaskRun
+func TaskRunSidecarOverrides(overrides ...v1beta1.TaskRunSidecarOverride) TaskRunSpecOp {
+	return func(spec *v1beta1.TaskRunSpec) {
+		spec.SidecarOverrides = overrides
+	}
+}
+
 // TaskRunWorkspaceEmptyDir adds a workspace binding to an empty dir volume source.
 func TaskRunWorkspaceEmptyDir(name, subPath string) TaskRunSpecOp {
 	return func(spec *v1beta1.TaskRunSpec) {
@@ -33,6 +33,10 @@ const (
 	requireGitSSHSecretKnownHostsKey        = "require-git-ssh-secret-known-hosts" // nolint: gosec
 	enableTektonOCIBundles                  = "enable-tekton-oci-bundles"
 	enableCustomTasks                       = "enable-custom-tasks"
+	resultExtractionMethodKey               = "results-from"
+	enableGitAndHTTPResolvers               = "enable-git-and-http-resolvers"
+	enableStepResourceUsage                 = "enable-step-resource-usage"
+	enableArrayAndObjectResults             = "enable-array-and-object-results"
 	DefaultDisableHomeEnvOverwrite          = false
 	DefaultDisableWorkingDirOverwrite       = false
 	DefaultDisableAffinityAssistant         = false
@@ -41,6 +45,17 @@ const (
 	DefaultRequireGitSSHSecretKnownHosts    = false
 	DefaultEnableTektonOciBundles           = false
 	DefaultEnableCustomTasks                = false
+	DefaultResultExtractionMethod           = ResultExtractionMethodTerminationMessage
+	DefaultEnableGitAndHTTPResolvers        = false
+	DefaultEnableStepResourceUsage          = false
+	DefaultEnableArrayAndObjectResults      = false
+
+	// ResultExtractionMethodTerminationMessage is the value used for "results-from" to read
+	// Task results from the termination messages of the steps.
+	ResultExtractionMethodTerminationMessage = "termination-message"
+	// ResultExtractionMethodSidecarLogs is the value used for "results-from" to read Task
+	// results from the logs of a sidecar injected in the TaskRun Pod.
+	ResultExtractionMethodSidecarLogs = "sidecar-logs"
 )
 
 // FeatureFlags holds the features configurations
@@ -54,6 +69,10 @@ type FeatureFlags struct {
 	RequireGitSSHSecretKnownHosts    bool
 	EnableTektonOCIBundles           bool
 	EnableCustomTasks                bool
+	ResultExtractionMethod           string
+	EnableGitAndHTTPResolvers        bool
+	EnableStepResourceUsage          bool
+	EnableArrayAndObjectResults      bool
 }
 
 // GetFeatureFlagsConfigName returns the name of the configmap containing all
@@ -105,9 +124,36 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setFeature(enableCustomTasks, DefaultEnableCustomTasks, &tc.EnableCustomTasks); err != nil {
 		return nil, err
 	}
+	if err := setFeature(enableGitAndHTTPResolvers, DefaultEnableGitAndHTTPResolvers, &tc.EnableGitAndHTTPResolvers); err != nil {
+		return nil, err
+	}
+	if err := setFeature(enableStepResourceUsage, DefaultEnableStepResourceUsage, &tc.EnableStepResourceUsage); err != nil {
+		return nil, err
+	}
+	if err := setFeature(enableArrayAndObjectResults, DefaultEnableArrayAndObjectResults, &tc.EnableArrayAndObjectResults); err != nil {
+		return nil, err
+	}
+	if err := setResultExtractionMethod(cfgMap, &tc); err != nil {
+		return nil, err
+	}
 	return &tc, nil
 }
 
+// setResultExtractionMethod sets the "results-from" flag based on the content of a given map.
+// If the value is missing, the default is used.
+func setResultExtractionMethod(cfgMap map[string]string, tc *FeatureFlags) error {
+	value := DefaultResultExtractionMethod
+	if cfg, ok := cfgMap[resultExtractionMethodKey]; ok {
+		value = cfg
+	}
+	switch value {
+	case ResultExtractionMethodTerminationMessage, ResultExtractionMethodSidecarLogs:
+		tc.ResultExtractionMethod = value
+		return nil
+	}
+	return fmt.Errorf("invalid value for feature flag %q: %q", resultExtractionMethodKey, value)
+}
+
 // NewFeatureFlagsFromConfigMap returns a Config for the given configmap
 func NewFeatureFlagsFromConfigMap(config *corev1.ConfigMap) (*FeatureFlags, error) {
 	return NewFeatureFlagsFromMap(config.Data)
@@ -36,6 +36,7 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 		{
 			expectedConfig: &config.FeatureFlags{
 				RunningInEnvWithInjectedSidecars: config.DefaultRunningInEnvWithInjectedSidecars,
+				ResultExtractionMethod:           config.DefaultResultExtractionMethod,
 			},
 			fileName: config.GetFeatureFlagsConfigName(),
 		},
@@ -48,6 +49,10 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				RequireGitSSHSecretKnownHosts:    true,
 				EnableTektonOCIBundles:           true,
 				EnableCustomTasks:                true,
+				ResultExtractionMethod:           config.ResultExtractionMethodSidecarLogs,
+				EnableGitAndHTTPResolvers:        true,
+				EnableStepResourceUsage:          true,
+				EnableArrayAndObjectResults:      true,
 			},
 			fileName: "feature-flags-all-flags-set",
 		},
@@ -62,10 +67,17 @@ func TestNewFeatureFlagsFromEmptyConfigMap(t *testing.T) {
 	FeatureFlagsConfigEmptyName := "feature-flags-empty"
 	expectedConfig := &config.FeatureFlags{
 		RunningInEnvWithInjectedSidecars: true,
+		ResultExtractionMethod:           config.DefaultResultExtractionMethod,
 	}
 	verifyConfigFileWithExpectedFeatureFlagsConfig(t, FeatureFlagsConfigEmptyName, expectedConfig)
 }
 
+func TestNewFeatureFlagsFromConfigMapInvalidResultExtractionMethod(t *testing.T) {
+	if _, err := config.NewFeatureFlagsFromMap(map[string]string{"results-from": "carrier-pigeon"}); err == nil {
+		t.Error("NewFeatureFlagsFromMap() expected an error for an invalid results-from value, got nil")
+	}
+}
+
 func TestGetFeatureFlagsConfigName(t *testing.T) {
 	for _, tc := range []struct {
 		description         string
@@ -32,7 +32,8 @@ type Images struct {
 	GitImage string
 	// KubeconfigWriterImage is the container image containing our kubeconfig writer binary.
 	KubeconfigWriterImage string
-	// ShellImage is the container image containing bash shell.
+	// ShellImage is the container image containing bash shell, used by the
+	// PipelineResources and the debug scripts.
 	ShellImage string
 	// GsutilImage is the container image containing gsutil.
 	GsutilImage string
@@ -42,6 +43,9 @@ type Images struct {
 	PRImage string
 	// ImageDigestExporterImage is the container image containing our image digest exporter binary.
 	ImageDigestExporterImage string
+	// SidecarLogResultsImage is the container image containing the binary that
+	// prints Task results in the logs of a sidecar.
+	SidecarLogResultsImage string
 
 	// NOTE: Make sure to add any new images to Validate below!
 }
@@ -61,6 +65,7 @@ func (i Images) Validate() error {
 		{i.BuildGCSFetcherImage, "build-gcs-fetcher"},
 		{i.PRImage, "pr"},
 		{i.ImageDigestExporterImage, "imagedigest-exporter"},
+		{i.SidecarLogResultsImage, "sidecarlogresults"},
 	} {
 		if f.v == "" {
 			unset = append(unset, f.name)
@@ -21,6 +21,9 @@ const (
 	WorkspaceDir = "/workspace"
 	// DefaultResultPath is the path for task result
 	DefaultResultPath = "/tekton/results"
+	// StepsDir is the directory holding a directory per step, in which the
+	// step writes its results
+	StepsDir = "/tekton/steps"
 	// HomeDir is the HOME directory of PipelineResources
 	HomeDir = "/tekton/home"
 	// CredsDir is the directory where credentials are placed to meet the legacy credentials
@@ -48,6 +48,15 @@ const (
 
 	// RunKey is used as the label identifier for a Run
 	RunKey = "/run"
+
+	// ConcurrencyKeyLabelKey is used as the label identifier for the hash of the resolved
+	// concurrency key of a PipelineRun
+	ConcurrencyKeyLabelKey = "/concurrencyKey"
+
+	// PipelineAncestryAnnotationKey is used as the annotation identifier for the comma separated
+	// names of the Pipelines run by the ancestors of a child PipelineRun, outermost first. It is
+	// informational only: the controller finds the ancestors through the owner references.
+	PipelineAncestryAnnotationKey = "/pipelineAncestry"
 )
 
 var (
@@ -29,13 +29,14 @@ type ParamSpec = v1beta1.ParamSpec
 type Param = v1beta1.Param
 
 // ParamType indicates the type of an input parameter;
-// Used to distinguish between a single string and an array of strings.
+// Used to distinguish between a single string, an array of strings and an object of strings.
 type ParamType = v1beta1.ParamType
 
 // Valid ParamTypes:
 const (
 	ParamTypeString ParamType = v1beta1.ParamTypeString
 	ParamTypeArray  ParamType = v1beta1.ParamTypeArray
+	ParamTypeObject ParamType = v1beta1.ParamTypeObject
 )
 
 // AllParamTypes can be used for ParamType validation.
@@ -62,6 +62,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&PipelineResourceList{},
 		&Run{},
 		&RunList{},
+		&StepAction{},
+		&StepActionList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StepAction represents a reusable step, which the steps of Tasks reference
+// through their ref field.
+// +k8s:openapi-gen=true
+type StepAction struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the StepAction from the client
+	// +optional
+	Spec StepActionSpec `json:"spec"`
+}
+
+// StepActionSpec defines the desired state of the StepAction
+type StepActionSpec struct {
+	// Description is a user-facing description of the StepAction that may be
+	// used to populate a UI.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Image is the container image the step runs.
+	Image string `json:"image,omitempty"`
+	// Command is the entrypoint array of the step.
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Args are the arguments to the entrypoint of the step.
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// Env is the list of environment variables to set in the step.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// Script is the contents of an executable file to execute.
+	//
+	// If Script is not empty, the StepAction cannot have a Command and the
+	// Args will be passed to the Script.
+	// +optional
+	Script string `json:"script,omitempty"`
+	// Params is a list of input parameters of the StepAction, which the steps
+	// referencing it pass values for. They can only be referenced by the
+	// StepAction itself.
+	// +optional
+	Params []ParamSpec `json:"params,omitempty"`
+	// Results are the results written by the step, which are reported in its
+	// StepState.
+	// +optional
+	Results []v1beta1.StepResult `json:"results,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StepActionList contains a list of StepActions
+type StepActionList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StepAction `json:"items"`
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"github.com/tektoncd/pipeline/pkg/substitution"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*StepAction)(nil)
+
+// Validate implements apis.Validatable
+func (s *StepAction) Validate(ctx context.Context) *apis.FieldError {
+	if err := validate.ObjectMetadata(s.GetObjectMeta()); err != nil {
+		return err.ViaField("metadata")
+	}
+	return s.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate checks that the StepActionSpec defines a valid step, which only
+// references the params and results it declares.
+func (ss *StepActionSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if ss.Image == "" {
+		errs = errs.Also(apis.ErrMissingField("image"))
+	}
+	if ss.Script != "" && len(ss.Command) > 0 {
+		errs = errs.Also(apis.ErrMultipleOneOf("script", "command"))
+	}
+	errs = errs.Also(v1beta1.ValidateParameterTypes(ss.Params).ViaField("params"))
+	errs = errs.Also(v1beta1.ValidateStepResults(ss.Results).ViaField("results"))
+
+	paramNames := sets.NewString()
+	arrayParamNames := sets.NewString()
+	for i, p := range ss.Params {
+		if paramNames.Has(p.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("parameter appears more than once: %s", p.Name), "name").ViaFieldIndex("params", i))
+		}
+		paramNames.Insert(p.Name)
+		if p.Type == ParamTypeArray {
+			arrayParamNames.Insert(p.Name)
+		}
+	}
+	errs = errs.Also(ss.validateVariables("params", paramNames.Union(arrayParamNames)))
+	errs = errs.Also(ss.validateArrayUsage("params", arrayParamNames))
+
+	resultNames := sets.NewString()
+	for _, r := range ss.Results {
+		resultNames.Insert(r.Name)
+	}
+	errs = errs.Also(ss.validateVariables("step\\.results", resultNames))
+	return errs
+}
+
+// validateVariables checks that the variables with the given prefix which
+// are referenced by the StepAction are in vars.
+func (ss *StepActionSpec) validateVariables(prefix string, vars sets.String) (errs *apis.FieldError) {
+	errs = errs.Also(substitution.ValidateVariableP(ss.Image, prefix, vars).ViaField("image"))
+	errs = errs.Also(substitution.ValidateVariableP(ss.Script, prefix, vars).ViaField("script"))
+	for i, cmd := range ss.Command {
+		errs = errs.Also(substitution.ValidateVariableP(cmd, prefix, vars).ViaFieldIndex("command", i))
+	}
+	for i, arg := range ss.Args {
+		errs = errs.Also(substitution.ValidateVariableP(arg, prefix, vars).ViaFieldIndex("args", i))
+	}
+	for _, env := range ss.Env {
+		errs = errs.Also(substitution.ValidateVariableP(env.Value, prefix, vars).ViaFieldKey("env", env.Name))
+	}
+	return errs
+}
+
+// validateArrayUsage checks that array params are only referenced as whole
+// items of the command or args of the StepAction.
+func (ss *StepActionSpec) validateArrayUsage(prefix string, arrayNames sets.String) (errs *apis.FieldError) {
+	errs = errs.Also(substitution.ValidateVariableProhibitedP(ss.Image, prefix, arrayNames).ViaField("image"))
+	errs = errs.Also(substitution.ValidateVariableProhibitedP(ss.Script, prefix, arrayNames).ViaField("script"))
+	for i, cmd := range ss.Command {
+		errs = errs.Also(substitution.ValidateVariableIsolatedP(cmd, prefix, arrayNames).ViaFieldIndex("command", i))
+	}
+	for i, arg := range ss.Args {
+		errs = errs.Also(substitution.ValidateVariableIsolatedP(arg, prefix, arrayNames).ViaFieldIndex("args", i))
+	}
+	for _, env := range ss.Env {
+		errs = errs.Also(substitution.ValidateVariableProhibitedP(env.Value, prefix, arrayNames).ViaFieldKey("env", env.Name))
+	}
+	return errs
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestStepAction_Validate(t *testing.T) {
+	sa := v1alpha1.StepAction{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-clone"},
+		Spec: v1alpha1.StepActionSpec{
+			Image: "alpine/git",
+			Args:  []string{"$(params.flags[*])"},
+			Script: `git clone $(params.url) .
+git rev-parse HEAD > $(step.results.commit.path)`,
+			Params: []v1alpha1.ParamSpec{{
+				Name: "url",
+				Type: v1alpha1.ParamTypeString,
+			}, {
+				Name: "flags",
+				Type: v1alpha1.ParamTypeArray,
+			}},
+			Results: []v1beta1.StepResult{{Name: "commit"}},
+		},
+	}
+	if err := sa.Validate(context.Background()); err != nil {
+		t.Errorf("StepAction.Validate() unexpected error = %v", err)
+	}
+}
+
+func TestStepAction_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		sa            *v1alpha1.StepAction
+		expectedError apis.FieldError
+	}{{
+		name: "invalid meta",
+		sa: &v1alpha1.StepAction{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid.,name"},
+		},
+		expectedError: apis.FieldError{
+			Message: "Invalid resource name: special character . must not be present",
+			Paths:   []string{"metadata.name"},
+		},
+	}, {
+		name: "no image",
+		sa: &v1alpha1.StepAction{
+			ObjectMeta: metav1.ObjectMeta{Name: "git-clone"},
+			Spec: v1alpha1.StepActionSpec{
+				Script: "git clone",
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"spec.image"},
+		},
+	}, {
+		name: "script and command",
+		sa: &v1alpha1.StepAction{
+			ObjectMeta: metav1.ObjectMeta{Name: "git-clone"},
+			Spec: v1alpha1.StepActionSpec{
+				Image:   "alpine/git",
+				Command: []string{"git"},
+				Script:  "git clone",
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   []string{"spec.command", "spec.script"},
+		},
+	}, {
+		name: "duplicated params",
+		sa: &v1alpha1.StepAction{
+			ObjectMeta: metav1.ObjectMeta{Name: "git-clone"},
+			Spec: v1alpha1.StepActionSpec{
+				Image: "alpine/git",
+				Params: []v1alpha1.ParamSpec{{
+					Name: "url",
+					Type: v1alpha1.ParamTypeString,
+				}, {
+					Name: "url",
+					Type: v1alpha1.ParamTypeString,
+				}},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "parameter appears more than once: url",
+			Paths:   []string{"spec.params[1].name"},
+		},
+	}, {
+		name: "reference to an undeclared param",
+		sa: &v1alpha1.StepAction{
+			ObjectMeta: metav1.ObjectMeta{Name: "git-clone"},
+			Spec: v1alpha1.StepActionSpec{
+				Image:  "alpine/git",
+				Script: "git clone $(params.revision)",
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "git clone $(params.revision)"`,
+			Paths:   []string{"spec.script"},
+		},
+	}, {
+		name: "array param referenced in script",
+		sa: &v1alpha1.StepAction{
+			ObjectMeta: metav1.ObjectMeta{Name: "git-clone"},
+			Spec: v1alpha1.StepActionSpec{
+				Image:  "alpine/git",
+				Script: "git clone $(params.flags)",
+				Params: []v1alpha1.ParamSpec{{
+					Name: "flags",
+					Type: v1alpha1.ParamTypeArray,
+				}},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "git clone $(params.flags)"`,
+			Paths:   []string{"spec.script"},
+		},
+	}, {
+		name: "reference to an undeclared result",
+		sa: &v1alpha1.StepAction{
+			ObjectMeta: metav1.ObjectMeta{Name: "git-clone"},
+			Spec: v1alpha1.StepActionSpec{
+				Image:  "alpine/git",
+				Script: "git rev-parse HEAD > $(step.results.sha.path)",
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "git rev-parse HEAD > $(step.results.sha.path)"`,
+			Paths:   []string{"spec.script"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.sa.Validate(context.Background())
+			if err == nil {
+				t.Fatalf("Expected an Error, got nothing for %v", tc)
+			}
+			if d := cmp.Diff(tc.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("StepAction.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -81,6 +81,35 @@ func TestTaskConversion(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "object params",
+		in: &Task{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "foo",
+				Namespace:  "bar",
+				Generation: 1,
+			},
+			Spec: TaskSpec{
+				TaskSpec: v1beta1.TaskSpec{
+					Steps: []v1beta1.Step{{Container: corev1.Container{
+						Image: "foo",
+						Args:  []string{"$(params.git.url)"},
+					}}},
+					Params: []v1beta1.ParamSpec{{
+						Name: "git",
+						Type: v1beta1.ParamTypeObject,
+						Properties: map[string]v1beta1.PropertySpec{
+							"url":      {Type: v1beta1.ParamTypeString},
+							"revision": {Type: v1beta1.ParamTypeString},
+						},
+						Default: v1beta1.NewObject(map[string]string{
+							"url":      "https://github.com/tektoncd/pipeline",
+							"revision": "main",
+						}),
+					}},
+				},
+			},
+		},
 	}, {
 		name: "deprecated and non deprecated inputs",
 		in: &Task{
@@ -73,6 +73,9 @@ func TestTaskRunConversion(t *testing.T) {
 				Params: []Param{{
 					Name:  "p1",
 					Value: *v1beta1.NewArrayOrString("baz"),
+				}, {
+					Name:  "p2",
+					Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"}),
 				}},
 				Resources: &v1beta1.TaskRunResources{
 					Inputs: []v1beta1.TaskResourceBinding{{
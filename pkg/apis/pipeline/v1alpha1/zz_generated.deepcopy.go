@@ -24,6 +24,7 @@ import (
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	v1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -383,7 +384,7 @@ func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
 	if in.PipelineRef != nil {
 		in, out := &in.PipelineRef, &out.PipelineRef
 		*out = new(v1beta1.PipelineRef)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.PipelineSpec != nil {
 		in, out := &in.PipelineSpec, &out.PipelineSpec
@@ -513,7 +514,7 @@ func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
 	if in.TaskRef != nil {
 		in, out := &in.TaskRef, &out.TaskRef
 		*out = new(v1beta1.TaskRef)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.TaskSpec != nil {
 		in, out := &in.TaskSpec, &out.TaskSpec
@@ -677,7 +678,7 @@ func (in *RunSpec) DeepCopyInto(out *RunSpec) {
 	if in.Ref != nil {
 		in, out := &in.Ref, &out.Ref
 		*out = new(v1beta1.TaskRef)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
@@ -711,6 +712,111 @@ func (in *RunSpec) DeepCopy() *RunSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepAction) DeepCopyInto(out *StepAction) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepAction.
+func (in *StepAction) DeepCopy() *StepAction {
+	if in == nil {
+		return nil
+	}
+	out := new(StepAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StepAction) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepActionList) DeepCopyInto(out *StepActionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]StepAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepActionList.
+func (in *StepActionList) DeepCopy() *StepActionList {
+	if in == nil {
+		return nil
+	}
+	out := new(StepActionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StepActionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepActionSpec) DeepCopyInto(out *StepActionSpec) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]v1beta1.ParamSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]v1beta1.StepResult, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepActionSpec.
+func (in *StepActionSpec) DeepCopy() *StepActionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StepActionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Task) DeepCopyInto(out *Task) {
 	*out = *in
@@ -891,7 +997,7 @@ func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 	if in.TaskRef != nil {
 		in, out := &in.TaskRef, &out.TaskRef
 		*out = new(v1beta1.TaskRef)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.TaskSpec != nil {
 		in, out := &in.TaskSpec, &out.TaskSpec
@@ -34,9 +34,13 @@ type ParamSpec struct {
 	// Name declares the name by which a parameter is referenced.
 	Name string `json:"name"`
 	// Type is the user-specified type of the parameter. The possible types
-	// are currently "string" and "array", and "string" is the default.
+	// are currently "string", "array" and "object", and "string" is the default.
 	// +optional
 	Type ParamType `json:"type,omitempty"`
+	// Properties is the JSON Schema properties to support key-value pairs parameter.
+	// It is required for, and only used by, parameters of type "object".
+	// +optional
+	Properties map[string]PropertySpec `json:"properties,omitempty"`
 	// Description is a user-facing description of the parameter that may be
 	// used to populate a UI.
 	// +optional
@@ -48,17 +52,53 @@ type ParamSpec struct {
 	Default *ArrayOrString `json:"default,omitempty"`
 }
 
+// PropertySpec defines the struct for object keys
+type PropertySpec struct {
+	// Type is the type of the value of the key. Only "string" is currently
+	// supported, and is the default.
+	// +optional
+	Type ParamType `json:"type,omitempty"`
+}
+
 // SetDefaults set the default type
 func (pp *ParamSpec) SetDefaults(ctx context.Context) {
-	if pp != nil && pp.Type == "" {
-		if pp.Default != nil {
+	if pp == nil {
+		return
+	}
+	if pp.Type == "" {
+		switch {
+		case pp.Default != nil:
 			// propagate the parsed ArrayOrString's type to the parent ParamSpec's type
 			pp.Type = pp.Default.Type
-		} else {
+		case pp.Properties != nil:
+			// only object parameters declare properties
+			pp.Type = ParamTypeObject
+		default:
 			// ParamTypeString is the default value (when no type can be inferred from the default value)
 			pp.Type = ParamTypeString
 		}
 	}
+	for key, property := range pp.Properties {
+		if property.Type == "" {
+			property.Type = ParamTypeString
+			pp.Properties[key] = property
+		}
+	}
+}
+
+// PropertyKeys returns the keys declared in the properties of an object ParamSpec.
+func (pp ParamSpec) PropertyKeys() sets.String {
+	keys := sets.NewString()
+	for key := range pp.Properties {
+		keys.Insert(key)
+	}
+	return keys
+}
+
+// MissingObjectKeys returns the sorted keys declared in the properties of the ParamSpec which are
+// not provided by the given object.
+func (pp ParamSpec) MissingObjectKeys(object map[string]string) []string {
+	return pp.PropertyKeys().Difference(sets.StringKeySet(object)).List()
 }
 
 // ResourceParam declares a string value to use for the parameter called Name, and is used in
@@ -72,34 +112,40 @@ type Param struct {
 }
 
 // ParamType indicates the type of an input parameter;
-// Used to distinguish between a single string and an array of strings.
+// Used to distinguish between a single string, an array of strings and an object of strings.
 type ParamType string
 
 // Valid ParamTypes:
 const (
 	ParamTypeString ParamType = "string"
 	ParamTypeArray  ParamType = "array"
+	ParamTypeObject ParamType = "object"
 )
 
 // AllParamTypes can be used for ParamType validation.
-var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray}
+var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray, ParamTypeObject}
 
 // ArrayOrString is modeled after IntOrString in kubernetes/apimachinery:
 
-// ArrayOrString is a type that can hold a single string or string array.
+// ArrayOrString is a type that can hold a single string, string array or string map.
 // Used in JSON unmarshalling so that a single JSON field can accept
-// either an individual string or an array of strings.
+// either an individual string, an array of strings or an object of strings.
 type ArrayOrString struct {
-	Type      ParamType `json:"type"` // Represents the stored type of ArrayOrString.
-	StringVal string    `json:"stringVal"`
-	ArrayVal  []string  `json:"arrayVal"`
+	Type      ParamType         `json:"type"` // Represents the stored type of ArrayOrString.
+	StringVal string            `json:"stringVal"`
+	ArrayVal  []string          `json:"arrayVal"`
+	ObjectVal map[string]string `json:"objectVal"`
 }
 
 // UnmarshalJSON implements the json.Unmarshaller interface.
 func (arrayOrString *ArrayOrString) UnmarshalJSON(value []byte) error {
-	if value[0] == '"' {
+	switch value[0] {
+	case '"':
 		arrayOrString.Type = ParamTypeString
 		return json.Unmarshal(value, &arrayOrString.StringVal)
+	case '{':
+		arrayOrString.Type = ParamTypeObject
+		return json.Unmarshal(value, &arrayOrString.ObjectVal)
 	}
 	arrayOrString.Type = ParamTypeArray
 	return json.Unmarshal(value, &arrayOrString.ArrayVal)
@@ -112,16 +158,39 @@ func (arrayOrString ArrayOrString) MarshalJSON() ([]byte, error) {
 		return json.Marshal(arrayOrString.StringVal)
 	case ParamTypeArray:
 		return json.Marshal(arrayOrString.ArrayVal)
+	case ParamTypeObject:
+		return json.Marshal(arrayOrString.ObjectVal)
 	default:
 		return []byte{}, fmt.Errorf("impossible ArrayOrString.Type: %q", arrayOrString.Type)
 	}
 }
 
 // ApplyReplacements applyes replacements for ArrayOrString type
-func (arrayOrString *ArrayOrString) ApplyReplacements(stringReplacements map[string]string, arrayReplacements map[string][]string) {
-	if arrayOrString.Type == ParamTypeString {
+// A string which only references a whole object or array, e.g. "$(params.foo[*])", is replaced by that object
+// or array.
+func (arrayOrString *ArrayOrString) ApplyReplacements(stringReplacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) {
+	switch arrayOrString.Type {
+	case ParamTypeString:
+		if objectVal, ok := substitution.ApplyObjectReplacements(arrayOrString.StringVal, objectReplacements); ok {
+			arrayOrString.Type = ParamTypeObject
+			arrayOrString.StringVal = ""
+			arrayOrString.ObjectVal = objectVal
+			return
+		}
+		if arrayVal, ok := substitution.ApplyWholeArrayReplacements(arrayOrString.StringVal, arrayReplacements); ok {
+			arrayOrString.Type = ParamTypeArray
+			arrayOrString.StringVal = ""
+			arrayOrString.ArrayVal = arrayVal
+			return
+		}
 		arrayOrString.StringVal = substitution.ApplyReplacements(arrayOrString.StringVal, stringReplacements)
-	} else {
+	case ParamTypeObject:
+		newObjectVal := make(map[string]string, len(arrayOrString.ObjectVal))
+		for k, v := range arrayOrString.ObjectVal {
+			newObjectVal[k] = substitution.ApplyReplacements(v, stringReplacements)
+		}
+		arrayOrString.ObjectVal = newObjectVal
+	default:
 		var newArrayVal []string
 		for _, v := range arrayOrString.ArrayVal {
 			newArrayVal = append(newArrayVal, substitution.ApplyArrayReplacements(v, stringReplacements, arrayReplacements)...)
@@ -145,25 +214,50 @@ func NewArrayOrString(value string, values ...string) *ArrayOrString {
 	}
 }
 
-func validatePipelineParametersVariablesInTaskParameters(params []Param, prefix string, paramNames sets.String, arrayParamNames sets.String) (errs *apis.FieldError) {
+// NewObject creates an ArrayOrString of type ParamTypeObject using the provided key-value pairs
+func NewObject(pairs map[string]string) *ArrayOrString {
+	return &ArrayOrString{
+		Type:      ParamTypeObject,
+		ObjectVal: pairs,
+	}
+}
+
+func validatePipelineParametersVariablesInTaskParameters(params []Param, prefix string, paramNames sets.String, arrayParamNames sets.String, objectParamKeys map[string]sets.String) (errs *apis.FieldError) {
 	for _, param := range params {
-		if param.Value.Type == ParamTypeString {
-			errs = errs.Also(validateStringVariableInTaskParameters(param.Value.StringVal, prefix, paramNames, arrayParamNames).ViaFieldKey("params", param.Name))
-		} else {
+		switch param.Value.Type {
+		case ParamTypeString:
+			errs = errs.Also(validateStringVariableInTaskParameters(param.Value.StringVal, prefix, paramNames, arrayParamNames, objectParamKeys).ViaFieldKey("params", param.Name))
+		case ParamTypeObject:
+			for key, value := range param.Value.ObjectVal {
+				errs = errs.Also(validateStringVariable(value, prefix, paramNames, arrayParamNames, objectParamKeys).ViaFieldKey("value", key).ViaFieldKey("params", param.Name))
+			}
+		default:
 			for idx, arrayElement := range param.Value.ArrayVal {
-				errs = errs.Also(validateArrayVariableInTaskParameters(arrayElement, prefix, paramNames, arrayParamNames).ViaFieldIndex("value", idx).ViaFieldKey("params", param.Name))
+				errs = errs.Also(validateArrayVariableInTaskParameters(arrayElement, prefix, paramNames, arrayParamNames, objectParamKeys).ViaFieldIndex("value", idx).ViaFieldKey("params", param.Name))
 			}
 		}
 	}
 	return errs
 }
 
-func validateStringVariableInTaskParameters(value, prefix string, stringVars sets.String, arrayVars sets.String) *apis.FieldError {
+func validatePipelineParametersVariablesInMatrixParameters(matrix []Param, prefix string, paramNames sets.String, arrayParamNames sets.String, objectParamKeys map[string]sets.String) (errs *apis.FieldError) {
+	for _, param := range matrix {
+		for idx, arrayElement := range param.Value.ArrayVal {
+			errs = errs.Also(validateArrayVariableInTaskParameters(arrayElement, prefix, paramNames, arrayParamNames, objectParamKeys).ViaFieldIndex("value", idx).ViaFieldKey("matrix", param.Name))
+		}
+	}
+	return errs
+}
+
+// validateStringVariableInTaskParameters allows whole objects to be passed to a task parameter, e.g. "$(params.foo[*])"
+func validateStringVariableInTaskParameters(value, prefix string, stringVars sets.String, arrayVars sets.String, objectKeys map[string]sets.String) *apis.FieldError {
 	errs := substitution.ValidateVariableP(value, prefix, stringVars)
+	errs = errs.Also(substitution.ValidateVariableObjectKeysP(value, prefix, objectKeys, true))
 	return errs.Also(substitution.ValidateVariableProhibitedP(value, prefix, arrayVars))
 }
 
-func validateArrayVariableInTaskParameters(value, prefix string, stringVars sets.String, arrayVars sets.String) *apis.FieldError {
+func validateArrayVariableInTaskParameters(value, prefix string, stringVars sets.String, arrayVars sets.String, objectKeys map[string]sets.String) *apis.FieldError {
 	errs := substitution.ValidateVariableP(value, prefix, stringVars)
+	errs = errs.Also(substitution.ValidateVariableObjectKeysP(value, prefix, objectKeys, false))
 	return errs.Also(substitution.ValidateVariableIsolatedP(value, prefix, arrayVars))
 }
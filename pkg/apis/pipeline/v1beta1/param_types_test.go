@@ -66,6 +66,20 @@ func TestParamSpec_SetDefaults(t *testing.T) {
 			Description: "a description",
 			Default:     v1beta1.NewArrayOrString("an", "array"),
 		},
+	}, {
+		name: "inferred object type from properties",
+		before: &v1beta1.ParamSpec{
+			Name:       "parametername",
+			Properties: map[string]v1beta1.PropertySpec{"key1": {}, "key2": {Type: v1beta1.ParamTypeString}},
+		},
+		defaultsApplied: &v1beta1.ParamSpec{
+			Name: "parametername",
+			Type: v1beta1.ParamTypeObject,
+			Properties: map[string]v1beta1.PropertySpec{
+				"key1": {Type: v1beta1.ParamTypeString},
+				"key2": {Type: v1beta1.ParamTypeString},
+			},
+		},
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -83,6 +97,7 @@ func TestArrayOrString_ApplyReplacements(t *testing.T) {
 		input              *v1beta1.ArrayOrString
 		stringReplacements map[string]string
 		arrayReplacements  map[string][]string
+		objectReplacements map[string]map[string]string
 	}
 	tests := []struct {
 		name           string
@@ -128,10 +143,38 @@ func TestArrayOrString_ApplyReplacements(t *testing.T) {
 			arrayReplacements:  map[string][]string{"arraykey": {}},
 		},
 		expectedOutput: v1beta1.NewArrayOrString("firstvalue", "lastvalue"),
+	}, {
+		name: "string replacements on object",
+		args: args{
+			input:              v1beta1.NewObject(map[string]string{"url": "$(params.git.url)", "revision": "main"}),
+			stringReplacements: map[string]string{"params.git.url": "https://github.com/tektoncd/pipeline"},
+		},
+		expectedOutput: v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline", "revision": "main"}),
+	}, {
+		name: "object replacement on string",
+		args: args{
+			input:              v1beta1.NewArrayOrString("$(params.git[*])"),
+			objectReplacements: map[string]map[string]string{"params.git": {"url": "https://github.com/tektoncd/pipeline", "revision": "main"}},
+		},
+		expectedOutput: v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline", "revision": "main"}),
+	}, {
+		name: "object replacement on non-isolated string",
+		args: args{
+			input:              v1beta1.NewArrayOrString("prefix-$(params.git[*])"),
+			objectReplacements: map[string]map[string]string{"params.git": {"url": "https://github.com/tektoncd/pipeline"}},
+		},
+		expectedOutput: v1beta1.NewArrayOrString("prefix-$(params.git[*])"),
+	}, {
+		name: "array replacement on string",
+		args: args{
+			input:             v1beta1.NewArrayOrString("$(tasks.list.results.files[*])"),
+			arrayReplacements: map[string][]string{"tasks.list.results.files": {"a.txt", "b.txt"}},
+		},
+		expectedOutput: v1beta1.NewArrayOrString("a.txt", "b.txt"),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.args.input.ApplyReplacements(tt.args.stringReplacements, tt.args.arrayReplacements)
+			tt.args.input.ApplyReplacements(tt.args.stringReplacements, tt.args.arrayReplacements, tt.args.objectReplacements)
 			if d := cmp.Diff(tt.expectedOutput, tt.args.input); d != "" {
 				t.Errorf("ApplyReplacements() output did not match expected value %s", diff.PrintWantGot(d))
 			}
@@ -153,6 +196,7 @@ func TestArrayOrString_UnmarshalJSON(t *testing.T) {
 		{"{\"val\":[]}", v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{}}},
 		{"{\"val\":[\"oneelement\"]}", v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"oneelement"}}},
 		{"{\"val\":[\"multiple\", \"elements\"]}", *v1beta1.NewArrayOrString("multiple", "elements")},
+		{"{\"val\":{\"key1\":\"val1\", \"key2\":\"val2\"}}", *v1beta1.NewObject(map[string]string{"key1": "val1", "key2": "val2"})},
 	}
 
 	for _, c := range cases {
@@ -174,6 +218,7 @@ func TestArrayOrString_MarshalJSON(t *testing.T) {
 		{*v1beta1.NewArrayOrString("123"), "{\"val\":\"123\"}"},
 		{*v1beta1.NewArrayOrString("123", "1234"), "{\"val\":[\"123\",\"1234\"]}"},
 		{*v1beta1.NewArrayOrString("a", "a", "a"), "{\"val\":[\"a\",\"a\",\"a\"]}"},
+		{*v1beta1.NewObject(map[string]string{"key1": "val1", "key2": "val2"}), "{\"val\":{\"key1\":\"val1\",\"key2\":\"val2\"}}"},
 	}
 
 	for _, c := range cases {
@@ -17,11 +17,18 @@ limitations under the License.
 package v1beta1
 
 import (
+	"math"
+	"time"
+
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+// PipelineTasksAggregateStatus is the variable referencing the aggregate execution
+// status of all the DAG tasks of a Pipeline, which finally tasks can consume
+const PipelineTasksAggregateStatus = "tasks.status"
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +genclient:noStatus
@@ -123,6 +130,15 @@ type PipelineTask struct {
 	// +optional
 	TaskSpec *EmbeddedTask `json:"taskSpec,omitempty"`
 
+	// PipelineRef is a reference to a pipeline definition. The PipelineTask is
+	// then executed by a child PipelineRun instead of a TaskRun.
+	// +optional
+	PipelineRef *PipelineRef `json:"pipelineRef,omitempty"`
+
+	// PipelineSpec is a specification of a pipeline executed by a child PipelineRun
+	// +optional
+	PipelineSpec *PipelineSpec `json:"pipelineSpec,omitempty"`
+
 	// Conditions is a list of conditions that need to be true for the task to run
 	// Conditions are deprecated, use WhenExpressions instead
 	// +optional
@@ -136,6 +152,11 @@ type PipelineTask struct {
 	// +optional
 	Retries int `json:"retries,omitempty"`
 
+	// RetryPolicy controls how many times, after which delay and for which
+	// failures this task is retried. It cannot be used together with Retries.
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+
 	// RunAfter is the list of PipelineTask names that should be executed before
 	// this Task executes. (Used to force a specific ordering in graph execution.)
 	// +optional
@@ -159,6 +180,98 @@ type PipelineTask struct {
 	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Matrix declares parameters used to fan out this task into multiple TaskRuns,
+	// one for each combination of the values of the array parameters.
+	// +optional
+	Matrix []Param `json:"matrix,omitempty"`
+}
+
+// RetryPolicy defines how a failed PipelineTask is retried
+type RetryPolicy struct {
+	// Count is the number of times the PipelineTask is retried
+	Count int `json:"count"`
+	// InitialDelay is the delay between the first failure and the first
+	// retry. Defaults to retrying immediately.
+	// +optional
+	InitialDelay *metav1.Duration `json:"initialDelay,omitempty"`
+	// BackoffFactor multiplies the delay before each further retry. Defaults to 2.
+	// +optional
+	BackoffFactor int `json:"backoffFactor,omitempty"`
+	// Reasons is the list of TaskRun failure reasons which are retried, e.g.
+	// "PodEvicted". Together with ExitCodes, it defaults to retrying any failure.
+	// +optional
+	Reasons []string `json:"reasons,omitempty"`
+	// ExitCodes is the list of exit codes of a failed step which are retried.
+	// Together with Reasons, it defaults to retrying any failure.
+	// +optional
+	ExitCodes []int32 `json:"exitCodes,omitempty"`
+}
+
+// DefaultRetryBackoffFactor is the factor applied to the delay before each
+// further retry when a RetryPolicy does not specify one
+const DefaultRetryBackoffFactor = 2
+
+// Delay returns the delay between the failure of the PipelineTask and its
+// retry, where retry counts the retries done so far.
+func (rp *RetryPolicy) Delay(retry int) time.Duration {
+	if rp.InitialDelay == nil {
+		return 0
+	}
+	factor := rp.BackoffFactor
+	if factor == 0 {
+		factor = DefaultRetryBackoffFactor
+	}
+	delay := rp.InitialDelay.Duration
+	for i := 0; i < retry; i++ {
+		if delay > time.Duration(math.MaxInt64)/time.Duration(factor) {
+			return time.Duration(math.MaxInt64)
+		}
+		delay *= time.Duration(factor)
+	}
+	return delay
+}
+
+// RetryCount returns the number of times the PipelineTask is retried in case of failure,
+// from its RetryPolicy if it has one.
+func (pt PipelineTask) RetryCount() int {
+	if pt.RetryPolicy != nil {
+		return pt.RetryPolicy.Count
+	}
+	return pt.Retries
+}
+
+// IsMatrixed returns true if the PipelineTask declares a Matrix to fan out into multiple TaskRuns.
+func (pt PipelineTask) IsMatrixed() bool {
+	return len(pt.Matrix) > 0
+}
+
+// IsChildPipeline returns true if the PipelineTask references or embeds a Pipeline, which is
+// executed by a child PipelineRun.
+func (pt PipelineTask) IsChildPipeline() bool {
+	return pt.PipelineRef != nil || pt.PipelineSpec != nil
+}
+
+// FanOut returns the combinations of the values of the Matrix parameters of the PipelineTask.
+// Each combination holds one string Param for every Matrix parameter, in the order in which
+// the Matrix parameters are declared. A PipelineTask without a Matrix has no combinations.
+func (pt PipelineTask) FanOut() [][]Param {
+	if !pt.IsMatrixed() {
+		return nil
+	}
+	combinations := [][]Param{{}}
+	for _, param := range pt.Matrix {
+		var next [][]Param
+		for _, combination := range combinations {
+			for _, value := range param.Value.ArrayVal {
+				c := make([]Param, len(combination), len(combination)+1)
+				copy(c, combination)
+				next = append(next, append(c, Param{Name: param.Name, Value: *NewArrayOrString(value)}))
+			}
+		}
+		combinations = next
+	}
+	return combinations
 }
 
 func (pt *PipelineTask) TaskSpecMetadata() PipelineTaskMetadata {
@@ -211,8 +324,10 @@ func (pt PipelineTask) resourceDeps() []string {
 		}
 	}
 
-	// Add any dependents from task results
-	for _, param := range pt.Params {
+	// Add any dependents from task results, including those consumed by the matrix
+	params := append([]Param{}, pt.Params...)
+	params = append(params, pt.Matrix...)
+	for _, param := range params {
 		expressions, ok := GetVarSubstitutionExpressionsForParam(param)
 		if ok {
 			resultRefs := NewResultRefs(expressions)
@@ -230,6 +345,7 @@ func (pt PipelineTask) resourceDeps() []string {
 				resourceDeps = append(resourceDeps, resultRef.PipelineTask)
 			}
 		}
+		resourceDeps = append(resourceDeps, whenExpression.celResultTasks()...)
 	}
 	return resourceDeps
 }
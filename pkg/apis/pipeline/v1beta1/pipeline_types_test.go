@@ -17,12 +17,15 @@ limitations under the License.
 package v1beta1
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"github.com/tektoncd/pipeline/test/diff"
 
 	"github.com/google/go-cmp/cmp"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -39,3 +42,151 @@ func TestPipelineTaskList_Names(t *testing.T) {
 		t.Fatalf("Failed to get list of pipeline task names, diff: %s", diff.PrintWantGot(d))
 	}
 }
+
+func TestPipelineTask_FanOut(t *testing.T) {
+	tests := []struct {
+		name string
+		pt   PipelineTask
+		want [][]Param
+	}{{
+		name: "no matrix",
+		pt:   PipelineTask{Name: "task"},
+		want: nil,
+	}, {
+		name: "single matrix parameter",
+		pt: PipelineTask{
+			Name:   "task",
+			Matrix: []Param{{Name: "platform", Value: *NewArrayOrString("linux", "mac")}},
+		},
+		want: [][]Param{
+			{{Name: "platform", Value: *NewArrayOrString("linux")}},
+			{{Name: "platform", Value: *NewArrayOrString("mac")}},
+		},
+	}, {
+		name: "multiple matrix parameters",
+		pt: PipelineTask{
+			Name: "task",
+			Matrix: []Param{
+				{Name: "platform", Value: *NewArrayOrString("linux", "mac")},
+				{Name: "browser", Value: *NewArrayOrString("chrome", "safari", "firefox")},
+			},
+		},
+		want: [][]Param{
+			{{Name: "platform", Value: *NewArrayOrString("linux")}, {Name: "browser", Value: *NewArrayOrString("chrome")}},
+			{{Name: "platform", Value: *NewArrayOrString("linux")}, {Name: "browser", Value: *NewArrayOrString("safari")}},
+			{{Name: "platform", Value: *NewArrayOrString("linux")}, {Name: "browser", Value: *NewArrayOrString("firefox")}},
+			{{Name: "platform", Value: *NewArrayOrString("mac")}, {Name: "browser", Value: *NewArrayOrString("chrome")}},
+			{{Name: "platform", Value: *NewArrayOrString("mac")}, {Name: "browser", Value: *NewArrayOrString("safari")}},
+			{{Name: "platform", Value: *NewArrayOrString("mac")}, {Name: "browser", Value: *NewArrayOrString("firefox")}},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if d := cmp.Diff(tt.want, tt.pt.FanOut()); d != "" {
+				t.Errorf("FanOut() diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineTask_RetryCount(t *testing.T) {
+	tests := []struct {
+		name string
+		pt   PipelineTask
+		want int
+	}{{
+		name: "no retries",
+		pt:   PipelineTask{Name: "task"},
+		want: 0,
+	}, {
+		name: "retries",
+		pt:   PipelineTask{Name: "task", Retries: 2},
+		want: 2,
+	}, {
+		name: "retry policy",
+		pt:   PipelineTask{Name: "task", RetryPolicy: &RetryPolicy{Count: 3}},
+		want: 3,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pt.RetryCount(); got != tt.want {
+				t.Errorf("RetryCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	tests := []struct {
+		name  string
+		rp    RetryPolicy
+		retry int
+		want  time.Duration
+	}{{
+		name:  "no initial delay",
+		rp:    RetryPolicy{Count: 3},
+		retry: 2,
+		want:  0,
+	}, {
+		name:  "first retry",
+		rp:    RetryPolicy{Count: 3, InitialDelay: &metav1.Duration{Duration: 10 * time.Second}},
+		retry: 0,
+		want:  10 * time.Second,
+	}, {
+		name:  "default backoff factor",
+		rp:    RetryPolicy{Count: 3, InitialDelay: &metav1.Duration{Duration: 10 * time.Second}},
+		retry: 2,
+		want:  40 * time.Second,
+	}, {
+		name:  "backoff factor",
+		rp:    RetryPolicy{Count: 3, InitialDelay: &metav1.Duration{Duration: 10 * time.Second}, BackoffFactor: 3},
+		retry: 2,
+		want:  90 * time.Second,
+	}, {
+		name:  "constant delay",
+		rp:    RetryPolicy{Count: 3, InitialDelay: &metav1.Duration{Duration: 10 * time.Second}, BackoffFactor: 1},
+		retry: 2,
+		want:  10 * time.Second,
+	}, {
+		name:  "overflowing delay",
+		rp:    RetryPolicy{Count: 100, InitialDelay: &metav1.Duration{Duration: time.Hour}, BackoffFactor: 10},
+		retry: 99,
+		want:  time.Duration(math.MaxInt64),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rp.Delay(tt.retry); got != tt.want {
+				t.Errorf("Delay(%d) = %s, want %s", tt.retry, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPipelineTask_Deps_WhenCEL(t *testing.T) {
+	pt := PipelineTask{
+		Name: "task",
+		WhenExpressions: WhenExpressions{{
+			CEL: "tasks.build.results.image != '' && tasks['unit-test'].results.coverage != '' && tasks.test.status == 'Succeeded'",
+		}},
+	}
+	if d := cmp.Diff([]string{"build", "unit-test"}, pt.Deps()); d != "" {
+		t.Errorf("Deps() diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPipelineTask_Deps_Matrix(t *testing.T) {
+	pt := PipelineTask{
+		Name:     "task",
+		RunAfter: []string{"a"},
+		Matrix: []Param{{
+			Name: "platform",
+			Value: ArrayOrString{
+				Type:     ParamTypeArray,
+				ArrayVal: []string{"$(tasks.b.results.platform)", "mac"},
+			},
+		}},
+	}
+	if d := cmp.Diff([]string{"a", "b"}, pt.Deps()); d != "" {
+		t.Errorf("Deps() diff %s", diff.PrintWantGot(d))
+	}
+}
@@ -58,6 +58,7 @@ func (ps *PipelineSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// Validate the pipeline task graph
 	errs = errs.Also(validateGraph(ps.Tasks))
 	errs = errs.Also(validateParamResults(ps.Tasks))
+	errs = errs.Also(validateResultsFromMatrixedPipelineTasksNotConsumed(ps.Tasks, ps.Finally))
 	// The parameter variables should be valid
 	errs = errs.Also(validatePipelineParameterVariables(ps.Tasks, ps.Params).ViaField("tasks"))
 	errs = errs.Also(validatePipelineParameterVariables(ps.Finally, ps.Params).ViaField("finally"))
@@ -69,7 +70,7 @@ func (ps *PipelineSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(validatePipelineResults(ps.Results))
 	errs = errs.Also(validateTasksAndFinallySection(ps))
 	errs = errs.Also(validateFinalTasks(ps.Tasks, ps.Finally))
-	errs = errs.Also(validateWhenExpressions(ps.Tasks))
+	errs = errs.Also(validateWhenExpressions(ps.Tasks, ps.Finally))
 	return errs
 }
 
@@ -112,8 +113,10 @@ func validatePipelineTask(ctx context.Context, t PipelineTask, taskNames sets.St
 	if hasTaskRef && hasTaskSpec {
 		errs = errs.Also(apis.ErrMultipleOneOf("taskRef", "taskSpec"))
 	}
-	// Check that one of TaskRef and TaskSpec is present
-	if !hasTaskRef && !hasTaskSpec {
+	// Check that one of TaskRef and TaskSpec is present, unless the pipeline task targets a Pipeline
+	if t.IsChildPipeline() {
+		errs = errs.Also(validateChildPipeline(ctx, t))
+	} else if !hasTaskRef && !hasTaskSpec {
 		errs = errs.Also(apis.ErrMissingOneOf("taskRef", "taskSpec"))
 	}
 	// Validate TaskSpec if it's present
@@ -134,11 +137,12 @@ func validatePipelineTask(ctx context.Context, t PipelineTask, taskNames sets.St
 				errs = errs.Also(apis.ErrInvalidValue(strings.Join(errSlice, ","), "name"))
 			}
 		} else {
-			// Custom Task refs are allowed to have no name.
-			if !isCustomTask {
+			// Custom Task refs and refs fetched by a resolver are allowed to have no name.
+			if !isCustomTask && !t.TaskRef.HasResolver() {
 				errs = errs.Also(apis.ErrInvalidValue("taskRef must specify name", "taskRef.name"))
 			}
 		}
+		errs = errs.Also(validateResolverRef(t.TaskRef.ResolverRef, t.TaskRef.Name, t.TaskRef.Bundle).ViaField("taskRef"))
 	}
 
 	if isCustomTask {
@@ -154,12 +158,29 @@ func validatePipelineTask(ctx context.Context, t PipelineTask, taskNames sets.St
 		if t.Retries > 0 {
 			errs = errs.Also(apis.ErrInvalidValue("custom tasks do not support retries", "retries"))
 		}
+		if t.RetryPolicy != nil {
+			errs = errs.Also(apis.ErrInvalidValue("custom tasks do not support retries", "retryPolicy"))
+		}
 		if t.Resources != nil {
 			errs = errs.Also(apis.ErrInvalidValue("custom tasks do not support PipelineResources", "resources"))
 		}
 		if t.Timeout != nil {
 			errs = errs.Also(apis.ErrInvalidValue("custom tasks do not support timeout", "timeout"))
 		}
+		if t.IsMatrixed() {
+			errs = errs.Also(apis.ErrInvalidValue("custom tasks do not support matrix", "matrix"))
+		}
+	}
+
+	if t.IsMatrixed() {
+		errs = errs.Also(validateMatrix(t))
+	}
+
+	if t.RetryPolicy != nil {
+		if t.Retries > 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("retries", "retryPolicy"))
+		}
+		errs = errs.Also(t.RetryPolicy.validate().ViaField("retryPolicy"))
 	}
 
 	// If EnableTektonOCIBundles feature flag is on validate it.
@@ -183,6 +204,110 @@ func validatePipelineTask(ctx context.Context, t PipelineTask, taskNames sets.St
 	return errs
 }
 
+func (rp *RetryPolicy) validate() (errs *apis.FieldError) {
+	if rp.Count < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", rp.Count), "count"))
+	}
+	if rp.InitialDelay != nil && rp.InitialDelay.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", rp.InitialDelay.Duration), "initialDelay"))
+	}
+	if rp.BackoffFactor < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 1", rp.BackoffFactor), "backoffFactor"))
+	}
+	for i, code := range rp.ExitCodes {
+		if code == 0 {
+			errs = errs.Also(apis.ErrInvalidValue("exit code 0 is not a failure", "").ViaFieldIndex("exitCodes", i))
+		}
+	}
+	return errs
+}
+
+// validateChildPipeline ensures that a pipeline task targeting a Pipeline specifies exactly one of
+// pipelineRef or pipelineSpec, that the Pipeline is valid, and that it does not use features which
+// are only supported for pipeline tasks executed by a TaskRun
+func validateChildPipeline(ctx context.Context, t PipelineTask) (errs *apis.FieldError) {
+	cfg := config.FromContextOrDefaults(ctx)
+	hasPipelineRef := t.PipelineRef != nil
+	hasPipelineSpec := t.PipelineSpec != nil
+
+	if t.TaskRef != nil || t.TaskSpec != nil {
+		errs = errs.Also(apis.ErrMultipleOneOf("taskRef", "taskSpec", "pipelineRef", "pipelineSpec"))
+	}
+	if hasPipelineRef && hasPipelineSpec {
+		errs = errs.Also(apis.ErrMultipleOneOf("pipelineRef", "pipelineSpec"))
+	}
+	if hasPipelineRef {
+		if t.PipelineRef.Name == "" {
+			if !t.PipelineRef.HasResolver() {
+				errs = errs.Also(apis.ErrMissingField("pipelineRef.name"))
+			}
+		} else if errSlice := validation.IsQualifiedName(t.PipelineRef.Name); len(errSlice) != 0 {
+			errs = errs.Also(apis.ErrInvalidValue(strings.Join(errSlice, ","), "pipelineRef.name"))
+		}
+		errs = errs.Also(validateResolverRef(t.PipelineRef.ResolverRef, t.PipelineRef.Name, t.PipelineRef.Bundle).ViaField("pipelineRef"))
+		if t.PipelineRef.Bundle != "" {
+			if !cfg.FeatureFlags.EnableTektonOCIBundles {
+				errs = errs.Also(apis.ErrDisallowedFields("pipelineRef.bundle"))
+			} else if _, err := name.ParseReference(t.PipelineRef.Bundle); err != nil {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("invalid bundle reference (%s)", err.Error()), "pipelineRef.bundle"))
+			}
+		}
+	}
+	if hasPipelineSpec {
+		errs = errs.Also(t.PipelineSpec.Validate(ctx).ViaField("pipelineSpec"))
+	}
+
+	// Conditions are deprecated so the effort to support them with child pipelines is not justified.
+	// When expressions should be used instead.
+	if len(t.Conditions) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue("pipeline tasks targeting a pipeline do not support conditions - use when expressions instead", "conditions"))
+	}
+	if t.Retries > 0 {
+		errs = errs.Also(apis.ErrInvalidValue("pipeline tasks targeting a pipeline do not support retries", "retries"))
+	}
+	if t.RetryPolicy != nil {
+		errs = errs.Also(apis.ErrInvalidValue("pipeline tasks targeting a pipeline do not support retries", "retryPolicy"))
+	}
+	if t.Resources != nil {
+		errs = errs.Also(apis.ErrInvalidValue("pipeline tasks targeting a pipeline do not support PipelineResources", "resources"))
+	}
+	if t.IsMatrixed() {
+		errs = errs.Also(apis.ErrInvalidValue("pipeline tasks targeting a pipeline do not support matrix", "matrix"))
+	}
+	return errs
+}
+
+// validateMatrix ensures that a matrixed pipeline task fans out over non-empty array parameters
+// which are not also passed as regular parameters, and that it does not declare conditions
+func validateMatrix(t PipelineTask) (errs *apis.FieldError) {
+	paramNames := sets.NewString()
+	for _, p := range t.Params {
+		paramNames.Insert(p.Name)
+	}
+	matrixNames := sets.NewString()
+	for _, p := range t.Matrix {
+		if p.Value.Type != ParamTypeArray {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("matrix parameters must be of type array, parameter %s is of type %s", p.Name, p.Value.Type),
+				"value").ViaFieldKey("matrix", p.Name))
+		} else if len(p.Value.ArrayVal) == 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("matrix parameter %s must have at least one value", p.Name),
+				"value").ViaFieldKey("matrix", p.Name))
+		}
+		if paramNames.Has(p.Name) {
+			errs = errs.Also(apis.ErrMultipleOneOf(fmt.Sprintf("params[%s]", p.Name), fmt.Sprintf("matrix[%s]", p.Name)))
+		}
+		if matrixNames.Has(p.Name) {
+			errs = errs.Also(apis.ErrGeneric("parameter appears more than once", "").ViaFieldKey("matrix", p.Name))
+		}
+		matrixNames.Insert(p.Name)
+	}
+	// Conditions are deprecated so the effort to support them with a matrix is not justified.
+	if len(t.Conditions) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue("matrixed pipeline tasks do not support conditions - use when expressions instead", "conditions"))
+	}
+	return errs
+}
+
 // validatePipelineWorkspaces validates the specified workspaces, ensuring having unique name without any empty string,
 // and validates that all the referenced workspaces (by pipeline tasks) are specified in the pipeline
 func validatePipelineWorkspaces(wss []PipelineWorkspaceDeclaration, pts []PipelineTask, finalTasks []PipelineTask) (errs *apis.FieldError) {
@@ -226,11 +351,13 @@ func validatePipelineWorkspaces(wss []PipelineWorkspaceDeclaration, pts []Pipeli
 }
 
 // validatePipelineParameterVariables validates parameters with those specified by each pipeline task,
-// (1) it validates the type of parameter is either string or array (2) parameter default value matches
+// (1) it validates the type of parameter is either string, array or object (2) parameter default value matches
 // with the type of that param (3) ensures that the referenced param variable is defined is part of the param declarations
+// (4) ensures that object params declare their properties, and are referenced through their declared keys
 func validatePipelineParameterVariables(tasks []PipelineTask, params []ParamSpec) (errs *apis.FieldError) {
 	parameterNames := sets.NewString()
 	arrayParameterNames := sets.NewString()
+	objectParameterKeys := map[string]sets.String{}
 
 	for _, p := range params {
 		// Verify that p is a valid type.
@@ -249,24 +376,30 @@ func validatePipelineParameterVariables(tasks []PipelineTask, params []ParamSpec
 			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("\"%v\" type does not match default value's type: \"%v\"", p.Type, p.Default.Type),
 				"type", "default.type").ViaFieldKey("params", p.Name))
 		}
+		errs = errs.Also(p.validateObjectProperties().ViaFieldKey("params", p.Name))
 
 		if parameterNames.Has(p.Name) {
 			errs = errs.Also(apis.ErrGeneric("parameter appears more than once", "").ViaFieldKey("params", p.Name))
 		}
-		// Add parameter name to parameterNames, and to arrayParameterNames if type is array.
+		// Add parameter name to parameterNames, to arrayParameterNames if type is array,
+		// and its keys to objectParameterKeys if type is object.
 		parameterNames.Insert(p.Name)
-		if p.Type == ParamTypeArray {
+		switch p.Type {
+		case ParamTypeArray:
 			arrayParameterNames.Insert(p.Name)
+		case ParamTypeObject:
+			objectParameterKeys[p.Name] = p.PropertyKeys()
 		}
 	}
 
-	return errs.Also(validatePipelineParametersVariables(tasks, "params", parameterNames, arrayParameterNames))
+	return errs.Also(validatePipelineParametersVariables(tasks, "params", parameterNames, arrayParameterNames, objectParameterKeys))
 }
 
-func validatePipelineParametersVariables(tasks []PipelineTask, prefix string, paramNames sets.String, arrayParamNames sets.String) (errs *apis.FieldError) {
+func validatePipelineParametersVariables(tasks []PipelineTask, prefix string, paramNames sets.String, arrayParamNames sets.String, objectParamKeys map[string]sets.String) (errs *apis.FieldError) {
 	for idx, task := range tasks {
-		errs = errs.Also(validatePipelineParametersVariablesInTaskParameters(task.Params, prefix, paramNames, arrayParamNames).ViaIndex(idx))
-		errs = errs.Also(task.WhenExpressions.validatePipelineParametersVariables(prefix, paramNames, arrayParamNames).ViaIndex(idx))
+		errs = errs.Also(validatePipelineParametersVariablesInTaskParameters(task.Params, prefix, paramNames, arrayParamNames, objectParamKeys).ViaIndex(idx))
+		errs = errs.Also(validatePipelineParametersVariablesInMatrixParameters(task.Matrix, prefix, paramNames, arrayParamNames, objectParamKeys).ViaIndex(idx))
+		errs = errs.Also(task.WhenExpressions.validatePipelineParametersVariables(prefix, paramNames, arrayParamNames, objectParamKeys).ViaIndex(idx))
 	}
 	return errs
 }
@@ -285,6 +418,9 @@ func validatePipelineContextVariables(tasks []PipelineTask) *apis.FieldError {
 		for _, param := range task.Params {
 			paramValues = append(paramValues, param.Value.StringVal)
 			paramValues = append(paramValues, param.Value.ArrayVal...)
+			for _, value := range param.Value.ObjectVal {
+				paramValues = append(paramValues, value)
+			}
 		}
 	}
 	errs := validatePipelineContextVariablesInParamValues(paramValues, "context\\.pipelineRun", pipelineRunContextNames)
@@ -298,8 +434,8 @@ func containsExecutionStatusRef(p string) bool {
 	return false
 }
 
-// validate dag pipeline tasks, task params can not access execution status of any other task
-// dag tasks cannot have param value as $(tasks.pipelineTask.status)
+// validate dag pipeline tasks, task params and when expressions can not access execution status of any other task
+// dag tasks cannot have param value or when expressions as $(tasks.pipelineTask.status) or $(tasks.status)
 func validateExecutionStatusVariablesInTasks(tasks []PipelineTask) (errs *apis.FieldError) {
 	for idx, t := range tasks {
 		for _, param := range t.Params {
@@ -317,12 +453,23 @@ func validateExecutionStatusVariablesInTasks(tasks []PipelineTask) (errs *apis.F
 				}
 			}
 		}
+		for i, we := range t.WhenExpressions {
+			if expressions, ok := we.GetVarSubstitutionExpressions(); ok {
+				for _, p := range expressions {
+					if !looksLikeResultRef(p) && containsExecutionStatusRef(p) {
+						errs = errs.Also(apis.ErrInvalidValue("when expressions in pipeline tasks can not refer to execution status of any other pipeline task",
+							"").ViaFieldIndex("when", i).ViaFieldIndex("tasks", idx))
+					}
+				}
+			}
+		}
 	}
 	return errs
 }
 
 // validate finally tasks accessing execution status of a dag task specified in the pipeline
 // $(tasks.pipelineTask.status) is invalid if pipelineTask is not defined as a dag task
+// $(tasks.status), the aggregate execution status of the dag tasks, is always valid
 func validateExecutionStatusVariablesInFinally(tasks []PipelineTask, finally []PipelineTask) (errs *apis.FieldError) {
 	// creating a list of pipelineTask names to validate tasks.<name>.status
 	ptNames := PipelineTaskList(tasks).Names()
@@ -334,7 +481,7 @@ func validateExecutionStatusVariablesInFinally(tasks []PipelineTask, finally []P
 				if !LooksLikeContainsResultRefs(ps) {
 					for _, p := range ps {
 						// check if it contains context variable accessing execution status - $(tasks.taskname.status)
-						if containsExecutionStatusRef(p) {
+						if containsExecutionStatusRef(p) && p != PipelineTasksAggregateStatus {
 							// strip tasks. and .status from tasks.taskname.status to further verify task name
 							pt := strings.TrimSuffix(strings.TrimPrefix(p, "tasks."), ".status")
 							// report an error if the task name does not exist in the list of dag tasks
@@ -347,6 +494,20 @@ func validateExecutionStatusVariablesInFinally(tasks []PipelineTask, finally []P
 				}
 			}
 		}
+		for i, we := range t.WhenExpressions {
+			if expressions, ok := we.GetVarSubstitutionExpressions(); ok {
+				for _, p := range expressions {
+					if looksLikeResultRef(p) || !containsExecutionStatusRef(p) || p == PipelineTasksAggregateStatus {
+						continue
+					}
+					pt := strings.TrimSuffix(strings.TrimPrefix(p, "tasks."), ".status")
+					if !ptNames.Has(pt) {
+						errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("pipeline task %s is not defined in the pipeline", pt),
+							"").ViaFieldIndex("when", i).ViaFieldIndex("finally", idx))
+					}
+				}
+			}
+		}
 	}
 	return errs
 }
@@ -384,6 +545,41 @@ func validateParamResults(tasks []PipelineTask) (errs *apis.FieldError) {
 	return errs
 }
 
+// validateResultsFromMatrixedPipelineTasksNotConsumed ensures that results of matrixed pipeline tasks are
+// not consumed by other pipeline tasks, since each of the TaskRuns fanned out by a matrix produces its own results
+func validateResultsFromMatrixedPipelineTasksNotConsumed(tasks []PipelineTask, finally []PipelineTask) (errs *apis.FieldError) {
+	matrixedTasks := sets.NewString()
+	for _, t := range tasks {
+		if t.IsMatrixed() {
+			matrixedTasks.Insert(t.Name)
+		}
+	}
+	if matrixedTasks.Len() == 0 {
+		return nil
+	}
+	validateTasks := func(pts []PipelineTask, field string) {
+		for idx, t := range pts {
+			params := append([]Param{}, t.Params...)
+			params = append(params, t.Matrix...)
+			for _, param := range params {
+				expressions, ok := GetVarSubstitutionExpressionsForParam(param)
+				if !ok || !LooksLikeContainsResultRefs(expressions) {
+					continue
+				}
+				for _, resultRef := range NewResultRefs(filter(expressions, looksLikeResultRef)) {
+					if matrixedTasks.Has(resultRef.PipelineTask) {
+						errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("consuming results from matrixed task %s is not allowed", resultRef.PipelineTask),
+							"value").ViaFieldKey("params", param.Name).ViaFieldIndex(field, idx))
+					}
+				}
+			}
+		}
+	}
+	validateTasks(tasks, "tasks")
+	validateTasks(finally, "finally")
+	return errs
+}
+
 func filter(arr []string, cond func(string) bool) []string {
 	result := []string{}
 	for i := range arr {
@@ -428,9 +624,6 @@ func validateFinalTasks(tasks []PipelineTask, finalTasks []PipelineTask) *apis.F
 		if len(f.Conditions) != 0 {
 			return apis.ErrInvalidValue(fmt.Sprintf("no conditions allowed under spec.finally, final task %s has conditions specified", f.Name), "").ViaFieldIndex("finally", idx)
 		}
-		if len(f.WhenExpressions) != 0 {
-			return apis.ErrInvalidValue(fmt.Sprintf("no when expressions allowed under spec.finally, final task %s has when expressions specified", f.Name), "").ViaFieldIndex("finally", idx)
-		}
 	}
 
 	ts := PipelineTaskList(tasks).Names()
@@ -466,6 +659,20 @@ func validateTaskResultReference(finalTasks []PipelineTask, ts, fts sets.String)
 				}
 			}
 		}
+		for _, we := range t.WhenExpressions {
+			expressions, ok := we.GetVarSubstitutionExpressions()
+			if ok && LooksLikeContainsResultRefs(expressions) {
+				for _, resultRef := range NewResultRefs(expressions) {
+					if fts.Has(resultRef.PipelineTask) {
+						return apis.ErrInvalidValue("invalid task result reference, "+
+							"final task when expression has task result reference from a final task", "when").ViaIndex(idx)
+					} else if !ts.Has(resultRef.PipelineTask) {
+						return apis.ErrInvalidValue("invalid task result reference, "+
+							"final task when expression has task result reference from a task which is not defined in the pipeline", "when").ViaIndex(idx)
+					}
+				}
+			}
+		}
 	}
 	return nil
 }
@@ -486,11 +693,14 @@ func validateTasksInputFrom(tasks []PipelineTask) (errs *apis.FieldError) {
 	return errs
 }
 
-func validateWhenExpressions(tasks []PipelineTask) (errs *apis.FieldError) {
+func validateWhenExpressions(tasks []PipelineTask, finalTasks []PipelineTask) (errs *apis.FieldError) {
 	for i, t := range tasks {
 		errs = errs.Also(validateOneOfWhenExpressionsOrConditions(t).ViaFieldIndex("tasks", i))
 		errs = errs.Also(t.WhenExpressions.validate().ViaFieldIndex("tasks", i))
 	}
+	for i, t := range finalTasks {
+		errs = errs.Also(t.WhenExpressions.validate().ViaFieldIndex("finally", i))
+	}
 	return errs
 }
 
@@ -476,6 +476,66 @@ func TestValidatePipelineTasks_Success(t *testing.T) {
 			Name:     "foo",
 			TaskSpec: &EmbeddedTask{TaskSpec: getTaskSpec()},
 		}},
+	}, {
+		name: "pipeline task with valid matrix",
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Params:  []Param{{Name: "version", Value: *NewArrayOrString("1.16")}},
+			Matrix: []Param{
+				{Name: "platform", Value: *NewArrayOrString("linux", "mac")},
+				{Name: "browser", Value: *NewArrayOrString("chrome", "safari")},
+			},
+		}},
+	}, {
+		name: "pipeline task with valid retry policy",
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			RetryPolicy: &RetryPolicy{
+				Count:         3,
+				InitialDelay:  &metav1.Duration{Duration: 10 * time.Second},
+				BackoffFactor: 2,
+				Reasons:       []string{"PodEvicted"},
+				ExitCodes:     []int32{137},
+			},
+		}},
+	}, {
+		name: "pipeline task with taskref fetched by a resolver",
+		tasks: []PipelineTask{{
+			Name: "foo",
+			TaskRef: &TaskRef{ResolverRef: ResolverRef{
+				Resolver: "git",
+				Params: []Param{
+					{Name: "url", Value: *NewArrayOrString("https://example.com/repo.git")},
+					{Name: "pathInRepo", Value: *NewArrayOrString("task.yaml")},
+				},
+			}},
+		}},
+	}, {
+		name: "pipeline task with pipelineref fetched by a resolver",
+		tasks: []PipelineTask{{
+			Name: "foo",
+			PipelineRef: &PipelineRef{ResolverRef: ResolverRef{
+				Resolver: "cluster",
+				Params:   []Param{{Name: "name", Value: *NewArrayOrString("child")}},
+			}},
+		}},
+	}, {
+		name: "pipeline task with valid pipelineref",
+		tasks: []PipelineTask{{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "build-pipeline"},
+			Params:      []Param{{Name: "version", Value: *NewArrayOrString("1.16")}},
+		}},
+	}, {
+		name: "pipeline task with valid pipelinespec",
+		tasks: []PipelineTask{{
+			Name: "foo",
+			PipelineSpec: &PipelineSpec{
+				Tasks: []PipelineTask{{Name: "bar", TaskRef: &TaskRef{Name: "bar-task"}}},
+			},
+		}},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -567,6 +627,34 @@ func TestValidatePipelineTasks_Failure(t *testing.T) {
 			Message: `invalid value: name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')`,
 			Paths:   []string{"tasks[0].name"},
 		},
+	}, {
+		name: "pipeline task with retries and retry policy",
+		tasks: []PipelineTask{{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo-task"},
+			Retries:     1,
+			RetryPolicy: &RetryPolicy{Count: 1},
+		}},
+		expectedError: apis.FieldError{
+			Message: `expected exactly one, got both`,
+			Paths:   []string{"tasks[0].retries", "tasks[0].retryPolicy"},
+		},
+	}, {
+		name: "pipeline task with invalid retry policy",
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			RetryPolicy: &RetryPolicy{
+				Count:         -1,
+				InitialDelay:  &metav1.Duration{Duration: -time.Second},
+				BackoffFactor: -2,
+				ExitCodes:     []int32{1, 0},
+			},
+		}},
+		expectedError: *apis.ErrInvalidValue("-1 should be >= 0", "tasks[0].retryPolicy.count").Also(
+			apis.ErrInvalidValue("-1s should be >= 0", "tasks[0].retryPolicy.initialDelay")).Also(
+			apis.ErrInvalidValue("-2 should be >= 1", "tasks[0].retryPolicy.backoffFactor")).Also(
+			apis.ErrInvalidValue("exit code 0 is not a failure", "tasks[0].retryPolicy.exitCodes[1]")),
 	}, {
 		name:  "pipelinetask taskRef without name",
 		tasks: []PipelineTask{{Name: "foo", TaskRef: &TaskRef{Name: ""}}},
@@ -632,6 +720,128 @@ func TestValidatePipelineTasks_Failure(t *testing.T) {
 			Paths:   []string{"tasks[0].timeout"},
 		},
 		wc: enableFeature(t, "enable-custom-tasks"),
+	}, {
+		name: "custom task reference in pipelinetask with matrix",
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{APIVersion: "example.dev/v0", Kind: "Example", Name: ""},
+			Matrix:  []Param{{Name: "platform", Value: *NewArrayOrString("linux", "mac")}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: custom tasks do not support matrix`,
+			Paths:   []string{"tasks[0].matrix"},
+		},
+		wc: enableFeature(t, "enable-custom-tasks"),
+	}, {
+		name: "pipeline task with matrix parameter of type string",
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Matrix:  []Param{{Name: "platform", Value: *NewArrayOrString("linux")}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: matrix parameters must be of type array, parameter platform is of type string`,
+			Paths:   []string{"tasks[0].matrix[platform].value"},
+		},
+	}, {
+		name: "pipeline task with empty matrix parameter",
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Matrix:  []Param{{Name: "platform", Value: ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{}}}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: matrix parameter platform must have at least one value`,
+			Paths:   []string{"tasks[0].matrix[platform].value"},
+		},
+	}, {
+		name: "pipeline task with parameter in both params and matrix",
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Params:  []Param{{Name: "platform", Value: *NewArrayOrString("linux")}},
+			Matrix:  []Param{{Name: "platform", Value: *NewArrayOrString("linux", "mac")}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `expected exactly one, got both`,
+			Paths:   []string{"tasks[0].matrix[platform]", "tasks[0].params[platform]"},
+		},
+	}, {
+		name: "pipeline task with duplicate matrix parameters",
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Matrix: []Param{
+				{Name: "platform", Value: *NewArrayOrString("linux", "mac")},
+				{Name: "platform", Value: *NewArrayOrString("windows", "mac")},
+			},
+		}},
+		expectedError: apis.FieldError{
+			Message: `parameter appears more than once`,
+			Paths:   []string{"tasks[0].matrix[platform]"},
+		},
+	}, {
+		name: "pipeline task with matrix and conditions",
+		tasks: []PipelineTask{{
+			Name:       "foo",
+			TaskRef:    &TaskRef{Name: "foo-task"},
+			Matrix:     []Param{{Name: "platform", Value: *NewArrayOrString("linux", "mac")}},
+			Conditions: []PipelineTaskCondition{{ConditionRef: "is-linux"}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: matrixed pipeline tasks do not support conditions - use when expressions instead`,
+			Paths:   []string{"tasks[0].conditions"},
+		},
+	}, {
+		name: "pipeline task with both taskref and pipelineref",
+		tasks: []PipelineTask{{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo-task"},
+			PipelineRef: &PipelineRef{Name: "foo-pipeline"},
+		}},
+		expectedError: apis.FieldError{
+			Message: `expected exactly one, got both`,
+			Paths:   []string{"tasks[0].pipelineRef", "tasks[0].pipelineSpec", "tasks[0].taskRef", "tasks[0].taskSpec"},
+		},
+	}, {
+		name: "pipeline task with both pipelineref and pipelinespec",
+		tasks: []PipelineTask{{
+			Name:         "foo",
+			PipelineRef:  &PipelineRef{Name: "foo-pipeline"},
+			PipelineSpec: &PipelineSpec{Tasks: []PipelineTask{{Name: "bar", TaskRef: &TaskRef{Name: "bar-task"}}}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `expected exactly one, got both`,
+			Paths:   []string{"tasks[0].pipelineRef", "tasks[0].pipelineSpec"},
+		},
+	}, {
+		name:  "pipeline task with pipelineref without name",
+		tasks: []PipelineTask{{Name: "foo", PipelineRef: &PipelineRef{}}},
+		expectedError: apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"tasks[0].pipelineRef.name"},
+		},
+	}, {
+		name: "pipeline task with invalid pipelinespec",
+		tasks: []PipelineTask{{
+			Name:         "foo",
+			PipelineSpec: &PipelineSpec{Tasks: []PipelineTask{{Name: "bar"}}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `expected exactly one, got neither`,
+			Paths:   []string{"tasks[0].pipelineSpec.tasks[0].taskRef", "tasks[0].pipelineSpec.tasks[0].taskSpec"},
+		},
+	}, {
+		name: "pipeline task with pipelineref and retries",
+		tasks: []PipelineTask{{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "foo-pipeline"},
+			Retries:     2,
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: pipeline tasks targeting a pipeline do not support retries`,
+			Paths:   []string{"tasks[0].retries"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1067,6 +1277,38 @@ func TestValidateParamResults_Failure(t *testing.T) {
 	}
 }
 
+func TestValidateResultsFromMatrixedPipelineTasksNotConsumed(t *testing.T) {
+	tasks := []PipelineTask{{
+		Name:    "a-task",
+		TaskRef: &TaskRef{Name: "a-task"},
+		Matrix:  []Param{{Name: "platform", Value: *NewArrayOrString("linux", "mac")}},
+	}, {
+		Name:    "b-task",
+		TaskRef: &TaskRef{Name: "b-task"},
+		Params:  []Param{{Name: "a-result", Value: *NewArrayOrString("$(tasks.a-task.results.output)")}},
+	}, {
+		Name:    "c-task",
+		TaskRef: &TaskRef{Name: "c-task"},
+		Params:  []Param{{Name: "b-result", Value: *NewArrayOrString("$(tasks.b-task.results.output)")}},
+	}}
+	finally := []PipelineTask{{
+		Name:    "final-task",
+		TaskRef: &TaskRef{Name: "final-task"},
+		Params:  []Param{{Name: "a-result", Value: *NewArrayOrString("$(tasks.a-task.results.output)")}},
+	}}
+	expectedError := apis.FieldError{
+		Message: `invalid value: consuming results from matrixed task a-task is not allowed`,
+		Paths:   []string{"finally[0].params[a-result].value", "tasks[1].params[a-result].value"},
+	}
+	err := validateResultsFromMatrixedPipelineTasksNotConsumed(tasks, finally)
+	if err == nil {
+		t.Fatal("Pipeline.validateResultsFromMatrixedPipelineTasksNotConsumed() did not return error for consumed results of a matrixed task")
+	}
+	if d := cmp.Diff(expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+		t.Errorf("Pipeline.validateResultsFromMatrixedPipelineTasksNotConsumed() errors diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestValidatePipelineResults_Success(t *testing.T) {
 	desc := "valid pipeline with valid pipeline results syntax"
 	results := []PipelineResult{{
@@ -1178,6 +1420,30 @@ func TestValidatePipelineParameterVariables_Success(t *testing.T) {
 				Name: "a-param", Value: ArrayOrString{StringVal: "$(input.workspace.$(baz))"},
 			}},
 		}},
+	}, {
+		name: "valid object parameter variables",
+		params: []ParamSpec{{
+			Name: "git", Type: ParamTypeObject,
+			Properties: map[string]PropertySpec{"url": {Type: ParamTypeString}, "revision": {Type: ParamTypeString}},
+		}},
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: []Param{{
+				Name: "url", Value: *NewArrayOrString("$(params.git.url)"),
+			}, {
+				Name: "whole-object", Value: *NewArrayOrString("$(params.git[*])"),
+			}, {
+				Name: "an-object", Value: *NewObject(map[string]string{"url": "$(params.git.url)", "revision": "main"}),
+			}, {
+				Name: "an-array", Value: *NewArrayOrString("$(params.git.url)", "$(params.git.revision)"),
+			}},
+			WhenExpressions: []WhenExpression{{
+				Input:    "$(params.git.revision)",
+				Operator: selection.In,
+				Values:   []string{"main"},
+			}},
+		}},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1456,6 +1722,55 @@ func TestValidatePipelineParameterVariables_Failure(t *testing.T) {
 			Message: `parameter appears more than once`,
 			Paths:   []string{"params[baz]"},
 		},
+	}, {
+		name: "object parameter without properties",
+		params: []ParamSpec{{
+			Name: "git", Type: ParamTypeObject,
+		}},
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+		}},
+		expectedError: apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"params[git].properties"},
+		},
+	}, {
+		name: "object parameter referenced with undeclared key",
+		params: []ParamSpec{{
+			Name: "git", Type: ParamTypeObject,
+			Properties: map[string]PropertySpec{"url": {Type: ParamTypeString}},
+		}},
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Params: []Param{{
+				Name: "a-param", Value: *NewArrayOrString("$(params.git.revision)"),
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent object key in "$(params.git.revision)"`,
+			Paths:   []string{"[0].params[a-param]"},
+		},
+	}, {
+		name: "object parameter referenced as a whole in when expression",
+		params: []ParamSpec{{
+			Name: "git", Type: ParamTypeObject,
+			Properties: map[string]PropertySpec{"url": {Type: ParamTypeString}},
+		}},
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			WhenExpressions: []WhenExpression{{
+				Input:    "$(params.git[*])",
+				Operator: selection.In,
+				Values:   []string{"foo"},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `object variable must reference one of its keys in "$(params.git[*])"`,
+			Paths:   []string{"[0].when[0].input"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2008,19 +2323,45 @@ func TestValidateFinalTasks_Failure(t *testing.T) {
 			Paths:   []string{"finally[0].params"},
 		},
 	}, {
-		name: "invalid pipeline with final task specifying when expressions",
+		name: "invalid pipeline with final task specifying when expressions consuming a result of a final task",
+		tasks: []PipelineTask{{
+			Name:    "non-final-task",
+			TaskRef: &TaskRef{Name: "non-final-task"},
+		}},
+		finalTasks: []PipelineTask{{
+			Name:    "final-task-1",
+			TaskRef: &TaskRef{Name: "final-task"},
+		}, {
+			Name:    "final-task-2",
+			TaskRef: &TaskRef{Name: "final-task"},
+			WhenExpressions: []WhenExpression{{
+				Input:    "$(tasks.final-task-1.results.output)",
+				Operator: selection.In,
+				Values:   []string{"foo", "bar"},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: invalid task result reference, final task when expression has task result reference from a final task`,
+			Paths:   []string{"finally[1].when"},
+		},
+	}, {
+		name: "invalid pipeline with final task specifying when expressions consuming a result of a missing task",
+		tasks: []PipelineTask{{
+			Name:    "non-final-task",
+			TaskRef: &TaskRef{Name: "non-final-task"},
+		}},
 		finalTasks: []PipelineTask{{
 			Name:    "final-task",
 			TaskRef: &TaskRef{Name: "final-task"},
 			WhenExpressions: []WhenExpression{{
-				Input:    "foo",
+				Input:    "$(tasks.no-such-task.results.output)",
 				Operator: selection.In,
 				Values:   []string{"foo", "bar"},
 			}},
 		}},
 		expectedError: apis.FieldError{
-			Message: `invalid value: no when expressions allowed under spec.finally, final task final-task has when expressions specified`,
-			Paths:   []string{"finally[0]"},
+			Message: `invalid value: invalid task result reference, final task when expression has task result reference from a task which is not defined in the pipeline`,
+			Paths:   []string{"finally[0].when"},
 		},
 	}}
 	for _, tt := range tests {
@@ -2202,6 +2543,57 @@ func TestPipelineTasksExecutionStatus(t *testing.T) {
 				Name: "foo-status", Value: ArrayOrString{Type: ParamTypeString, StringVal: "Execution status of $(tasks.taskname) is $(tasks.foo.status)."},
 			}},
 		}},
+	}, {
+		name: "valid when expressions in finally accessing pipelineTask status and aggregate status",
+		tasks: []PipelineTask{{
+			Name: "foo",
+		}},
+		finalTasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: []Param{{
+				Name: "tasks-status", Value: ArrayOrString{Type: ParamTypeString, StringVal: "$(tasks.status)"},
+			}},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(tasks.foo.status)",
+				Operator: selection.In,
+				Values:   []string{"Failed"},
+			}, {
+				Input:    "$(tasks.status)",
+				Operator: selection.NotIn,
+				Values:   []string{"Succeeded"},
+			}},
+		}},
+	}, {
+		name: "invalid when expression in dag task accessing pipelineTask status",
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(tasks.bar.status)",
+				Operator: selection.In,
+				Values:   []string{"Failed"},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: when expressions in pipeline tasks can not refer to execution status of any other pipeline task`,
+			Paths:   []string{"tasks[0].when[0]"},
+		},
+	}, {
+		name: "invalid when expression in finally accessing missing pipelineTask status",
+		finalTasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(tasks.notask.status)",
+				Operator: selection.In,
+				Values:   []string{"Failed"},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: pipeline task notask is not defined in the pipeline`,
+			Paths:   []string{"finally[0].when[0]"},
+		},
 	}, {
 		name: "invalid string variable in dag task accessing pipelineTask status",
 		tasks: []PipelineTask{{
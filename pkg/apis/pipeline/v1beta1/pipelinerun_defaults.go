@@ -33,9 +33,12 @@ func (pr *PipelineRun) SetDefaults(ctx context.Context) {
 
 func (prs *PipelineRunSpec) SetDefaults(ctx context.Context) {
 	cfg := config.FromContextOrDefaults(ctx)
-	if prs.Timeout == nil {
+	if prs.Timeout == nil && prs.Timeouts == nil {
 		prs.Timeout = &metav1.Duration{Duration: time.Duration(cfg.Defaults.DefaultTimeoutMinutes) * time.Minute}
 	}
+	if prs.Timeouts != nil && prs.Timeouts.Pipeline == nil {
+		prs.Timeouts.Pipeline = &metav1.Duration{Duration: time.Duration(cfg.Defaults.DefaultTimeoutMinutes) * time.Minute}
+	}
 
 	defaultSA := cfg.Defaults.DefaultServiceAccount
 	if prs.ServiceAccountName == "" && defaultSA != "" {
@@ -50,4 +53,13 @@ func (prs *PipelineRunSpec) SetDefaults(ctx context.Context) {
 	if prs.PipelineSpec != nil {
 		prs.PipelineSpec.SetDefaults(ctx)
 	}
+
+	if prs.Concurrency != nil {
+		if prs.Concurrency.MaxRuns == 0 {
+			prs.Concurrency.MaxRuns = 1
+		}
+		if prs.Concurrency.Strategy == "" {
+			prs.Concurrency.Strategy = PipelineRunConcurrencyStrategyQueue
+		}
+	}
 }
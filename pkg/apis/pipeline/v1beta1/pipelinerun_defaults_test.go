@@ -56,6 +56,21 @@ func TestPipelineRunSpec_SetDefaults(t *testing.T) {
 				Timeout:            &metav1.Duration{Duration: 500 * time.Millisecond},
 			},
 		},
+		{
+			desc: "timeouts without pipeline timeout",
+			prs: &v1beta1.PipelineRunSpec{
+				Timeouts: &v1beta1.TimeoutFields{
+					Tasks: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			},
+			want: &v1beta1.PipelineRunSpec{
+				ServiceAccountName: config.DefaultServiceAccountValue,
+				Timeouts: &v1beta1.TimeoutFields{
+					Pipeline: &metav1.Duration{Duration: config.DefaultTimeoutMinutes * time.Minute},
+					Tasks:    &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			},
+		},
 		{
 			desc: "pod template is nil",
 			prs:  &v1beta1.PipelineRunSpec{},
@@ -83,6 +98,40 @@ func TestPipelineRunSpec_SetDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "concurrency maxRuns and strategy are not set",
+			prs: &v1beta1.PipelineRunSpec{
+				Concurrency: &v1beta1.PipelineRunConcurrency{Key: "deploy"},
+			},
+			want: &v1beta1.PipelineRunSpec{
+				ServiceAccountName: config.DefaultServiceAccountValue,
+				Timeout:            &metav1.Duration{Duration: config.DefaultTimeoutMinutes * time.Minute},
+				Concurrency: &v1beta1.PipelineRunConcurrency{
+					Key:      "deploy",
+					MaxRuns:  1,
+					Strategy: v1beta1.PipelineRunConcurrencyStrategyQueue,
+				},
+			},
+		},
+		{
+			desc: "concurrency maxRuns and strategy are set",
+			prs: &v1beta1.PipelineRunSpec{
+				Concurrency: &v1beta1.PipelineRunConcurrency{
+					Key:      "deploy",
+					MaxRuns:  3,
+					Strategy: v1beta1.PipelineRunConcurrencyStrategyCancelOlder,
+				},
+			},
+			want: &v1beta1.PipelineRunSpec{
+				ServiceAccountName: config.DefaultServiceAccountValue,
+				Timeout:            &metav1.Duration{Duration: config.DefaultTimeoutMinutes * time.Minute},
+				Concurrency: &v1beta1.PipelineRunConcurrency{
+					Key:      "deploy",
+					MaxRuns:  3,
+					Strategy: v1beta1.PipelineRunConcurrencyStrategyCancelOlder,
+				},
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
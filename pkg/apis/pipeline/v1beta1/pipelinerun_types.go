@@ -88,13 +88,83 @@ func (pr *PipelineRun) IsCancelled() bool {
 	return pr.Spec.Status == PipelineRunSpecStatusCancelled
 }
 
+// IsGracefullyCancelled returns true if the PipelineRun's spec status is set to CancelledRunFinally state
+func (pr *PipelineRun) IsGracefullyCancelled() bool {
+	return pr.Spec.Status == PipelineRunSpecStatusCancelledRunFinally
+}
+
+// IsGracefullyStopped returns true if the PipelineRun's spec status is set to StoppedRunFinally state
+func (pr *PipelineRun) IsGracefullyStopped() bool {
+	return pr.Spec.Status == PipelineRunSpecStatusStoppedRunFinally
+}
+
+// IsPending returns true if the PipelineRun's spec status is set to Pending state
+func (pr *PipelineRun) IsPending() bool {
+	return pr.Spec.Status == PipelineRunSpecStatusPending
+}
+
+// GetTimeout returns the timeout of the whole PipelineRun, set either with
+// spec.timeout or with spec.timeouts.pipeline
 func (pr *PipelineRun) GetTimeout(ctx context.Context) time.Duration {
 	// Use the platform default is no timeout is set
-	if pr.Spec.Timeout == nil {
+	pipelineTimeout := pr.pipelineTimeout()
+	if pipelineTimeout == nil {
 		defaultTimeout := time.Duration(config.FromContextOrDefaults(ctx).Defaults.DefaultTimeoutMinutes)
 		return defaultTimeout * time.Minute
 	}
-	return pr.Spec.Timeout.Duration
+	return pipelineTimeout.Duration
+}
+
+func (pr *PipelineRun) pipelineTimeout() *metav1.Duration {
+	if pr.Spec.Timeout != nil {
+		return pr.Spec.Timeout
+	}
+	if pr.Spec.Timeouts != nil {
+		return pr.Spec.Timeouts.Pipeline
+	}
+	return nil
+}
+
+// TasksTimeout returns the timeout of the tasks of the PipelineRun, excluding
+// the finally tasks. It is either set with spec.timeouts.tasks or computed from
+// the pipeline and finally timeouts. It returns nil if the tasks don't have a
+// timeout of their own.
+func (pr *PipelineRun) TasksTimeout() *metav1.Duration {
+	t := pr.Spec.Timeouts
+	if t == nil {
+		return nil
+	}
+	if t.Tasks != nil {
+		return t.Tasks
+	}
+	if t.Pipeline != nil && t.Finally != nil {
+		if t.Pipeline.Duration == config.NoTimeoutDuration || t.Finally.Duration == config.NoTimeoutDuration {
+			return nil
+		}
+		return &metav1.Duration{Duration: t.Pipeline.Duration - t.Finally.Duration}
+	}
+	return nil
+}
+
+// FinallyTimeout returns the timeout of the finally tasks of the PipelineRun,
+// counted from the time they start. It is either set with spec.timeouts.finally
+// or computed from the pipeline and tasks timeouts. It returns nil if the
+// finally tasks don't have a timeout of their own.
+func (pr *PipelineRun) FinallyTimeout() *metav1.Duration {
+	t := pr.Spec.Timeouts
+	if t == nil {
+		return nil
+	}
+	if t.Finally != nil {
+		return t.Finally
+	}
+	if t.Pipeline != nil && t.Tasks != nil {
+		if t.Pipeline.Duration == config.NoTimeoutDuration || t.Tasks.Duration == config.NoTimeoutDuration {
+			return nil
+		}
+		return &metav1.Duration{Duration: t.Pipeline.Duration - t.Tasks.Duration}
+	}
+	return nil
 }
 
 // GetNamespacedName returns a k8s namespaced name that identifies this PipelineRun
@@ -109,7 +179,7 @@ func (pr *PipelineRun) IsTimedOut() bool {
 
 // HasTimedOut returns true if a pipelinerun has exceeded its spec.Timeout based on its status.Timeout
 func (pr *PipelineRun) HasTimedOut() bool {
-	pipelineTimeout := pr.Spec.Timeout
+	pipelineTimeout := pr.pipelineTimeout()
 	startTime := pr.Status.StartTime
 
 	if !startTime.IsZero() && pipelineTimeout != nil {
@@ -125,6 +195,32 @@ func (pr *PipelineRun) HasTimedOut() bool {
 	return false
 }
 
+// HaveTasksTimedOut returns true if the tasks timeout of a pipelinerun was reached
+// before its finally tasks started, based on its status.StartTime and status.FinallyStartTime
+func (pr *PipelineRun) HaveTasksTimedOut() bool {
+	timeout := pr.TasksTimeout()
+	startTime := pr.Status.StartTime
+	if startTime.IsZero() || timeout == nil || timeout.Duration == config.NoTimeoutDuration {
+		return false
+	}
+	endTime := time.Now()
+	if pr.Status.FinallyStartTime != nil {
+		endTime = pr.Status.FinallyStartTime.Time
+	}
+	return endTime.Sub(startTime.Time) > timeout.Duration
+}
+
+// HasFinallyTimedOut returns true if the finally tasks of a pipelinerun have exceeded
+// their timeout, based on its status.FinallyStartTime
+func (pr *PipelineRun) HasFinallyTimedOut() bool {
+	timeout := pr.FinallyTimeout()
+	startTime := pr.Status.FinallyStartTime
+	if startTime.IsZero() || timeout == nil || timeout.Duration == config.NoTimeoutDuration {
+		return false
+	}
+	return time.Since(startTime.Time) > timeout.Duration
+}
+
 // GetServiceAccountName returns the service account name for a given
 // PipelineTask if configured, otherwise it returns the PipelineRun's serviceAccountName.
 func (pr *PipelineRun) GetServiceAccountName(pipelineTaskName string) string {
@@ -173,6 +269,10 @@ type PipelineRunSpec struct {
 	// Refer to Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Timeouts splits the timeout of the PipelineRun between its tasks and its
+	// finally tasks. It cannot be used together with Timeout.
+	// +optional
+	Timeouts *TimeoutFields `json:"timeouts,omitempty"`
 	// PodTemplate holds pod specific configuration
 	PodTemplate *PodTemplate `json:"podTemplate,omitempty"`
 	// Workspaces holds a set of workspace bindings that must match names
@@ -182,8 +282,63 @@ type PipelineRunSpec struct {
 	// TaskRunSpecs holds a set of runtime specs
 	// +optional
 	TaskRunSpecs []PipelineTaskRunSpec `json:"taskRunSpecs,omitempty"`
+	// Concurrency limits the number of PipelineRuns sharing the same
+	// concurrency key which can run at the same time
+	// +optional
+	Concurrency *PipelineRunConcurrency `json:"concurrency,omitempty"`
+	// ResumeFrom is the name of a completed PipelineRun of the same Pipeline,
+	// in the same namespace. The successful TaskRuns of that PipelineRun are
+	// reused instead of running their PipelineTasks again.
+	// +optional
+	ResumeFrom string `json:"resumeFrom,omitempty"`
 }
 
+// TimeoutFields allows granular specification of pipeline, tasks, and finally timeouts
+type TimeoutFields struct {
+	// Pipeline sets the maximum allowed duration for execution of the entire pipeline.
+	// The sum of individual timeouts for tasks and finally must not exceed this value.
+	// +optional
+	Pipeline *metav1.Duration `json:"pipeline,omitempty"`
+	// Tasks sets the maximum allowed duration of this pipeline's tasks
+	// +optional
+	Tasks *metav1.Duration `json:"tasks,omitempty"`
+	// Finally sets the maximum allowed duration of this pipeline's finally tasks,
+	// counted from the time they start
+	// +optional
+	Finally *metav1.Duration `json:"finally,omitempty"`
+}
+
+// PipelineRunConcurrency limits the number of PipelineRuns of a group which
+// can run at the same time. A group is made of the PipelineRuns of a namespace
+// with the same resolved Key.
+type PipelineRunConcurrency struct {
+	// Key identifies the group of the PipelineRun. It can reference the
+	// parameters of the PipelineRun, e.g. "deploy-$(params.environment)".
+	Key string `json:"key"`
+	// MaxRuns is the number of PipelineRuns of the group which can run at the
+	// same time. Defaults to 1.
+	// +optional
+	MaxRuns int `json:"maxRuns,omitempty"`
+	// Strategy defines what happens to a new PipelineRun when MaxRuns
+	// PipelineRuns of its group are already running: "Queue" (the default)
+	// holds it until they finish, "CancelOlder" cancels the oldest ones.
+	// +optional
+	Strategy PipelineRunConcurrencyStrategy `json:"strategy,omitempty"`
+}
+
+// PipelineRunConcurrencyStrategy defines how the concurrency limit of a group
+// of PipelineRuns is enforced
+type PipelineRunConcurrencyStrategy string
+
+const (
+	// PipelineRunConcurrencyStrategyQueue holds new PipelineRuns until the
+	// earlier PipelineRuns of their group finish
+	PipelineRunConcurrencyStrategyQueue = "Queue"
+	// PipelineRunConcurrencyStrategyCancelOlder cancels the oldest running
+	// PipelineRuns of the group to make room for new PipelineRuns
+	PipelineRunConcurrencyStrategyCancelOlder = "CancelOlder"
+)
+
 // PipelineRunSpecStatus defines the pipelinerun spec status the user can provide
 type PipelineRunSpecStatus string
 
@@ -191,6 +346,18 @@ const (
 	// PipelineRunSpecStatusCancelled indicates that the user wants to cancel the task,
 	// if not already cancelled or terminated
 	PipelineRunSpecStatusCancelled = "PipelineRunCancelled"
+
+	// PipelineRunSpecStatusCancelledRunFinally indicates that the user wants to cancel the PipelineRun,
+	// cancelling its running tasks but still executing its finally tasks
+	PipelineRunSpecStatusCancelledRunFinally = "CancelledRunFinally"
+
+	// PipelineRunSpecStatusStoppedRunFinally indicates that the user wants to stop the PipelineRun,
+	// letting its running tasks complete and then executing its finally tasks
+	PipelineRunSpecStatusStoppedRunFinally = "StoppedRunFinally"
+
+	// PipelineRunSpecStatusPending indicates that the user wants to postpone starting a PipelineRun
+	// until some condition is met
+	PipelineRunSpecStatusPending = "PipelineRunPending"
 )
 
 // PipelineRef can be used to refer to a specific instance of a Pipeline.
@@ -204,6 +371,11 @@ type PipelineRef struct {
 	// Bundle url reference to a Tekton Bundle.
 	// +optional
 	Bundle string `json:"bundle,omitempty"`
+
+	// ResolverRef allows referencing a Pipeline in a remote location
+	// like a git repository.
+	// +optional
+	ResolverRef `json:",omitempty"`
 }
 
 // PipelineRunStatus defines the observed state of PipelineRun
@@ -234,9 +406,33 @@ const (
 	PipelineRunReasonCancelled PipelineRunReason = "Cancelled"
 	// PipelineRunReasonTimedOut is the reason set when the PipelineRun has timed out
 	PipelineRunReasonTimedOut PipelineRunReason = "PipelineRunTimeout"
+	// PipelineRunReasonTasksTimedOut is the reason set when the tasks of the PipelineRun
+	// exceeded the tasks timeout, whether or not its finally tasks completed successfully
+	PipelineRunReasonTasksTimedOut PipelineRunReason = "PipelineRunTasksTimeout"
+	// PipelineRunReasonFinallyTimedOut is the reason set when the finally tasks of the
+	// PipelineRun exceeded the finally timeout
+	PipelineRunReasonFinallyTimedOut PipelineRunReason = "PipelineRunFinallyTimeout"
 	// PipelineRunReasonStopping indicates that no new Tasks will be scheduled by the controller, and the
 	// pipeline will stop once all running tasks complete their work
 	PipelineRunReasonStopping PipelineRunReason = "PipelineRunStopping"
+	// PipelineRunReasonCancelledRunningFinally indicates that the PipelineRun was gracefully cancelled:
+	// its running tasks are cancelled and its finally tasks are executed before it completes
+	PipelineRunReasonCancelledRunningFinally PipelineRunReason = "CancelledRunningFinally"
+	// PipelineRunReasonStoppedRunningFinally indicates that the PipelineRun was gracefully stopped:
+	// its running tasks complete and its finally tasks are executed before it completes
+	PipelineRunReasonStoppedRunningFinally PipelineRunReason = "StoppedRunningFinally"
+	// PipelineRunReasonCancelledRunFinally is the reason set when the PipelineRun was gracefully
+	// cancelled and its finally tasks completed
+	PipelineRunReasonCancelledRunFinally PipelineRunReason = "CancelledRunFinally"
+	// PipelineRunReasonStoppedRunFinally is the reason set when the PipelineRun was gracefully
+	// stopped and its finally tasks completed
+	PipelineRunReasonStoppedRunFinally PipelineRunReason = "StoppedRunFinally"
+	// PipelineRunReasonQueued is the reason set when the PipelineRun is waiting for
+	// earlier PipelineRuns of its concurrency group to finish before it can start
+	PipelineRunReasonQueued PipelineRunReason = "PipelineRunQueued"
+	// PipelineRunReasonPending is the reason set when the PipelineRun is in the pending state
+	// and is not started until its spec status is cleared
+	PipelineRunReasonPending PipelineRunReason = "PipelineRunPending"
 )
 
 func (t PipelineRunReason) String() string {
@@ -260,6 +456,9 @@ func (pr *PipelineRunStatus) InitializeConditions() {
 	if pr.Runs == nil {
 		pr.Runs = make(map[string]*PipelineRunRunStatus)
 	}
+	if pr.PipelineRuns == nil {
+		pr.PipelineRuns = make(map[string]*PipelineRunPipelineRunStatus)
+	}
 	if pr.StartTime.IsZero() {
 		pr.StartTime = &metav1.Time{Time: time.Now()}
 		started = true
@@ -325,6 +524,10 @@ type PipelineRunStatusFields struct {
 	// +optional
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 
+	// FinallyStartTime is the time the finally tasks of the PipelineRun started.
+	// +optional
+	FinallyStartTime *metav1.Time `json:"finallyStartTime,omitempty"`
+
 	// map of PipelineRunTaskRunStatus with the taskRun name as the key
 	// +optional
 	TaskRuns map[string]*PipelineRunTaskRunStatus `json:"taskRuns,omitempty"`
@@ -333,6 +536,10 @@ type PipelineRunStatusFields struct {
 	// +optional
 	Runs map[string]*PipelineRunRunStatus `json:"runs,omitempty"`
 
+	// map of PipelineRunPipelineRunStatus with the child PipelineRun name as the key
+	// +optional
+	PipelineRuns map[string]*PipelineRunPipelineRunStatus `json:"pipelineRuns,omitempty"`
+
 	// PipelineResults are the list of results written out by the pipeline task's containers
 	// +optional
 	PipelineResults []PipelineRunResult `json:"pipelineResults,omitempty"`
@@ -351,11 +558,36 @@ type PipelineRunStatusFields struct {
 type SkippedTask struct {
 	// Name is the Pipeline Task name
 	Name string `json:"name"`
+	// Reason is the cause of the PipelineTask being skipped.
+	// +optional
+	Reason SkippingReason `json:"reason,omitempty"`
 	// WhenExpressions is the list of checks guarding the execution of the PipelineTask
 	// +optional
 	WhenExpressions []WhenExpression `json:"whenExpressions,omitempty"`
 }
 
+// SkippingReason explains why a PipelineTask was skipped.
+type SkippingReason string
+
+const (
+	// WhenExpressionsSkip means the task was skipped because its when expressions evaluated to false
+	WhenExpressionsSkip SkippingReason = "When Expressions evaluated to false"
+	// ConditionsSkip means the task was skipped because one of its conditions failed
+	ConditionsSkip SkippingReason = "Conditions failed"
+	// ParentTasksSkip means the task was skipped because its parent task was skipped
+	ParentTasksSkip SkippingReason = "Parent Tasks were skipped"
+	// StoppingSkip means the task was skipped because the PipelineRun was stopping after a failure
+	StoppingSkip SkippingReason = "PipelineRun was stopping"
+	// TasksTimedOutSkip means the task was skipped because the tasks timeout of the PipelineRun was reached
+	TasksTimedOutSkip SkippingReason = "PipelineRun Tasks timeout has been reached"
+	// GracefullyCancelledSkip means the task was skipped because the PipelineRun was gracefully cancelled
+	GracefullyCancelledSkip SkippingReason = "PipelineRun was gracefully cancelled"
+	// GracefullyStoppedSkip means the task was skipped because the PipelineRun was gracefully stopped
+	GracefullyStoppedSkip SkippingReason = "PipelineRun was gracefully stopped"
+	// MissingResultsSkip means the finally task was skipped because the results it consumes were missing
+	MissingResultsSkip SkippingReason = "Results were missing"
+)
+
 // PipelineRunResult used to describe the results of a pipeline
 type PipelineRunResult struct {
 	// Name is the result's name as declared by the Pipeline
@@ -378,6 +610,10 @@ type PipelineRunTaskRunStatus struct {
 	// WhenExpressions is the list of checks guarding the execution of the PipelineTask
 	// +optional
 	WhenExpressions []WhenExpression `json:"whenExpressions,omitempty"`
+	// ReusedFrom is the name of the PipelineRun the TaskRun was reused from,
+	// when the PipelineRun resumes from it
+	// +optional
+	ReusedFrom string `json:"reusedFrom,omitempty"`
 }
 
 // PipelineRunRunStatus contains the name of the PipelineTask for this Run and the Run's Status
@@ -392,6 +628,19 @@ type PipelineRunRunStatus struct {
 	WhenExpressions []WhenExpression `json:"whenExpressions,omitempty"`
 }
 
+// PipelineRunPipelineRunStatus contains the name of the PipelineTask for this child PipelineRun
+// and the child PipelineRun's Status
+type PipelineRunPipelineRunStatus struct {
+	// PipelineTaskName is the name of the PipelineTask.
+	PipelineTaskName string `json:"pipelineTaskName,omitempty"`
+	// Status is the PipelineRunStatus for the corresponding child PipelineRun
+	// +optional
+	Status *PipelineRunStatus `json:"status,omitempty"`
+	// WhenExpressions is the list of checks guarding the execution of the PipelineTask
+	// +optional
+	WhenExpressions []WhenExpression `json:"whenExpressions,omitempty"`
+}
+
 // PipelineRunConditionCheckStatus returns the condition check status
 type PipelineRunConditionCheckStatus struct {
 	// ConditionName is the name of the Condition
@@ -431,6 +680,18 @@ type PipelineTaskRunSpec struct {
 	PipelineTaskName       string       `json:"pipelineTaskName,omitempty"`
 	TaskServiceAccountName string       `json:"taskServiceAccountName,omitempty"`
 	TaskPodTemplate        *PodTemplate `json:"taskPodTemplate,omitempty"`
+	// ComputeResources holds the compute resources of the whole Task of the
+	// PipelineTask
+	// +optional
+	ComputeResources *corev1.ResourceRequirements `json:"computeResources,omitempty"`
+	// StepOverrides overrides the configuration of the steps of the Task of
+	// the PipelineTask
+	// +optional
+	StepOverrides []TaskRunStepOverride `json:"stepOverrides,omitempty"`
+	// SidecarOverrides overrides the configuration of the sidecars of the
+	// Task of the PipelineTask
+	// +optional
+	SidecarOverrides []TaskRunSidecarOverride `json:"sidecarOverrides,omitempty"`
 }
 
 // GetTaskRunSpec returns the task specific spec for a given
@@ -449,6 +710,9 @@ func (pr *PipelineRun) GetTaskRunSpec(pipelineTaskName string) PipelineTaskRunSp
 			if task.TaskServiceAccountName != "" {
 				s.TaskServiceAccountName = task.TaskServiceAccountName
 			}
+			s.ComputeResources = task.ComputeResources
+			s.StepOverrides = task.StepOverrides
+			s.SidecarOverrides = task.SidecarOverrides
 		}
 	}
 	return s
@@ -135,6 +135,34 @@ func TestPipelineRunIsCancelled(t *testing.T) {
 	}
 }
 
+func TestPipelineRunIsGracefullyCancelled(t *testing.T) {
+	pr := &v1beta1.PipelineRun{
+		Spec: v1beta1.PipelineRunSpec{
+			Status: v1beta1.PipelineRunSpecStatusCancelledRunFinally,
+		},
+	}
+	if !pr.IsGracefullyCancelled() {
+		t.Fatal("Expected pipelinerun status to be gracefully cancelled")
+	}
+	if pr.IsCancelled() || pr.IsGracefullyStopped() {
+		t.Fatal("Expected pipelinerun status to be neither cancelled nor gracefully stopped")
+	}
+}
+
+func TestPipelineRunIsGracefullyStopped(t *testing.T) {
+	pr := &v1beta1.PipelineRun{
+		Spec: v1beta1.PipelineRunSpec{
+			Status: v1beta1.PipelineRunSpecStatusStoppedRunFinally,
+		},
+	}
+	if !pr.IsGracefullyStopped() {
+		t.Fatal("Expected pipelinerun status to be gracefully stopped")
+	}
+	if pr.IsCancelled() || pr.IsGracefullyCancelled() {
+		t.Fatal("Expected pipelinerun status to be neither cancelled nor gracefully cancelled")
+	}
+}
+
 func TestPipelineRunHasVolumeClaimTemplate(t *testing.T) {
 	pr := &v1beta1.PipelineRun{
 		Spec: v1beta1.PipelineRunSpec{
@@ -248,6 +276,115 @@ func TestPipelineRunHasTimedOut(t *testing.T) {
 	}
 }
 
+func TestPipelineRunTasksAndFinallyTimeouts(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		timeouts    *v1beta1.TimeoutFields
+		wantTasks   *metav1.Duration
+		wantFinally *metav1.Duration
+	}{{
+		name: "no timeouts",
+	}, {
+		name: "tasks and finally set",
+		timeouts: &v1beta1.TimeoutFields{
+			Pipeline: &metav1.Duration{Duration: time.Hour},
+			Tasks:    &metav1.Duration{Duration: 30 * time.Minute},
+			Finally:  &metav1.Duration{Duration: 10 * time.Minute},
+		},
+		wantTasks:   &metav1.Duration{Duration: 30 * time.Minute},
+		wantFinally: &metav1.Duration{Duration: 10 * time.Minute},
+	}, {
+		name: "finally computed from pipeline and tasks",
+		timeouts: &v1beta1.TimeoutFields{
+			Pipeline: &metav1.Duration{Duration: time.Hour},
+			Tasks:    &metav1.Duration{Duration: 40 * time.Minute},
+		},
+		wantTasks:   &metav1.Duration{Duration: 40 * time.Minute},
+		wantFinally: &metav1.Duration{Duration: 20 * time.Minute},
+	}, {
+		name: "tasks computed from pipeline and finally",
+		timeouts: &v1beta1.TimeoutFields{
+			Pipeline: &metav1.Duration{Duration: time.Hour},
+			Finally:  &metav1.Duration{Duration: 15 * time.Minute},
+		},
+		wantTasks:   &metav1.Duration{Duration: 45 * time.Minute},
+		wantFinally: &metav1.Duration{Duration: 15 * time.Minute},
+	}, {
+		name: "no pipeline timeout",
+		timeouts: &v1beta1.TimeoutFields{
+			Pipeline: &metav1.Duration{Duration: 0},
+			Tasks:    &metav1.Duration{Duration: 40 * time.Minute},
+		},
+		wantTasks: &metav1.Duration{Duration: 40 * time.Minute},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{
+				Spec: v1beta1.PipelineRunSpec{Timeouts: tc.timeouts},
+			}
+			if d := cmp.Diff(tc.wantTasks, pr.TasksTimeout()); d != "" {
+				t.Errorf("TasksTimeout() %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantFinally, pr.FinallyTimeout()); d != "" {
+				t.Errorf("FinallyTimeout() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRunHaveTasksAndFinallyTimedOut(t *testing.T) {
+	now := time.Now()
+	for _, tc := range []struct {
+		name             string
+		startTime        time.Time
+		finallyStartTime *metav1.Time
+		wantTasks        bool
+		wantFinally      bool
+	}{{
+		name:      "tasks running within timeout",
+		startTime: now.Add(-10 * time.Minute),
+	}, {
+		name:      "tasks running past timeout",
+		startTime: now.Add(-40 * time.Minute),
+		wantTasks: true,
+	}, {
+		name:             "finally started within tasks timeout",
+		startTime:        now.Add(-40 * time.Minute),
+		finallyStartTime: &metav1.Time{Time: now.Add(-15 * time.Minute)},
+	}, {
+		name:             "finally started after tasks timeout",
+		startTime:        now.Add(-50 * time.Minute),
+		finallyStartTime: &metav1.Time{Time: now.Add(-15 * time.Minute)},
+		wantTasks:        true,
+	}, {
+		name:             "finally running past timeout",
+		startTime:        now.Add(-50 * time.Minute),
+		finallyStartTime: &metav1.Time{Time: now.Add(-30 * time.Minute)},
+		wantFinally:      true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{
+				Spec: v1beta1.PipelineRunSpec{
+					Timeouts: &v1beta1.TimeoutFields{
+						Pipeline: &metav1.Duration{Duration: time.Hour},
+						Tasks:    &metav1.Duration{Duration: 30 * time.Minute},
+						Finally:  &metav1.Duration{Duration: 20 * time.Minute},
+					},
+				},
+				Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+					StartTime:        &metav1.Time{Time: tc.startTime},
+					FinallyStartTime: tc.finallyStartTime,
+				}},
+			}
+			if got := pr.HaveTasksTimedOut(); got != tc.wantTasks {
+				t.Errorf("HaveTasksTimedOut() = %t, want %t", got, tc.wantTasks)
+			}
+			if got := pr.HasFinallyTimedOut(); got != tc.wantFinally {
+				t.Errorf("HasFinallyTimedOut() = %t, want %t", got, tc.wantFinally)
+			}
+		})
+	}
+}
+
 func TestPipelineRunGetServiceAccountName(t *testing.T) {
 	for _, tt := range []struct {
 		name    string
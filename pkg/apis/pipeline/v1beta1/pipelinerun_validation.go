@@ -19,10 +19,14 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
 
@@ -31,22 +35,37 @@ var _ apis.Validatable = (*PipelineRun)(nil)
 // Validate pipelinerun
 func (pr *PipelineRun) Validate(ctx context.Context) *apis.FieldError {
 	errs := validate.ObjectMetadata(pr.GetObjectMeta()).ViaField("metadata")
+
+	if pr.IsPending() && pr.HasStarted() {
+		errs = errs.Also(apis.ErrInvalidValue("PipelineRun cannot be Pending after it is started", "spec.status"))
+	}
+
+	if pr.Spec.ResumeFrom != "" && pr.Spec.ResumeFrom == pr.Name {
+		errs = errs.Also(apis.ErrInvalidValue("PipelineRun cannot resume from itself", "spec.resumeFrom"))
+	}
+
 	return errs.Also(pr.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 }
 
 // Validate pipelinerun spec
 func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	cfg := config.FromContextOrDefaults(ctx)
+	hasPipelineRef := ps.PipelineRef != nil && (ps.PipelineRef.Name != "" || ps.PipelineRef.HasResolver())
 	// can't have both pipelineRef and pipelineSpec at the same time
-	if (ps.PipelineRef != nil && ps.PipelineRef.Name != "") && ps.PipelineSpec != nil {
+	if hasPipelineRef && ps.PipelineSpec != nil {
 		errs = errs.Also(apis.ErrDisallowedFields("pipelineref", "pipelinespec"))
 	}
 
 	// Check that one of PipelineRef and PipelineSpec is present
-	if (ps.PipelineRef == nil || (ps.PipelineRef != nil && ps.PipelineRef.Name == "")) && ps.PipelineSpec == nil {
+	if !hasPipelineRef && ps.PipelineSpec == nil {
 		errs = errs.Also(apis.ErrMissingField("pipelineref.name", "pipelinespec"))
 	}
 
+	// Validate the resolver reference if it's present
+	if ps.PipelineRef != nil {
+		errs = errs.Also(validateResolverRef(ps.PipelineRef.ResolverRef, ps.PipelineRef.Name, ps.PipelineRef.Bundle).ViaField("pipelineref"))
+	}
+
 	// If EnableTektonOCIBundles feature flag is on validate it.
 	// Otherwise, fail if it is present (as it won't be allowed nor used)
 	if cfg.FeatureFlags.EnableTektonOCIBundles {
@@ -77,10 +96,29 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 		}
 	}
 
-	if ps.Status != "" {
-		if ps.Status != PipelineRunSpecStatusCancelled {
-			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %s", ps.Status, PipelineRunSpecStatusCancelled), "status"))
+	if ps.Timeouts != nil {
+		if ps.Timeout != nil {
+			// can't have both at the same time
+			errs = errs.Also(apis.ErrDisallowedFields("timeout", "timeouts"))
 		}
+		errs = errs.Also(ps.Timeouts.validate(ctx).ViaField("timeouts"))
+	}
+
+	if ps.Concurrency != nil {
+		errs = errs.Also(ps.Concurrency.validate().ViaField("concurrency"))
+	}
+
+	if ps.ResumeFrom != "" {
+		if errSlice := validation.IsDNS1123Subdomain(ps.ResumeFrom); len(errSlice) != 0 {
+			errs = errs.Also(apis.ErrInvalidValue(strings.Join(errSlice, ","), "resumeFrom"))
+		}
+	}
+
+	switch ps.Status {
+	case "", PipelineRunSpecStatusCancelled, PipelineRunSpecStatusCancelledRunFinally, PipelineRunSpecStatusStoppedRunFinally, PipelineRunSpecStatusPending:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %s, %s, %s or %s", ps.Status,
+			PipelineRunSpecStatusCancelled, PipelineRunSpecStatusCancelledRunFinally, PipelineRunSpecStatusStoppedRunFinally, PipelineRunSpecStatusPending), "status"))
 	}
 
 	if ps.Workspaces != nil {
@@ -94,5 +132,70 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 		}
 	}
 
+	for idx, trs := range ps.TaskRunSpecs {
+		if trs.ComputeResources != nil {
+			errs = errs.Also(validateComputeResources(*trs.ComputeResources).ViaField("computeResources").ViaFieldIndex("taskRunSpecs", idx))
+		}
+		errs = errs.Also(validateOverrides(trs.StepOverrides, trs.SidecarOverrides, trs.ComputeResources).ViaFieldIndex("taskRunSpecs", idx))
+	}
+
+	return errs
+}
+
+func (t *TimeoutFields) validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(validateTimeoutDuration(t.Pipeline, "pipeline"))
+	errs = errs.Also(validateTimeoutDuration(t.Tasks, "tasks"))
+	errs = errs.Also(validateTimeoutDuration(t.Finally, "finally"))
+	if errs != nil {
+		return errs
+	}
+
+	pipelineTimeout := time.Duration(config.FromContextOrDefaults(ctx).Defaults.DefaultTimeoutMinutes) * time.Minute
+	if t.Pipeline != nil {
+		pipelineTimeout = t.Pipeline.Duration
+	}
+	if pipelineTimeout == config.NoTimeoutDuration {
+		// tasks and finally can have any timeout when the pipeline has none
+		return nil
+	}
+	// tasks and finally can't run without timeout when the pipeline has one
+	errs = errs.Also(validateTimeoutWithinPipeline(t.Tasks, pipelineTimeout, "tasks"))
+	errs = errs.Also(validateTimeoutWithinPipeline(t.Finally, pipelineTimeout, "finally"))
+	if errs == nil && t.Tasks != nil && t.Finally != nil && t.Tasks.Duration+t.Finally.Duration > pipelineTimeout {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("invalid value: %s + %s should be <= the pipeline timeout %s", t.Tasks.Duration, t.Finally.Duration, pipelineTimeout),
+			Paths:   []string{"tasks", "finally"},
+		})
+	}
+	return errs
+}
+
+func validateTimeoutDuration(timeout *metav1.Duration, field string) *apis.FieldError {
+	// timeout should be a valid duration of at least 0.
+	if timeout != nil && timeout.Duration < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", timeout.Duration), field)
+	}
+	return nil
+}
+
+func validateTimeoutWithinPipeline(timeout *metav1.Duration, pipelineTimeout time.Duration, field string) *apis.FieldError {
+	if timeout != nil && (timeout.Duration == config.NoTimeoutDuration || timeout.Duration > pipelineTimeout) {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0 and <= the pipeline timeout %s", timeout.Duration, pipelineTimeout), field)
+	}
+	return nil
+}
+
+func (c *PipelineRunConcurrency) validate() (errs *apis.FieldError) {
+	if c.Key == "" {
+		errs = errs.Also(apis.ErrMissingField("key"))
+	}
+	if c.MaxRuns < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 1", c.MaxRuns), "maxRuns"))
+	}
+	switch c.Strategy {
+	case "", PipelineRunConcurrencyStrategyQueue, PipelineRunConcurrencyStrategyCancelOlder:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %s or %s", c.Strategy, PipelineRunConcurrencyStrategyQueue, PipelineRunConcurrencyStrategyCancelOlder), "strategy"))
+	}
 	return errs
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	logtesting "knative.dev/pkg/logging/testing"
@@ -65,6 +66,20 @@ func TestPipelineRun_Invalid(t *testing.T) {
 				},
 			},
 			want: apis.ErrMissingField("spec.pipelineref.name, spec.pipelinespec"),
+		}, {
+			name: "resume from itself",
+			pr: v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinerun",
+				},
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineRef: &v1beta1.PipelineRef{
+						Name: "prname",
+					},
+					ResumeFrom: "pipelinerun",
+				},
+			},
+			want: apis.ErrInvalidValue("PipelineRun cannot resume from itself", "spec.resumeFrom"),
 		}, {
 			name: "negative pipeline timeout",
 			pr: v1beta1.PipelineRun{
@@ -92,7 +107,26 @@ func TestPipelineRun_Invalid(t *testing.T) {
 					Status: "PipelineRunCancell",
 				},
 			},
-			want: apis.ErrInvalidValue("PipelineRunCancell should be PipelineRunCancelled", "spec.status"),
+			want: apis.ErrInvalidValue("PipelineRunCancell should be PipelineRunCancelled, CancelledRunFinally, StoppedRunFinally or PipelineRunPending", "spec.status"),
+		}, {
+			name: "pipelinerun pending after it is started",
+			pr: v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineRef: &v1beta1.PipelineRef{
+						Name: "prname",
+					},
+					Status: v1beta1.PipelineRunSpecStatusPending,
+				},
+				Status: v1beta1.PipelineRunStatus{
+					PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+						StartTime: &metav1.Time{Time: time.Now()},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("PipelineRun cannot be Pending after it is started", "spec.status"),
 		}, {
 			name: "use of bundle without the feature flag set",
 			pr: v1beta1.PipelineRun{
@@ -183,6 +217,45 @@ func TestPipelineRun_Validate(t *testing.T) {
 				Timeout: &metav1.Duration{Duration: 0},
 			},
 		},
+	}, {
+		name: "pending pipelinerun",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelineName",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				Status: v1beta1.PipelineRunSpecStatusPending,
+			},
+		},
+	}, {
+		name: "gracefully cancelled pipelinerun",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelineName",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				Status: v1beta1.PipelineRunSpecStatusCancelledRunFinally,
+			},
+		},
+	}, {
+		name: "gracefully stopped pipelinerun",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelineName",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				Status: v1beta1.PipelineRunSpecStatusStoppedRunFinally,
+			},
+		},
 	}, {
 		name: "array param with pipelinespec and taskspec",
 		pr: v1beta1.PipelineRun{
@@ -258,6 +331,16 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 				}}},
 		},
 		wantErr: apis.ErrDisallowedFields("pipelinespec", "pipelineref"),
+	}, {
+		name: "pipelineRef with a resolver and a bundle",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{
+				Bundle:      "docker.io/foo",
+				ResolverRef: v1beta1.ResolverRef{Resolver: "git"},
+			},
+		},
+		wantErr: apis.ErrMultipleOneOf("pipelineref.bundle", "pipelineref.resolver").Also(
+			apis.ErrDisallowedFields("pipelineref.bundle")),
 	}, {
 		name: "workspaces may only appear once",
 		spec: v1beta1.PipelineRunSpec{
@@ -296,6 +379,130 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 				"workspaces[0].volumeclaimtemplate",
 			},
 		},
+	}, {
+		name: "concurrency without key",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{
+				Name: "pipelinerefname",
+			},
+			Concurrency: &v1beta1.PipelineRunConcurrency{
+				MaxRuns: 1,
+			},
+		},
+		wantErr: apis.ErrMissingField("concurrency.key"),
+	}, {
+		name: "concurrency with negative maxRuns",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{
+				Name: "pipelinerefname",
+			},
+			Concurrency: &v1beta1.PipelineRunConcurrency{
+				Key:     "deploy",
+				MaxRuns: -1,
+			},
+		},
+		wantErr: apis.ErrInvalidValue("-1 should be >= 1", "concurrency.maxRuns"),
+	}, {
+		name: "concurrency with invalid strategy",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{
+				Name: "pipelinerefname",
+			},
+			Concurrency: &v1beta1.PipelineRunConcurrency{
+				Key:      "deploy",
+				Strategy: "CancelNewer",
+			},
+		},
+		wantErr: apis.ErrInvalidValue("CancelNewer should be Queue or CancelOlder", "concurrency.strategy"),
+	}, {
+		name: "invalid resumeFrom name",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{
+				Name: "pipelinerefname",
+			},
+			ResumeFrom: "Previous_Run",
+		},
+		wantErr: apis.ErrInvalidValue("a DNS-1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')", "resumeFrom"),
+	}, {
+		name: "timeout and timeouts together",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+			Timeout:     &metav1.Duration{Duration: time.Hour},
+			Timeouts: &v1beta1.TimeoutFields{
+				Tasks: &metav1.Duration{Duration: 30 * time.Minute},
+			},
+		},
+		wantErr: apis.ErrDisallowedFields("timeout", "timeouts"),
+	}, {
+		name: "negative tasks timeout",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+			Timeouts: &v1beta1.TimeoutFields{
+				Tasks: &metav1.Duration{Duration: -time.Minute},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("-1m0s should be >= 0", "timeouts.tasks"),
+	}, {
+		name: "finally timeout greater than pipeline timeout",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+			Timeouts: &v1beta1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: time.Hour},
+				Finally:  &metav1.Duration{Duration: 2 * time.Hour},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("2h0m0s should be > 0 and <= the pipeline timeout 1h0m0s", "timeouts.finally"),
+	}, {
+		name: "no tasks timeout with a pipeline timeout",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+			Timeouts: &v1beta1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: time.Hour},
+				Tasks:    &metav1.Duration{Duration: 0},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("0s should be > 0 and <= the pipeline timeout 1h0m0s", "timeouts.tasks"),
+	}, {
+		name: "tasks and finally timeouts exceed pipeline timeout",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+			Timeouts: &v1beta1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: time.Hour},
+				Tasks:    &metav1.Duration{Duration: 40 * time.Minute},
+				Finally:  &metav1.Duration{Duration: 30 * time.Minute},
+			},
+		},
+		wantErr: &apis.FieldError{
+			Message: "invalid value: 40m0s + 30m0s should be <= the pipeline timeout 1h0m0s",
+			Paths:   []string{"timeouts.tasks", "timeouts.finally"},
+		},
+	}, {
+		name: "task compute resources request greater than limit",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "build",
+				ComputeResources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			}},
+		},
+		wantErr: apis.ErrInvalidValue("2Gi must be less than or equal to the limit 1Gi", "taskRunSpecs[0].computeResources.requests[memory]"),
+	}, {
+		name: "step overridden more than once",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "build",
+				StepOverrides: []v1beta1.TaskRunStepOverride{{
+					Name: "compile",
+				}, {
+					Name: "compile",
+				}},
+			}},
+		},
+		wantErr: apis.ErrInvalidValue("compile is overridden more than once", "taskRunSpecs[0].stepOverrides[1].name"),
 	}}
 	for _, ps := range tests {
 		t.Run(ps.name, func(t *testing.T) {
@@ -312,6 +519,14 @@ func TestPipelineRunSpec_Validate(t *testing.T) {
 		name string
 		spec v1beta1.PipelineRunSpec
 	}{{
+		name: "PipelineRun resuming from another PipelineRun",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{
+				Name: "pipelinerefname",
+			},
+			ResumeFrom: "previous-run",
+		},
+	}, {
 		name: "PipelineRun without pipelineRef",
 		spec: v1beta1.PipelineRunSpec{
 			PipelineSpec: &v1beta1.PipelineSpec{
@@ -323,6 +538,50 @@ func TestPipelineRunSpec_Validate(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "PipelineRun with a resolver",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{
+				ResolverRef: v1beta1.ResolverRef{
+					Resolver: "http",
+					Params: []v1beta1.Param{{
+						Name:  "url",
+						Value: *v1beta1.NewArrayOrString("https://example.com/pipeline.yaml"),
+					}},
+				},
+			},
+		},
+	}, {
+		name: "PipelineRun with tasks and finally timeouts",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+			Timeouts: &v1beta1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: time.Hour},
+				Tasks:    &metav1.Duration{Duration: 40 * time.Minute},
+				Finally:  &metav1.Duration{Duration: 20 * time.Minute},
+			},
+		},
+	}, {
+		name: "PipelineRun with tasks timeout and no pipeline timeout",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+			Timeouts: &v1beta1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: 0},
+				Tasks:    &metav1.Duration{Duration: 0},
+			},
+		},
+	}, {
+		name: "PipelineRun with task compute resources",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "build",
+				ComputeResources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				},
+			}},
+		},
 	}}
 	for _, ps := range tests {
 		t.Run(ps.name, func(t *testing.T) {
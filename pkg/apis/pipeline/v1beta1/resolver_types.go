@@ -0,0 +1,45 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// ResolverRef can be used to refer to a Task or Pipeline stored in a remote
+// location, such as a git repository, and fetched by a named resolver.
+type ResolverRef struct {
+	// Resolver is the name of the resolver that should fetch the referenced
+	// Tekton resource, for example "git", "cluster" or "http".
+	// +optional
+	Resolver string `json:"resolver,omitempty"`
+	// Params contains the parameters used by the resolver to identify the
+	// referenced Tekton resource. The set of supported params depends on
+	// the chosen resolver.
+	// +optional
+	Params []Param `json:"params,omitempty"`
+}
+
+// HasResolver returns true if the reference should be fetched by a resolver.
+func (r ResolverRef) HasResolver() bool {
+	return r.Resolver != ""
+}
+
+// ParamsMap returns the string values of the resolver params keyed by name.
+func (r ResolverRef) ParamsMap() map[string]string {
+	params := make(map[string]string, len(r.Params))
+	for _, p := range r.Params {
+		params[p.Name] = p.Value.StringVal
+	}
+	return params
+}
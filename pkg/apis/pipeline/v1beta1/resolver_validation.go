@@ -0,0 +1,46 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	"knative.dev/pkg/apis"
+)
+
+// validateResolverRef ensures that params are only given along with a resolver, that a resolver
+// is not combined with a name or a bundle, and that the resolver params are unique strings.
+func validateResolverRef(r ResolverRef, name, bundle string) (errs *apis.FieldError) {
+	if !r.HasResolver() {
+		if len(r.Params) > 0 {
+			errs = errs.Also(apis.ErrMissingField("resolver"))
+		}
+		return errs
+	}
+	if name != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("name", "resolver"))
+	}
+	if bundle != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("bundle", "resolver"))
+	}
+	for i, p := range r.Params {
+		if p.Value.Type != "" && p.Value.Type != ParamTypeString {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("resolver param %q must be a string", p.Name), "value").ViaFieldIndex("params", i))
+		}
+	}
+	return errs.Also(validateParameters(r.Params).ViaField("params"))
+}
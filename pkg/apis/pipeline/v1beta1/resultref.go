@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -35,13 +36,18 @@ const (
 	// ResultResultPart Constant used to define the "results" part of a pipeline result reference
 	ResultResultPart = "results"
 	// TODO(#2462) use one regex across all substitutions
-	variableSubstitutionFormat = `\$\([_a-zA-Z0-9.-]+(\.[_a-zA-Z0-9.-]+)*\)`
+	// A variable may end with an index, e.g. $(tasks.a.results.b[1]), or a
+	// star, e.g. $(tasks.a.results.b[*]), to reference the elements of an array.
+	variableSubstitutionFormat = `\$\([_a-zA-Z0-9.-]+(\.[_a-zA-Z0-9.-]+)*(\[([0-9]+|\*)\])?\)`
+	// arrayIndexingFormat is the format of the index or star ending a variable
+	arrayIndexingFormat = `\[([0-9]+|\*)\]$`
 	// ResultNameFormat Constant used to define the the regex Result.Name should follow
 	ResultNameFormat = `^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$`
 )
 
 var variableSubstitutionRegex = regexp.MustCompile(variableSubstitutionFormat)
 var resultNameFormatRegex = regexp.MustCompile(ResultNameFormat)
+var arrayIndexingRegex = regexp.MustCompile(arrayIndexingFormat)
 
 // NewResultRefs extracts all ResultReferences from a param or a pipeline result.
 // If the ResultReference can be extracted, they are returned. Expressions which are not
@@ -94,6 +100,11 @@ func GetVarSubstitutionExpressionsForParam(param Param) ([]string, bool) {
 	case ParamTypeString:
 		// string type
 		allExpressions = append(allExpressions, validateString(param.Value.StringVal)...)
+	case ParamTypeObject:
+		// object type
+		for _, value := range param.Value.ObjectVal {
+			allExpressions = append(allExpressions, validateString(value)...)
+		}
 	default:
 		return nil, false
 	}
@@ -123,9 +134,26 @@ func stripVarSubExpression(expression string) string {
 }
 
 func parseExpression(substitutionExpression string) (string, string, error) {
+	// The elements of an array result reference the result itself.
+	substitutionExpression = arrayIndexingRegex.ReplaceAllString(substitutionExpression, "")
 	subExpressions := strings.Split(substitutionExpression, ".")
 	if len(subExpressions) != 4 || subExpressions[0] != ResultTaskPart || subExpressions[2] != ResultResultPart {
 		return "", "", fmt.Errorf("Must be of the form %q", resultExpressionFormat)
 	}
 	return subExpressions[1], subExpressions[3], nil
 }
+
+// ParseResultIndex returns the index of the array element referenced by the
+// given result expression, e.g. 1 for "tasks.a.results.b[1]". It returns false
+// if the expression does not reference a single element of an array.
+func ParseResultIndex(substitutionExpression string) (int, bool) {
+	match := arrayIndexingRegex.FindStringSubmatch(substitutionExpression)
+	if match == nil || match[1] == "*" {
+		return 0, false
+	}
+	index, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+	return index, true
+}
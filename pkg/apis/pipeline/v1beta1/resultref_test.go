@@ -108,6 +108,26 @@ func TestNewResultReference(t *testing.T) {
 			PipelineTask: "sumTask1",
 			Result:       "sumResult",
 		}},
+	}, {
+		name: "whole array result",
+		param: v1beta1.Param{
+			Name:  "param",
+			Value: *v1beta1.NewArrayOrString("$(tasks.listTask.results.files[*])"),
+		},
+		want: []*v1beta1.ResultRef{{
+			PipelineTask: "listTask",
+			Result:       "files",
+		}},
+	}, {
+		name: "element of array result",
+		param: v1beta1.Param{
+			Name:  "param",
+			Value: *v1beta1.NewArrayOrString("first file: $(tasks.listTask.results.files[0])"),
+		},
+		want: []*v1beta1.ResultRef{{
+			PipelineTask: "listTask",
+			Result:       "files",
+		}},
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			expressions, ok := v1beta1.GetVarSubstitutionExpressionsForParam(tt.param)
@@ -123,6 +143,33 @@ func TestNewResultReference(t *testing.T) {
 	}
 }
 
+func TestParseResultIndex(t *testing.T) {
+	for _, tt := range []struct {
+		expression string
+		wantIndex  int
+		wantOK     bool
+	}{{
+		expression: "tasks.listTask.results.files[2]",
+		wantIndex:  2,
+		wantOK:     true,
+	}, {
+		expression: "tasks.listTask.results.files[10]",
+		wantIndex:  10,
+		wantOK:     true,
+	}, {
+		expression: "tasks.listTask.results.files[*]",
+	}, {
+		expression: "tasks.listTask.results.files",
+	}} {
+		t.Run(tt.expression, func(t *testing.T) {
+			index, ok := v1beta1.ParseResultIndex(tt.expression)
+			if index != tt.wantIndex || ok != tt.wantOK {
+				t.Errorf("ParseResultIndex(%q) = %d, %t, want %d, %t", tt.expression, index, ok, tt.wantIndex, tt.wantOK)
+			}
+		})
+	}
+}
+
 func TestHasResultReference(t *testing.T) {
 	for _, tt := range []struct {
 		name    string
@@ -28,6 +28,8 @@ const (
 	PipelineResourceResultType ResultType = "PipelineResourceResult"
 	// InternalTektonResultType default internal tekton result value
 	InternalTektonResultType ResultType = "InternalTektonResult"
+	// StepResultType is the type of the results of a single step
+	StepResultType ResultType = "StepResult"
 	// UnknownResultType default unknown result type value
 	UnknownResultType ResultType = ""
 )
@@ -111,11 +113,32 @@ type TaskResult struct {
 	// Name the given name
 	Name string `json:"name"`
 
+	// Type is the user-specified type of the result. The possible types
+	// are string, array and object, and the result is a string if it is
+	// not specified. The values of array and object results are written as
+	// JSON by the steps.
+	// +optional
+	Type ResultsType `json:"type,omitempty"`
+
 	// Description is a human-readable description of the result
 	// +optional
 	Description string `json:"description"`
 }
 
+// ResultsType indicates the type of a result;
+// Used to distinguish between a single string, an array of strings and an object of strings.
+type ResultsType string
+
+// Valid ResultsTypes:
+const (
+	ResultsTypeString ResultsType = "string"
+	ResultsTypeArray  ResultsType = "array"
+	ResultsTypeObject ResultsType = "object"
+)
+
+// AllResultsTypes can be used for ResultsTypes validation.
+var AllResultsTypes = []ResultsType{ResultsTypeString, ResultsTypeArray, ResultsTypeObject}
+
 // Step embeds the Container type, which allows it to include fields not
 // provided by Container.
 type Step struct {
@@ -128,8 +151,59 @@ type Step struct {
 	// Timeout is the time after which the step times out. Defaults to never.
 	// Refer to Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// OnError defines the exiting behavior of a container on error.
+	// Can be set to [ continue | stopAndFail ]; defaults to stopAndFail.
+	// +optional
+	OnError string `json:"onError,omitempty"`
+	// Ref references the StepAction which defines the image, command, args,
+	// script, env and results of the step.
+	// +optional
+	Ref *StepRef `json:"ref,omitempty"`
+	// Params are the values of the parameters of the referenced StepAction.
+	// +optional
+	Params []Param `json:"params,omitempty"`
+	// Results are the results written by the step, which are reported in its
+	// StepState instead of the results of the Task.
+	// +optional
+	Results []StepResult `json:"results,omitempty"`
+	// Workspaces are the workspaces of the Task used by the step. A workspace
+	// used by any step or sidecar is only mounted in the steps and sidecars
+	// which use it.
+	// +optional
+	Workspaces []WorkspaceUsage `json:"workspaces,omitempty"`
+}
+
+// StepRef references a StepAction, in the namespace of the TaskRun or in a
+// Tekton Bundle.
+type StepRef struct {
+	// Name of the referenced StepAction
+	Name string `json:"name,omitempty"`
+	// Bundle url reference to a Tekton Bundle.
+	// +optional
+	Bundle string `json:"bundle,omitempty"`
+}
+
+// StepResult declares a result of a step, which the step writes to the file
+// $(step.results.<name>.path).
+type StepResult struct {
+	// Name the given name
+	Name string `json:"name"`
+
+	// Description is a human-readable description of the result
+	// +optional
+	Description string `json:"description,omitempty"`
 }
 
+const (
+	// ContinueOnError indicates a step should keep running the remaining steps
+	// of the Task even if it exits with a non-zero exit code. It doesn't apply
+	// to a step killed by its timeout.
+	ContinueOnError = "continue"
+	// StopAndFail indicates a step failure stops the Task and fails the
+	// TaskRun. This is the default behavior.
+	StopAndFail = "stopAndFail"
+)
+
 // Sidecar has nearly the same data structure as Step, consisting of a Container and an optional Script, but does not have the ability to timeout.
 type Sidecar struct {
 	corev1.Container `json:",inline"`
@@ -138,6 +212,11 @@ type Sidecar struct {
 	//
 	// If Script is not empty, the Step cannot have an Command or Args.
 	Script string `json:"script,omitempty"`
+	// Workspaces are the workspaces of the Task used by the sidecar. A
+	// workspace used by any step or sidecar is only mounted in the steps and
+	// sidecars which use it.
+	// +optional
+	Workspaces []WorkspaceUsage `json:"workspaces,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -162,6 +241,11 @@ type TaskRef struct {
 	// Bundle url reference to a Tekton Bundle.
 	// +optional
 	Bundle string `json:"bundle,omitempty"`
+
+	// ResolverRef allows referencing a Task in a remote location
+	// like a git repository.
+	// +optional
+	ResolverRef `json:",omitempty"`
 }
 
 // Check that Pipeline may be validated and defaulted.
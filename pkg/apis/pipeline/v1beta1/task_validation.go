@@ -23,6 +23,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	corev1 "k8s.io/api/core/v1"
@@ -44,6 +46,7 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 	errs = errs.Also(ValidateVolumes(ts.Volumes).ViaField("volumes"))
 	errs = errs.Also(ValidateDeclaredWorkspaces(ts.Workspaces, ts.Steps, ts.StepTemplate).ViaField("workspaces"))
+	errs = errs.Also(validateWorkspaceUsages(ts))
 	mergedSteps, err := MergeStepsWithStepTemplate(ts.StepTemplate, ts.Steps)
 	if err != nil {
 		errs = errs.Also(&apis.FieldError{
@@ -54,6 +57,7 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 
 	errs = errs.Also(validateSteps(mergedSteps).ViaField("steps"))
+	errs = errs.Also(validateStepRefs(ctx, ts.Steps).ViaField("steps"))
 	errs = errs.Also(ts.Resources.Validate(ctx).ViaField("resources"))
 	errs = errs.Also(ValidateParameterTypes(ts.Params).ViaField("params"))
 	errs = errs.Also(ValidateParameterVariables(ts.Steps, ts.Params))
@@ -70,11 +74,27 @@ func validateResults(ctx context.Context, results []TaskResult) (errs *apis.Fiel
 	return errs
 }
 
-func (tr TaskResult) Validate(_ context.Context) *apis.FieldError {
+func (tr TaskResult) Validate(ctx context.Context) *apis.FieldError {
 	if !resultNameFormatRegex.MatchString(tr.Name) {
 		return apis.ErrInvalidKeyName(tr.Name, "name", fmt.Sprintf("Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '%s')", ResultNameFormat))
 	}
-	return nil
+	return ValidateResultType(ctx, tr.Type).ViaField("type")
+}
+
+// ValidateResultType checks that t is a known type of result, and that the
+// array and object types are only used when the enable-array-and-object-results
+// feature flag is on.
+func ValidateResultType(ctx context.Context, t ResultsType) *apis.FieldError {
+	switch t {
+	case "", ResultsTypeString:
+		return nil
+	case ResultsTypeArray, ResultsTypeObject:
+		if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableArrayAndObjectResults {
+			return apis.ErrGeneric(fmt.Sprintf("%s results require the enable-array-and-object-results feature flag to be \"true\"", t), apis.CurrentField)
+		}
+		return nil
+	}
+	return apis.ErrInvalidValue(t, apis.CurrentField)
 }
 
 // a mount path which conflicts with any other declared workspaces, with the explicitly
@@ -110,6 +130,36 @@ func ValidateDeclaredWorkspaces(workspaces []WorkspaceDeclaration, steps []Step,
 	return errs
 }
 
+// validateWorkspaceUsages validates that the workspaces used by the steps and
+// sidecars of ts are declared by ts.
+func validateWorkspaceUsages(ts *TaskSpec) (errs *apis.FieldError) {
+	wsNames := sets.NewString()
+	for _, w := range ts.Workspaces {
+		wsNames.Insert(w.Name)
+	}
+	for idx, step := range ts.Steps {
+		errs = errs.Also(validateWorkspaceUsage(step.Workspaces, wsNames).ViaFieldIndex("steps", idx))
+	}
+	for idx, sidecar := range ts.Sidecars {
+		errs = errs.Also(validateWorkspaceUsage(sidecar.Workspaces, wsNames).ViaFieldIndex("sidecars", idx))
+	}
+	return errs
+}
+
+func validateWorkspaceUsage(usages []WorkspaceUsage, wsNames sets.String) (errs *apis.FieldError) {
+	used := sets.NewString()
+	for idx, w := range usages {
+		switch {
+		case !wsNames.Has(w.Name):
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("undefined workspace %q", w.Name), "name").ViaFieldIndex("workspaces", idx))
+		case used.Has(w.Name):
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("workspace %q must only be used once", w.Name), "name").ViaFieldIndex("workspaces", idx))
+		}
+		used.Insert(w.Name)
+	}
+	return errs
+}
+
 func ValidateVolumes(volumes []corev1.Volume) (errs *apis.FieldError) {
 	// Task must not have duplicate volume names.
 	vols := sets.NewString()
@@ -133,7 +183,8 @@ func validateSteps(steps []Step) (errs *apis.FieldError) {
 }
 
 func validateStep(s Step, names sets.String) (errs *apis.FieldError) {
-	if s.Image == "" {
+	// The image of a step referencing a StepAction is taken from the StepAction.
+	if s.Image == "" && s.Ref == nil {
 		errs = errs.Also(apis.ErrMissingField("Image"))
 	}
 
@@ -166,6 +217,16 @@ func validateStep(s Step, names sets.String) (errs *apis.FieldError) {
 		}
 	}
 
+	if s.OnError != "" {
+		if s.OnError != ContinueOnError && s.OnError != StopAndFail {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("invalid value: %q", s.OnError),
+				Paths:   []string{"onError"},
+				Details: fmt.Sprintf("Task step onError must be either %q or %q", ContinueOnError, StopAndFail),
+			})
+		}
+	}
+
 	for j, vm := range s.VolumeMounts {
 		if strings.HasPrefix(vm.MountPath, "/tekton/") &&
 			!strings.HasPrefix(vm.MountPath, "/tekton/home") {
@@ -175,6 +236,80 @@ func validateStep(s Step, names sets.String) (errs *apis.FieldError) {
 			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf(`volumeMount name %q cannot start with "tekton-internal-"`, vm.Name), "name").ViaFieldIndex("volumeMounts", j))
 		}
 	}
+
+	if len(s.Results) > 0 {
+		if s.Name == "" {
+			errs = errs.Also(apis.ErrGeneric("steps which declare results must be named", "name"))
+		}
+		errs = errs.Also(ValidateStepResults(s.Results).ViaField("results"))
+	}
+	resultNames := sets.NewString()
+	for _, r := range s.Results {
+		resultNames.Insert(r.Name)
+	}
+	errs = errs.Also(validateStepVariables(s, "step\\.results", resultNames))
+	return errs
+}
+
+// ValidateStepResults checks that the names of the results of a step are
+// valid and unique.
+func ValidateStepResults(results []StepResult) (errs *apis.FieldError) {
+	seen := sets.NewString()
+	for i, r := range results {
+		if !resultNameFormatRegex.MatchString(r.Name) {
+			errs = errs.Also(apis.ErrInvalidKeyName(r.Name, "name", fmt.Sprintf("Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '%s')", ResultNameFormat)).ViaIndex(i))
+		} else if seen.Has(r.Name) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("duplicate result name %q", r.Name), "name").ViaIndex(i))
+		}
+		seen.Insert(r.Name)
+	}
+	return errs
+}
+
+// validateStepRefs checks the steps which reference a StepAction: the fields
+// defined by the StepAction cannot be set on the step, and params can only be
+// passed to a StepAction.
+func validateStepRefs(ctx context.Context, steps []Step) (errs *apis.FieldError) {
+	cfg := config.FromContextOrDefaults(ctx)
+	for idx, s := range steps {
+		if s.Ref == nil {
+			if len(s.Params) > 0 {
+				errs = errs.Also(apis.ErrGeneric("params can only be passed to a referenced StepAction", "params").ViaIndex(idx))
+			}
+			continue
+		}
+		var stepErrs *apis.FieldError
+		if s.Name == "" {
+			stepErrs = stepErrs.Also(apis.ErrGeneric("steps which reference a StepAction must be named", "name"))
+		}
+		if s.Ref.Name == "" {
+			stepErrs = stepErrs.Also(apis.ErrMissingField("ref.name"))
+		}
+		if s.Ref.Bundle != "" {
+			if !cfg.FeatureFlags.EnableTektonOCIBundles {
+				stepErrs = stepErrs.Also(apis.ErrDisallowedFields("ref.bundle"))
+			} else if _, err := name.ParseReference(s.Ref.Bundle); err != nil {
+				stepErrs = stepErrs.Also(apis.ErrInvalidValue(fmt.Sprintf("invalid bundle reference (%s)", err.Error()), "ref.bundle"))
+			}
+		}
+		for _, f := range []struct {
+			name string
+			set  bool
+		}{
+			{"image", s.Image != ""},
+			{"command", len(s.Command) > 0},
+			{"args", len(s.Args) > 0},
+			{"script", s.Script != ""},
+			{"env", len(s.Env) > 0},
+			{"results", len(s.Results) > 0},
+		} {
+			if f.set {
+				stepErrs = stepErrs.Also(apis.ErrMultipleOneOf("ref", f.name))
+			}
+		}
+		stepErrs = stepErrs.Also(validateParameters(s.Params).ViaField("params"))
+		errs = errs.Also(stepErrs.ViaIndex(idx))
+	}
 	return errs
 }
 
@@ -208,22 +343,52 @@ func (p ParamSpec) ValidateType() *apis.FieldError {
 			},
 		}
 	}
+	return p.validateObjectProperties().ViaField(p.Name)
+}
+
+// validateObjectProperties ensures that properties are declared for, and only for, parameters of
+// type object, and that the default value of an object parameter provides all the declared keys.
+func (p ParamSpec) validateObjectProperties() *apis.FieldError {
+	if p.Type != ParamTypeObject {
+		if p.Properties != nil {
+			return apis.ErrGeneric(fmt.Sprintf("properties can only be declared for parameters of type %q", ParamTypeObject), "properties")
+		}
+		return nil
+	}
+	if len(p.Properties) == 0 {
+		return apis.ErrMissingField("properties")
+	}
+	for key, property := range p.Properties {
+		if property.Type != ParamTypeString {
+			return apis.ErrInvalidValue(property.Type, "type").ViaFieldKey("properties", key)
+		}
+	}
+	if p.Default != nil {
+		if missingKeys := p.MissingObjectKeys(p.Default.ObjectVal); len(missingKeys) > 0 {
+			return apis.ErrMissingField(missingKeys...).ViaField("default")
+		}
+	}
 	return nil
 }
 
 func ValidateParameterVariables(steps []Step, params []ParamSpec) *apis.FieldError {
 	parameterNames := sets.NewString()
 	arrayParameterNames := sets.NewString()
+	objectParameterKeys := map[string]sets.String{}
 
 	for _, p := range params {
 		parameterNames.Insert(p.Name)
-		if p.Type == ParamTypeArray {
+		switch p.Type {
+		case ParamTypeArray:
 			arrayParameterNames.Insert(p.Name)
+		case ParamTypeObject:
+			objectParameterKeys[p.Name] = p.PropertyKeys()
 		}
 	}
 
 	errs := validateVariables(steps, "params", parameterNames)
-	return errs.Also(validateArrayUsage(steps, "params", arrayParameterNames))
+	errs = errs.Also(validateArrayUsage(steps, "params", arrayParameterNames))
+	return errs.Also(validateObjectUsage(steps, "params", objectParameterKeys))
 }
 
 func validateTaskContextVariables(steps []Step) *apis.FieldError {
@@ -287,6 +452,38 @@ func validateStepArrayUsage(step Step, prefix string, vars sets.String) *apis.Fi
 	return errs
 }
 
+func validateObjectUsage(steps []Step, prefix string, objectKeys map[string]sets.String) (errs *apis.FieldError) {
+	if len(objectKeys) == 0 {
+		return nil
+	}
+	for idx, step := range steps {
+		errs = errs.Also(validateStepObjectUsage(step, prefix, objectKeys).ViaFieldIndex("steps", idx))
+	}
+	return errs
+}
+
+func validateStepObjectUsage(step Step, prefix string, objectKeys map[string]sets.String) *apis.FieldError {
+	errs := validateTaskObjectKeys(step.Name, prefix, objectKeys).ViaField("name")
+	errs = errs.Also(validateTaskObjectKeys(step.Image, prefix, objectKeys).ViaField("image"))
+	errs = errs.Also(validateTaskObjectKeys(step.WorkingDir, prefix, objectKeys).ViaField("workingDir"))
+	errs = errs.Also(validateTaskObjectKeys(step.Script, prefix, objectKeys).ViaField("script"))
+	for i, cmd := range step.Command {
+		errs = errs.Also(validateTaskObjectKeys(cmd, prefix, objectKeys).ViaFieldIndex("command", i))
+	}
+	for i, arg := range step.Args {
+		errs = errs.Also(validateTaskObjectKeys(arg, prefix, objectKeys).ViaFieldIndex("args", i))
+	}
+	for _, env := range step.Env {
+		errs = errs.Also(validateTaskObjectKeys(env.Value, prefix, objectKeys).ViaFieldKey("env", env.Name))
+	}
+	for i, v := range step.VolumeMounts {
+		errs = errs.Also(validateTaskObjectKeys(v.Name, prefix, objectKeys).ViaField("name").ViaFieldIndex("volumeMount", i))
+		errs = errs.Also(validateTaskObjectKeys(v.MountPath, prefix, objectKeys).ViaField("mountPath").ViaFieldIndex("volumeMount", i))
+		errs = errs.Also(validateTaskObjectKeys(v.SubPath, prefix, objectKeys).ViaField("subPath").ViaFieldIndex("volumeMount", i))
+	}
+	return errs
+}
+
 func validateVariables(steps []Step, prefix string, vars sets.String) (errs *apis.FieldError) {
 	for idx, step := range steps {
 		errs = errs.Also(validateStepVariables(step, prefix, vars).ViaFieldIndex("steps", idx))
@@ -308,6 +505,12 @@ func validateStepVariables(step Step, prefix string, vars sets.String) *apis.Fie
 	for _, env := range step.Env {
 		errs = errs.Also(validateTaskVariable(env.Value, prefix, vars).ViaFieldKey("env", env.Name))
 	}
+	for _, p := range step.Params {
+		errs = errs.Also(validateTaskVariable(p.Value.StringVal, prefix, vars).ViaFieldKey("params", p.Name))
+		for _, v := range p.Value.ArrayVal {
+			errs = errs.Also(validateTaskVariable(v, prefix, vars).ViaFieldKey("params", p.Name))
+		}
+	}
 	for i, v := range step.VolumeMounts {
 		errs = errs.Also(validateTaskVariable(v.Name, prefix, vars).ViaField("name").ViaFieldIndex("volumeMount", i))
 		errs = errs.Also(validateTaskVariable(v.MountPath, prefix, vars).ViaField("MountPath").ViaFieldIndex("volumeMount", i))
@@ -324,6 +527,10 @@ func validateTaskNoArrayReferenced(value, prefix string, arrayNames sets.String)
 	return substitution.ValidateVariableProhibitedP(value, prefix, arrayNames)
 }
 
+func validateTaskObjectKeys(value, prefix string, objectKeys map[string]sets.String) *apis.FieldError {
+	return substitution.ValidateVariableObjectKeysP(value, prefix, objectKeys, false)
+}
+
 func validateTaskArraysIsolated(value, prefix string, arrayNames sets.String) *apis.FieldError {
 	return substitution.ValidateVariableIsolatedP(value, prefix, arrayNames)
 }
@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
@@ -64,9 +65,25 @@ func TestTaskSpecValidate(t *testing.T) {
 		Results      []v1beta1.TaskResult
 	}
 	tests := []struct {
-		name   string
-		fields fields
+		name         string
+		fields       fields
+		featureFlags config.FeatureFlags
 	}{{
+		name: "step with onError",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{
+					Image: "lint",
+				},
+				OnError: "continue",
+			}, {
+				Container: corev1.Container{
+					Image: "report",
+				},
+				OnError: "stopAndFail",
+			}},
+		},
+	}, {
 		name: "unnamed steps",
 		fields: fields{
 			Steps: []v1beta1.Step{{Container: corev1.Container{
@@ -127,6 +144,28 @@ func TestTaskSpecValidate(t *testing.T) {
 				WorkingDir: "/foo/bar/src/",
 			}}},
 		},
+	}, {
+		name: "valid object template variable",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name: "gitrepo",
+				Type: v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{
+					"url":    {Type: v1beta1.ParamTypeString},
+					"commit": {Type: v1beta1.ParamTypeString},
+				},
+				Default: v1beta1.NewObject(map[string]string{
+					"url":    "https://github.com/tektoncd/pipeline",
+					"commit": "main",
+				}),
+			}},
+			Steps: []v1beta1.Step{{Container: corev1.Container{
+				Name:       "mystep",
+				Image:      "url",
+				Args:       []string{"--url=$(params.gitrepo.url)", "--commit=$(params.gitrepo.commit)"},
+				WorkingDir: "/foo/bar/src/",
+			}}},
+		},
 	}, {
 		name: "valid array template variable",
 		fields: fields{
@@ -266,6 +305,27 @@ func TestTaskSpecValidate(t *testing.T) {
 				Description: "my great result",
 			}},
 		},
+	}, {
+		name: "valid typed results",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{
+					Image: "my-image",
+					Args:  []string{"arg"},
+				},
+			}},
+			Results: []v1beta1.TaskResult{{
+				Name: "commit",
+				Type: v1beta1.ResultsTypeString,
+			}, {
+				Name: "files",
+				Type: v1beta1.ResultsTypeArray,
+			}, {
+				Name: "image",
+				Type: v1beta1.ResultsTypeObject,
+			}},
+		},
+		featureFlags: config.FeatureFlags{EnableArrayAndObjectResults: true},
 	}, {
 		name: "valid task name context",
 		fields: fields{
@@ -318,6 +378,54 @@ func TestTaskSpecValidate(t *testing.T) {
 				hello "$(context.taskRun.namespace)"`,
 			}},
 		},
+	}, {
+		name: "valid step referencing a StepAction",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name: "revision",
+				Type: v1beta1.ParamTypeString,
+			}},
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Name: "clone"},
+				Ref:       &v1beta1.StepRef{Name: "git-clone"},
+				Params: []v1beta1.Param{{
+					Name:  "revision",
+					Value: *v1beta1.NewArrayOrString("$(params.revision)"),
+				}},
+			}},
+		},
+	}, {
+		name: "valid step results",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{
+					Name:  "digest",
+					Image: "my-image",
+				},
+				Script:  "sha256sum file > $(step.results.digest.path)",
+				Results: []v1beta1.StepResult{{Name: "digest"}},
+			}},
+		},
+	}, {
+		name: "step using a workspace",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{
+					Name:  "scan",
+					Image: "scanner",
+				},
+			}, {
+				Container: corev1.Container{
+					Name:  "sign",
+					Image: "signer",
+				},
+				Workspaces: []v1beta1.WorkspaceUsage{{Name: "signing-key"}},
+			}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{
+				Name:     "signing-key",
+				ReadOnly: true,
+			}},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -329,7 +437,7 @@ func TestTaskSpecValidate(t *testing.T) {
 				Workspaces:   tt.fields.Workspaces,
 				Results:      tt.fields.Results,
 			}
-			ctx := context.Background()
+			ctx := config.ToContext(context.Background(), &config.Config{FeatureFlags: &tt.featureFlags})
 			ts.SetDefaults(ctx)
 			if err := ts.Validate(ctx); err != nil {
 				t.Errorf("TaskSpec.Validate() = %v", err)
@@ -343,6 +451,7 @@ func TestTaskSpecValidateError(t *testing.T) {
 		Params       []v1beta1.ParamSpec
 		Resources    *v1beta1.TaskResources
 		Steps        []v1beta1.Step
+		Sidecars     []v1beta1.Sidecar
 		Volumes      []corev1.Volume
 		StepTemplate *corev1.Container
 		Workspaces   []v1beta1.WorkspaceDeclaration
@@ -466,6 +575,123 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: `invalid value: invalidtype`,
 			Paths:   []string{"params.param-with-invalid-type.type"},
 		},
+	}, {
+		name: "object param without properties",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name: "gitrepo",
+				Type: v1beta1.ParamTypeObject,
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"params.gitrepo.properties"},
+		},
+	}, {
+		name: "properties declared for string param",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "gitrepo",
+				Type:       v1beta1.ParamTypeString,
+				Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeString}},
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `properties can only be declared for parameters of type "object"`,
+			Paths:   []string{"params.gitrepo.properties"},
+		},
+	}, {
+		name: "object param with invalid property type",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "gitrepo",
+				Type:       v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeArray}},
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: array`,
+			Paths:   []string{"params.gitrepo.properties[url].type"},
+		},
+	}, {
+		name: "object param default missing keys",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name: "gitrepo",
+				Type: v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{
+					"url":    {Type: v1beta1.ParamTypeString},
+					"commit": {Type: v1beta1.ParamTypeString},
+				},
+				Default: v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"}),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"params.gitrepo.default.commit"},
+		},
+	}, {
+		name: "object param referenced with undeclared key",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "gitrepo",
+				Type:       v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeString}},
+			}},
+			Steps: []v1beta1.Step{{Container: corev1.Container{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"--commit=$(params.gitrepo.commit)"},
+			}}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent object key in "--commit=$(params.gitrepo.commit)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "object param referenced with undeclared key in several steps",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "gitrepo",
+				Type:       v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeString}},
+			}},
+			Steps: []v1beta1.Step{{Container: corev1.Container{
+				Name:  "first",
+				Image: "myimage",
+				Args:  []string{"--commit=$(params.gitrepo.commit)"},
+			}}, {Container: corev1.Container{
+				Name:  "second",
+				Image: "myimage",
+				Args:  []string{"--commit=$(params.gitrepo.commit)"},
+			}}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent object key in "--commit=$(params.gitrepo.commit)"`,
+			Paths:   []string{"steps[0].args[0]", "steps[1].args[0]"},
+		},
+	}, {
+		name: "object param referenced as a whole",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name:       "gitrepo",
+				Type:       v1beta1.ParamTypeObject,
+				Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeString}},
+			}},
+			Steps: []v1beta1.Step{{Container: corev1.Container{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"$(params.gitrepo[*])"},
+			}}},
+		},
+		expectedError: apis.FieldError{
+			Message: `object variable must reference one of its keys in "$(params.gitrepo[*])"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
 	}, {
 		name: "param mismatching default/type 1",
 		fields: fields{
@@ -915,6 +1141,32 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Paths:   []string{"results[0].name"},
 			Details: "Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$')",
 		},
+	}, {
+		name: "result type not validate",
+		fields: fields{
+			Steps: validSteps,
+			Results: []v1beta1.TaskResult{{
+				Name: "files",
+				Type: "list",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: list`,
+			Paths:   []string{"results[0].type"},
+		},
+	}, {
+		name: "array result without the feature flag",
+		fields: fields{
+			Steps: validSteps,
+			Results: []v1beta1.TaskResult{{
+				Name: "files",
+				Type: v1beta1.ResultsTypeArray,
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `array results require the enable-array-and-object-results feature flag to be "true"`,
+			Paths:   []string{"results[0].type"},
+		},
 	}, {
 		name: "context not validate",
 		fields: fields{
@@ -943,6 +1195,151 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: "invalid value: -10s",
 			Paths:   []string{"steps[0].negative timeout"},
 		},
+	}, {
+		name: "invalid onError value",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{
+					Image: "my-image",
+				},
+				OnError: "ignore",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: "ignore"`,
+			Paths:   []string{"steps[0].onError"},
+			Details: `Task step onError must be either "continue" or "stopAndFail"`,
+		},
+	}, {
+		name: "step referencing a StepAction with an image",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{
+					Name:  "clone",
+					Image: "my-image",
+				},
+				Ref: &v1beta1.StepRef{Name: "git-clone"},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `expected exactly one, got both`,
+			Paths:   []string{"steps[0].image", "steps[0].ref"},
+		},
+	}, {
+		name: "unnamed step referencing a StepAction",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Ref: &v1beta1.StepRef{Name: "git-clone"},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `steps which reference a StepAction must be named`,
+			Paths:   []string{"steps[0].name"},
+		},
+	}, {
+		name: "step referencing a StepAction from a bundle without the feature flag",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Name: "clone"},
+				Ref:       &v1beta1.StepRef{Name: "git-clone", Bundle: "example.com/bundle:latest"},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `must not set the field(s)`,
+			Paths:   []string{"steps[0].ref.bundle"},
+		},
+	}, {
+		name: "params passed to a step without ref",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "my-image"},
+				Params: []v1beta1.Param{{
+					Name:  "revision",
+					Value: *v1beta1.NewArrayOrString("main"),
+				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `params can only be passed to a referenced StepAction`,
+			Paths:   []string{"steps[0].params"},
+		},
+	}, {
+		name: "step results declared by an unnamed step",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Image: "my-image"},
+				Results:   []v1beta1.StepResult{{Name: "digest"}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `steps which declare results must be named`,
+			Paths:   []string{"steps[0].name"},
+		},
+	}, {
+		name: "duplicated step results",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Name: "digest", Image: "my-image"},
+				Results:   []v1beta1.StepResult{{Name: "digest"}, {Name: "digest"}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: duplicate result name "digest"`,
+			Paths:   []string{"steps[0].results[1].name"},
+		},
+	}, {
+		name: "reference to an undeclared step result",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Name: "digest", Image: "my-image"},
+				Script:    "sha256sum file > $(step.results.sha.path)",
+				Results:   []v1beta1.StepResult{{Name: "digest"}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "sha256sum file > $(step.results.sha.path)"`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "step using an undeclared workspace",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container:  corev1.Container{Name: "sign", Image: "signer"},
+				Workspaces: []v1beta1.WorkspaceUsage{{Name: "signing-key"}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: undefined workspace "signing-key"`,
+			Paths:   []string{"steps[0].workspaces[0].name"},
+		},
+	}, {
+		name: "sidecar using an undeclared workspace",
+		fields: fields{
+			Steps: validSteps,
+			Sidecars: []v1beta1.Sidecar{{
+				Container:  corev1.Container{Name: "signer", Image: "signer"},
+				Workspaces: []v1beta1.WorkspaceUsage{{Name: "signing-key"}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: undefined workspace "signing-key"`,
+			Paths:   []string{"sidecars[0].workspaces[0].name"},
+		},
+	}, {
+		name: "step using a workspace twice",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Container:  corev1.Container{Name: "sign", Image: "signer"},
+				Workspaces: []v1beta1.WorkspaceUsage{{Name: "signing-key"}, {Name: "signing-key"}},
+			}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{
+				Name: "signing-key",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `workspace "signing-key" must only be used once`,
+			Paths:   []string{"steps[0].workspaces[1].name"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -950,6 +1347,7 @@ func TestTaskSpecValidateError(t *testing.T) {
 				Params:       tt.fields.Params,
 				Resources:    tt.fields.Resources,
 				Steps:        tt.fields.Steps,
+				Sidecars:     tt.fields.Sidecars,
 				Volumes:      tt.fields.Volumes,
 				StepTemplate: tt.fields.StepTemplate,
 				Workspaces:   tt.fields.Workspaces,
@@ -25,6 +25,7 @@ import (
 	apisconfig "github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -66,6 +67,85 @@ type TaskRunSpec struct {
 	// Workspaces is a list of WorkspaceBindings from volumes to workspaces.
 	// +optional
 	Workspaces []WorkspaceBinding `json:"workspaces,omitempty"`
+	// Debug holds the options to debug the steps of the TaskRun
+	// +optional
+	Debug *TaskRunDebug `json:"debug,omitempty"`
+	// ExecutionMode defines how the steps of the TaskRun are executed.
+	// Set it to "hermetic" to execute the steps without network access.
+	// +optional
+	ExecutionMode TaskRunExecutionMode `json:"executionMode,omitempty"`
+	// ComputeResources holds the compute resources of the whole Task, which
+	// are distributed among its steps
+	// +optional
+	ComputeResources *corev1.ResourceRequirements `json:"computeResources,omitempty"`
+	// StepOverrides overrides the configuration of the steps of the Task,
+	// which are matched by name
+	// +optional
+	StepOverrides []TaskRunStepOverride `json:"stepOverrides,omitempty"`
+	// SidecarOverrides overrides the configuration of the sidecars of the
+	// Task, which are matched by name
+	// +optional
+	SidecarOverrides []TaskRunSidecarOverride `json:"sidecarOverrides,omitempty"`
+}
+
+// TaskRunStepOverride overrides the configuration of a step of the Task
+type TaskRunStepOverride struct {
+	// Name is the name of the step to override
+	Name string `json:"name"`
+	// Resources replaces the compute resources of the step
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Env sets environment variables of the step, replacing the ones of the
+	// step which have the same name
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// TaskRunSidecarOverride overrides the configuration of a sidecar of the Task
+type TaskRunSidecarOverride struct {
+	// Name is the name of the sidecar to override
+	Name string `json:"name"`
+	// Resources replaces the compute resources of the sidecar
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Env sets environment variables of the sidecar, replacing the ones of
+	// the sidecar which have the same name
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// TaskRunExecutionMode defines how the steps of a TaskRun are executed
+type TaskRunExecutionMode string
+
+const (
+	// TaskRunExecutionModeHermetic executes the steps of the TaskRun in their
+	// own namespaces, without access to the network
+	TaskRunExecutionModeHermetic TaskRunExecutionMode = "hermetic"
+)
+
+// BreakpointOnFailure is the breakpoint which pauses a step of the TaskRun
+// after it fails, until it is resumed with the debug scripts
+const BreakpointOnFailure = "onFailure"
+
+// TaskRunDebug defines the breakpoints at which the steps of the TaskRun pause
+type TaskRunDebug struct {
+	// Breakpoint lists the breakpoints of the TaskRun.
+	// The only supported breakpoint is "onFailure".
+	// +optional
+	Breakpoint []string `json:"breakpoint,omitempty"`
+}
+
+// NeedsDebugOnFailure returns true if the steps of the TaskRun pause after they fail
+func (trd *TaskRunDebug) NeedsDebugOnFailure() bool {
+	if trd == nil {
+		return false
+	}
+	for _, b := range trd.Breakpoint {
+		if b == BreakpointOnFailure {
+			return true
+		}
+	}
+	return false
 }
 
 // TaskRunSpecStatus defines the taskrun spec status the user can provide
@@ -75,6 +155,10 @@ const (
 	// TaskRunSpecStatusCancelled indicates that the user wants to cancel the task,
 	// if not already cancelled or terminated
 	TaskRunSpecStatusCancelled = "TaskRunCancelled"
+
+	// TaskRunSpecStatusPending indicates that the user wants to postpone starting a TaskRun
+	// until some condition is met
+	TaskRunSpecStatusPending = "TaskRunPending"
 )
 
 // TaskRunInputs holds the input values that this task was invoked with.
@@ -119,6 +203,15 @@ const (
 	TaskRunReasonCancelled TaskRunReason = "TaskRunCancelled"
 	// TaskRunReasonTimedOut is the reason set when the Taskrun has timed out
 	TaskRunReasonTimedOut TaskRunReason = "TaskRunTimeout"
+	// TaskRunReasonPending is the reason set when the TaskRun is in the pending state
+	// and is not started until its spec status is cleared
+	TaskRunReasonPending TaskRunReason = "TaskRunPending"
+	// TaskRunReasonPausedAtBreakpoint is the reason set when a step of the TaskRun
+	// failed and is paused at the onFailure breakpoint
+	TaskRunReasonPausedAtBreakpoint TaskRunReason = "PausedAtBreakpoint"
+	// TaskRunReasonImagePullFailed is the reason set when the image of a step
+	// couldn't be pulled
+	TaskRunReasonImagePullFailed TaskRunReason = "ImagePullFailed"
 )
 
 func (t TaskRunReason) String() string {
@@ -218,6 +311,29 @@ type TaskRunStatusFields struct {
 
 	// TaskSpec contains the Spec from the dereferenced Task definition used to instantiate this TaskRun.
 	TaskSpec *TaskSpec `json:"taskSpec,omitempty"`
+
+	// StepProvenance records the StepActions which the steps of the TaskSpec
+	// were resolved from.
+	// +optional
+	StepProvenance []StepProvenance `json:"stepProvenance,omitempty"`
+}
+
+// StepProvenance records the StepAction a step was resolved from
+type StepProvenance struct {
+	// StepName is the name of the step
+	StepName string `json:"stepName"`
+	// Name is the name of the StepAction
+	Name string `json:"name"`
+	// Bundle is the Tekton Bundle the StepAction was read from, if any
+	// +optional
+	Bundle string `json:"bundle,omitempty"`
+	// UID is the UID of the StepAction, if it was read from the cluster
+	// +optional
+	UID types.UID `json:"uid,omitempty"`
+	// ResourceVersion is the resource version of the StepAction, if it was
+	// read from the cluster
+	// +optional
+	ResourceVersion string `json:"resourceVersion,omitempty"`
 }
 
 // TaskRunResult used to describe the results of a task
@@ -225,8 +341,12 @@ type TaskRunResult struct {
 	// Name the given name
 	Name string `json:"name"`
 
+	// Type is the type of the result, as declared by the Task.
+	// +optional
+	Type ResultsType `json:"type,omitempty"`
+
 	// Value the given value of the result
-	Value string `json:"value"`
+	Value ArrayOrString `json:"value"`
 }
 
 // GetOwnerReference gets the task run as owner reference for any related objects
@@ -276,6 +396,29 @@ type StepState struct {
 	Name                  string `json:"name,omitempty"`
 	ContainerName         string `json:"container,omitempty"`
 	ImageID               string `json:"imageID,omitempty"`
+	// Hermetic is true if the step was executed without network access
+	// +optional
+	Hermetic bool `json:"hermetic,omitempty"`
+	// Results are the results written by the step
+	// +optional
+	Results []TaskRunResult `json:"results,omitempty"`
+	// ResourceUsage is the resource usage of the step, as measured by its
+	// entrypoint
+	// +optional
+	ResourceUsage *StepResourceUsage `json:"resourceUsage,omitempty"`
+}
+
+// StepResourceUsage reports the resources consumed by the process of a step,
+// including the processes it started and waited for.
+type StepResourceUsage struct {
+	// MaxRSS is the maximum resident set size of the processes of the step
+	MaxRSS resource.Quantity `json:"maxRSS"`
+	// UserCPUTime is the CPU time spent by the processes of the step in user mode
+	UserCPUTime metav1.Duration `json:"userCPUTime"`
+	// SystemCPUTime is the CPU time spent by the processes of the step in kernel mode
+	SystemCPUTime metav1.Duration `json:"systemCPUTime"`
+	// WallTime is the time elapsed between the start and the end of the step
+	WallTime metav1.Duration `json:"wallTime"`
 }
 
 // SidecarState reports the results of running a sidecar in a Task.
@@ -395,6 +538,17 @@ func (tr *TaskRun) IsCancelled() bool {
 	return tr.Spec.Status == TaskRunSpecStatusCancelled
 }
 
+// IsHermetic returns true if the steps of the TaskRun are executed without
+// network access
+func (tr *TaskRun) IsHermetic() bool {
+	return tr.Spec.ExecutionMode == TaskRunExecutionModeHermetic
+}
+
+// IsPending returns true if the TaskRun's spec status is set to Pending state
+func (tr *TaskRun) IsPending() bool {
+	return tr.Spec.Status == TaskRunSpecStatusPending
+}
+
 // HasTimedOut returns true if the TaskRun runtime is beyond the allowed timeout
 func (tr *TaskRun) HasTimedOut(ctx context.Context) bool {
 	if tr.Status.StartTime.IsZero() {
@@ -135,6 +135,40 @@ func TestTaskRunIsCancelled(t *testing.T) {
 	}
 }
 
+func TestTaskRunIsHermetic(t *testing.T) {
+	tr := &v1beta1.TaskRun{
+		Spec: v1beta1.TaskRunSpec{
+			ExecutionMode: v1beta1.TaskRunExecutionModeHermetic,
+		},
+	}
+	if !tr.IsHermetic() {
+		t.Fatal("Expected taskrun to be hermetic")
+	}
+}
+
+func TestTaskRunDebugNeedsDebugOnFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		debug *v1beta1.TaskRunDebug
+		want  bool
+	}{{
+		name: "no debug",
+	}, {
+		name:  "no breakpoint",
+		debug: &v1beta1.TaskRunDebug{},
+	}, {
+		name:  "breakpoint on failure",
+		debug: &v1beta1.TaskRunDebug{Breakpoint: []string{v1beta1.BreakpointOnFailure}},
+		want:  true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.debug.NeedsDebugOnFailure(); got != tc.want {
+				t.Errorf("NeedsDebugOnFailure() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestTaskRunHasVolumeClaimTemplate(t *testing.T) {
 	tr := &v1beta1.TaskRun{
 		Spec: v1beta1.TaskRunSpec{
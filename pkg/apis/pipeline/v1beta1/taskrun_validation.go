@@ -19,11 +19,13 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
@@ -33,22 +35,33 @@ var _ apis.Validatable = (*TaskRun)(nil)
 // Validate taskrun
 func (tr *TaskRun) Validate(ctx context.Context) *apis.FieldError {
 	errs := validate.ObjectMetadata(tr.GetObjectMeta()).ViaField("metadata")
+
+	if tr.IsPending() && tr.HasStarted() {
+		errs = errs.Also(apis.ErrInvalidValue("TaskRun cannot be Pending after it is started", "spec.status"))
+	}
+
 	return errs.Also(tr.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 }
 
 // Validate taskrun spec
 func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	cfg := config.FromContextOrDefaults(ctx)
+	hasTaskRef := ts.TaskRef != nil && (ts.TaskRef.Name != "" || ts.TaskRef.HasResolver())
 	// can't have both taskRef and taskSpec at the same time
-	if (ts.TaskRef != nil && ts.TaskRef.Name != "") && ts.TaskSpec != nil {
+	if hasTaskRef && ts.TaskSpec != nil {
 		errs = errs.Also(apis.ErrDisallowedFields("taskref", "taskspec"))
 	}
 
 	// Check that one of TaskRef and TaskSpec is present
-	if (ts.TaskRef == nil || (ts.TaskRef != nil && ts.TaskRef.Name == "")) && ts.TaskSpec == nil {
+	if !hasTaskRef && ts.TaskSpec == nil {
 		errs = errs.Also(apis.ErrMissingField("taskref.name", "taskspec"))
 	}
 
+	// Validate the resolver reference if it's present
+	if ts.TaskRef != nil {
+		errs = errs.Also(validateResolverRef(ts.TaskRef.ResolverRef, ts.TaskRef.Name, ts.TaskRef.Bundle).ViaField("taskref"))
+	}
+
 	// If EnableTektonOCIBundles feature flag is on validate it.
 	// Otherwise, fail if it is present (as it won't be allowed nor used)
 	if cfg.FeatureFlags.EnableTektonOCIBundles {
@@ -77,8 +90,8 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(ts.Resources.Validate(ctx).ViaField("resources"))
 
 	if ts.Status != "" {
-		if ts.Status != TaskRunSpecStatusCancelled {
-			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %s", ts.Status, TaskRunSpecStatusCancelled), "status"))
+		if ts.Status != TaskRunSpecStatusCancelled && ts.Status != TaskRunSpecStatusPending {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %s or %s", ts.Status, TaskRunSpecStatusCancelled, TaskRunSpecStatusPending), "status"))
 		}
 	}
 	if ts.Timeout != nil {
@@ -87,7 +100,156 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", ts.Timeout.Duration.String()), "timeout"))
 		}
 	}
+	if ts.Debug != nil {
+		errs = errs.Also(ts.Debug.validate().ViaField("debug"))
+	}
+	if ts.ExecutionMode != "" {
+		errs = errs.Also(ts.validateExecutionMode())
+	}
+	if ts.ComputeResources != nil {
+		errs = errs.Also(validateComputeResources(*ts.ComputeResources).ViaField("computeResources"))
+		// The compute resources of the Task replace the resources of its steps.
+		if ts.TaskSpec != nil {
+			if ts.TaskSpec.StepTemplate != nil && hasResources(ts.TaskSpec.StepTemplate.Resources) {
+				errs = errs.Also(apis.ErrMultipleOneOf("computeResources", "taskspec.stepTemplate.resources"))
+			}
+			for i, s := range ts.TaskSpec.Steps {
+				if hasResources(s.Resources) {
+					errs = errs.Also(apis.ErrMultipleOneOf("computeResources", fmt.Sprintf("taskspec.steps[%d].resources", i)))
+				}
+			}
+		}
+	}
+	errs = errs.Also(validateOverrides(ts.StepOverrides, ts.SidecarOverrides, ts.ComputeResources))
+
+	return errs
+}
+
+// computeResourceNames are the resources which can be set for a whole Task.
+var computeResourceNames = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage}
 
+// validateComputeResources checks that the compute resources of a Task only
+// hold resources which can be distributed among its steps, and that none of
+// its requests exceeds its limit.
+func validateComputeResources(r corev1.ResourceRequirements) (errs *apis.FieldError) {
+	supported := map[corev1.ResourceName]bool{}
+	for _, name := range computeResourceNames {
+		supported[name] = true
+	}
+	for _, field := range []struct {
+		name      string
+		resources corev1.ResourceList
+	}{{"requests", r.Requests}, {"limits", r.Limits}} {
+		var names []string
+		for name := range field.resources {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if !supported[corev1.ResourceName(name)] {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s is not supported, only %s, %s and %s are", name, corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage), fmt.Sprintf("%s[%s]", field.name, name)))
+			}
+		}
+	}
+	for _, name := range computeResourceNames {
+		request, hasRequest := r.Requests[name]
+		limit, hasLimit := r.Limits[name]
+		if hasRequest && hasLimit && request.Cmp(limit) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s must be less than or equal to the limit %s", request.String(), limit.String()), "").ViaFieldKey("requests", string(name)))
+		}
+	}
+	return errs
+}
+
+// validateOverrides checks that each step and sidecar is overridden at most
+// once, and that the resources of the steps are not overridden when the
+// compute resources of the whole Task are set.
+func validateOverrides(steps []TaskRunStepOverride, sidecars []TaskRunSidecarOverride, computeResources *corev1.ResourceRequirements) (errs *apis.FieldError) {
+	stepNames := make([]string, 0, len(steps))
+	for i, o := range steps {
+		stepNames = append(stepNames, o.Name)
+		if computeResources != nil && hasResources(o.Resources) {
+			errs = errs.Also(apis.ErrMultipleOneOf("computeResources", fmt.Sprintf("stepOverrides[%d].resources", i)))
+		}
+	}
+	errs = errs.Also(validateOverrideNames(stepNames).ViaField("stepOverrides"))
+	sidecarNames := make([]string, 0, len(sidecars))
+	for _, o := range sidecars {
+		sidecarNames = append(sidecarNames, o.Name)
+	}
+	return errs.Also(validateOverrideNames(sidecarNames).ViaField("sidecarOverrides"))
+}
+
+// validateOverrideNames checks that the names of the overridden steps or
+// sidecars are set and unique.
+func validateOverrideNames(names []string) (errs *apis.FieldError) {
+	seen := sets.NewString()
+	for i, name := range names {
+		if name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaIndex(i))
+		} else if seen.Has(name) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s is overridden more than once", name), "name").ViaIndex(i))
+		}
+		seen.Insert(name)
+	}
+	return errs
+}
+
+// hasResources returns true if any resource request or limit is set.
+func hasResources(r corev1.ResourceRequirements) bool {
+	return len(r.Requests) > 0 || len(r.Limits) > 0
+}
+
+// validateExecutionMode checks that the steps of a hermetic TaskRun can be
+// executed in their own namespaces: this is only implemented on Linux, and
+// mapping the user and group IDs into the new user namespace requires the
+// steps to run as root.
+func (ts *TaskRunSpec) validateExecutionMode() (errs *apis.FieldError) {
+	if ts.ExecutionMode != TaskRunExecutionModeHermetic {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s", ts.ExecutionMode, TaskRunExecutionModeHermetic), "executionMode")
+	}
+	if tpl := ts.PodTemplate; tpl != nil {
+		if tpl.HostNetwork {
+			errs = errs.Also(apis.ErrGeneric("hostNetwork cannot be used with the hermetic execution mode", "podTemplate.hostNetwork"))
+		}
+		if nodeOS, ok := tpl.NodeSelector[corev1.LabelOSStable]; ok && nodeOS != "linux" {
+			errs = errs.Also(apis.ErrGeneric("the hermetic execution mode is only supported on linux nodes", fmt.Sprintf("podTemplate.nodeSelector[%s]", corev1.LabelOSStable)))
+		}
+		if sc := tpl.SecurityContext; sc != nil {
+			errs = errs.Also(validateHermeticRunAs(sc.RunAsUser, sc.RunAsNonRoot).ViaField("podTemplate.securityContext"))
+		}
+	}
+	if ts.TaskSpec != nil {
+		for i, s := range ts.TaskSpec.Steps {
+			if sc := s.SecurityContext; sc != nil {
+				errs = errs.Also(validateHermeticRunAs(sc.RunAsUser, sc.RunAsNonRoot).ViaField("securityContext").ViaFieldIndex("steps", i).ViaField("taskspec"))
+			}
+		}
+	}
+	return errs
+}
+
+// validateHermeticRunAs checks that the user a hermetic step runs as is root.
+func validateHermeticRunAs(runAsUser *int64, runAsNonRoot *bool) (errs *apis.FieldError) {
+	if runAsUser != nil && *runAsUser != 0 {
+		errs = errs.Also(apis.ErrGeneric("steps must run as root with the hermetic execution mode", "runAsUser"))
+	}
+	if runAsNonRoot != nil && *runAsNonRoot {
+		errs = errs.Also(apis.ErrGeneric("steps must run as root with the hermetic execution mode", "runAsNonRoot"))
+	}
+	return errs
+}
+
+func (trd *TaskRunDebug) validate() (errs *apis.FieldError) {
+	seen := map[string]bool{}
+	for i, b := range trd.Breakpoint {
+		if b != BreakpointOnFailure {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %s", b, BreakpointOnFailure), "").ViaFieldIndex("breakpoint", i))
+		} else if seen[b] {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s is specified more than once", b), "").ViaFieldIndex("breakpoint", i))
+		}
+		seen[b] = true
+	}
 	return errs
 }
 
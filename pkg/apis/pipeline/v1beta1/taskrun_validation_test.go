@@ -26,6 +26,7 @@ import (
 	resource "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -53,6 +54,23 @@ func TestTaskRun_Invalidate(t *testing.T) {
 			Message: "Invalid resource name: special character . must not be present",
 			Paths:   []string{"metadata.name"},
 		},
+	}, {
+		name: "taskrun pending after it is started",
+		task: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "taskrname",
+			},
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef: &v1beta1.TaskRef{Name: "task"},
+				Status:  v1beta1.TaskRunSpecStatusPending,
+			},
+			Status: v1beta1.TaskRunStatus{
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					StartTime: &metav1.Time{Time: time.Now()},
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("TaskRun cannot be Pending after it is started", "spec.status"),
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
@@ -65,16 +83,37 @@ func TestTaskRun_Invalidate(t *testing.T) {
 }
 
 func TestTaskRun_Validate(t *testing.T) {
-	tr := &v1beta1.TaskRun{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "taskrname",
+	tests := []struct {
+		name string
+		tr   *v1beta1.TaskRun
+	}{{
+		name: "normal case",
+		tr: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "taskrname",
+			},
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef: &v1beta1.TaskRef{Name: "taskrefname"},
+			},
 		},
-		Spec: v1beta1.TaskRunSpec{
-			TaskRef: &v1beta1.TaskRef{Name: "taskrefname"},
+	}, {
+		name: "pending taskrun",
+		tr: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "taskrname",
+			},
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef: &v1beta1.TaskRef{Name: "taskrefname"},
+				Status:  v1beta1.TaskRunSpecStatusPending,
+			},
 		},
-	}
-	if err := tr.Validate(context.Background()); err != nil {
-		t.Errorf("TaskRun.Validate() error = %v", err)
+	}}
+	for _, ts := range tests {
+		t.Run(ts.name, func(t *testing.T) {
+			if err := ts.tr.Validate(context.Background()); err != nil {
+				t.Errorf("TaskRun.Validate() error = %v", err)
+			}
+		})
 	}
 }
 
@@ -127,6 +166,8 @@ func TestTaskRun_Workspaces_Invalid(t *testing.T) {
 }
 
 func TestTaskRunSpec_Invalidate(t *testing.T) {
+	trueValue := true
+	nonRootUser := int64(1000)
 	tests := []struct {
 		name    string
 		spec    v1beta1.TaskRunSpec
@@ -173,7 +214,7 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 			},
 			Status: "TaskRunCancell",
 		},
-		wantErr: apis.ErrInvalidValue("TaskRunCancell should be TaskRunCancelled", "status"),
+		wantErr: apis.ErrInvalidValue("TaskRunCancell should be TaskRunCancelled or TaskRunPending", "status"),
 	}, {
 		name: "invalid taskspec",
 		spec: v1beta1.TaskRunSpec{
@@ -231,6 +272,171 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 		},
 		wantErr: apis.ErrInvalidValue("invalid bundle reference (could not parse reference: invalid reference)", "taskref.bundle"),
 		wc:      enableTektonOCIBundles(t),
+	}, {
+		name: "resolver params without a resolver",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "my-task",
+				ResolverRef: v1beta1.ResolverRef{
+					Params: []v1beta1.Param{{Name: "url", Value: *v1beta1.NewArrayOrString("https://example.com/repo.git")}},
+				},
+			},
+		},
+		wantErr: apis.ErrMissingField("taskref.resolver"),
+	}, {
+		name: "resolver with a name",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name:        "my-task",
+				ResolverRef: v1beta1.ResolverRef{Resolver: "git"},
+			},
+		},
+		wantErr: apis.ErrMultipleOneOf("taskref.name", "taskref.resolver"),
+	}, {
+		name: "resolver with an array param and a duplicate param",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				ResolverRef: v1beta1.ResolverRef{
+					Resolver: "git",
+					Params: []v1beta1.Param{{
+						Name:  "url",
+						Value: *v1beta1.NewArrayOrString("a", "b"),
+					}, {
+						Name:  "url",
+						Value: *v1beta1.NewArrayOrString("c"),
+					}},
+				},
+			},
+		},
+		wantErr: apis.ErrInvalidValue(`resolver param "url" must be a string`, "taskref.params[0].value").Also(
+			apis.ErrMultipleOneOf("taskref.params[url].name")),
+	}, {
+		name: "invalid debug breakpoint",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "my-task"},
+			Debug: &v1beta1.TaskRunDebug{
+				Breakpoint: []string{"onSuccess"},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("onSuccess should be onFailure", "debug.breakpoint[0]"),
+	}, {
+		name: "duplicate debug breakpoint",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "my-task"},
+			Debug: &v1beta1.TaskRunDebug{
+				Breakpoint: []string{"onFailure", "onFailure"},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("onFailure is specified more than once", "debug.breakpoint[1]"),
+	}, {
+		name: "invalid execution mode",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:       &v1beta1.TaskRef{Name: "my-task"},
+			ExecutionMode: "sandboxed",
+		},
+		wantErr: apis.ErrInvalidValue("sandboxed should be hermetic", "executionMode"),
+	}, {
+		name: "hermetic execution mode with host network",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:       &v1beta1.TaskRef{Name: "my-task"},
+			ExecutionMode: v1beta1.TaskRunExecutionModeHermetic,
+			PodTemplate:   &v1beta1.PodTemplate{HostNetwork: true},
+		},
+		wantErr: apis.ErrGeneric("hostNetwork cannot be used with the hermetic execution mode", "podTemplate.hostNetwork"),
+	}, {
+		name: "hermetic execution mode on windows nodes",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:       &v1beta1.TaskRef{Name: "my-task"},
+			ExecutionMode: v1beta1.TaskRunExecutionModeHermetic,
+			PodTemplate: &v1beta1.PodTemplate{
+				NodeSelector: map[string]string{"kubernetes.io/os": "windows"},
+			},
+		},
+		wantErr: apis.ErrGeneric("the hermetic execution mode is only supported on linux nodes", "podTemplate.nodeSelector[kubernetes.io/os]"),
+	}, {
+		name: "hermetic execution mode with non-root pod",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:       &v1beta1.TaskRef{Name: "my-task"},
+			ExecutionMode: v1beta1.TaskRunExecutionModeHermetic,
+			PodTemplate: &v1beta1.PodTemplate{
+				SecurityContext: &corev1.PodSecurityContext{RunAsNonRoot: &trueValue},
+			},
+		},
+		wantErr: apis.ErrGeneric("steps must run as root with the hermetic execution mode", "podTemplate.securityContext.runAsNonRoot"),
+	}, {
+		name: "hermetic execution mode with non-root step",
+		spec: v1beta1.TaskRunSpec{
+			ExecutionMode: v1beta1.TaskRunExecutionModeHermetic,
+			TaskSpec: &v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{Container: corev1.Container{
+					Name:            "mystep",
+					Image:           "myimage",
+					SecurityContext: &corev1.SecurityContext{RunAsUser: &nonRootUser},
+				}}},
+			},
+		},
+		wantErr: apis.ErrGeneric("steps must run as root with the hermetic execution mode", "taskspec.steps[0].securityContext.runAsUser"),
+	}, {
+		name: "unsupported compute resource",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "my-task"},
+			ComputeResources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{"nvidia.com/gpu": k8sresource.MustParse("1")},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("nvidia.com/gpu is not supported, only cpu, memory and ephemeral-storage are", "computeResources.limits[nvidia.com/gpu]"),
+	}, {
+		name: "compute resources with step resources",
+		spec: v1beta1.TaskRunSpec{
+			ComputeResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: k8sresource.MustParse("1")},
+			},
+			TaskSpec: &v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{Container: corev1.Container{
+					Name:  "mystep",
+					Image: "myimage",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: k8sresource.MustParse("1")},
+					},
+				}}},
+			},
+		},
+		wantErr: apis.ErrMultipleOneOf("computeResources", "taskspec.steps[0].resources"),
+	}, {
+		name: "step override without name",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "my-task"},
+			StepOverrides: []v1beta1.TaskRunStepOverride{{
+				Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+			}},
+		},
+		wantErr: apis.ErrMissingField("stepOverrides[0].name"),
+	}, {
+		name: "sidecar overridden more than once",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "my-task"},
+			SidecarOverrides: []v1beta1.TaskRunSidecarOverride{{
+				Name: "mysidecar",
+			}, {
+				Name: "mysidecar",
+			}},
+		},
+		wantErr: apis.ErrInvalidValue("mysidecar is overridden more than once", "sidecarOverrides[1].name"),
+	}, {
+		name: "compute resources with step override resources",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "my-task"},
+			ComputeResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: k8sresource.MustParse("1")},
+			},
+			StepOverrides: []v1beta1.TaskRunStepOverride{{
+				Name: "mystep",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: k8sresource.MustParse("1Gi")},
+				},
+			}},
+		},
+		wantErr: apis.ErrMultipleOneOf("computeResources", "stepOverrides[0].resources"),
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
@@ -247,6 +453,7 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 }
 
 func TestTaskRunSpec_Validate(t *testing.T) {
+	rootUser := int64(0)
 	tests := []struct {
 		name string
 		spec v1beta1.TaskRunSpec
@@ -286,6 +493,22 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 				}}},
 			},
 		},
+	}, {
+		name: "taskref with a resolver",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				ResolverRef: v1beta1.ResolverRef{
+					Resolver: "git",
+					Params: []v1beta1.Param{{
+						Name:  "url",
+						Value: *v1beta1.NewArrayOrString("https://example.com/repo.git"),
+					}, {
+						Name:  "pathInRepo",
+						Value: *v1beta1.NewArrayOrString("task.yaml"),
+					}},
+				},
+			},
+		},
 	}, {
 		name: "task spec with credentials.path variable",
 		spec: v1beta1.TaskRunSpec{
@@ -299,6 +522,47 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "hermetic execution mode",
+		spec: v1beta1.TaskRunSpec{
+			ExecutionMode: v1beta1.TaskRunExecutionModeHermetic,
+			PodTemplate: &v1beta1.PodTemplate{
+				NodeSelector:    map[string]string{"kubernetes.io/os": "linux"},
+				SecurityContext: &corev1.PodSecurityContext{RunAsUser: &rootUser},
+			},
+			TaskSpec: &v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{Container: corev1.Container{
+					Name:  "mystep",
+					Image: "myimage",
+				}}},
+			},
+		},
+	}, {
+		name: "debug breakpoint on failure",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "my-task"},
+			Debug: &v1beta1.TaskRunDebug{
+				Breakpoint: []string{"onFailure"},
+			},
+		},
+	}, {
+		name: "step and sidecar overrides",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "my-task"},
+			StepOverrides: []v1beta1.TaskRunStepOverride{{
+				Name: "mystep",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: k8sresource.MustParse("1Gi")},
+				},
+				Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+			}},
+			SidecarOverrides: []v1beta1.TaskRunSidecarOverride{{
+				Name: "mysidecar",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceCPU: k8sresource.MustParse("1")},
+				},
+			}},
+		},
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+)
+
+const (
+	// WhenCELParams is the CEL variable holding the values of the params of the
+	// PipelineRun, e.g. params.branch
+	WhenCELParams = "params"
+	// WhenCELTasks is the CEL variable holding the results and the execution
+	// status of the PipelineTasks, e.g. tasks.test.results.coverage or
+	// tasks.test.status
+	WhenCELTasks = "tasks"
+	// WhenCELContext is the CEL variable holding the context variables of the
+	// PipelineRun, e.g. context.pipelineRun.name
+	WhenCELContext = "context"
+)
+
+// celResultRef matches the references to the results of PipelineTasks in a CEL
+// expression, e.g. tasks.test.results.coverage, or tasks['unit-test'].results.coverage
+// for names which aren't CEL identifiers
+var celResultRef = regexp.MustCompile(`\b` + WhenCELTasks + `(\.([a-z0-9]+)|\[['"]([a-z0-9]([-a-z0-9]*[a-z0-9])?)['"]\])\.results\b`)
+
+// whenCELEnv is the CEL environment the CEL expressions of WhenExpressions are
+// compiled and evaluated in.
+var whenCELEnv, whenCELEnvErr = cel.NewEnv(cel.Declarations(
+	decls.NewVar(WhenCELParams, decls.NewMapType(decls.String, decls.Dyn)),
+	decls.NewVar(WhenCELTasks, decls.NewMapType(decls.String, decls.Dyn)),
+	decls.NewVar(WhenCELContext, decls.NewMapType(decls.String, decls.Dyn)),
+))
+
+// compileCEL compiles the CEL expression of the WhenExpression, which must
+// evaluate to a bool.
+func (we *WhenExpression) compileCEL() (cel.Program, error) {
+	if whenCELEnvErr != nil {
+		return nil, whenCELEnvErr
+	}
+	ast, iss := whenCELEnv.Compile(we.CEL)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	if !proto.Equal(ast.ResultType(), decls.Bool) && !proto.Equal(ast.ResultType(), decls.Dyn) {
+		return nil, fmt.Errorf("expression must evaluate to a bool, not to a %v", ast.ResultType())
+	}
+	return whenCELEnv.Program(ast)
+}
+
+// celResultTasks returns the names of the PipelineTasks whose results the CEL
+// expression of the WhenExpression references.
+func (we *WhenExpression) celResultTasks() []string {
+	var names []string
+	for _, match := range celResultRef.FindAllStringSubmatch(we.CEL, -1) {
+		if match[2] != "" {
+			names = append(names, match[2])
+		} else {
+			names = append(names, match[3])
+		}
+	}
+	return names
+}
+
+// HaveCEL indicates whether one of the When Expressions is a CEL expression.
+func (wes WhenExpressions) HaveCEL() bool {
+	for _, we := range wes {
+		if we.CEL != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// EvaluateCEL evaluates the CEL expressions of the When Expressions with the
+// given values of the params, tasks and context variables, to determine whether
+// all of them are true. The When Expressions which are not CEL expressions are
+// evaluated by AllowsExecution.
+func (wes WhenExpressions) EvaluateCEL(variables map[string]interface{}) (bool, error) {
+	for _, we := range wes {
+		if we.CEL == "" {
+			continue
+		}
+		prg, err := we.compileCEL()
+		if err != nil {
+			return false, fmt.Errorf("invalid CEL expression %q: %w", we.CEL, err)
+		}
+		out, _, err := prg.Eval(variables)
+		if err != nil {
+			return false, fmt.Errorf("failed to evaluate CEL expression %q: %w", we.CEL, err)
+		}
+		allowed, ok := out.Value().(bool)
+		if !ok {
+			return false, fmt.Errorf("CEL expression %q evaluated to %v instead of a bool", we.CEL, out.Value())
+		}
+		if !allowed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
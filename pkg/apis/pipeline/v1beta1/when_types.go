@@ -48,6 +48,13 @@ type WhenExpression struct {
 	// it represents a DeprecatedInput's relationship to the DeprecatedValues
 	// +optional
 	DeprecatedValues []string `json:"Values,omitempty"`
+
+	// CEL is a Common Expression Language expression evaluating to a bool, which
+	// can be used instead of the Input, Operator and Values. It can reference the
+	// params, the results and execution status of the PipelineTasks and the context
+	// variables of the PipelineRun, e.g. tasks.test.results.coverage > 80
+	// +optional
+	CEL string `json:"cel,omitempty"`
 }
 
 // GetInput returns the input string for guard checking
@@ -88,6 +95,10 @@ func (we *WhenExpression) isInputInValues() bool {
 }
 
 func (we *WhenExpression) isTrue() bool {
+	if we.CEL != "" {
+		// CEL expressions are evaluated by EvaluateCEL
+		return true
+	}
 	if we.GetOperator() == selection.In {
 		return we.isInputInValues()
 	}
@@ -110,7 +121,7 @@ func (we *WhenExpression) applyReplacements(replacements map[string]string) When
 		replacedValues = append(replacedValues, substitution.ApplyReplacements(val, replacements))
 	}
 
-	return WhenExpression{Input: replacedInput, Operator: we.GetOperator(), Values: replacedValues}
+	return WhenExpression{Input: replacedInput, Operator: we.GetOperator(), Values: replacedValues, CEL: we.CEL}
 }
 
 // GetVarSubstitutionExpressions extracts all the values between "$(" and ")" in a When Expression
@@ -129,7 +140,7 @@ type WhenExpressions []WhenExpression
 
 // AllowsExecution evaluates an Input's relationship to an array of Values, based on the Operator,
 // to determine whether all the When Expressions are True. If they are all True, the guarded Task is
-// executed, otherwise it is skipped.
+// executed, otherwise it is skipped. CEL expressions are not evaluated here but by EvaluateCEL.
 func (wes WhenExpressions) AllowsExecution() bool {
 	for _, we := range wes {
 		if !we.isTrue() {
@@ -77,6 +77,14 @@ func TestAllowsExecution(t *testing.T) {
 			},
 		},
 		expected: true,
+	}, {
+		name: "CEL expressions are left to EvaluateCEL",
+		whenExpressions: WhenExpressions{
+			{
+				CEL: "params.branch == 'other'",
+			},
+		},
+		expected: true,
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -317,3 +325,87 @@ func TestApplyReplacements(t *testing.T) {
 		})
 	}
 }
+
+func TestEvaluateCEL(t *testing.T) {
+	variables := map[string]interface{}{
+		WhenCELParams: map[string]interface{}{
+			"branch":    "main",
+			"platforms": []string{"linux", "mac"},
+		},
+		WhenCELTasks: map[string]interface{}{
+			"test": map[string]interface{}{
+				"results": map[string]interface{}{"coverage": "85"},
+				"status":  "Succeeded",
+			},
+		},
+		WhenCELContext: map[string]interface{}{
+			"pipelineRun": map[string]interface{}{"name": "pr", "namespace": "dev"},
+		},
+	}
+	tests := []struct {
+		name     string
+		wes      WhenExpressions
+		expected bool
+	}{{
+		name:     "no CEL expression",
+		wes:      WhenExpressions{{Input: "foo", Operator: selection.NotIn, Values: []string{"foo"}}},
+		expected: true,
+	}, {
+		name:     "true on params and results",
+		wes:      WhenExpressions{{CEL: "params.branch == 'main' && int(tasks.test.results.coverage) > 80"}},
+		expected: true,
+	}, {
+		name:     "true on an array param",
+		wes:      WhenExpressions{{CEL: "'mac' in params.platforms"}},
+		expected: true,
+	}, {
+		name:     "false on the execution status",
+		wes:      WhenExpressions{{CEL: "tasks.test.status == 'Failed'"}},
+		expected: false,
+	}, {
+		name: "false when one of the expressions is false",
+		wes: WhenExpressions{
+			{CEL: "context.pipelineRun.namespace == 'dev'"},
+			{CEL: "context.pipelineRun.name == 'other'"},
+		},
+		expected: false,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.wes.EvaluateCEL(variables)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("Expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestEvaluateCEL_Error(t *testing.T) {
+	variables := map[string]interface{}{
+		WhenCELParams:  map[string]interface{}{"branch": "main"},
+		WhenCELTasks:   map[string]interface{}{},
+		WhenCELContext: map[string]interface{}{},
+	}
+	for _, tc := range []struct {
+		name string
+		cel  string
+	}{{
+		name: "missing param",
+		cel:  "params.missing == 'main'",
+	}, {
+		name: "result which isn't an int",
+		cel:  "int(params.branch) > 80",
+	}, {
+		name: "not a bool",
+		cel:  "params.branch",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := (WhenExpressions{{CEL: tc.cel}}).EvaluateCEL(variables); err == nil {
+				t.Errorf("Expected an error evaluating %q", tc.cel)
+			}
+		})
+	}
+}
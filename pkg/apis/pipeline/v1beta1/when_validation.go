@@ -48,6 +48,9 @@ func (we *WhenExpression) validateWhenExpressionFields() *apis.FieldError {
 	if equality.Semantic.DeepEqual(we, &WhenExpression{}) || we == nil {
 		return apis.ErrMissingField(apis.CurrentField)
 	}
+	if we.CEL != "" {
+		return we.validateCEL()
+	}
 	if !sets.NewString(validWhenOperators...).Has(string(we.GetOperator())) {
 		message := fmt.Sprintf("operator %q is not recognized. valid operators: %s", we.GetOperator(), strings.Join(validWhenOperators, ","))
 		return apis.ErrInvalidValue(message, apis.CurrentField)
@@ -58,6 +61,18 @@ func (we *WhenExpression) validateWhenExpressionFields() *apis.FieldError {
 	return we.validateWhenExpressionsDuplicateFields()
 }
 
+// validateCEL checks that the CEL expression isn't combined with an input, an
+// operator or values, and that it compiles to a bool.
+func (we *WhenExpression) validateCEL() *apis.FieldError {
+	if we.GetInput() != "" || we.GetOperator() != "" || len(we.GetValues()) != 0 {
+		return apis.ErrMultipleOneOf("cel", "input")
+	}
+	if _, err := we.compileCEL(); err != nil {
+		return apis.ErrInvalidValue(fmt.Sprintf("invalid CEL expression %q: %v", we.CEL, err), "cel")
+	}
+	return nil
+}
+
 func (we *WhenExpression) validateWhenExpressionsDuplicateFields() *apis.FieldError {
 	if we.Input != "" && we.DeprecatedInput != "" {
 		return apis.ErrMultipleOneOf("input", "Input")
@@ -88,16 +103,17 @@ func (wes WhenExpressions) validateTaskResultsVariables() *apis.FieldError {
 	return nil
 }
 
-func (wes WhenExpressions) validatePipelineParametersVariables(prefix string, paramNames sets.String, arrayParamNames sets.String) (errs *apis.FieldError) {
+func (wes WhenExpressions) validatePipelineParametersVariables(prefix string, paramNames sets.String, arrayParamNames sets.String, objectParamKeys map[string]sets.String) (errs *apis.FieldError) {
 	for idx, we := range wes {
-		errs = errs.Also(validateStringVariable(we.GetInput(), prefix, paramNames, arrayParamNames).ViaField("input").ViaFieldIndex("when", idx))
+		errs = errs.Also(validateStringVariable(we.GetInput(), prefix, paramNames, arrayParamNames, objectParamKeys).ViaField("input").ViaFieldIndex("when", idx))
 		for _, val := range we.GetValues() {
-			errs = errs.Also(validateStringVariable(val, prefix, paramNames, arrayParamNames).ViaField("values").ViaFieldIndex("when", idx))
+			errs = errs.Also(validateStringVariable(val, prefix, paramNames, arrayParamNames, objectParamKeys).ViaField("values").ViaFieldIndex("when", idx))
 		}
 	}
 	return errs
 }
-func validateStringVariable(value, prefix string, stringVars sets.String, arrayVars sets.String) *apis.FieldError {
+func validateStringVariable(value, prefix string, stringVars sets.String, arrayVars sets.String, objectKeys map[string]sets.String) *apis.FieldError {
 	errs := substitution.ValidateVariableP(value, prefix, stringVars)
+	errs = errs.Also(substitution.ValidateVariableObjectKeysP(value, prefix, objectKeys, false))
 	return errs.Also(substitution.ValidateVariableProhibitedP(value, prefix, arrayVars))
 }
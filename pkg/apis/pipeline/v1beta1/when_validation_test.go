@@ -51,6 +51,16 @@ func TestWhenExpressions_Valid(t *testing.T) {
 			Operator: selection.In,
 			Values:   []string{""},
 		}},
+	}, {
+		name: "valid CEL expression",
+		wes: []WhenExpression{{
+			CEL: "params.branch == 'main' && int(tasks.test.results.coverage) > 80",
+		}},
+	}, {
+		name: "CEL expression on the context",
+		wes: []WhenExpression{{
+			CEL: "context.pipelineRun.namespace in ['dev', 'test']",
+		}},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -125,6 +135,29 @@ func TestWhenExpressions_Invalid(t *testing.T) {
 	}, {
 		name: "missing when expression",
 		wes:  []WhenExpression{{}},
+	}, {
+		name: "CEL expression with an input",
+		wes: []WhenExpression{{
+			CEL:      "params.branch == 'main'",
+			Input:    "foo",
+			Operator: selection.In,
+			Values:   []string{"foo"},
+		}},
+	}, {
+		name: "CEL expression which doesn't parse",
+		wes: []WhenExpression{{
+			CEL: "params.branch ==",
+		}},
+	}, {
+		name: "CEL expression with an unknown variable",
+		wes: []WhenExpression{{
+			CEL: "workspaces.source.bound",
+		}},
+	}, {
+		name: "CEL expression which doesn't evaluate to a bool",
+		wes: []WhenExpression{{
+			CEL: "'main'",
+		}},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
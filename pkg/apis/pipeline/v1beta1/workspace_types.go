@@ -50,6 +50,14 @@ func (w *WorkspaceDeclaration) GetMountPath() string {
 	return filepath.Join(pipeline.WorkspaceDir, w.Name)
 }
 
+// WorkspaceUsage is used by a Step or a Sidecar to declare that it uses a
+// workspace of the Task, which isolates the workspace to the Steps and Sidecars
+// declaring it.
+type WorkspaceUsage struct {
+	// Name is the name of the workspace, as declared by the Task.
+	Name string `json:"name"`
+}
+
 // WorkspaceBinding maps a Task's declared workspace to a Volume.
 type WorkspaceBinding struct {
 	// Name is the name of the workspace populated by the volume.
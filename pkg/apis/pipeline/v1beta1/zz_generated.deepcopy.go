@@ -37,6 +37,13 @@ func (in *ArrayOrString) DeepCopyInto(out *ArrayOrString) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ObjectVal != nil {
+		in, out := &in.ObjectVal, &out.ObjectVal
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -301,6 +308,13 @@ func (in *Param) DeepCopy() *Param {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParamSpec) DeepCopyInto(out *ParamSpec) {
 	*out = *in
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]PropertySpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Default != nil {
 		in, out := &in.Default, &out.Default
 		*out = new(ArrayOrString)
@@ -398,6 +412,7 @@ func (in *PipelineList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineRef) DeepCopyInto(out *PipelineRef) {
 	*out = *in
+	in.ResolverRef.DeepCopyInto(&out.ResolverRef)
 	return
 }
 
@@ -518,6 +533,22 @@ func (in *PipelineRun) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunConcurrency) DeepCopyInto(out *PipelineRunConcurrency) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunConcurrency.
+func (in *PipelineRunConcurrency) DeepCopy() *PipelineRunConcurrency {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunConcurrency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineRunConditionCheckStatus) DeepCopyInto(out *PipelineRunConditionCheckStatus) {
 	*out = *in
@@ -572,6 +603,34 @@ func (in *PipelineRunList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunPipelineRunStatus) DeepCopyInto(out *PipelineRunPipelineRunStatus) {
+	*out = *in
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(PipelineRunStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WhenExpressions != nil {
+		in, out := &in.WhenExpressions, &out.WhenExpressions
+		*out = make([]WhenExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunPipelineRunStatus.
+func (in *PipelineRunPipelineRunStatus) DeepCopy() *PipelineRunPipelineRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunPipelineRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineRunResult) DeepCopyInto(out *PipelineRunResult) {
 	*out = *in
@@ -622,7 +681,7 @@ func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
 	if in.PipelineRef != nil {
 		in, out := &in.PipelineRef, &out.PipelineRef
 		*out = new(PipelineRef)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.PipelineSpec != nil {
 		in, out := &in.PipelineSpec, &out.PipelineSpec
@@ -653,6 +712,11 @@ func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(TimeoutFields)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PodTemplate != nil {
 		in, out := &in.PodTemplate, &out.PodTemplate
 		*out = new(pod.Template)
@@ -672,6 +736,11 @@ func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(PipelineRunConcurrency)
+		**out = **in
+	}
 	return
 }
 
@@ -730,6 +799,10 @@ func (in *PipelineRunStatusFields) DeepCopyInto(out *PipelineRunStatusFields) {
 		in, out := &in.CompletionTime, &out.CompletionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.FinallyStartTime != nil {
+		in, out := &in.FinallyStartTime, &out.FinallyStartTime
+		*out = (*in).DeepCopy()
+	}
 	if in.TaskRuns != nil {
 		in, out := &in.TaskRuns, &out.TaskRuns
 		*out = make(map[string]*PipelineRunTaskRunStatus, len(*in))
@@ -760,6 +833,21 @@ func (in *PipelineRunStatusFields) DeepCopyInto(out *PipelineRunStatusFields) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.PipelineRuns != nil {
+		in, out := &in.PipelineRuns, &out.PipelineRuns
+		*out = make(map[string]*PipelineRunPipelineRunStatus, len(*in))
+		for key, val := range *in {
+			var outVal *PipelineRunPipelineRunStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(PipelineRunPipelineRunStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
 	if in.PipelineResults != nil {
 		in, out := &in.PipelineResults, &out.PipelineResults
 		*out = make([]PipelineRunResult, len(*in))
@@ -891,13 +979,23 @@ func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
 	if in.TaskRef != nil {
 		in, out := &in.TaskRef, &out.TaskRef
 		*out = new(TaskRef)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.TaskSpec != nil {
 		in, out := &in.TaskSpec, &out.TaskSpec
 		*out = new(EmbeddedTask)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PipelineRef != nil {
+		in, out := &in.PipelineRef, &out.PipelineRef
+		*out = new(PipelineRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PipelineSpec != nil {
+		in, out := &in.PipelineSpec, &out.PipelineSpec
+		*out = new(PipelineSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]PipelineTaskCondition, len(*in))
@@ -912,6 +1010,11 @@ func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RunAfter != nil {
 		in, out := &in.RunAfter, &out.RunAfter
 		*out = make([]string, len(*in))
@@ -939,6 +1042,13 @@ func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Matrix != nil {
+		in, out := &in.Matrix, &out.Matrix
+		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1139,6 +1249,25 @@ func (in *PipelineTaskRunSpec) DeepCopyInto(out *PipelineTaskRunSpec) {
 		*out = new(pod.Template)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ComputeResources != nil {
+		in, out := &in.ComputeResources, &out.ComputeResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StepOverrides != nil {
+		in, out := &in.StepOverrides, &out.StepOverrides
+		*out = make([]TaskRunStepOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SidecarOverrides != nil {
+		in, out := &in.SidecarOverrides, &out.SidecarOverrides
+		*out = make([]TaskRunSidecarOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1168,6 +1297,76 @@ func (in *PipelineWorkspaceDeclaration) DeepCopy() *PipelineWorkspaceDeclaration
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PropertySpec) DeepCopyInto(out *PropertySpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropertySpec.
+func (in *PropertySpec) DeepCopy() *PropertySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PropertySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolverRef) DeepCopyInto(out *ResolverRef) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolverRef.
+func (in *ResolverRef) DeepCopy() *ResolverRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolverRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.InitialDelay != nil {
+		in, out := &in.InitialDelay, &out.InitialDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExitCodes != nil {
+		in, out := &in.ExitCodes, &out.ExitCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResultRef) DeepCopyInto(out *ResultRef) {
 	*out = *in
@@ -1188,6 +1387,11 @@ func (in *ResultRef) DeepCopy() *ResultRef {
 func (in *Sidecar) DeepCopyInto(out *Sidecar) {
 	*out = *in
 	in.Container.DeepCopyInto(&out.Container)
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]WorkspaceUsage, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1250,6 +1454,28 @@ func (in *Step) DeepCopyInto(out *Step) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(StepRef)
+		**out = **in
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]StepResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]WorkspaceUsage, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1263,10 +1489,90 @@ func (in *Step) DeepCopy() *Step {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepProvenance) DeepCopyInto(out *StepProvenance) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepProvenance.
+func (in *StepProvenance) DeepCopy() *StepProvenance {
+	if in == nil {
+		return nil
+	}
+	out := new(StepProvenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepRef) DeepCopyInto(out *StepRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepRef.
+func (in *StepRef) DeepCopy() *StepRef {
+	if in == nil {
+		return nil
+	}
+	out := new(StepRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepResourceUsage) DeepCopyInto(out *StepResourceUsage) {
+	*out = *in
+	out.MaxRSS = in.MaxRSS.DeepCopy()
+	out.UserCPUTime = in.UserCPUTime
+	out.SystemCPUTime = in.SystemCPUTime
+	out.WallTime = in.WallTime
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepResourceUsage.
+func (in *StepResourceUsage) DeepCopy() *StepResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(StepResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepResult) DeepCopyInto(out *StepResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepResult.
+func (in *StepResult) DeepCopy() *StepResult {
+	if in == nil {
+		return nil
+	}
+	out := new(StepResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StepState) DeepCopyInto(out *StepState) {
 	*out = *in
 	in.ContainerState.DeepCopyInto(&out.ContainerState)
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]TaskRunResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = new(StepResourceUsage)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1343,6 +1649,7 @@ func (in *TaskList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskRef) DeepCopyInto(out *TaskRef) {
 	*out = *in
+	in.ResolverRef.DeepCopyInto(&out.ResolverRef)
 	return
 }
 
@@ -1465,6 +1772,27 @@ func (in *TaskRun) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRunDebug) DeepCopyInto(out *TaskRunDebug) {
+	*out = *in
+	if in.Breakpoint != nil {
+		in, out := &in.Breakpoint, &out.Breakpoint
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRunDebug.
+func (in *TaskRunDebug) DeepCopy() *TaskRunDebug {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRunDebug)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskRunInputs) DeepCopyInto(out *TaskRunInputs) {
 	*out = *in
@@ -1584,6 +1912,7 @@ func (in *TaskRunResources) DeepCopy() *TaskRunResources {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskRunResult) DeepCopyInto(out *TaskRunResult) {
 	*out = *in
+	in.Value.DeepCopyInto(&out.Value)
 	return
 }
 
@@ -1597,6 +1926,30 @@ func (in *TaskRunResult) DeepCopy() *TaskRunResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRunSidecarOverride) DeepCopyInto(out *TaskRunSidecarOverride) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRunSidecarOverride.
+func (in *TaskRunSidecarOverride) DeepCopy() *TaskRunSidecarOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRunSidecarOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 	*out = *in
@@ -1615,7 +1968,7 @@ func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 	if in.TaskRef != nil {
 		in, out := &in.TaskRef, &out.TaskRef
 		*out = new(TaskRef)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.TaskSpec != nil {
 		in, out := &in.TaskSpec, &out.TaskSpec
@@ -1639,6 +1992,30 @@ func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(TaskRunDebug)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ComputeResources != nil {
+		in, out := &in.ComputeResources, &out.ComputeResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StepOverrides != nil {
+		in, out := &in.StepOverrides, &out.StepOverrides
+		*out = make([]TaskRunStepOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SidecarOverrides != nil {
+		in, out := &in.SidecarOverrides, &out.SidecarOverrides
+		*out = make([]TaskRunSidecarOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1652,6 +2029,30 @@ func (in *TaskRunSpec) DeepCopy() *TaskRunSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRunStepOverride) DeepCopyInto(out *TaskRunStepOverride) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRunStepOverride.
+func (in *TaskRunStepOverride) DeepCopy() *TaskRunStepOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRunStepOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskRunStatus) DeepCopyInto(out *TaskRunStatus) {
 	*out = *in
@@ -1712,7 +2113,9 @@ func (in *TaskRunStatusFields) DeepCopyInto(out *TaskRunStatusFields) {
 	if in.TaskRunResults != nil {
 		in, out := &in.TaskRunResults, &out.TaskRunResults
 		*out = make([]TaskRunResult, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Sidecars != nil {
 		in, out := &in.Sidecars, &out.Sidecars
@@ -1726,6 +2129,11 @@ func (in *TaskRunStatusFields) DeepCopyInto(out *TaskRunStatusFields) {
 		*out = new(TaskSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.StepProvenance != nil {
+		in, out := &in.StepProvenance, &out.StepProvenance
+		*out = make([]StepProvenance, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1803,6 +2211,37 @@ func (in *TaskSpec) DeepCopy() *TaskSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeoutFields) DeepCopyInto(out *TimeoutFields) {
+	*out = *in
+	if in.Pipeline != nil {
+		in, out := &in.Pipeline, &out.Pipeline
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Finally != nil {
+		in, out := &in.Finally, &out.Finally
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeoutFields.
+func (in *TimeoutFields) DeepCopy() *TimeoutFields {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeoutFields)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WhenExpression) DeepCopyInto(out *WhenExpression) {
 	*out = *in
@@ -1923,3 +2362,19 @@ func (in *WorkspacePipelineTaskBinding) DeepCopy() *WorkspacePipelineTaskBinding
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceUsage) DeepCopyInto(out *WorkspaceUsage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceUsage.
+func (in *WorkspaceUsage) DeepCopy() *WorkspaceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -48,6 +48,10 @@ func (c *FakeTektonV1alpha1) Runs(namespace string) v1alpha1.RunInterface {
 	return &FakeRuns{c, namespace}
 }
 
+func (c *FakeTektonV1alpha1) StepActions(namespace string) v1alpha1.StepActionInterface {
+	return &FakeStepActions{c, namespace}
+}
+
 func (c *FakeTektonV1alpha1) Tasks(namespace string) v1alpha1.TaskInterface {
 	return &FakeTasks{c, namespace}
 }
@@ -28,6 +28,8 @@ type PipelineRunExpansion interface{}
 
 type RunExpansion interface{}
 
+type StepActionExpansion interface{}
+
 type TaskExpansion interface{}
 
 type TaskRunExpansion interface{}
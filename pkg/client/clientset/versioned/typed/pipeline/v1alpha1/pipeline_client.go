@@ -31,6 +31,7 @@ type TektonV1alpha1Interface interface {
 	PipelinesGetter
 	PipelineRunsGetter
 	RunsGetter
+	StepActionsGetter
 	TasksGetter
 	TaskRunsGetter
 }
@@ -60,6 +61,10 @@ func (c *TektonV1alpha1Client) Runs(namespace string) RunInterface {
 	return newRuns(c, namespace)
 }
 
+func (c *TektonV1alpha1Client) StepActions(namespace string) StepActionInterface {
+	return newStepActions(c, namespace)
+}
+
 func (c *TektonV1alpha1Client) Tasks(namespace string) TaskInterface {
 	return newTasks(c, namespace)
 }
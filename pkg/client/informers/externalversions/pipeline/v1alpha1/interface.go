@@ -34,6 +34,8 @@ type Interface interface {
 	PipelineRuns() PipelineRunInformer
 	// Runs returns a RunInformer.
 	Runs() RunInformer
+	// StepActions returns a StepActionInformer.
+	StepActions() StepActionInformer
 	// Tasks returns a TaskInformer.
 	Tasks() TaskInformer
 	// TaskRuns returns a TaskRunInformer.
@@ -76,6 +78,11 @@ func (v *version) Runs() RunInformer {
 	return &runInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// StepActions returns a StepActionInformer.
+func (v *version) StepActions() StepActionInformer {
+	return &stepActionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Tasks returns a TaskInformer.
 func (v *version) Tasks() TaskInformer {
 	return &taskInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
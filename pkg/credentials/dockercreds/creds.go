@@ -37,6 +37,14 @@ var config basicDocker
 var dockerConfig arrayArg
 var dockerCfg arrayArg
 
+func init() {
+	credentials.Register("docker", credentials.Registration{
+		Builder:         NewBuilder(),
+		AddFlags:        AddFlags,
+		CredentialPaths: func() []string { return []string{".docker"} },
+	})
+}
+
 // AddFlags adds CLI flags that dockercreds supports to a given flag.FlagSet.
 func AddFlags(flagSet *flag.FlagSet) {
 	flags(flagSet)
@@ -0,0 +1,188 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filecreds
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/credentials"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// annotationPrefix is followed by the key of the Secret to write, and the
+	// value of the annotation is the path, relative to HOME, to write it to.
+	annotationPrefix = "tekton.dev/file-"
+	fileFlag         = "file"
+)
+
+var config fileConfig
+
+func init() {
+	credentials.Register("file", credentials.Registration{
+		Builder:         NewBuilder(),
+		AddFlags:        AddFlags,
+		CredentialPaths: func() []string { return config.paths() },
+	})
+}
+
+// AddFlags adds CLI flags that filecreds supports to a given flag.FlagSet.
+func AddFlags(flagSet *flag.FlagSet) {
+	flags(flagSet)
+}
+
+func flags(fs *flag.FlagSet) {
+	config = fileConfig{entries: make(map[string]fileEntry)}
+	fs.Var(&config, fileFlag, "List of secret=key=path triples.")
+}
+
+// As the flag is read, this status is populated.
+// fileConfig implements flag.Value
+type fileConfig struct {
+	entries map[string]fileEntry
+	// The order we see things, for iterating over the above.
+	order []string
+}
+
+type fileEntry struct {
+	secret string
+	key    string
+}
+
+func (fc *fileConfig) String() string {
+	if fc == nil {
+		// According to flag.Value this can happen.
+		return ""
+	}
+	var files []string
+	for _, k := range fc.order {
+		files = append(files, fmt.Sprintf("%s=%s=%s", fc.entries[k].secret, fc.entries[k].key, k))
+	}
+	return strings.Join(files, ",")
+}
+
+func (fc *fileConfig) Set(value string) error {
+	parts := strings.SplitN(value, "=", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("expect entries of the form secret=key=path, got: %v", value)
+	}
+	secret := parts[0]
+	key := parts[1]
+	path, err := cleanPath(parts[2])
+	if err != nil {
+		return err
+	}
+
+	if _, ok := fc.entries[path]; ok {
+		return fmt.Errorf("multiple entries for path: %v", path)
+	}
+	if _, err := os.Stat(filepath.Join(credentials.VolumeName(secret), key)); err != nil {
+		return err
+	}
+
+	fc.entries[path] = fileEntry{secret: secret, key: key}
+	fc.order = append(fc.order, path)
+	return nil
+}
+
+// cleanPath returns the cleaned form of the given path, or an error if it is
+// not a path within the HOME directory.
+func cleanPath(path string) (string, error) {
+	if path == "" || filepath.IsAbs(path) {
+		return "", fmt.Errorf("expect a path relative to HOME, got: %q", path)
+	}
+	cleaned := filepath.Clean(path)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("expect a path within HOME, got: %q", path)
+	}
+	return cleaned, nil
+}
+
+// Write copies the keys of the Secrets in fc.entries to their paths in the
+// directory provided. If fc.entries is empty then nothing is written.
+func (fc *fileConfig) Write(directory string) error {
+	for _, path := range fc.order {
+		e := fc.entries[path]
+		content, err := ioutil.ReadFile(filepath.Join(credentials.VolumeName(e.secret), e.key))
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(directory, path)
+		if err := os.MkdirAll(filepath.Dir(dest), os.ModePerm); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(dest, content, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// paths returns the top-level paths, relative to HOME, under which the files
+// are written.
+func (fc *fileConfig) paths() []string {
+	seen := map[string]bool{}
+	var paths []string
+	for _, path := range fc.order {
+		top := strings.SplitN(path, string(filepath.Separator), 2)[0]
+		if !seen[top] {
+			seen[top] = true
+			paths = append(paths, top)
+		}
+	}
+	return paths
+}
+
+type fileConfigBuilder struct{}
+
+// NewBuilder returns a new builder for generic credential files.
+func NewBuilder() credentials.Builder { return &fileConfigBuilder{} }
+
+// MatchingAnnotations extracts flags for the credential helper
+// from the supplied secret and returns a slice (of length 0 or
+// greater) of applicable files. Annotations whose path is not
+// within HOME are ignored.
+func (*fileConfigBuilder) MatchingAnnotations(secret *corev1.Secret) []string {
+	var keys []string
+	for k := range secret.Annotations {
+		if strings.HasPrefix(k, annotationPrefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var flags []string
+	for _, k := range keys {
+		key := strings.TrimPrefix(k, annotationPrefix)
+		path, err := cleanPath(secret.Annotations[k])
+		if key == "" || err != nil {
+			continue
+		}
+		flags = append(flags, fmt.Sprintf("-%s=%s=%s=%s", fileFlag, secret.Name, key, path))
+	}
+	return flags
+}
+
+func (*fileConfigBuilder) Write(directory string) error {
+	return config.Write(directory)
+}
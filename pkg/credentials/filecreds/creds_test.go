@@ -0,0 +1,172 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filecreds
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/credentials"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func writeSecret(t *testing.T, name string, data map[string]string) {
+	t.Helper()
+	dir := credentials.VolumeName(name)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatalf("os.MkdirAll(%s) = %v", dir, err)
+	}
+	for k, v := range data {
+		if err := ioutil.WriteFile(filepath.Join(dir, k), []byte(v), 0777); err != nil {
+			t.Fatalf("ioutil.WriteFile(%s) = %v", k, err)
+		}
+	}
+}
+
+func TestFlagHandling(t *testing.T) {
+	credentials.VolumePath, _ = ioutil.TempDir("", "")
+	writeSecret(t, "gcloud", map[string]string{"credentials.json": "{}"})
+	writeSecret(t, "pypi", map[string]string{".pypirc": "[pypi]"})
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	AddFlags(fs)
+	err := fs.Parse([]string{
+		"-file=gcloud=credentials.json=.config/gcloud/application_default_credentials.json",
+		"-file=pypi=.pypirc=.pypirc",
+	})
+	if err != nil {
+		t.Fatalf("flag.CommandLine.Parse() = %v", err)
+	}
+
+	dir, _ := ioutil.TempDir("", "")
+	if err := NewBuilder().Write(dir); err != nil {
+		t.Fatalf("Write() = %v", err)
+	}
+
+	for path, want := range map[string]string{
+		".config/gcloud/application_default_credentials.json": "{}",
+		".pypirc": "[pypi]",
+	} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, path))
+		if err != nil {
+			t.Fatalf("ioutil.ReadFile(%s) = %v", path, err)
+		}
+		if string(b) != want {
+			t.Errorf("got: %v, wanted: %v", string(b), want)
+		}
+	}
+
+	wantPaths := []string{".config", ".pypirc"}
+	if got := config.paths(); !cmp.Equal(wantPaths, got) {
+		t.Errorf("paths() = %v, wanted: %v", got, wantPaths)
+	}
+}
+
+func TestFlagHandlingMissingFiles(t *testing.T) {
+	credentials.VolumePath, _ = ioutil.TempDir("", "")
+	writeSecret(t, "not-found", nil)
+
+	cfg := fileConfig{entries: make(map[string]fileEntry)}
+	if err := cfg.Set("not-found=key=.config/file"); err == nil {
+		t.Error("Set(); got success, wanted error.")
+	}
+}
+
+func TestFlagHandlingPathCollision(t *testing.T) {
+	credentials.VolumePath, _ = ioutil.TempDir("", "")
+	writeSecret(t, "foo", map[string]string{"key": "value"})
+
+	cfg := fileConfig{entries: make(map[string]fileEntry)}
+	if err := cfg.Set("foo=key=.config/file"); err != nil {
+		t.Fatalf("First Set() = %v", err)
+	}
+	if err := cfg.Set("foo=key=.config/./file"); err == nil {
+		t.Error("Second Set(); got success, wanted error.")
+	}
+}
+
+func TestMalformedValues(t *testing.T) {
+	credentials.VolumePath, _ = ioutil.TempDir("", "")
+	writeSecret(t, "foo", map[string]string{"key": "value"})
+	tests := []string{
+		"foo",
+		"foo=key",
+		"foo=key=",
+		"=key=.config/file",
+		"foo==.config/file",
+		"foo=key=/etc/passwd",
+		"foo=key=..",
+		"foo=key=../file",
+		"foo=key=.config/../../file",
+		"foo=key=.",
+	}
+	for _, test := range tests {
+		cfg := fileConfig{entries: make(map[string]fileEntry)}
+		if err := cfg.Set(test); err == nil {
+			t.Errorf("Set(%v); got success, wanted error.", test)
+		}
+	}
+}
+
+func TestMatchingAnnotations(t *testing.T) {
+	tests := []struct {
+		secret   *corev1.Secret
+		wantFlag []string
+	}{{
+		secret: &corev1.Secret{
+			Type: corev1.SecretTypeOpaque,
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "files",
+				Annotations: map[string]string{
+					fmt.Sprintf("%s.pypirc", annotationPrefix):          ".pypirc",
+					fmt.Sprintf("%scredentials.json", annotationPrefix): ".config/gcloud/credentials.json",
+				},
+			},
+		},
+		wantFlag: []string{
+			fmt.Sprintf("-%s=files=.pypirc=.pypirc", fileFlag),
+			fmt.Sprintf("-%s=files=credentials.json=.config/gcloud/credentials.json", fileFlag),
+		},
+	}, {
+		secret: &corev1.Secret{
+			Type: corev1.SecretTypeOpaque,
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "escaping",
+				Annotations: map[string]string{
+					fmt.Sprintf("%sabsolute", annotationPrefix): "/etc/passwd",
+					fmt.Sprintf("%sparent", annotationPrefix):   "../.profile",
+					annotationPrefix: ".config/file",
+				},
+			},
+		},
+		wantFlag: nil,
+	}}
+
+	nb := NewBuilder()
+	for _, ts := range tests {
+		gotFlag := nb.MatchingAnnotations(ts.secret)
+		if !cmp.Equal(ts.wantFlag, gotFlag) {
+			t.Errorf("MatchingAnnotations() Mismatch of flags; wanted: %v got: %v", ts.wantFlag, gotFlag)
+		}
+	}
+}
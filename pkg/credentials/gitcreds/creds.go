@@ -36,6 +36,14 @@ var (
 	sshConfig   sshGitConfig
 )
 
+func init() {
+	credentials.Register("git", credentials.Registration{
+		Builder:         NewBuilder(),
+		AddFlags:        AddFlags,
+		CredentialPaths: func() []string { return []string{".gitconfig", ".git-credentials", ".ssh"} },
+	})
+}
+
 // AddFlags adds CLI flags that gitcreds supports to a given flag.FlagSet.
 func AddFlags(flagSet *flag.FlagSet) {
 	flags(flagSet)
@@ -0,0 +1,183 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mavencreds
+
+import (
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/credentials"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	annotationPrefix = "tekton.dev/maven-"
+	basicAuthFlag    = "basic-maven"
+	// settingsDir is the directory, relative to HOME, holding the Maven
+	// user settings.
+	settingsDir = ".m2"
+)
+
+var config basicMavenConfig
+
+func init() {
+	credentials.Register("maven", credentials.Registration{
+		Builder:         NewBuilder(),
+		AddFlags:        AddFlags,
+		CredentialPaths: func() []string { return []string{settingsDir} },
+	})
+}
+
+// AddFlags adds CLI flags that mavencreds supports to a given flag.FlagSet.
+func AddFlags(flagSet *flag.FlagSet) {
+	flags(flagSet)
+}
+
+func flags(fs *flag.FlagSet) {
+	config = basicMavenConfig{entries: make(map[string]server)}
+	fs.Var(&config, basicAuthFlag, "List of secret=server-id pairs.")
+}
+
+// As the flag is read, this status is populated.
+// basicMavenConfig implements flag.Value
+type basicMavenConfig struct {
+	entries map[string]server
+	// The order we see things, for iterating over the above.
+	order []string
+}
+
+func (mc *basicMavenConfig) String() string {
+	if mc == nil {
+		// According to flag.Value this can happen.
+		return ""
+	}
+	var ids []string
+	for _, k := range mc.order {
+		ids = append(ids, fmt.Sprintf("%s=%s", mc.entries[k].secret, k))
+	}
+	return strings.Join(ids, ",")
+}
+
+func (mc *basicMavenConfig) Set(value string) error {
+	parts := strings.Split(value, "=")
+	if len(parts) != 2 {
+		return fmt.Errorf("expect entries of the form secret=server-id, got: %v", value)
+	}
+	secret := parts[0]
+	id := parts[1]
+
+	if _, ok := mc.entries[id]; ok {
+		return fmt.Errorf("multiple entries for server: %v", id)
+	}
+
+	s, err := newServer(id, secret)
+	if err != nil {
+		return err
+	}
+	mc.entries[id] = *s
+	mc.order = append(mc.order, id)
+	return nil
+}
+
+// settings is the subset of the Maven settings.xml file holding the
+// credentials of servers.
+type settings struct {
+	XMLName xml.Name `xml:"settings"`
+	Servers []server `xml:"servers>server"`
+}
+
+type server struct {
+	secret   string
+	ID       string `xml:"id"`
+	Username string `xml:"username"`
+	Password string `xml:"password"`
+}
+
+// Write builds a .m2/settings.xml file from mc.entries and writes it to disk
+// in the directory provided. If mc.entries is empty then nothing is written.
+func (mc *basicMavenConfig) Write(directory string) error {
+	if len(mc.entries) == 0 {
+		return nil
+	}
+	s := settings{}
+	for _, k := range mc.order {
+		s.Servers = append(s.Servers, mc.entries[k])
+	}
+	content, err := xml.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	content = append([]byte(xml.Header), append(content, '\n')...)
+
+	m2Dir := filepath.Join(directory, settingsDir)
+	if err := os.MkdirAll(m2Dir, os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(m2Dir, "settings.xml"), content, 0600)
+}
+
+func newServer(id, secret string) (*server, error) {
+	secretPath := credentials.VolumeName(secret)
+
+	ub, err := ioutil.ReadFile(filepath.Join(secretPath, corev1.BasicAuthUsernameKey))
+	if err != nil {
+		return nil, err
+	}
+	username := string(ub)
+
+	pb, err := ioutil.ReadFile(filepath.Join(secretPath, corev1.BasicAuthPasswordKey))
+	if err != nil {
+		return nil, err
+	}
+	password := string(pb)
+
+	return &server{
+		secret:   secret,
+		ID:       id,
+		Username: username,
+		Password: password,
+	}, nil
+}
+
+type basicMavenConfigBuilder struct{}
+
+// NewBuilder returns a new builder for Maven credentials.
+func NewBuilder() credentials.Builder { return &basicMavenConfigBuilder{} }
+
+// MatchingAnnotations extracts flags for the credential helper
+// from the supplied secret and returns a slice (of length 0 or
+// greater) of applicable domains.
+func (*basicMavenConfigBuilder) MatchingAnnotations(secret *corev1.Secret) []string {
+	var flags []string
+	if secret.Type != corev1.SecretTypeBasicAuth {
+		return flags
+	}
+
+	for _, v := range credentials.SortAnnotations(secret.Annotations, annotationPrefix) {
+		flags = append(flags, fmt.Sprintf("-%s=%s=%s", basicAuthFlag, secret.Name, v))
+	}
+	return flags
+}
+
+func (*basicMavenConfigBuilder) Write(directory string) error {
+	return config.Write(directory)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mavencreds
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/credentials"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func writeSecret(t *testing.T, name, username, password string) {
+	t.Helper()
+	dir := credentials.VolumeName(name)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatalf("os.MkdirAll(%s) = %v", dir, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, corev1.BasicAuthUsernameKey), []byte(username), 0777); err != nil {
+		t.Fatalf("ioutil.WriteFile(username) = %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, corev1.BasicAuthPasswordKey), []byte(password), 0777); err != nil {
+		t.Fatalf("ioutil.WriteFile(password) = %v", err)
+	}
+}
+
+func TestBasicFlagHandling(t *testing.T) {
+	credentials.VolumePath, _ = ioutil.TempDir("", "")
+	writeSecret(t, "foo", "bar", "baz")
+	writeSecret(t, "qux", "deployer", "<p&ss>")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	AddFlags(fs)
+	err := fs.Parse([]string{
+		"-basic-maven=foo=central",
+		"-basic-maven=qux=releases",
+	})
+	if err != nil {
+		t.Fatalf("flag.CommandLine.Parse() = %v", err)
+	}
+
+	if err := NewBuilder().Write(credentials.VolumePath); err != nil {
+		t.Fatalf("Write() = %v", err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(credentials.VolumePath, ".m2", "settings.xml"))
+	if err != nil {
+		t.Fatalf("ioutil.ReadFile(.m2/settings.xml) = %v", err)
+	}
+
+	expectedSettings := `<?xml version="1.0" encoding="UTF-8"?>
+<settings>
+  <servers>
+    <server>
+      <id>central</id>
+      <username>bar</username>
+      <password>baz</password>
+    </server>
+    <server>
+      <id>releases</id>
+      <username>deployer</username>
+      <password>&lt;p&amp;ss&gt;</password>
+    </server>
+  </servers>
+</settings>
+`
+	if d := cmp.Diff(expectedSettings, string(b)); d != "" {
+		t.Errorf("settings.xml diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestBasicFlagHandlingMissingFiles(t *testing.T) {
+	credentials.VolumePath, _ = ioutil.TempDir("", "")
+	dir := credentials.VolumeName("not-found")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatalf("os.MkdirAll(%s) = %v", dir, err)
+	}
+	// No username / password files yields an error.
+
+	cfg := basicMavenConfig{entries: make(map[string]server)}
+	if err := cfg.Set("not-found=central"); err == nil {
+		t.Error("Set(); got success, wanted error.")
+	}
+}
+
+func TestBasicFlagHandlingServerCollision(t *testing.T) {
+	credentials.VolumePath, _ = ioutil.TempDir("", "")
+	writeSecret(t, "foo", "bar", "baz")
+
+	cfg := basicMavenConfig{entries: make(map[string]server)}
+	if err := cfg.Set("foo=central"); err != nil {
+		t.Fatalf("First Set() = %v", err)
+	}
+	if err := cfg.Set("bar=central"); err == nil {
+		t.Error("Second Set(); got success, wanted error.")
+	}
+}
+
+func TestBasicMalformedValues(t *testing.T) {
+	tests := []string{
+		"bar=baz=blah",
+		"bar",
+	}
+	for _, test := range tests {
+		cfg := basicMavenConfig{}
+		if err := cfg.Set(test); err == nil {
+			t.Errorf("Set(%v); got success, wanted error.", test)
+		}
+	}
+}
+
+func TestMatchingAnnotations(t *testing.T) {
+	tests := []struct {
+		secret   *corev1.Secret
+		wantFlag []string
+	}{{
+		secret: &corev1.Secret{
+			Type: corev1.SecretTypeBasicAuth,
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "maven",
+				Annotations: map[string]string{
+					fmt.Sprintf("%s0", annotationPrefix): "releases",
+					fmt.Sprintf("%s1", annotationPrefix): "central",
+				},
+			},
+		},
+		wantFlag: []string{fmt.Sprintf("-%s=maven=central", basicAuthFlag), fmt.Sprintf("-%s=maven=releases", basicAuthFlag)},
+	}, {
+		secret: &corev1.Secret{
+			Type: corev1.SecretTypeOpaque,
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "opaque",
+				Annotations: map[string]string{
+					fmt.Sprintf("%s0", annotationPrefix): "central",
+				},
+			},
+		},
+		wantFlag: nil,
+	}}
+
+	nb := NewBuilder()
+	for _, ts := range tests {
+		gotFlag := nb.MatchingAnnotations(ts.secret)
+		if !cmp.Equal(ts.wantFlag, gotFlag) {
+			t.Errorf("MatchingAnnotations() Mismatch of flags; wanted: %v got: %v", ts.wantFlag, gotFlag)
+		}
+	}
+}
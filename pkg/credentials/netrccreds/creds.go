@@ -0,0 +1,180 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netrccreds
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/credentials"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	annotationPrefix = "tekton.dev/netrc-"
+	basicAuthFlag    = "basic-netrc"
+)
+
+var config basicNetrcConfig
+
+func init() {
+	credentials.Register("netrc", credentials.Registration{
+		Builder:         NewBuilder(),
+		AddFlags:        AddFlags,
+		CredentialPaths: func() []string { return []string{".netrc"} },
+	})
+}
+
+// AddFlags adds CLI flags that netrccreds supports to a given flag.FlagSet.
+func AddFlags(flagSet *flag.FlagSet) {
+	flags(flagSet)
+}
+
+func flags(fs *flag.FlagSet) {
+	config = basicNetrcConfig{entries: make(map[string]basicEntry)}
+	fs.Var(&config, basicAuthFlag, "List of secret=host pairs.")
+}
+
+// As the flag is read, this status is populated.
+// basicNetrcConfig implements flag.Value
+type basicNetrcConfig struct {
+	entries map[string]basicEntry
+	// The order we see things, for iterating over the above.
+	order []string
+}
+
+func (nc *basicNetrcConfig) String() string {
+	if nc == nil {
+		// According to flag.Value this can happen.
+		return ""
+	}
+	var hosts []string
+	for _, k := range nc.order {
+		hosts = append(hosts, fmt.Sprintf("%s=%s", nc.entries[k].secret, k))
+	}
+	return strings.Join(hosts, ",")
+}
+
+func (nc *basicNetrcConfig) Set(value string) error {
+	parts := strings.Split(value, "=")
+	if len(parts) != 2 {
+		return fmt.Errorf("expect entries of the form secret=host, got: %v", value)
+	}
+	secret := parts[0]
+	host := machine(parts[1])
+	if host == "" || strings.ContainsAny(host, " \t\n") {
+		return fmt.Errorf("expect a host or a url, got: %v", parts[1])
+	}
+
+	if _, ok := nc.entries[host]; ok {
+		return fmt.Errorf("multiple entries for host: %v", host)
+	}
+
+	e, err := newBasicEntry(secret)
+	if err != nil {
+		return err
+	}
+	nc.entries[host] = *e
+	nc.order = append(nc.order, host)
+	return nil
+}
+
+// machine returns the name of the machine matched by .netrc for the given
+// host, which may also be given as a url.
+func machine(host string) string {
+	if u, err := url.Parse(host); err == nil && u.Hostname() != "" {
+		return u.Hostname()
+	}
+	return host
+}
+
+// Write builds a .netrc file from nc.entries and writes it to disk in the
+// directory provided. If nc.entries is empty then nothing is written.
+func (nc *basicNetrcConfig) Write(directory string) error {
+	if len(nc.entries) == 0 {
+		return nil
+	}
+	var lines []string
+	for _, k := range nc.order {
+		e := nc.entries[k]
+		lines = append(lines, fmt.Sprintf("machine %s login %s password %s", k, e.username, e.password))
+	}
+	lines = append(lines, "") // Get a trailing newline
+	return ioutil.WriteFile(filepath.Join(directory, ".netrc"), []byte(strings.Join(lines, "\n")), 0600)
+}
+
+type basicEntry struct {
+	secret   string
+	username string
+	password string
+}
+
+func newBasicEntry(secret string) (*basicEntry, error) {
+	secretPath := credentials.VolumeName(secret)
+
+	ub, err := ioutil.ReadFile(filepath.Join(secretPath, corev1.BasicAuthUsernameKey))
+	if err != nil {
+		return nil, err
+	}
+	username := string(ub)
+
+	pb, err := ioutil.ReadFile(filepath.Join(secretPath, corev1.BasicAuthPasswordKey))
+	if err != nil {
+		return nil, err
+	}
+	password := string(pb)
+
+	// The .netrc format separates its tokens by whitespace, which the
+	// credentials can therefore not contain.
+	if strings.ContainsAny(username+password, " \t\n") {
+		return nil, fmt.Errorf("the credentials of secret %q contain whitespace, which .netrc does not support", secret)
+	}
+
+	return &basicEntry{
+		secret:   secret,
+		username: username,
+		password: password,
+	}, nil
+}
+
+type basicNetrcConfigBuilder struct{}
+
+// NewBuilder returns a new builder for .netrc credentials.
+func NewBuilder() credentials.Builder { return &basicNetrcConfigBuilder{} }
+
+// MatchingAnnotations extracts flags for the credential helper
+// from the supplied secret and returns a slice (of length 0 or
+// greater) of applicable domains.
+func (*basicNetrcConfigBuilder) MatchingAnnotations(secret *corev1.Secret) []string {
+	var flags []string
+	if secret.Type != corev1.SecretTypeBasicAuth {
+		return flags
+	}
+
+	for _, v := range credentials.SortAnnotations(secret.Annotations, annotationPrefix) {
+		flags = append(flags, fmt.Sprintf("-%s=%s=%s", basicAuthFlag, secret.Name, v))
+	}
+	return flags
+}
+
+func (*basicNetrcConfigBuilder) Write(directory string) error {
+	return config.Write(directory)
+}
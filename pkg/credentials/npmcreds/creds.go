@@ -0,0 +1,200 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package npmcreds
+
+import (
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/credentials"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	annotationPrefix = "tekton.dev/npm-"
+	npmFlag          = "npm"
+	// tokenKey is the key of the Secrets holding an npm access token instead
+	// of a username and a password.
+	tokenKey = "token"
+)
+
+var config npmConfig
+
+func init() {
+	credentials.Register("npm", credentials.Registration{
+		Builder:         NewBuilder(),
+		AddFlags:        AddFlags,
+		CredentialPaths: func() []string { return []string{".npmrc"} },
+	})
+}
+
+// AddFlags adds CLI flags that npmcreds supports to a given flag.FlagSet.
+func AddFlags(flagSet *flag.FlagSet) {
+	flags(flagSet)
+}
+
+func flags(fs *flag.FlagSet) {
+	config = npmConfig{entries: make(map[string]entry)}
+	fs.Var(&config, npmFlag, "List of secret=url pairs.")
+}
+
+// As the flag is read, this status is populated.
+// npmConfig implements flag.Value
+type npmConfig struct {
+	entries map[string]entry
+	// The order we see things, for iterating over the above.
+	order []string
+}
+
+func (nc *npmConfig) String() string {
+	if nc == nil {
+		// According to flag.Value this can happen.
+		return ""
+	}
+	var urls []string
+	for _, k := range nc.order {
+		urls = append(urls, fmt.Sprintf("%s=%s", nc.entries[k].secret, k))
+	}
+	return strings.Join(urls, ",")
+}
+
+func (nc *npmConfig) Set(value string) error {
+	parts := strings.Split(value, "=")
+	if len(parts) != 2 {
+		return fmt.Errorf("expect entries of the form secret=url, got: %v", value)
+	}
+	secret := parts[0]
+	url := parts[1]
+
+	if _, ok := nc.entries[url]; ok {
+		return fmt.Errorf("multiple entries for url: %v", url)
+	}
+
+	e, err := newEntry(url, secret)
+	if err != nil {
+		return err
+	}
+	nc.entries[url] = *e
+	nc.order = append(nc.order, url)
+	return nil
+}
+
+// Write builds a .npmrc file from nc.entries and writes it to disk in the
+// directory provided. If nc.entries is empty then nothing is written.
+func (nc *npmConfig) Write(directory string) error {
+	if len(nc.entries) == 0 {
+		return nil
+	}
+	var lines []string
+	for _, k := range nc.order {
+		lines = append(lines, nc.entries[k].configLines()...)
+	}
+	lines = append(lines, "") // Get a trailing newline
+	return ioutil.WriteFile(filepath.Join(directory, ".npmrc"), []byte(strings.Join(lines, "\n")), 0600)
+}
+
+type entry struct {
+	secret string
+	// registry is the prefix with which npm scopes the settings of the
+	// registry, of the form //registry.example.com/path/.
+	registry string
+	token    string
+	username string
+	password string
+}
+
+// configLines returns the lines of the .npmrc file authenticating to the
+// registry of the entry.
+func (e entry) configLines() []string {
+	if e.token != "" {
+		return []string{fmt.Sprintf("%s:_authToken=%s", e.registry, e.token)}
+	}
+	return []string{
+		fmt.Sprintf("%s:username=%s", e.registry, e.username),
+		fmt.Sprintf("%s:_password=%s", e.registry, base64.StdEncoding.EncodeToString([]byte(e.password))),
+	}
+}
+
+func newEntry(u, secret string) (*entry, error) {
+	pu, err := url.Parse(u)
+	if err != nil {
+		return nil, err
+	}
+	if pu.Host == "" {
+		return nil, fmt.Errorf("expect an absolute registry url, got: %v", u)
+	}
+	e := &entry{
+		secret:   secret,
+		registry: "//" + pu.Host + strings.TrimSuffix(pu.Path, "/") + "/",
+	}
+
+	secretPath := credentials.VolumeName(secret)
+	tb, err := ioutil.ReadFile(filepath.Join(secretPath, tokenKey))
+	if err == nil {
+		// Secrets created from a file usually end with a newline, which
+		// would break the line of the .npmrc file holding the token.
+		e.token = strings.TrimSpace(string(tb))
+		return e, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	ub, err := ioutil.ReadFile(filepath.Join(secretPath, corev1.BasicAuthUsernameKey))
+	if err != nil {
+		return nil, err
+	}
+	e.username = string(ub)
+
+	pb, err := ioutil.ReadFile(filepath.Join(secretPath, corev1.BasicAuthPasswordKey))
+	if err != nil {
+		return nil, err
+	}
+	e.password = string(pb)
+	return e, nil
+}
+
+type npmConfigBuilder struct{}
+
+// NewBuilder returns a new builder for npm credentials.
+func NewBuilder() credentials.Builder { return &npmConfigBuilder{} }
+
+// MatchingAnnotations extracts flags for the credential helper
+// from the supplied secret and returns a slice (of length 0 or
+// greater) of applicable domains.
+func (*npmConfigBuilder) MatchingAnnotations(secret *corev1.Secret) []string {
+	var flags []string
+	switch secret.Type {
+	case corev1.SecretTypeBasicAuth, corev1.SecretTypeOpaque, "":
+	default:
+		return flags
+	}
+
+	for _, v := range credentials.SortAnnotations(secret.Annotations, annotationPrefix) {
+		flags = append(flags, fmt.Sprintf("-%s=%s=%s", npmFlag, secret.Name, v))
+	}
+	return flags
+}
+
+func (*npmConfigBuilder) Write(directory string) error {
+	return config.Write(directory)
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package npmcreds
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/credentials"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func writeSecret(t *testing.T, name string, data map[string]string) {
+	t.Helper()
+	dir := credentials.VolumeName(name)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatalf("os.MkdirAll(%s) = %v", dir, err)
+	}
+	for k, v := range data {
+		if err := ioutil.WriteFile(filepath.Join(dir, k), []byte(v), 0777); err != nil {
+			t.Fatalf("ioutil.WriteFile(%s) = %v", k, err)
+		}
+	}
+}
+
+func TestFlagHandling(t *testing.T) {
+	credentials.VolumePath, _ = ioutil.TempDir("", "")
+	writeSecret(t, "basic", map[string]string{
+		corev1.BasicAuthUsernameKey: "bar",
+		corev1.BasicAuthPasswordKey: "baz",
+	})
+	writeSecret(t, "token", map[string]string{
+		tokenKey: "s3cr3t",
+	})
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	AddFlags(fs)
+	err := fs.Parse([]string{
+		"-npm=token=https://registry.npmjs.org",
+		"-npm=basic=https://npm.example.com/repository/npm/",
+	})
+	if err != nil {
+		t.Fatalf("flag.CommandLine.Parse() = %v", err)
+	}
+
+	if err := NewBuilder().Write(credentials.VolumePath); err != nil {
+		t.Fatalf("Write() = %v", err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(credentials.VolumePath, ".npmrc"))
+	if err != nil {
+		t.Fatalf("ioutil.ReadFile(.npmrc) = %v", err)
+	}
+
+	expectedNpmrc := `//registry.npmjs.org/:_authToken=s3cr3t
+//npm.example.com/repository/npm/:username=bar
+//npm.example.com/repository/npm/:_password=YmF6
+`
+	if d := cmp.Diff(expectedNpmrc, string(b)); d != "" {
+		t.Errorf(".npmrc diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestFlagHandlingTokenWithTrailingNewline(t *testing.T) {
+	credentials.VolumePath, _ = ioutil.TempDir("", "")
+	// Secrets created with kubectl create secret --from-file usually end
+	// with a newline.
+	writeSecret(t, "token", map[string]string{
+		tokenKey: "s3cr3t\n",
+	})
+
+	cfg := npmConfig{entries: make(map[string]entry)}
+	if err := cfg.Set("token=https://registry.npmjs.org"); err != nil {
+		t.Fatalf("Set() = %v", err)
+	}
+	if err := cfg.Write(credentials.VolumePath); err != nil {
+		t.Fatalf("Write() = %v", err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(credentials.VolumePath, ".npmrc"))
+	if err != nil {
+		t.Fatalf("ioutil.ReadFile(.npmrc) = %v", err)
+	}
+	expectedNpmrc := "//registry.npmjs.org/:_authToken=s3cr3t\n"
+	if d := cmp.Diff(expectedNpmrc, string(b)); d != "" {
+		t.Errorf(".npmrc diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestFlagHandlingMissingFiles(t *testing.T) {
+	credentials.VolumePath, _ = ioutil.TempDir("", "")
+	writeSecret(t, "not-found", nil)
+	// No token nor username / password files yields an error.
+
+	cfg := npmConfig{entries: make(map[string]entry)}
+	if err := cfg.Set("not-found=https://registry.npmjs.org"); err == nil {
+		t.Error("Set(); got success, wanted error.")
+	}
+}
+
+func TestFlagHandlingURLCollision(t *testing.T) {
+	credentials.VolumePath, _ = ioutil.TempDir("", "")
+	writeSecret(t, "foo", map[string]string{tokenKey: "s3cr3t"})
+
+	cfg := npmConfig{entries: make(map[string]entry)}
+	if err := cfg.Set("foo=https://registry.npmjs.org"); err != nil {
+		t.Fatalf("First Set() = %v", err)
+	}
+	if err := cfg.Set("bar=https://registry.npmjs.org"); err == nil {
+		t.Error("Second Set(); got success, wanted error.")
+	}
+}
+
+func TestMalformedValues(t *testing.T) {
+	credentials.VolumePath, _ = ioutil.TempDir("", "")
+	writeSecret(t, "bar", map[string]string{tokenKey: "s3cr3t"})
+	tests := []string{
+		"bar=baz=blah",
+		"bar",
+		"bar=registry.npmjs.org",
+	}
+	for _, test := range tests {
+		cfg := npmConfig{entries: make(map[string]entry)}
+		if err := cfg.Set(test); err == nil {
+			t.Errorf("Set(%v); got success, wanted error.", test)
+		}
+	}
+}
+
+func TestMatchingAnnotations(t *testing.T) {
+	tests := []struct {
+		secret   *corev1.Secret
+		wantFlag []string
+	}{{
+		secret: &corev1.Secret{
+			Type: corev1.SecretTypeBasicAuth,
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "basic",
+				Annotations: map[string]string{
+					fmt.Sprintf("%s0", annotationPrefix): "https://npm.example.com",
+				},
+			},
+		},
+		wantFlag: []string{fmt.Sprintf("-%s=basic=https://npm.example.com", npmFlag)},
+	}, {
+		secret: &corev1.Secret{
+			Type: corev1.SecretTypeOpaque,
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "token",
+				Annotations: map[string]string{
+					fmt.Sprintf("%s0", annotationPrefix): "https://registry.npmjs.org",
+					fmt.Sprintf("%s1", annotationPrefix): "https://npm.example.com",
+				},
+			},
+		},
+		wantFlag: []string{fmt.Sprintf("-%s=token=https://npm.example.com", npmFlag), fmt.Sprintf("-%s=token=https://registry.npmjs.org", npmFlag)},
+	}, {
+		secret: &corev1.Secret{
+			Type: corev1.SecretTypeSSHAuth,
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ssh",
+				Annotations: map[string]string{
+					fmt.Sprintf("%s0", annotationPrefix): "https://registry.npmjs.org",
+				},
+			},
+		},
+		wantFlag: nil,
+	}}
+
+	nb := NewBuilder()
+	for _, ts := range tests {
+		gotFlag := nb.MatchingAnnotations(ts.secret)
+		if !cmp.Equal(ts.wantFlag, gotFlag) {
+			t.Errorf("MatchingAnnotations() Mismatch of flags; wanted: %v got: %v", ts.wantFlag, gotFlag)
+		}
+	}
+}
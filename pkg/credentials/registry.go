@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Registration describes how a credential Builder is used by the controller
+// and by the entrypoint of the Steps.
+type Registration struct {
+	// Builder selects the annotated Secrets in the controller, and writes the
+	// credentials in the Steps.
+	Builder Builder
+	// AddFlags adds the entrypoint flags returned by the MatchingAnnotations of
+	// the Builder to a FlagSet.
+	AddFlags func(*flag.FlagSet)
+	// CredentialPaths returns the paths, relative to the directory given to the
+	// Write of the Builder, of the credentials it writes. These paths are
+	// copied into the HOME directory of the Steps.
+	CredentialPaths func() []string
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Registration{}
+)
+
+// Register makes a credential Builder available under the given name. It is
+// meant to be called from the init function of the package implementing the
+// Builder, and panics if the name is already registered.
+func Register(name string, r Registration) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("credentials: Register called twice for builder %q", name))
+	}
+	registry[name] = r
+}
+
+// registrations returns the registered Builders sorted by name, so that the
+// flags they produce are in a stable order.
+func registrations() []Registration {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	var names []string
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var rs []Registration
+	for _, name := range names {
+		rs = append(rs, registry[name])
+	}
+	return rs
+}
+
+// Builders returns the registered credential Builders.
+func Builders() []Builder {
+	var builders []Builder
+	for _, r := range registrations() {
+		builders = append(builders, r.Builder)
+	}
+	return builders
+}
+
+// AddFlags adds the flags of all the registered credential Builders to the
+// given FlagSet.
+func AddFlags(flagSet *flag.FlagSet) {
+	for _, r := range registrations() {
+		if r.AddFlags != nil {
+			r.AddFlags(flagSet)
+		}
+	}
+}
+
+// CredentialPaths returns the paths of the credentials written by all the
+// registered credential Builders, to be given to CopyCredsToHome.
+func CredentialPaths() []string {
+	var paths []string
+	for _, r := range registrations() {
+		if r.CredentialPaths != nil {
+			paths = append(paths, r.CredentialPaths()...)
+		}
+	}
+	return paths
+}
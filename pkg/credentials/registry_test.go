@@ -0,0 +1,79 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type fakeBuilder struct {
+	name string
+}
+
+func (b *fakeBuilder) MatchingAnnotations(*corev1.Secret) []string { return []string{"-" + b.name} }
+
+func (b *fakeBuilder) Write(string) error { return nil }
+
+func TestRegistry(t *testing.T) {
+	registry = map[string]Registration{}
+	var flagged []string
+	for _, name := range []string{"second", "first"} {
+		name := name
+		Register(name, Registration{
+			Builder: &fakeBuilder{name: name},
+			AddFlags: func(fs *flag.FlagSet) {
+				flagged = append(flagged, name)
+				fs.Bool(name, false, "")
+			},
+			CredentialPaths: func() []string { return []string{"." + name} },
+		})
+	}
+	// A Builder may write no credentials to copy into HOME.
+	Register("third", Registration{Builder: &fakeBuilder{name: "third"}})
+
+	var got []string
+	for _, b := range Builders() {
+		got = append(got, b.MatchingAnnotations(nil)...)
+	}
+	if d := cmp.Diff([]string{"-first", "-second", "-third"}, got); d != "" {
+		t.Errorf("Builders() diff -want, +got: %s", d)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	AddFlags(fs)
+	if d := cmp.Diff([]string{"first", "second"}, flagged); d != "" {
+		t.Errorf("AddFlags() diff -want, +got: %s", d)
+	}
+	if err := fs.Parse([]string{"-first", "-second"}); err != nil {
+		t.Errorf("Parse() = %v", err)
+	}
+
+	if d := cmp.Diff([]string{".first", ".second"}, CredentialPaths()); d != "" {
+		t.Errorf("CredentialPaths() diff -want, +got: %s", d)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Register() of a duplicate name did not panic")
+		}
+	}()
+	Register("first", Registration{Builder: &fakeBuilder{name: "first"}})
+}
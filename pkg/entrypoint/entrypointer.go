@@ -18,10 +18,15 @@ package entrypoint
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -62,12 +67,36 @@ type Entrypointer struct {
 	// PostWriter encapsulates writing files when complete.
 	PostWriter PostWriter
 
-	// Results is the set of files that might contain task results
+	// Results is the set of files that might contain task results. The
+	// results which are not strings are followed by their type, e.g.
+	// "files:array", and their files must contain JSON.
 	Results []string
 	// Timeout is an optional user-specified duration within which the Step must complete
 	Timeout *time.Duration
+	// OnError defines exiting behavior of the entrypoint
+	// set it to "stopAndFail" to indicate the entrypoint to exit the taskRun if the container exits with non zero exit code
+	// set it to "continue" to indicate the entrypoint to continue executing the rest of the steps irrespective of the container exit code
+	OnError string
+	// BreakpointOnFailure pauses the step after it fails, until it is resumed
+	// with the debug scripts or its timeout is reached
+	BreakpointOnFailure bool
+	// DebugInfoDir is the directory in which the step signals that it is paused
+	// at a breakpoint, and in which the debug scripts signal how to resume it
+	DebugInfoDir string
+	// StepResults is the set of files in StepResultsDir that might contain
+	// results of the step
+	StepResults []string
+	// StepResultsDir is the directory in which the step writes its results
+	StepResultsDir string
+	// ReportResourceUsage is set to report the resource usage of the step in
+	// the termination message, if the Runner measures it
+	ReportResourceUsage bool
 }
 
+// breakpointPollingInterval is how often a step paused at a breakpoint checks
+// whether it was resumed.
+var breakpointPollingInterval = time.Second
+
 // Waiter encapsulates waiting for files to exist.
 type Waiter interface {
 	// Wait blocks until the specified file exists.
@@ -79,6 +108,14 @@ type Runner interface {
 	Run(ctx context.Context, args ...string) error
 }
 
+// ResourceUsageReporter is implemented by the Runners which measure the resource
+// usage of the command they ran.
+type ResourceUsageReporter interface {
+	// ResourceUsage returns the resource usage of the last command run, as
+	// internal results, or nothing if it was not measured.
+	ResourceUsage() []v1beta1.PipelineResourceResult
+}
+
 // PostWriter encapsulates writing a file when complete.
 type PostWriter interface {
 	// Write writes to the path when complete.
@@ -96,6 +133,7 @@ func (e Entrypointer) Go() error {
 		if wErr := termination.WriteMessage(e.TerminationPath, output); wErr != nil {
 			logger.Fatalf("Error while writing message: %s", wErr)
 		}
+		e.writeResourceUsage(logger)
 		_ = logger.Sync()
 	}()
 
@@ -124,18 +162,55 @@ func (e Entrypointer) Go() error {
 	})
 
 	var err error
-	if e.Timeout != nil && *e.Timeout < time.Duration(0) {
+	if len(e.StepResults) >= 1 && e.StepResults[0] != "" {
+		// The step writes its results directly in its results directory,
+		// which is created beforehand.
+		if mkErr := os.MkdirAll(e.StepResultsDir, 0755); mkErr != nil {
+			err = fmt.Errorf("failed to create the results directory of the step: %w", mkErr)
+		}
+	}
+	if err == nil && e.Timeout != nil && *e.Timeout < time.Duration(0) {
 		err = fmt.Errorf("negative timeout specified")
 	}
 
+	ctx := context.Background()
 	if err == nil {
-		ctx := context.Background()
 		var cancel context.CancelFunc
 		if e.Timeout != nil && *e.Timeout != time.Duration(0) {
 			ctx, cancel = context.WithTimeout(ctx, *e.Timeout)
 			defer cancel()
 		}
 		err = e.Runner.Run(ctx, e.Args...)
+		if err != nil && ctx.Err() == context.DeadlineExceeded {
+			// The step was killed by its timeout, which the Runner may report
+			// as the exit error of the killed process. It is never continued.
+			err = context.DeadlineExceeded
+		}
+		if err == context.DeadlineExceeded {
+			output = append(output, v1beta1.PipelineResourceResult{
+				Key:        "Reason",
+				Value:      "TimeoutExceeded",
+				ResultType: v1beta1.InternalTektonResultType,
+			})
+		}
+	}
+
+	var ee *exec.ExitError
+	if err != nil && e.OnError == v1beta1.ContinueOnError && errors.As(err, &ee) {
+		// Record the exit code instead of failing the step, so that the
+		// following steps are not skipped.
+		output = append(output, v1beta1.PipelineResourceResult{
+			Key:        "ExitCode",
+			Value:      strconv.Itoa(ee.ExitCode()),
+			ResultType: v1beta1.InternalTektonResultType,
+		})
+		logger.Infof("Step exited with code %d, continuing because onError is set to %q", ee.ExitCode(), v1beta1.ContinueOnError)
+		err = nil
+	}
+
+	// A step which timed out is not paused, since its timeout must still apply.
+	if err != nil && e.BreakpointOnFailure && ctx.Err() == nil {
+		err = e.waitAtBreakpoint(ctx, logger, err)
 		if err == context.DeadlineExceeded {
 			output = append(output, v1beta1.PipelineResourceResult{
 				Key:        "Reason",
@@ -151,31 +226,104 @@ func (e Entrypointer) Go() error {
 	// strings.Split(..) with an empty string returns an array that contains one element, an empty string.
 	// This creates an error when trying to open the result folder as a file.
 	if len(e.Results) >= 1 && e.Results[0] != "" {
-		if err := e.readResultsFromDisk(); err != nil {
+		if err := e.readResultsFromDisk(pipeline.DefaultResultPath, e.Results, v1beta1.TaskRunResultType); err != nil {
 			logger.Fatalf("Error while handling results: %s", err)
 		}
 	}
+	if len(e.StepResults) >= 1 && e.StepResults[0] != "" {
+		if err := e.readResultsFromDisk(e.StepResultsDir, e.StepResults, v1beta1.StepResultType); err != nil {
+			logger.Fatalf("Error while handling step results: %s", err)
+		}
+	}
 
 	return err
 }
 
-func (e Entrypointer) readResultsFromDisk() error {
+// writeResourceUsage reports the resource usage of the step in the termination
+// message, once everything else was written to it. The usage is dropped if the
+// termination message has no room left for it, rather than failing the step.
+func (e Entrypointer) writeResourceUsage(logger *zap.SugaredLogger) {
+	if !e.ReportResourceUsage {
+		return
+	}
+	r, ok := e.Runner.(ResourceUsageReporter)
+	if !ok {
+		return
+	}
+	usage := r.ResourceUsage()
+	if len(usage) == 0 {
+		return
+	}
+	if err := termination.WriteMessage(e.TerminationPath, usage); err != nil {
+		var lengthErr termination.MessageLengthError
+		if errors.As(err, &lengthErr) {
+			logger.Warn("Not reporting the resource usage of the step: the termination message has no room left for it")
+			return
+		}
+		logger.Errorf("Error reporting the resource usage of the step: %s", err)
+	}
+}
+
+// waitAtBreakpoint pauses the step after it failed with err, until it is resumed
+// with the debug scripts or ctx is done. It returns nil if the step was resumed as
+// successful, err if it was resumed as failed, and the error of ctx if it is done.
+func (e Entrypointer) waitAtBreakpoint(ctx context.Context, logger *zap.SugaredLogger, err error) error {
+	base := filepath.Join(e.DebugInfoDir, filepath.Base(e.PostFile))
+	breakpoint := base + ".breakpoint"
+	if wErr := ioutil.WriteFile(breakpoint, []byte(err.Error()), 0644); wErr != nil {
+		logger.Errorf("Error pausing the step at a breakpoint: %s", wErr)
+		return err
+	}
+	defer os.Remove(breakpoint)
+	logger.Infof("Step failed with %q, pausing at a breakpoint: run debug-continue to resume it as successful, or debug-fail-continue to resume it as failed", err)
+
+	ticker := time.NewTicker(breakpointPollingInterval)
+	defer ticker.Stop()
+	for {
+		if _, sErr := os.Stat(base + ".continue"); sErr == nil {
+			logger.Info("Resuming the step as successful")
+			return nil
+		}
+		if _, sErr := os.Stat(base + ".fail-continue"); sErr == nil {
+			logger.Info("Resuming the step as failed")
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			logger.Info("Resuming the step because its timeout was reached")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// readResultsFromDisk writes the contents of the given result files of dir to
+// the termination message, as results of the given type.
+func (e Entrypointer) readResultsFromDisk(dir string, results []string, resultType v1beta1.ResultType) error {
 	output := []v1beta1.PipelineResourceResult{}
-	for _, resultFile := range e.Results {
-		if resultFile == "" {
+	for _, result := range results {
+		if result == "" {
 			continue
 		}
-		fileContents, err := ioutil.ReadFile(filepath.Join(pipeline.DefaultResultPath, resultFile))
+		resultFile, valueType := result, v1beta1.ResultsTypeString
+		if i := strings.LastIndex(result, ":"); i >= 0 {
+			resultFile, valueType = result[:i], v1beta1.ResultsType(result[i+1:])
+		}
+		fileContents, err := ioutil.ReadFile(filepath.Join(dir, resultFile))
 		if os.IsNotExist(err) {
 			continue
 		} else if err != nil {
 			return err
 		}
+		value, err := parseResultValue(fileContents, valueType)
+		if err != nil {
+			return fmt.Errorf("invalid value of result %q: %w", resultFile, err)
+		}
 		// if the file doesn't exist, ignore it
 		output = append(output, v1beta1.PipelineResourceResult{
 			Key:        resultFile,
-			Value:      string(fileContents),
-			ResultType: v1beta1.TaskRunResultType,
+			Value:      value,
+			ResultType: resultType,
 		})
 	}
 	// push output to termination path
@@ -187,6 +335,29 @@ func (e Entrypointer) readResultsFromDisk() error {
 	return nil
 }
 
+// parseResultValue returns the value of a result of the given type, as it is
+// written to the termination message. The values of array and object results
+// must be JSON, which is compacted to save space in the termination message.
+func parseResultValue(contents []byte, valueType v1beta1.ResultsType) (string, error) {
+	var value interface{}
+	switch valueType {
+	case v1beta1.ResultsTypeArray:
+		value = &[]string{}
+	case v1beta1.ResultsTypeObject:
+		value = &map[string]string{}
+	default:
+		return string(contents), nil
+	}
+	if err := json.Unmarshal(contents, value); err != nil {
+		return "", fmt.Errorf("expected a JSON %s of strings: %w", valueType, err)
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
 // WritePostFile write the postfile
 func (e Entrypointer) WritePostFile(postFile string, err error) {
 	if err != nil && postFile != "" {
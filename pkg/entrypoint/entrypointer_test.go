@@ -22,12 +22,16 @@ import (
 	"errors"
 	"io/ioutil"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 )
 
@@ -225,6 +229,399 @@ func TestEntrypointer(t *testing.T) {
 	}
 }
 
+func TestEntrypointer_OnError(t *testing.T) {
+	timeout := 100 * time.Millisecond
+	for _, c := range []struct {
+		desc, onError, wantPostFile string
+		script                      string
+		timeout                     *time.Duration
+		wantErr                     bool
+		wantExitCode                string
+		wantReason                  string
+	}{{
+		desc:         "continue records the exit code",
+		onError:      v1beta1.ContinueOnError,
+		wantPostFile: "writeme",
+		wantExitCode: "3",
+	}, {
+		desc:         "continue doesn't apply to a step killed by its timeout",
+		onError:      v1beta1.ContinueOnError,
+		script:       "sleep 10",
+		timeout:      &timeout,
+		wantPostFile: "writeme.err",
+		wantErr:      true,
+		wantReason:   "TimeoutExceeded",
+	}, {
+		desc:         "stopAndFail writes the error post file",
+		onError:      v1beta1.StopAndFail,
+		wantPostFile: "writeme.err",
+		wantErr:      true,
+	}, {
+		desc:         "default writes the error post file",
+		wantPostFile: "writeme.err",
+		wantErr:      true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			fpw := &fakePostWriter{}
+			terminationPath := "termination"
+			if terminationFile, err := ioutil.TempFile("", "termination"); err != nil {
+				t.Fatalf("unexpected error creating temporary termination file: %v", err)
+			} else {
+				terminationPath = terminationFile.Name()
+				defer os.Remove(terminationFile.Name())
+			}
+			script := "exit 3"
+			if c.script != "" {
+				script = c.script
+			}
+			err := Entrypointer{
+				Entrypoint:      "sh",
+				Args:            []string{"-c", script},
+				PostFile:        "writeme",
+				Waiter:          &fakeWaiter{},
+				Runner:          &fakeExitErrorRunner{},
+				PostWriter:      fpw,
+				TerminationPath: terminationPath,
+				OnError:         c.onError,
+				Timeout:         c.timeout,
+			}.Go()
+			if c.wantErr && err == nil {
+				t.Fatalf("Entrypointer didn't fail")
+			}
+			if !c.wantErr && err != nil {
+				t.Fatalf("Entrypointer failed: %v", err)
+			}
+			if fpw.wrote == nil || *fpw.wrote != c.wantPostFile {
+				t.Errorf("Wrote post file %v, want %q", fpw.wrote, c.wantPostFile)
+			}
+
+			fileContents, err := ioutil.ReadFile(terminationPath)
+			if err != nil {
+				t.Fatalf("unexpected error reading termination file: %v", err)
+			}
+			var entries []v1alpha1.PipelineResourceResult
+			if err := json.Unmarshal(fileContents, &entries); err != nil {
+				t.Fatalf("unexpected error unmarshalling termination message: %v", err)
+			}
+			gotExitCode, gotReason := "", ""
+			for _, result := range entries {
+				switch result.Key {
+				case "ExitCode":
+					gotExitCode = result.Value
+				case "Reason":
+					gotReason = result.Value
+				}
+			}
+			if gotExitCode != c.wantExitCode {
+				t.Errorf("Recorded exit code %q, want %q", gotExitCode, c.wantExitCode)
+			}
+			if gotReason != c.wantReason {
+				t.Errorf("Recorded reason %q, want %q", gotReason, c.wantReason)
+			}
+		})
+	}
+}
+
+func TestEntrypointer_BreakpointOnFailure(t *testing.T) {
+	defer func(interval time.Duration) { breakpointPollingInterval = interval }(breakpointPollingInterval)
+	breakpointPollingInterval = 10 * time.Millisecond
+
+	for _, c := range []struct {
+		desc, resume, wantPostFile, wantReason string
+		timeout                                time.Duration
+		wantErr                                bool
+	}{{
+		desc:         "continue resumes the step as successful",
+		resume:       "writeme.continue",
+		wantPostFile: "writeme",
+	}, {
+		desc:         "fail-continue resumes the step as failed",
+		resume:       "writeme.fail-continue",
+		wantPostFile: "writeme.err",
+		wantErr:      true,
+	}, {
+		desc:         "timeout resumes the step as failed",
+		timeout:      200 * time.Millisecond,
+		wantPostFile: "writeme.err",
+		wantReason:   "TimeoutExceeded",
+		wantErr:      true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			debugInfoDir, err := ioutil.TempDir("", "debug")
+			if err != nil {
+				t.Fatalf("unexpected error creating temporary directory: %v", err)
+			}
+			defer os.RemoveAll(debugInfoDir)
+			terminationPath := filepath.Join(debugInfoDir, "termination")
+
+			if c.resume != "" {
+				go func() {
+					for {
+						if _, err := os.Stat(filepath.Join(debugInfoDir, "writeme.breakpoint")); err == nil {
+							break
+						}
+						time.Sleep(10 * time.Millisecond)
+					}
+					ioutil.WriteFile(filepath.Join(debugInfoDir, c.resume), nil, 0644)
+				}()
+			}
+
+			fpw := &fakePostWriter{}
+			err = Entrypointer{
+				Entrypoint:          "sh",
+				Args:                []string{"-c", "exit 3"},
+				PostFile:            "writeme",
+				Waiter:              &fakeWaiter{},
+				Runner:              &fakeExitErrorRunner{},
+				PostWriter:          fpw,
+				TerminationPath:     terminationPath,
+				Timeout:             &c.timeout,
+				BreakpointOnFailure: true,
+				DebugInfoDir:        debugInfoDir,
+			}.Go()
+			if c.wantErr && err == nil {
+				t.Fatalf("Entrypointer didn't fail")
+			}
+			if !c.wantErr && err != nil {
+				t.Fatalf("Entrypointer failed: %v", err)
+			}
+			if fpw.wrote == nil || *fpw.wrote != c.wantPostFile {
+				t.Errorf("Wrote post file %v, want %q", fpw.wrote, c.wantPostFile)
+			}
+			if _, err := os.Stat(filepath.Join(debugInfoDir, "writeme.breakpoint")); !os.IsNotExist(err) {
+				t.Errorf("Expected the breakpoint file to be removed after resuming, got %v", err)
+			}
+
+			fileContents, err := ioutil.ReadFile(terminationPath)
+			if err != nil {
+				t.Fatalf("unexpected error reading termination file: %v", err)
+			}
+			var entries []v1alpha1.PipelineResourceResult
+			if err := json.Unmarshal(fileContents, &entries); err != nil {
+				t.Fatalf("unexpected error unmarshalling termination message: %v", err)
+			}
+			gotReason := ""
+			for _, result := range entries {
+				if result.Key == "Reason" {
+					gotReason = result.Value
+				}
+			}
+			if gotReason != c.wantReason {
+				t.Errorf("Recorded reason %q, want %q", gotReason, c.wantReason)
+			}
+		})
+	}
+}
+
+func TestEntrypointer_StepResults(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "steps")
+	if err != nil {
+		t.Fatalf("unexpected error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+	terminationPath := filepath.Join(tmp, "termination")
+	stepResultsDir := filepath.Join(tmp, "clone", "results")
+
+	err = Entrypointer{
+		Entrypoint:      "sh",
+		Args:            []string{"-c", "printf abc123 > " + filepath.Join(stepResultsDir, "commit")},
+		PostFile:        "writeme",
+		Waiter:          &fakeWaiter{},
+		Runner:          &fakeExitErrorRunner{},
+		PostWriter:      &fakePostWriter{},
+		TerminationPath: terminationPath,
+		StepResults:     []string{"commit", "url"},
+		StepResultsDir:  stepResultsDir,
+	}.Go()
+	if err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+
+	fileContents, err := ioutil.ReadFile(terminationPath)
+	if err != nil {
+		t.Fatalf("unexpected error reading termination file: %v", err)
+	}
+	var entries []v1alpha1.PipelineResourceResult
+	if err := json.Unmarshal(fileContents, &entries); err != nil {
+		t.Fatalf("unexpected error unmarshalling termination message: %v", err)
+	}
+	var got []v1alpha1.PipelineResourceResult
+	for _, result := range entries {
+		if result.ResultType == v1beta1.StepResultType {
+			got = append(got, result)
+		}
+	}
+	want := []v1alpha1.PipelineResourceResult{{
+		Key:        "commit",
+		Value:      "abc123",
+		ResultType: v1beta1.StepResultType,
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Step results diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestEntrypointer_ReadResultsFromDisk(t *testing.T) {
+	for _, c := range []struct {
+		desc     string
+		contents map[string]string
+		results  []string
+		want     []v1beta1.PipelineResourceResult
+		wantErr  bool
+	}{{
+		desc:     "string result",
+		contents: map[string]string{"commit": "abc123"},
+		results:  []string{"commit", "url"},
+		want: []v1beta1.PipelineResourceResult{{
+			Key:        "commit",
+			Value:      "abc123",
+			ResultType: v1beta1.TaskRunResultType,
+		}},
+	}, {
+		desc: "array and object results",
+		contents: map[string]string{
+			"files": `[ "a.txt", "b.txt" ]`,
+			"image": "{\n  \"url\": \"gcr.io/foo/bar\",\n  \"digest\": \"sha256:abc\"\n}",
+		},
+		results: []string{"files:array", "image:object"},
+		want: []v1beta1.PipelineResourceResult{{
+			Key:        "files",
+			Value:      `["a.txt","b.txt"]`,
+			ResultType: v1beta1.TaskRunResultType,
+		}, {
+			Key:        "image",
+			Value:      `{"digest":"sha256:abc","url":"gcr.io/foo/bar"}`,
+			ResultType: v1beta1.TaskRunResultType,
+		}},
+	}, {
+		desc:     "array result which is not a JSON array",
+		contents: map[string]string{"files": "a.txt"},
+		results:  []string{"files:array"},
+		wantErr:  true,
+	}, {
+		desc:     "object result with values which are not strings",
+		contents: map[string]string{"image": `{"size": 3}`},
+		results:  []string{"image:object"},
+		wantErr:  true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			tmp, err := ioutil.TempDir("", "results")
+			if err != nil {
+				t.Fatalf("unexpected error creating temporary directory: %v", err)
+			}
+			defer os.RemoveAll(tmp)
+			for name, contents := range c.contents {
+				if err := ioutil.WriteFile(filepath.Join(tmp, name), []byte(contents), 0644); err != nil {
+					t.Fatalf("unexpected error writing result %q: %v", name, err)
+				}
+			}
+			terminationPath := filepath.Join(tmp, "termination")
+			e := Entrypointer{TerminationPath: terminationPath}
+
+			err = e.readResultsFromDisk(tmp, c.results, v1beta1.TaskRunResultType)
+			if c.wantErr {
+				if err == nil {
+					t.Fatal("expected an error reading the results, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error reading the results: %v", err)
+			}
+			fileContents, err := ioutil.ReadFile(terminationPath)
+			if err != nil {
+				t.Fatalf("unexpected error reading termination file: %v", err)
+			}
+			var got []v1beta1.PipelineResourceResult
+			if err := json.Unmarshal(fileContents, &got); err != nil {
+				t.Fatalf("unexpected error unmarshalling termination message: %v", err)
+			}
+			if d := cmp.Diff(c.want, got); d != "" {
+				t.Errorf("Results diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestEntrypointer_ResourceUsage(t *testing.T) {
+	for _, c := range []struct {
+		desc                string
+		reportResourceUsage bool
+		result              string
+		wantUsage           bool
+	}{{
+		desc:   "resource usage not reported",
+		result: "abc123",
+	}, {
+		desc:                "resource usage reported after the results",
+		reportResourceUsage: true,
+		result:              "abc123",
+		wantUsage:           true,
+	}, {
+		desc:                "resource usage dropped when the results leave no room for it",
+		reportResourceUsage: true,
+		result:              strings.Repeat("a", 3950),
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			tmp, err := ioutil.TempDir("", "steps")
+			if err != nil {
+				t.Fatalf("unexpected error creating temporary directory: %v", err)
+			}
+			defer os.RemoveAll(tmp)
+			terminationPath := filepath.Join(tmp, "termination")
+			stepResultsDir := filepath.Join(tmp, "results")
+			if err := os.MkdirAll(stepResultsDir, 0755); err != nil {
+				t.Fatalf("unexpected error creating the results directory: %v", err)
+			}
+			if err := ioutil.WriteFile(filepath.Join(stepResultsDir, "commit"), []byte(c.result), 0644); err != nil {
+				t.Fatalf("unexpected error writing the result: %v", err)
+			}
+
+			err = Entrypointer{
+				Entrypoint:          "echo",
+				PostFile:            "writeme",
+				Waiter:              &fakeWaiter{},
+				Runner:              &fakeResourceUsageRunner{},
+				PostWriter:          &fakePostWriter{},
+				TerminationPath:     terminationPath,
+				StepResults:         []string{"commit"},
+				StepResultsDir:      stepResultsDir,
+				ReportResourceUsage: c.reportResourceUsage,
+			}.Go()
+			if err != nil {
+				t.Fatalf("Entrypointer failed: %v", err)
+			}
+
+			fileContents, err := ioutil.ReadFile(terminationPath)
+			if err != nil {
+				t.Fatalf("unexpected error reading termination file: %v", err)
+			}
+			var entries []v1beta1.PipelineResourceResult
+			if err := json.Unmarshal(fileContents, &entries); err != nil {
+				t.Fatalf("unexpected error unmarshalling termination message: %v", err)
+			}
+			gotResult, gotUsage := false, false
+			for _, result := range entries {
+				switch result.Key {
+				case "commit":
+					gotResult = result.Value == c.result
+				case "WallTime":
+					if !gotResult {
+						t.Error("Reported the resource usage before the results")
+					}
+					gotUsage = true
+				}
+			}
+			if !gotResult {
+				t.Error("Result was not written to the termination message")
+			}
+			if gotUsage != c.wantUsage {
+				t.Errorf("Reported resource usage %t, want %t", gotUsage, c.wantUsage)
+			}
+		})
+	}
+}
+
 type fakeWaiter struct{ waited []string }
 
 func (f *fakeWaiter) Wait(file string, _ bool) error {
@@ -239,6 +636,18 @@ func (f *fakeRunner) Run(ctx context.Context, args ...string) error {
 	return nil
 }
 
+type fakeResourceUsageRunner struct{}
+
+func (f *fakeResourceUsageRunner) Run(ctx context.Context, args ...string) error { return nil }
+
+func (f *fakeResourceUsageRunner) ResourceUsage() []v1beta1.PipelineResourceResult {
+	return []v1beta1.PipelineResourceResult{{
+		Key:        "WallTime",
+		Value:      "1s",
+		ResultType: v1beta1.InternalTektonResultType,
+	}}
+}
+
 type fakePostWriter struct{ wrote *string }
 
 func (f *fakePostWriter) Write(file string) { f.wrote = &file }
@@ -257,6 +666,13 @@ func (f *fakeErrorRunner) Run(ctx context.Context, args ...string) error {
 	return errors.New("runner failed")
 }
 
+type fakeExitErrorRunner struct{ args *[]string }
+
+func (f *fakeExitErrorRunner) Run(ctx context.Context, args ...string) error {
+	f.args = &args
+	return exec.CommandContext(ctx, args[0], args[1:]...).Run()
+}
+
 type fakeZeroTimeoutRunner struct{ args *[]string }
 
 func (f *fakeZeroTimeoutRunner) Run(ctx context.Context, args ...string) error {
@@ -23,12 +23,18 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/credentials"
-	"github.com/tektoncd/pipeline/pkg/credentials/dockercreds"
-	"github.com/tektoncd/pipeline/pkg/credentials/gitcreds"
 	"github.com/tektoncd/pipeline/pkg/names"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+
+	// Register the credential builders.
+	_ "github.com/tektoncd/pipeline/pkg/credentials/dockercreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/filecreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/gitcreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/mavencreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/netrccreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/npmcreds"
 )
 
 const (
@@ -64,7 +70,7 @@ func credsInit(ctx context.Context, serviceAccountName, namespace string, kubecl
 		return nil, nil, nil, err
 	}
 
-	builders := []credentials.Builder{dockercreds.NewBuilder(), gitcreds.NewBuilder()}
+	builders := credentials.Builders()
 
 	var volumeMounts []corev1.VolumeMount
 	var volumes []corev1.Volume
@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	debugScriptsVolumeName = "tekton-internal-debug-scripts"
+	debugScriptsDir        = "/tekton/debug/scripts"
+	debugInfoVolumeName    = "tekton-internal-debug-info"
+	debugInfoDir           = "/tekton/debug/info"
+
+	breakpointOnFailureFlag = "-breakpoint_on_failure"
+	checkBreakpointFlag     = "-check_breakpoint"
+
+	// debugScriptTemplate resumes the step paused at a breakpoint by creating
+	// the file with the given extension next to its breakpoint file.
+	debugScriptTemplate = `#!/bin/sh
+set -e
+for breakpoint in %[1]s/*.breakpoint; do
+  if [ ! -e "${breakpoint}" ]; then
+    echo "No step is paused at a breakpoint"
+    exit 1
+  fi
+  touch "${breakpoint%%.breakpoint}.%[2]s"
+  echo "%[3]s"
+done
+`
+)
+
+var (
+	// Volumes attached to Pods generated from TaskRuns that pause their steps
+	// at a breakpoint when they fail.
+	debugScriptsVolume = corev1.Volume{
+		Name:         debugScriptsVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+	debugScriptsVolumeMount = corev1.VolumeMount{
+		Name:      debugScriptsVolumeName,
+		MountPath: debugScriptsDir,
+	}
+	debugInfoVolume = corev1.Volume{
+		Name:         debugInfoVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+	debugInfoVolumeMount = corev1.VolumeMount{
+		Name:      debugInfoVolumeName,
+		MountPath: debugInfoDir,
+	}
+
+	// debugScripts are the scripts placed in debugScriptsDir, which resume a
+	// step paused at a breakpoint as successful or as failed.
+	debugScripts = []struct{ name, extension, message string }{
+		{"debug-continue", "continue", "Resuming the step as successful"},
+		{"debug-fail-continue", "fail-continue", "Resuming the step as failed"},
+	}
+)
+
+// debugScriptsInit returns a container that places the debug scripts in the
+// debug scripts volume, the same way as the scripts of the steps.
+func debugScriptsInit(entrypointImage string) corev1.Container {
+	placeDebugScriptsInit := decodeScriptsInit("place-debug-scripts", entrypointImage, debugScriptsVolumeMount)
+	for _, s := range debugScripts {
+		script := fmt.Sprintf(debugScriptTemplate, debugInfoDir, s.extension, s.message)
+		placeDebugScriptsInit.Args = append(placeDebugScriptsInit.Args, scriptArg(filepath.Join(debugScriptsDir, s.name), script))
+	}
+	return placeDebugScriptsInit
+}
+
+// addBreakpoints mounts the debug volumes into the steps, and adds to each
+// of them a readiness probe which only succeeds while the step is paused at
+// a breakpoint, so that the paused step can be reported in the TaskRun status.
+// Steps with a readiness probe of their own are rejected, rather than having
+// their probe replaced.
+func addBreakpoints(steps []corev1.Container) ([]corev1.Container, error) {
+	for i := range steps {
+		if steps[i].ReadinessProbe != nil {
+			return nil, fmt.Errorf("TaskRun validation failed. Step %q has a readinessProbe, which can't be used with the %q breakpoint", trimStepPrefix(steps[i].Name), v1beta1.BreakpointOnFailure)
+		}
+		steps[i].VolumeMounts = append(steps[i].VolumeMounts, debugScriptsVolumeMount, debugInfoVolumeMount)
+		steps[i].ReadinessProbe = &corev1.Probe{
+			Handler: corev1.Handler{
+				Exec: &corev1.ExecAction{
+					Command: []string{entrypointBinary, checkBreakpointFlag, filepath.Join(debugInfoDir, strconv.Itoa(i)+".breakpoint")},
+				},
+			},
+		}
+	}
+	return steps, nil
+}
+
+// hasBreakpoint returns true if the container pauses at a breakpoint when it
+// fails.
+func hasBreakpoint(c corev1.Container) bool {
+	if c.ReadinessProbe == nil || c.ReadinessProbe.Exec == nil {
+		return false
+	}
+	cmd := c.ReadinessProbe.Exec.Command
+	return len(cmd) == 3 && cmd[0] == entrypointBinary && cmd[1] == checkBreakpointFlag
+}
+
+// pausedStep returns the name of the step of the Pod which is paused at a
+// breakpoint, if any.
+func pausedStep(pod *corev1.Pod) (string, bool) {
+	withBreakpoint := map[string]bool{}
+	for _, c := range pod.Spec.Containers {
+		if IsContainerStep(c.Name) && hasBreakpoint(c) {
+			withBreakpoint[c.Name] = true
+		}
+	}
+	for _, s := range pod.Status.ContainerStatuses {
+		if withBreakpoint[s.Name] && s.State.Running != nil && s.Ready {
+			return trimStepPrefix(s.Name), true
+		}
+	}
+	return "", false
+}
@@ -25,6 +25,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"gomodules.xyz/jsonpatch/v2"
 	corev1 "k8s.io/api/core/v1"
@@ -48,6 +49,15 @@ const (
 
 	stepPrefix    = "step-"
 	sidecarPrefix = "sidecar-"
+
+	dropNetworkingFlag      = "-drop_networking"
+	reportResourceUsageFlag = "-report_resource_usage"
+
+	stepsVolumeName    = "tekton-internal-steps"
+	stepResultsFlag    = "-step_results"
+	stepResultsDirFlag = "-step_results_dir"
+	// resourceNameEnvVar is set on the steps which provide PipelineResources.
+	resourceNameEnvVar = "TEKTON_RESOURCE_NAME"
 )
 
 var (
@@ -80,6 +90,18 @@ var (
 		Name:      downwardVolumeName,
 		MountPath: downwardMountPoint,
 	}
+
+	// Volume holding the results of the steps which declare results, mounted
+	// into every step so that the steps can read the results of the previous
+	// ones.
+	stepsVolume = corev1.Volume{
+		Name:         stepsVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+	stepsMount = corev1.VolumeMount{
+		Name:      stepsVolumeName,
+		MountPath: pipeline.StepsDir,
+	}
 )
 
 // orderContainers returns the specified steps, modified so that they are
@@ -90,8 +112,10 @@ var (
 // Containers must have Command specified; if the user didn't specify a
 // command, we must have fetched the image's ENTRYPOINT before calling this
 // method, using entrypoint_lookup.go.
-// Additionally, Step timeouts are added as entrypoint flag.
-func orderContainers(entrypointImage string, commonExtraEntrypointArgs []string, steps []corev1.Container, taskSpec *v1beta1.TaskSpec) (corev1.Container, []corev1.Container, error) {
+// Additionally, Step timeouts and onError are added as entrypoint flags.
+// When hermetic is true, the entrypoint of each step, except the steps which
+// provide PipelineResources, is told to execute it without network access.
+func orderContainers(entrypointImage string, commonExtraEntrypointArgs []string, steps []corev1.Container, taskSpec *v1beta1.TaskSpec, hermetic bool) (corev1.Container, []corev1.Container, error) {
 	initContainer := corev1.Container{
 		Name:  "place-tools",
 		Image: entrypointImage,
@@ -130,7 +154,18 @@ func orderContainers(entrypointImage string, commonExtraEntrypointArgs []string,
 			if taskSpec.Steps != nil && len(taskSpec.Steps) >= i+1 && taskSpec.Steps[i].Timeout != nil {
 				argsForEntrypoint = append(argsForEntrypoint, "-timeout", taskSpec.Steps[i].Timeout.Duration.String())
 			}
+			if taskSpec.Steps != nil && len(taskSpec.Steps) >= i+1 && taskSpec.Steps[i].OnError != "" {
+				argsForEntrypoint = append(argsForEntrypoint, "-on_error", taskSpec.Steps[i].OnError)
+			}
 			argsForEntrypoint = append(argsForEntrypoint, resultArgument(steps, taskSpec.Results)...)
+			if taskSpec.Steps != nil && len(taskSpec.Steps) >= i+1 {
+				argsForEntrypoint = append(argsForEntrypoint, stepResultArguments(taskSpec.Steps[i])...)
+			}
+		}
+		// Steps which provide PipelineResources, e.g. by cloning a git
+		// repository, need network access even in a hermetic TaskRun.
+		if hermetic && !isResourceStep(s) {
+			argsForEntrypoint = append(argsForEntrypoint, dropNetworkingFlag)
 		}
 
 		cmd, args := s.Command, s.Args
@@ -155,11 +190,49 @@ func orderContainers(entrypointImage string, commonExtraEntrypointArgs []string,
 	return initContainer, steps, nil
 }
 
+// stepResultArguments returns the entrypoint flags telling the step which
+// results it writes, and where.
+func stepResultArguments(step v1beta1.Step) []string {
+	if len(step.Results) == 0 {
+		return nil
+	}
+	var names []string
+	for _, r := range step.Results {
+		names = append(names, r.Name)
+	}
+	return []string{
+		stepResultsFlag, strings.Join(names, ","),
+		stepResultsDirFlag, filepath.Join(pipeline.StepsDir, step.Name, "results"),
+	}
+}
+
+// hasStepResults returns true if any of the steps declares results.
+func hasStepResults(steps []v1beta1.Step) bool {
+	for _, s := range steps {
+		if len(s.Results) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// resultArgument returns the entrypoint flag listing the results of the Task.
+// The results which are not strings are followed by their type, e.g.
+// "files:array", so that the entrypoint parses them as JSON.
 func resultArgument(steps []corev1.Container, results []v1beta1.TaskResult) []string {
 	if len(results) == 0 {
 		return nil
 	}
-	return []string{"-results", collectResultsName(results)}
+	var resultNames []string
+	for _, r := range results {
+		switch r.Type {
+		case v1beta1.ResultsTypeArray, v1beta1.ResultsTypeObject:
+			resultNames = append(resultNames, fmt.Sprintf("%s:%s", r.Name, r.Type))
+		default:
+			resultNames = append(resultNames, r.Name)
+		}
+	}
+	return []string{"-results", strings.Join(resultNames, ",")}
 }
 
 func collectResultsName(results []v1beta1.TaskResult) string {
@@ -247,6 +320,16 @@ func IsSidecarStatusRunning(tr *v1beta1.TaskRun) bool {
 // represents a step.
 func IsContainerStep(name string) bool { return strings.HasPrefix(name, stepPrefix) }
 
+// isResourceStep returns true if the step provides a PipelineResource.
+func isResourceStep(s corev1.Container) bool {
+	for _, e := range s.Env {
+		if e.Name == resourceNameEnvVar {
+			return true
+		}
+	}
+	return false
+}
+
 // isContainerSidecar returns true if the container name indicates that it
 // represents a sidecar.
 func isContainerSidecar(name string) bool { return strings.HasPrefix(name, sidecarPrefix) }
@@ -88,7 +88,7 @@ func TestOrderContainers(t *testing.T) {
 		VolumeMounts:           []corev1.VolumeMount{toolsMount},
 		TerminationMessagePath: "/tekton/termination",
 	}}
-	gotInit, got, err := orderContainers(images.EntrypointImage, []string{}, steps, nil)
+	gotInit, got, err := orderContainers(images.EntrypointImage, []string{}, steps, nil, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -107,6 +107,74 @@ func TestOrderContainers(t *testing.T) {
 	}
 }
 
+func TestEntryPointOnError(t *testing.T) {
+	taskSpec := v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{Image: "step-1"},
+			OnError:   v1beta1.ContinueOnError,
+		}, {
+			Container: corev1.Container{Image: "step-2"},
+			OnError:   v1beta1.StopAndFail,
+		}, {
+			Container: corev1.Container{Image: "step-3"},
+		}},
+	}
+	steps := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}, {
+		Image:   "step-2",
+		Command: []string{"cmd"},
+	}, {
+		Image:   "step-3",
+		Command: []string{"cmd"},
+	}}
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/tools/0",
+			"-termination_path", "/tekton/termination",
+			"-on_error", "continue",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount, downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}, {
+		Image:   "step-2",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/tools/0",
+			"-post_file", "/tekton/tools/1",
+			"-termination_path", "/tekton/termination",
+			"-on_error", "stopAndFail",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount},
+		TerminationMessagePath: "/tekton/termination",
+	}, {
+		Image:   "step-3",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/tools/1",
+			"-post_file", "/tekton/tools/2",
+			"-termination_path", "/tekton/termination",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec, false)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestEntryPointResults(t *testing.T) {
 	taskSpec := v1beta1.TaskSpec{
 		Results: []v1beta1.TaskResult{{
@@ -174,7 +242,7 @@ func TestEntryPointResults(t *testing.T) {
 		VolumeMounts:           []corev1.VolumeMount{toolsMount},
 		TerminationMessagePath: "/tekton/termination",
 	}}
-	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec)
+	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -214,7 +282,7 @@ func TestEntryPointResultsSingleStep(t *testing.T) {
 		VolumeMounts:           []corev1.VolumeMount{toolsMount, downwardMount},
 		TerminationMessagePath: "/tekton/termination",
 	}}
-	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec)
+	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -250,7 +318,50 @@ func TestEntryPointSingleResultsSingleStep(t *testing.T) {
 		VolumeMounts:           []corev1.VolumeMount{toolsMount, downwardMount},
 		TerminationMessagePath: "/tekton/termination",
 	}}
-	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec)
+	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec, false)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestEntryPointTypedResults(t *testing.T) {
+	taskSpec := v1beta1.TaskSpec{
+		Results: []v1beta1.TaskResult{{
+			Name: "sum",
+		}, {
+			Name: "files",
+			Type: v1beta1.ResultsTypeArray,
+		}, {
+			Name: "image",
+			Type: v1beta1.ResultsTypeObject,
+		}, {
+			Name: "digest",
+			Type: v1beta1.ResultsTypeString,
+		}},
+	}
+
+	steps := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}}
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/tools/0",
+			"-termination_path", "/tekton/termination",
+			"-results", "sum,files:array,image:object,digest",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount, downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec, false)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -258,6 +369,91 @@ func TestEntryPointSingleResultsSingleStep(t *testing.T) {
 		t.Errorf("Diff %s", diff.PrintWantGot(d))
 	}
 }
+
+func TestEntryPointHermetic(t *testing.T) {
+	steps := []corev1.Container{{
+		Image:   "git-init",
+		Command: []string{"git-init"},
+		Env:     []corev1.EnvVar{{Name: "TEKTON_RESOURCE_NAME", Value: "source"}},
+	}, {
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}}
+	want := []corev1.Container{{
+		Image:   "git-init",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/tools/0",
+			"-termination_path", "/tekton/termination",
+			"-entrypoint", "git-init", "--",
+		},
+		Env:                    []corev1.EnvVar{{Name: "TEKTON_RESOURCE_NAME", Value: "source"}},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount, downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}, {
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/tools/0",
+			"-post_file", "/tekton/tools/1",
+			"-termination_path", "/tekton/termination",
+			"-drop_networking",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &v1beta1.TaskSpec{}, true)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestEntryPointStepResults(t *testing.T) {
+	taskSpec := v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name:  "clone",
+				Image: "alpine/git",
+			},
+			Results: []v1beta1.StepResult{{Name: "commit"}, {Name: "url"}},
+		}},
+	}
+	steps := []corev1.Container{{
+		Name:    "step-clone",
+		Image:   "alpine/git",
+		Command: []string{"cmd"},
+	}}
+	want := []corev1.Container{{
+		Name:    "step-clone",
+		Image:   "alpine/git",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/tools/0",
+			"-termination_path", "/tekton/termination",
+			"-step_results", "commit,url",
+			"-step_results_dir", "/tekton/steps/clone/results",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount, downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	_, got, err := orderContainers(images.EntrypointImage, []string{}, steps, &taskSpec, false)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestUpdateReady(t *testing.T) {
 	for _, c := range []struct {
 		desc            string
@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// applyStepOverrides returns the steps with the overrides of the TaskRun
+// applied to the steps they name. The given steps are not modified.
+func applyStepOverrides(steps []v1beta1.Step, overrides []v1beta1.TaskRunStepOverride) []v1beta1.Step {
+	if len(overrides) == 0 {
+		return steps
+	}
+	byName := map[string]v1beta1.TaskRunStepOverride{}
+	for _, o := range overrides {
+		byName[o.Name] = o
+	}
+	overridden := make([]v1beta1.Step, 0, len(steps))
+	for _, s := range steps {
+		if o, ok := byName[s.Name]; ok {
+			s.Container = overrideContainer(s.Container, o.Resources, o.Env)
+		}
+		overridden = append(overridden, s)
+	}
+	return overridden
+}
+
+// applySidecarOverrides returns the sidecars with the overrides of the TaskRun
+// applied to the sidecars they name. The given sidecars are not modified.
+func applySidecarOverrides(sidecars []v1beta1.Sidecar, overrides []v1beta1.TaskRunSidecarOverride) []v1beta1.Sidecar {
+	if len(overrides) == 0 {
+		return sidecars
+	}
+	byName := map[string]v1beta1.TaskRunSidecarOverride{}
+	for _, o := range overrides {
+		byName[o.Name] = o
+	}
+	overridden := make([]v1beta1.Sidecar, 0, len(sidecars))
+	for _, s := range sidecars {
+		if o, ok := byName[s.Name]; ok {
+			s.Container = overrideContainer(s.Container, o.Resources, o.Env)
+		}
+		overridden = append(overridden, s)
+	}
+	return overridden
+}
+
+// overrideContainer replaces the resources of the container if any are set,
+// and sets the given environment variables in place of the ones of the
+// container which have the same name.
+func overrideContainer(c corev1.Container, resources corev1.ResourceRequirements, env []corev1.EnvVar) corev1.Container {
+	if len(resources.Requests) > 0 || len(resources.Limits) > 0 {
+		c.Resources = resources
+	}
+	if len(env) == 0 {
+		return c
+	}
+	overrides := map[string]corev1.EnvVar{}
+	for _, e := range env {
+		overrides[e.Name] = e
+	}
+	merged := make([]corev1.EnvVar, 0, len(c.Env)+len(env))
+	for _, e := range c.Env {
+		if o, ok := overrides[e.Name]; ok {
+			e = o
+			delete(overrides, e.Name)
+		}
+		merged = append(merged, e)
+	}
+	for _, e := range env {
+		if _, ok := overrides[e.Name]; ok {
+			merged = append(merged, e)
+		}
+	}
+	c.Env = merged
+	return c
+}
@@ -120,16 +120,23 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		return nil, err
 	}
 
+	// Apply the overrides of the TaskRun to the steps and sidecars they name.
+	steps = applyStepOverrides(steps, taskRun.Spec.StepOverrides)
+	sidecars := applySidecarOverrides(taskSpec.Sidecars, taskRun.Spec.SidecarOverrides)
+
 	// Convert any steps with Script to command+args.
 	// If any are found, append an init container to initialize scripts.
-	scriptsInit, stepContainers, sidecarContainers := convertScripts(b.Images.ShellImage, steps, taskSpec.Sidecars)
+	scriptsInit, stepContainers, sidecarContainers, err := convertScripts(b.Images.EntrypointImage, steps, sidecars)
+	if err != nil {
+		return nil, err
+	}
 	if scriptsInit != nil {
 		initContainers = append(initContainers, *scriptsInit)
 		volumes = append(volumes, scriptsVolume)
 	}
 
 	// Initialize any workingDirs under /workspace.
-	if workingDirInit := workingDirInit(b.Images.ShellImage, stepContainers); workingDirInit != nil {
+	if workingDirInit := workingDirInit(b.Images.EntrypointImage, stepContainers); workingDirInit != nil {
 		initContainers = append(initContainers, *workingDirInit)
 	}
 
@@ -142,20 +149,58 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 	// Rewrite steps with entrypoint binary. Append the entrypoint init
 	// container to place the entrypoint binary. Also add timeout flags
 	// to entrypoint binary.
-	entrypointInit, stepContainers, err := orderContainers(b.Images.EntrypointImage, credEntrypointArgs, stepContainers, &taskSpec)
+	// When results are read from the logs of a sidecar, the steps must not
+	// write them to their termination messages, so they are not passed on
+	// to the entrypoint.
+	useResultsSidecar := shouldUseResultsSidecar(ctx, taskSpec)
+	entrypointTaskSpec := taskSpec
+	if useResultsSidecar {
+		entrypointTaskSpec.Results = nil
+	}
+	// When the TaskRun is debugged, the steps pause at a breakpoint when they
+	// fail.
+	entrypointArgs := credEntrypointArgs
+	breakpointOnFailure := taskRun.Spec.Debug.NeedsDebugOnFailure()
+	if breakpointOnFailure {
+		entrypointArgs = append(entrypointArgs, breakpointOnFailureFlag)
+	}
+	// The steps report their resource usage when they have room left for it
+	// in their termination messages.
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableStepResourceUsage {
+		entrypointArgs = append(entrypointArgs, reportResourceUsageFlag)
+	}
+	entrypointInit, stepContainers, err := orderContainers(b.Images.EntrypointImage, entrypointArgs, stepContainers, &entrypointTaskSpec, taskRun.IsHermetic())
 	if err != nil {
 		return nil, err
 	}
 	initContainers = append(initContainers, entrypointInit)
 	volumes = append(volumes, toolsVolume, downwardVolume)
+	if hasStepResults(taskSpec.Steps) {
+		volumes = append(volumes, stepsVolume)
+		for i := range stepContainers {
+			stepContainers[i].VolumeMounts = append(stepContainers[i].VolumeMounts, stepsMount)
+		}
+	}
+	if breakpointOnFailure {
+		initContainers = append(initContainers, debugScriptsInit(b.Images.EntrypointImage))
+		volumes = append(volumes, debugScriptsVolume, debugInfoVolume)
+		if stepContainers, err = addBreakpoints(stepContainers); err != nil {
+			return nil, err
+		}
+	}
 
 	limitRangeMin, err := getLimitRangeMinimum(ctx, taskRun.Namespace, b.KubeClient)
 	if err != nil {
 		return nil, err
 	}
 
-	// Zero out non-max resource requests.
-	stepContainers = resolveResourceRequests(stepContainers, limitRangeMin)
+	if taskRun.Spec.ComputeResources != nil {
+		// Distribute the compute resources of the whole Task among the steps.
+		stepContainers = resolveTaskResources(stepContainers, *taskRun.Spec.ComputeResources, limitRangeMin)
+	} else {
+		// Zero out non-max resource requests.
+		stepContainers = resolveResourceRequests(stepContainers, limitRangeMin)
+	}
 
 	// Add implicit env vars.
 	// They're prepended to the list, so that if the user specified any
@@ -243,6 +288,9 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		sc.Name = names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%v%v", sidecarPrefix, sc.Name))
 		mergedPodContainers = append(mergedPodContainers, sc)
 	}
+	if useResultsSidecar {
+		mergedPodContainers = append(mergedPodContainers, resultsSidecar(b.Images.SidecarLogResultsImage, taskSpec.Results, len(stepContainers)))
+	}
 
 	var dnsPolicy corev1.DNSPolicy
 	if podTemplate.DNSPolicy != nil {
@@ -254,6 +302,17 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		priorityClassName = *podTemplate.PriorityClassName
 	}
 
+	nodeSelector := podTemplate.NodeSelector
+	if taskRun.IsHermetic() {
+		// The steps of a hermetic TaskRun are isolated with Linux namespaces,
+		// so its pod must be scheduled on a Linux node.
+		nodeSelector = make(map[string]string, len(podTemplate.NodeSelector)+1)
+		for k, v := range podTemplate.NodeSelector {
+			nodeSelector[k] = v
+		}
+		nodeSelector[corev1.LabelOSStable] = "linux"
+	}
+
 	podAnnotations := taskRun.Annotations
 	podAnnotations[ReleaseAnnotation] = version.PipelineVersion
 
@@ -284,7 +343,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 			Containers:                   mergedPodContainers,
 			ServiceAccountName:           taskRun.Spec.ServiceAccountName,
 			Volumes:                      volumes,
-			NodeSelector:                 podTemplate.NodeSelector,
+			NodeSelector:                 nodeSelector,
 			Tolerations:                  podTemplate.Tolerations,
 			Affinity:                     affinity,
 			SecurityContext:              podTemplate.SecurityContext,
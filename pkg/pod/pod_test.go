@@ -18,7 +18,7 @@ package pod
 
 import (
 	"context"
-	"fmt"
+	"encoding/base64"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -45,8 +45,9 @@ import (
 
 var (
 	images = pipeline.Images{
-		EntrypointImage: "entrypoint-image",
-		ShellImage:      "busybox",
+		EntrypointImage:        "entrypoint-image",
+		ShellImage:             "busybox",
+		SidecarLogResultsImage: "sidecarlogresults-image",
 	}
 
 	ignoreReleaseAnnotation = func(k string, v string) bool {
@@ -404,9 +405,9 @@ func TestPodBuild(t *testing.T) {
 			InitContainers: []corev1.Container{
 				{
 					Name:         "working-dir-initializer",
-					Image:        images.ShellImage,
-					Command:      []string{"sh"},
-					Args:         []string{"-c", fmt.Sprintf("mkdir -p %s", filepath.Join(pipeline.WorkspaceDir, "test"))},
+					Image:        images.EntrypointImage,
+					Command:      []string{"/ko-app/entrypoint", "mkdirs"},
+					Args:         []string{filepath.Join(pipeline.WorkspaceDir, "test")},
 					WorkingDir:   pipeline.WorkspaceDir,
 					VolumeMounts: implicitVolumeMounts,
 				},
@@ -519,16 +520,12 @@ func TestPodBuild(t *testing.T) {
 			InitContainers: []corev1.Container{
 				{
 					Name:         "place-scripts",
-					Image:        "busybox",
-					Command:      []string{"sh"},
+					Image:        images.EntrypointImage,
+					Command:      []string{"/ko-app/entrypoint", "decode-scripts"},
 					VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
-					Args: []string{"-c", `tmpfile="/tekton/scripts/sidecar-script-0-9l9zj"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'sidecar-script-heredoc-randomly-generated-mz4c7'
-#!/bin/sh
+					Args: []string{"/tekton/scripts/sidecar-script-0-9l9zj=" + base64.StdEncoding.EncodeToString([]byte(`#!/bin/sh
 echo hello from sidecar
-sidecar-script-heredoc-randomly-generated-mz4c7
-`},
+`))},
 				},
 				placeToolsInit,
 			},
@@ -550,7 +547,7 @@ sidecar-script-heredoc-randomly-generated-mz4c7
 				},
 				Env: implicitEnvVars,
 				VolumeMounts: append([]corev1.VolumeMount{toolsMount, downwardMount, {
-					Name:      "tekton-creds-init-home-mssqb",
+					Name:      "tekton-creds-init-home-mz4c7",
 					MountPath: "/tekton/creds",
 				}}, implicitVolumeMounts...),
 				WorkingDir:             pipeline.WorkspaceDir,
@@ -566,7 +563,7 @@ sidecar-script-heredoc-randomly-generated-mz4c7
 				VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
 			}},
 			Volumes: append(implicitVolumes, scriptsVolume, toolsVolume, downwardVolume, corev1.Volume{
-				Name:         "tekton-creds-init-home-mssqb",
+				Name:         "tekton-creds-init-home-mz4c7",
 				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
 			}),
 		},
@@ -756,21 +753,16 @@ print("Hello from Python")`,
 			InitContainers: []corev1.Container{
 				{
 					Name:    "place-scripts",
-					Image:   images.ShellImage,
-					Command: []string{"sh"},
-					Args: []string{"-c", `tmpfile="/tekton/scripts/script-0-9l9zj"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'script-heredoc-randomly-generated-mz4c7'
-#!/bin/sh
+					Image:   images.EntrypointImage,
+					Command: []string{"/ko-app/entrypoint", "decode-scripts"},
+					Args: []string{
+						"/tekton/scripts/script-0-9l9zj=" + base64.StdEncoding.EncodeToString([]byte(`#!/bin/sh
 echo hello from step one
-script-heredoc-randomly-generated-mz4c7
-tmpfile="/tekton/scripts/script-1-mssqb"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'script-heredoc-randomly-generated-78c5n'
-#!/usr/bin/env python
+`)),
+						"/tekton/scripts/script-1-mz4c7=" + base64.StdEncoding.EncodeToString([]byte(`#!/usr/bin/env python
 print("Hello from Python")
-script-heredoc-randomly-generated-78c5n
-`},
+`)),
+					},
 					VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
 				},
 				{
@@ -799,7 +791,7 @@ script-heredoc-randomly-generated-78c5n
 				},
 				Env: append(implicitEnvVars, corev1.EnvVar{Name: "FOO", Value: "bar"}),
 				VolumeMounts: append([]corev1.VolumeMount{scriptsVolumeMount, toolsMount, downwardMount, {
-					Name:      "tekton-creds-init-home-6nl7g",
+					Name:      "tekton-creds-init-home-mssqb",
 					MountPath: "/tekton/creds",
 				}}, implicitVolumeMounts...),
 				WorkingDir:             pipeline.WorkspaceDir,
@@ -817,14 +809,14 @@ script-heredoc-randomly-generated-78c5n
 					"-termination_path",
 					"/tekton/termination",
 					"-entrypoint",
-					"/tekton/scripts/script-1-mssqb",
+					"/tekton/scripts/script-1-mz4c7",
 					"--",
 					"template",
 					"args",
 				},
 				Env: append(implicitEnvVars, corev1.EnvVar{Name: "FOO", Value: "bar"}),
 				VolumeMounts: append([]corev1.VolumeMount{{Name: "i-have-a-volume-mount"}, scriptsVolumeMount, toolsMount, {
-					Name:      "tekton-creds-init-home-j2tds",
+					Name:      "tekton-creds-init-home-78c5n",
 					MountPath: "/tekton/creds",
 				}}, implicitVolumeMounts...),
 				WorkingDir:             pipeline.WorkspaceDir,
@@ -850,7 +842,7 @@ script-heredoc-randomly-generated-78c5n
 				},
 				Env: append(implicitEnvVars, corev1.EnvVar{Name: "FOO", Value: "bar"}),
 				VolumeMounts: append([]corev1.VolumeMount{toolsMount, {
-					Name:      "tekton-creds-init-home-vr6ds",
+					Name:      "tekton-creds-init-home-6nl7g",
 					MountPath: "/tekton/creds",
 				}}, implicitVolumeMounts...),
 				WorkingDir:             pipeline.WorkspaceDir,
@@ -858,13 +850,13 @@ script-heredoc-randomly-generated-78c5n
 				TerminationMessagePath: "/tekton/termination",
 			}},
 			Volumes: append(implicitVolumes, scriptsVolume, toolsVolume, downwardVolume, corev1.Volume{
-				Name:         "tekton-creds-init-home-6nl7g",
+				Name:         "tekton-creds-init-home-mssqb",
 				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
 			}, corev1.Volume{
-				Name:         "tekton-creds-init-home-j2tds",
+				Name:         "tekton-creds-init-home-78c5n",
 				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
 			}, corev1.Volume{
-				Name:         "tekton-creds-init-home-vr6ds",
+				Name:         "tekton-creds-init-home-6nl7g",
 				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
 			}),
 		},
@@ -1135,6 +1127,206 @@ script-heredoc-randomly-generated-78c5n
 				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
 			}),
 		},
+	}, {
+		desc: "task-level compute resources",
+		ts: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{Container: corev1.Container{
+				Name:    "name",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+				},
+			}}},
+		},
+		trs: v1beta1.TaskRunSpec{
+			ComputeResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{placeToolsInit},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/tools/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/tools/0",
+					"-termination_path",
+					"/tekton/termination",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				Env: implicitEnvVars,
+				VolumeMounts: append([]corev1.VolumeMount{toolsMount, downwardMount, {
+					Name:      "tekton-creds-init-home-9l9zj",
+					MountPath: "/tekton/creds",
+				}}, implicitVolumeMounts...),
+				WorkingDir: pipeline.WorkspaceDir,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:              resource.MustParse("1"),
+						corev1.ResourceMemory:           zeroQty,
+						corev1.ResourceEphemeralStorage: zeroQty,
+					},
+					Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				},
+				TerminationMessagePath: "/tekton/termination",
+			}},
+			Volumes: append(implicitVolumes, toolsVolume, downwardVolume, corev1.Volume{
+				Name:         "tekton-creds-init-home-9l9zj",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+			}),
+		},
+	}, {
+		desc: "step and sidecar overrides",
+		ts: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{Container: corev1.Container{
+				Name:    "name",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+				Env: []corev1.EnvVar{
+					{Name: "FOO", Value: "foo"},
+					{Name: "BAR", Value: "bar"},
+				},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+				},
+			}}},
+			Sidecars: []v1beta1.Sidecar{{
+				Container: corev1.Container{
+					Name:  "sc-name",
+					Image: "sidecar-image",
+				},
+			}},
+		},
+		trs: v1beta1.TaskRunSpec{
+			StepOverrides: []v1beta1.TaskRunStepOverride{{
+				Name: "name",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+				Env: []corev1.EnvVar{
+					{Name: "BAR", Value: "overridden"},
+					{Name: "BAZ", Value: "baz"},
+				},
+			}},
+			SidecarOverrides: []v1beta1.TaskRunSidecarOverride{{
+				Name: "sc-name",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				},
+			}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{placeToolsInit},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/tools/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/tools/0",
+					"-termination_path",
+					"/tekton/termination",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				Env: append(implicitEnvVars, []corev1.EnvVar{
+					{Name: "FOO", Value: "foo"},
+					{Name: "BAR", Value: "overridden"},
+					{Name: "BAZ", Value: "baz"},
+				}...),
+				VolumeMounts: append([]corev1.VolumeMount{toolsMount, downwardMount, {
+					Name:      "tekton-creds-init-home-9l9zj",
+					MountPath: "/tekton/creds",
+				}}, implicitVolumeMounts...),
+				WorkingDir: pipeline.WorkspaceDir,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:              zeroQty,
+						corev1.ResourceMemory:           resource.MustParse("1Gi"),
+						corev1.ResourceEphemeralStorage: zeroQty,
+					},
+				},
+				TerminationMessagePath: "/tekton/termination",
+			}, {
+				Name:  "sidecar-sc-name",
+				Image: "sidecar-image",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				},
+			}},
+			Volumes: append(implicitVolumes, toolsVolume, downwardVolume, corev1.Volume{
+				Name:         "tekton-creds-init-home-9l9zj",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+			}),
+		},
+	}, {
+		desc: "debug breakpoint on failure",
+		ts: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{Container: corev1.Container{
+				Name:    "name",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}}},
+		},
+		trs: v1beta1.TaskRunSpec{
+			Debug: &v1beta1.TaskRunDebug{Breakpoint: []string{v1beta1.BreakpointOnFailure}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{placeToolsInit, debugScriptsInit(images.EntrypointImage)},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/tools/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/tools/0",
+					"-termination_path",
+					"/tekton/termination",
+					"-breakpoint_on_failure",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				Env: implicitEnvVars,
+				VolumeMounts: append([]corev1.VolumeMount{toolsMount, downwardMount, debugScriptsVolumeMount, debugInfoVolumeMount, {
+					Name:      "tekton-creds-init-home-9l9zj",
+					MountPath: "/tekton/creds",
+				}}, implicitVolumeMounts...),
+				ReadinessProbe: &corev1.Probe{
+					Handler: corev1.Handler{
+						Exec: &corev1.ExecAction{
+							Command: []string{"/tekton/tools/entrypoint", "-check_breakpoint", "/tekton/debug/info/0.breakpoint"},
+						},
+					},
+				},
+				WorkingDir:             pipeline.WorkspaceDir,
+				Resources:              corev1.ResourceRequirements{Requests: allZeroQty()},
+				TerminationMessagePath: "/tekton/termination",
+			}},
+			Volumes: append(implicitVolumes, toolsVolume, downwardVolume, debugScriptsVolume, debugInfoVolume, corev1.Volume{
+				Name:         "tekton-creds-init-home-9l9zj",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+			}),
+		},
 	}, {
 		desc: "task-with-creds-init-disabled",
 		featureFlags: map[string]string{
@@ -1174,6 +1366,110 @@ script-heredoc-randomly-generated-78c5n
 			}},
 			Volumes: append(implicitVolumes, toolsVolume, downwardVolume),
 		},
+	}, {
+		desc: "step resource usage reported",
+		featureFlags: map[string]string{
+			"disable-creds-init":         "true",
+			"enable-step-resource-usage": "true",
+		},
+		ts: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{Container: corev1.Container{
+				Name:    "name",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{placeToolsInit},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/tools/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/tools/0",
+					"-termination_path",
+					"/tekton/termination",
+					"-report_resource_usage",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				Env:                    implicitEnvVars,
+				VolumeMounts:           append([]corev1.VolumeMount{toolsMount, downwardMount}, implicitVolumeMounts...),
+				WorkingDir:             pipeline.WorkspaceDir,
+				Resources:              corev1.ResourceRequirements{Requests: allZeroQty()},
+				TerminationMessagePath: "/tekton/termination",
+			}},
+			Volumes: append(implicitVolumes, toolsVolume, downwardVolume),
+		},
+	}, {
+		desc: "results read from sidecar logs",
+		featureFlags: map[string]string{
+			"disable-creds-init": "true",
+			"results-from":       "sidecar-logs",
+		},
+		ts: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{Container: corev1.Container{
+				Name:    "name",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}}},
+			Results: []v1beta1.TaskResult{{
+				Name: "sbom",
+			}, {
+				Name: "digest",
+			}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{placeToolsInit},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/tools/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/tools/0",
+					"-termination_path",
+					"/tekton/termination",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				Env:                    implicitEnvVars,
+				VolumeMounts:           append([]corev1.VolumeMount{toolsMount, downwardMount}, implicitVolumeMounts...),
+				WorkingDir:             pipeline.WorkspaceDir,
+				Resources:              corev1.ResourceRequirements{Requests: allZeroQty()},
+				TerminationMessagePath: "/tekton/termination",
+			}, {
+				Name:  "sidecar-tekton-log-results",
+				Image: "sidecarlogresults-image",
+				Command: []string{
+					"/ko-app/sidecarlogresults",
+					"-results_dir", "/tekton/results",
+					"-result_names", "sbom,digest",
+					"-wait_file", "/tekton/tools/0",
+				},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "tekton-internal-tools",
+					MountPath: "/tekton/tools",
+					ReadOnly:  true,
+				}, {
+					Name:      "tekton-internal-results",
+					MountPath: "/tekton/results",
+					ReadOnly:  true,
+				}},
+			}},
+			Volumes: append(implicitVolumes, toolsVolume, downwardVolume),
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			names.TestingSeed()
@@ -1257,6 +1553,91 @@ script-heredoc-randomly-generated-78c5n
 	}
 }
 
+func TestPodBuild_BreakpointWithReadinessProbe(t *testing.T) {
+	names.TestingSeed()
+	store := config.NewStore(logtesting.TestLogger(t))
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+	)
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default"},
+		Spec: v1beta1.TaskRunSpec{
+			Debug: &v1beta1.TaskRunDebug{Breakpoint: []string{v1beta1.BreakpointOnFailure}},
+		},
+	}
+	ts := v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{Container: corev1.Container{
+			Name:    "name",
+			Image:   "image",
+			Command: []string{"cmd"}, // avoid entrypoint lookup.
+			ReadinessProbe: &corev1.Probe{
+				Handler: corev1.Handler{Exec: &corev1.ExecAction{Command: []string{"ready"}}},
+			},
+		}}},
+	}
+	builder := Builder{
+		Images:          images,
+		KubeClient:      kubeclient,
+		EntrypointCache: fakeCache{},
+	}
+	_, err := builder.Build(store.ToContext(context.Background()), tr, ts)
+	if err == nil || !strings.Contains(err.Error(), "TaskRun validation failed") {
+		t.Errorf("expected a validation error for a step with its own readinessProbe, got %v", err)
+	}
+}
+
+func TestPodBuild_HermeticNodeSelector(t *testing.T) {
+	for _, tc := range []struct {
+		desc         string
+		nodeSelector map[string]string
+		want         map[string]string
+	}{{
+		desc: "no node selector",
+		want: map[string]string{corev1.LabelOSStable: "linux"},
+	}, {
+		desc:         "other node selector",
+		nodeSelector: map[string]string{"disktype": "ssd"},
+		want:         map[string]string{"disktype": "ssd", corev1.LabelOSStable: "linux"},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			names.TestingSeed()
+			store := config.NewStore(logtesting.TestLogger(t))
+			kubeclient := fakek8s.NewSimpleClientset(
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+			)
+			tr := &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default", Annotations: map[string]string{}},
+				Spec: v1beta1.TaskRunSpec{
+					ExecutionMode: v1beta1.TaskRunExecutionModeHermetic,
+					PodTemplate:   &v1beta1.PodTemplate{NodeSelector: tc.nodeSelector},
+				},
+			}
+			ts := v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{Container: corev1.Container{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}}},
+			}
+			builder := Builder{
+				Images:          images,
+				KubeClient:      kubeclient,
+				EntrypointCache: fakeCache{},
+			}
+			got, err := builder.Build(store.ToContext(context.Background()), tr, ts)
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got.Spec.NodeSelector); d != "" {
+				t.Errorf("Diff node selector %s", diff.PrintWantGot(d))
+			}
+			if _, ok := tc.nodeSelector[corev1.LabelOSStable]; ok {
+				t.Errorf("the node selector of the pod template was modified: %v", tc.nodeSelector)
+			}
+		})
+	}
+}
+
 func TestMakeLabels(t *testing.T) {
 	taskRunName := "task-run-name"
 	want := map[string]string{
@@ -78,3 +78,64 @@ func resolveResourceRequests(containers []corev1.Container, limitRangeMin corev1
 
 	return containers
 }
+
+// resolveTaskResources sets the resources of the steps from the compute
+// resources of the whole Task, replacing their own resources. Since the steps
+// run one at a time, each of them gets the limits of the Task, while the
+// requests of the Task are divided among them so that the requests of the Pod
+// add up to the requests of the Task. Each step requests at least the
+// LimitRange minimum, even if this makes the Pod request more than the Task.
+func resolveTaskResources(containers []corev1.Container, taskResources corev1.ResourceRequirements, limitRangeMin corev1.ResourceList) []corev1.Container {
+	resourceNames := []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage}
+	for i := range containers {
+		requests := corev1.ResourceList{}
+		var limits corev1.ResourceList
+		for _, resourceName := range resourceNames {
+			min := limitRangeMin[resourceName]
+			request := zeroQty
+			if taskRequest, ok := taskResources.Requests[resourceName]; ok {
+				request = divideQuantity(resourceName, taskRequest, len(containers), i)
+			}
+			if request.Cmp(min) < 0 {
+				request = min
+			}
+			requests[resourceName] = request
+
+			if taskLimit, ok := taskResources.Limits[resourceName]; ok {
+				if limits == nil {
+					limits = corev1.ResourceList{}
+				}
+				if taskLimit.Cmp(min) < 0 {
+					taskLimit = min
+				}
+				limits[resourceName] = taskLimit
+			}
+		}
+		containers[i].Resources = corev1.ResourceRequirements{
+			Requests: requests,
+			Limits:   limits,
+		}
+	}
+	return containers
+}
+
+// divideQuantity returns the share of the i-th of n containers of the given
+// quantity. The remainder of the division goes to the first container, so
+// that the shares add up to the quantity. CPU is divided in millicores, other
+// resources in units.
+func divideQuantity(resourceName corev1.ResourceName, q resource.Quantity, n, i int) resource.Quantity {
+	if resourceName == corev1.ResourceCPU {
+		total := q.MilliValue()
+		share := total / int64(n)
+		if i == 0 {
+			share += total % int64(n)
+		}
+		return *resource.NewMilliQuantity(share, q.Format)
+	}
+	total := q.Value()
+	share := total / int64(n)
+	if i == 0 {
+		share += total % int64(n)
+	}
+	return *resource.NewQuantity(share, q.Format)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ResultsSidecarContainerName is the name of the container which prints the
+// Task results in its logs when results are read from sidecar logs.
+const ResultsSidecarContainerName = sidecarPrefix + "tekton-log-results"
+
+// shouldUseResultsSidecar returns true if the results of the Task should be
+// read from the logs of a sidecar instead of the steps' termination messages.
+func shouldUseResultsSidecar(ctx context.Context, taskSpec v1beta1.TaskSpec) bool {
+	if len(taskSpec.Results) == 0 {
+		return false
+	}
+	cfg := config.FromContextOrDefaults(ctx)
+	return cfg.FeatureFlags.ResultExtractionMethod == config.ResultExtractionMethodSidecarLogs
+}
+
+// resultsSidecar returns a Container that waits for the last step to complete
+// and then prints the results of the Task in its logs.
+func resultsSidecar(image string, results []v1beta1.TaskResult, stepCount int) corev1.Container {
+	toolsReadOnlyMount := toolsMount
+	toolsReadOnlyMount.ReadOnly = true
+	return corev1.Container{
+		Name:  ResultsSidecarContainerName,
+		Image: image,
+		Command: []string{
+			"/ko-app/sidecarlogresults",
+			"-results_dir", ResultsDir,
+			"-result_names", collectResultsName(results),
+			"-wait_file", filepath.Join(mountPoint, strconv.Itoa(stepCount-1)),
+		},
+		VolumeMounts: []corev1.VolumeMount{toolsReadOnlyMount, {
+			Name:      "tekton-internal-results",
+			MountPath: ResultsDir,
+			ReadOnly:  true,
+		}},
+	}
+}
+
+// IsResultsSidecarTerminated returns true if the Pod has a results sidecar and
+// it has terminated, which means its logs hold all the results of the Task.
+func IsResultsSidecarTerminated(pod *corev1.Pod) bool {
+	for _, s := range pod.Status.ContainerStatuses {
+		if s.Name == ResultsSidecarContainerName {
+			return s.State.Terminated != nil
+		}
+	}
+	return false
+}
+
+// isResultsSidecarPending returns true if the Pod has a results sidecar which
+// has not terminated yet.
+func isResultsSidecarPending(pod *corev1.Pod) bool {
+	for _, c := range pod.Spec.Containers {
+		if c.Name == ResultsSidecarContainerName {
+			return !IsResultsSidecarTerminated(pod)
+		}
+	}
+	return false
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package pod
 
 import (
+	"encoding/base64"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -31,6 +32,12 @@ const (
 	scriptsVolumeName     = "tekton-internal-scripts"
 	scriptsDir            = "/tekton/scripts"
 	defaultScriptPreamble = "#!/bin/sh\nset -xe\n"
+
+	// maxScriptArgLength is the maximum length of a single arg of the
+	// container placing the scripts. Linux rejects any arg longer than
+	// MAX_ARG_STRLEN (128 KiB, including its terminating null byte), which
+	// makes the container fail to start.
+	maxScriptArgLength = 128*1024 - 1
 )
 
 var (
@@ -50,16 +57,13 @@ var (
 //
 // It does this by prepending a container that writes specified Script bodies
 // to executable files in a shared volumeMount, then produces Containers that
-// simply run those executable files.
-func convertScripts(shellImage string, steps []v1beta1.Step, sidecars []v1beta1.Sidecar) (*corev1.Container, []corev1.Container, []corev1.Container) {
+// simply run those executable files. The Script bodies are written by the
+// entrypoint binary pulled from the entrypointImage, which decodes them from
+// its args, so that no shell is required to place them. An error is returned
+// if any script is too large to be passed as an arg.
+func convertScripts(entrypointImage string, steps []v1beta1.Step, sidecars []v1beta1.Sidecar) (*corev1.Container, []corev1.Container, []corev1.Container, error) {
 	placeScripts := false
-	placeScriptsInit := corev1.Container{
-		Name:         "place-scripts",
-		Image:        shellImage,
-		Command:      []string{"sh"},
-		Args:         []string{"-c", ""},
-		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
-	}
+	placeScriptsInit := decodeScriptsInit("place-scripts", entrypointImage, scriptsVolumeMount)
 
 	convertedStepContainers := convertListOfSteps(steps, &placeScriptsInit, &placeScripts, "script")
 
@@ -74,16 +78,50 @@ func convertScripts(shellImage string, steps []v1beta1.Step, sidecars []v1beta1.
 	}
 	sidecarContainers := convertListOfSteps(sideCarSteps, &placeScriptsInit, &placeScripts, "sidecar-script")
 
-	if placeScripts {
-		return &placeScriptsInit, convertedStepContainers, sidecarContainers
+	if !placeScripts {
+		return nil, convertedStepContainers, sidecarContainers, nil
 	}
-	return nil, convertedStepContainers, sidecarContainers
+	if err := checkScriptArgs(placeScriptsInit); err != nil {
+		return nil, nil, nil, err
+	}
+	return &placeScriptsInit, convertedStepContainers, sidecarContainers, nil
+}
+
+// decodeScriptsInit returns a container that runs the entrypoint binary in
+// "decode-scripts mode", to write each script given as a path=base64 arg to
+// the volume mounted by volumeMount.
+func decodeScriptsInit(name, entrypointImage string, volumeMount corev1.VolumeMount) corev1.Container {
+	return corev1.Container{
+		Name:         name,
+		Image:        entrypointImage,
+		Command:      []string{"/ko-app/entrypoint", "decode-scripts"},
+		VolumeMounts: []corev1.VolumeMount{volumeMount},
+	}
+}
+
+// scriptArg returns the arg of a container returned by decodeScriptsInit
+// which writes the script to the given path. The script is base64-encoded so
+// that it is passed as is, whatever the characters it contains.
+func scriptArg(path, script string) string {
+	return fmt.Sprintf("%s=%s", path, base64.StdEncoding.EncodeToString([]byte(script)))
+}
+
+// checkScriptArgs returns an error if any arg of the container placing the
+// scripts is longer than the kernel accepts.
+func checkScriptArgs(c corev1.Container) error {
+	for _, arg := range c.Args {
+		if len(arg) > maxScriptArgLength {
+			path := strings.SplitN(arg, "=", 2)[0]
+			return fmt.Errorf("script %s is too large: its base64 encoding is %d bytes long, which exceeds the limit of %d bytes", path, len(arg)-len(path)-1, maxScriptArgLength-len(path)-1)
+		}
+	}
+	return nil
 }
 
 // convertListOfSteps does the heavy lifting for convertScripts.
 //
-// It iterates through the list of steps (or sidecars), generates the script file name and heredoc termination string,
-// adds an entry to the init container args, sets up the step container to run the script, and sets the volume mounts.
+// It iterates through the list of steps (or sidecars), generates the script file name, adds an entry to the init
+// container args, sets up the step container to run the script, and sets the volume mounts.
 func convertListOfSteps(steps []v1beta1.Step, initContainer *corev1.Container, placeScripts *bool, namePrefix string) []corev1.Container {
 	containers := []corev1.Container{}
 	for i, s := range steps {
@@ -102,28 +140,20 @@ func convertListOfSteps(steps []v1beta1.Step, initContainer *corev1.Container, p
 		if !hasShebang {
 			script = defaultScriptPreamble + s.Script
 		}
+		// Terminate the last line of the script, which some interpreters
+		// require.
+		if !strings.HasSuffix(script, "\n") {
+			script += "\n"
+		}
 
 		// At least one step uses a script, so we should return a
 		// non-nil init container.
 		*placeScripts = true
 
-		// Append to the place-scripts script to place the
-		// script file in a known location in the scripts volume.
+		// Append to the place-scripts args to place the script file
+		// in a known location in the scripts volume.
 		tmpFile := filepath.Join(scriptsDir, names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%d", namePrefix, i)))
-		// heredoc is the "here document" placeholder string
-		// used to cat script contents into the file. Typically
-		// this is the string "EOF" but if this value were
-		// "EOF" it would prevent users from including the
-		// string "EOF" in their own scripts. Instead we
-		// randomly generate a string to (hopefully) prevent
-		// collisions.
-		heredoc := names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-heredoc-randomly-generated", namePrefix))
-		initContainer.Args[1] += fmt.Sprintf(`tmpfile="%s"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << '%s'
-%s
-%s
-`, tmpFile, heredoc, script, heredoc)
+		initContainer.Args = append(initContainer.Args, scriptArg(tmpFile, script))
 
 		// Set the command to execute the correct script in the mounted
 		// volume.
@@ -17,6 +17,8 @@ limitations under the License.
 package pod
 
 import (
+	"encoding/base64"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -27,7 +29,7 @@ import (
 )
 
 func TestConvertScripts_NothingToConvert_EmptySidecars(t *testing.T) {
-	gotInit, gotScripts, gotSidecars := convertScripts(images.ShellImage, []v1beta1.Step{{
+	gotInit, gotScripts, gotSidecars, err := convertScripts(images.EntrypointImage, []v1beta1.Step{{
 		Container: corev1.Container{
 			Image: "step-1",
 		},
@@ -36,6 +38,9 @@ func TestConvertScripts_NothingToConvert_EmptySidecars(t *testing.T) {
 			Image: "step-2",
 		},
 	}}, []v1beta1.Sidecar{})
+	if err != nil {
+		t.Fatalf("convertScripts: %v", err)
+	}
 	want := []corev1.Container{{
 		Image: "step-1",
 	}, {
@@ -54,7 +59,7 @@ func TestConvertScripts_NothingToConvert_EmptySidecars(t *testing.T) {
 }
 
 func TestConvertScripts_NothingToConvert_NilSidecars(t *testing.T) {
-	gotInit, gotScripts, gotSidecars := convertScripts(images.ShellImage, []v1beta1.Step{{
+	gotInit, gotScripts, gotSidecars, err := convertScripts(images.EntrypointImage, []v1beta1.Step{{
 		Container: corev1.Container{
 			Image: "step-1",
 		},
@@ -63,6 +68,9 @@ func TestConvertScripts_NothingToConvert_NilSidecars(t *testing.T) {
 			Image: "step-2",
 		},
 	}}, nil)
+	if err != nil {
+		t.Fatalf("convertScripts: %v", err)
+	}
 	want := []corev1.Container{{
 		Image: "step-1",
 	}, {
@@ -81,7 +89,7 @@ func TestConvertScripts_NothingToConvert_NilSidecars(t *testing.T) {
 }
 
 func TestConvertScripts_NothingToConvert_WithSidecar(t *testing.T) {
-	gotInit, gotScripts, gotSidecars := convertScripts(images.ShellImage, []v1beta1.Step{{
+	gotInit, gotScripts, gotSidecars, err := convertScripts(images.EntrypointImage, []v1beta1.Step{{
 		Container: corev1.Container{
 			Image: "step-1",
 		},
@@ -94,6 +102,9 @@ func TestConvertScripts_NothingToConvert_WithSidecar(t *testing.T) {
 			Image: "sidecar-1",
 		},
 	}})
+	if err != nil {
+		t.Fatalf("convertScripts: %v", err)
+	}
 	want := []corev1.Container{{
 		Image: "step-1",
 	}, {
@@ -130,7 +141,7 @@ func TestConvertScripts(t *testing.T) {
 		MountPath: "/another/one",
 	}}
 
-	gotInit, gotSteps, gotSidecars := convertScripts(images.ShellImage, []v1beta1.Step{{
+	gotInit, gotSteps, gotSidecars, err := convertScripts(images.EntrypointImage, []v1beta1.Step{{
 		Script: `#!/bin/sh
 script-1`,
 		Container: corev1.Container{Image: "step-1"},
@@ -154,31 +165,26 @@ script-3`,
 			Args:         []string{"my", "args"},
 		},
 	}}, []v1beta1.Sidecar{})
+	if err != nil {
+		t.Fatalf("convertScripts: %v", err)
+	}
 	wantInit := &corev1.Container{
 		Name:    "place-scripts",
-		Image:   images.ShellImage,
-		Command: []string{"sh"},
-		Args: []string{"-c", `tmpfile="/tekton/scripts/script-0-9l9zj"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'script-heredoc-randomly-generated-mz4c7'
-#!/bin/sh
+		Image:   images.EntrypointImage,
+		Command: []string{"/ko-app/entrypoint", "decode-scripts"},
+		Args: []string{
+			"/tekton/scripts/script-0-9l9zj=" + base64.StdEncoding.EncodeToString([]byte(`#!/bin/sh
 script-1
-script-heredoc-randomly-generated-mz4c7
-tmpfile="/tekton/scripts/script-2-mssqb"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'script-heredoc-randomly-generated-78c5n'
-
+`)),
+			"/tekton/scripts/script-2-mz4c7=" + base64.StdEncoding.EncodeToString([]byte(`
 #!/bin/sh
 script-3
-script-heredoc-randomly-generated-78c5n
-tmpfile="/tekton/scripts/script-3-6nl7g"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'script-heredoc-randomly-generated-j2tds'
-#!/bin/sh
+`)),
+			"/tekton/scripts/script-3-mssqb=" + base64.StdEncoding.EncodeToString([]byte(`#!/bin/sh
 set -xe
 no-shebang
-script-heredoc-randomly-generated-j2tds
-`},
+`)),
+		},
 		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
 	}
 	want := []corev1.Container{{
@@ -189,12 +195,12 @@ script-heredoc-randomly-generated-j2tds
 		Image: "step-2",
 	}, {
 		Image:        "step-3",
-		Command:      []string{"/tekton/scripts/script-2-mssqb"},
+		Command:      []string{"/tekton/scripts/script-2-mz4c7"},
 		Args:         []string{"my", "args"},
 		VolumeMounts: append(preExistingVolumeMounts, scriptsVolumeMount),
 	}, {
 		Image:   "step-3",
-		Command: []string{"/tekton/scripts/script-3-6nl7g"},
+		Command: []string{"/tekton/scripts/script-3-mssqb"},
 		Args:    []string{"my", "args"},
 		VolumeMounts: []corev1.VolumeMount{
 			{Name: "pre-existing-volume-mount", MountPath: "/mount/path"},
@@ -225,7 +231,7 @@ func TestConvertScripts_WithSidecar(t *testing.T) {
 		MountPath: "/another/one",
 	}}
 
-	gotInit, gotSteps, gotSidecars := convertScripts(images.ShellImage, []v1beta1.Step{{
+	gotInit, gotSteps, gotSidecars, err := convertScripts(images.EntrypointImage, []v1beta1.Step{{
 		Script: `#!/bin/sh
 script-1`,
 		Container: corev1.Container{Image: "step-1"},
@@ -245,29 +251,24 @@ script-3`,
 sidecar-1`,
 		Container: corev1.Container{Image: "sidecar-1"},
 	}})
+	if err != nil {
+		t.Fatalf("convertScripts: %v", err)
+	}
 	wantInit := &corev1.Container{
 		Name:    "place-scripts",
-		Image:   images.ShellImage,
-		Command: []string{"sh"},
-		Args: []string{"-c", `tmpfile="/tekton/scripts/script-0-9l9zj"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'script-heredoc-randomly-generated-mz4c7'
-#!/bin/sh
+		Image:   images.EntrypointImage,
+		Command: []string{"/ko-app/entrypoint", "decode-scripts"},
+		Args: []string{
+			"/tekton/scripts/script-0-9l9zj=" + base64.StdEncoding.EncodeToString([]byte(`#!/bin/sh
 script-1
-script-heredoc-randomly-generated-mz4c7
-tmpfile="/tekton/scripts/script-2-mssqb"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'script-heredoc-randomly-generated-78c5n'
-#!/bin/sh
+`)),
+			"/tekton/scripts/script-2-mz4c7=" + base64.StdEncoding.EncodeToString([]byte(`#!/bin/sh
 script-3
-script-heredoc-randomly-generated-78c5n
-tmpfile="/tekton/scripts/sidecar-script-0-6nl7g"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'sidecar-script-heredoc-randomly-generated-j2tds'
-#!/bin/sh
+`)),
+			"/tekton/scripts/sidecar-script-0-mssqb=" + base64.StdEncoding.EncodeToString([]byte(`#!/bin/sh
 sidecar-1
-sidecar-script-heredoc-randomly-generated-j2tds
-`},
+`)),
+		},
 		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
 	}
 	want := []corev1.Container{{
@@ -278,7 +279,7 @@ sidecar-script-heredoc-randomly-generated-j2tds
 		Image: "step-2",
 	}, {
 		Image:   "step-3",
-		Command: []string{"/tekton/scripts/script-2-mssqb"},
+		Command: []string{"/tekton/scripts/script-2-mz4c7"},
 		Args:    []string{"my", "args"},
 		VolumeMounts: []corev1.VolumeMount{
 			{Name: "pre-existing-volume-mount", MountPath: "/mount/path"},
@@ -289,7 +290,7 @@ sidecar-script-heredoc-randomly-generated-j2tds
 
 	wantSidecars := []corev1.Container{{
 		Image:        "sidecar-1",
-		Command:      []string{"/tekton/scripts/sidecar-script-0-6nl7g"},
+		Command:      []string{"/tekton/scripts/sidecar-script-0-mssqb"},
 		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
 	}}
 	if d := cmp.Diff(wantInit, gotInit); d != "" {
@@ -307,3 +308,30 @@ sidecar-script-heredoc-randomly-generated-j2tds
 	}
 
 }
+
+func TestConvertScripts_ScriptTooLarge(t *testing.T) {
+	names.TestingSeed()
+
+	for _, tc := range []struct {
+		name    string
+		script  string
+		wantErr bool
+	}{{
+		name:   "script under the limit",
+		script: strings.Repeat("a", 90*1024),
+	}, {
+		name:    "script over the limit",
+		script:  strings.Repeat("a", 100*1024),
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, _, err := convertScripts(images.EntrypointImage, []v1beta1.Step{{
+				Script:    tc.script,
+				Container: corev1.Container{Image: "step-1"},
+			}}, nil)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("convertScripts() error = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
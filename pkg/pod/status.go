@@ -19,6 +19,8 @@ package pod
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,6 +29,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/termination"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -40,6 +43,10 @@ const (
 	// that references within the TaskRun could not be resolved
 	ReasonFailedResolution = "TaskRunResolutionFailed"
 
+	// ReasonFailedRemoteResolution indicated that the reason for failure status is
+	// that the Task referenced by the TaskRun could not be fetched by its resolver
+	ReasonFailedRemoteResolution = "TaskRunRemoteResolutionFailed"
+
 	// ReasonFailedValidation indicated that the reason for failure status is
 	// that taskrun failed runtime validation
 	ReasonFailedValidation = "TaskRunValidationFailed"
@@ -64,11 +71,28 @@ const (
 	// ReasonExceededNodeResources or IsPodHitConfigError
 	ReasonPending = "Pending"
 
+	// ReasonFailedSidecarLogResults indicates that the results of the TaskRun could
+	// not be read from the logs of the results sidecar
+	ReasonFailedSidecarLogResults = "TaskRunSidecarLogResultsFailed"
+
+	// ReasonStepFailedContinued indicates that a step exited with a non-zero exit code
+	// but the rest of the steps were run because the step sets onError to continue
+	ReasonStepFailedContinued = "FailedContinued"
+
+	// ReasonPodEvicted indicates that the TaskRun failed because its pod was
+	// evicted from the node, e.g. because the node ran out of resources
+	ReasonPodEvicted = "PodEvicted"
+
 	//timeFormat is RFC3339 with millisecond
 	timeFormat = "2006-01-02T15:04:05.000Z07:00"
 )
 
-const oomKilled = "OOMKilled"
+const (
+	oomKilled        = "OOMKilled"
+	evicted          = "Evicted"
+	errImagePull     = "ErrImagePull"
+	imagePullBackOff = "ImagePullBackOff"
+)
 
 // SidecarsReady returns true if all of the Pod's sidecars are Ready or
 // Terminated.
@@ -96,7 +120,9 @@ func SidecarsReady(podStatus corev1.PodStatus) bool {
 }
 
 // MakeTaskRunStatus returns a TaskRunStatus based on the Pod's status.
-func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev1.Pod) (v1beta1.TaskRunStatus, error) {
+// sidecarLogResults are the results read from the logs of the results
+// sidecar, if the Pod has one; they are ignored otherwise.
+func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev1.Pod, sidecarLogResults []v1beta1.PipelineResourceResult) (v1beta1.TaskRunStatus, error) {
 	trs := &tr.Status
 	if trs.GetCondition(apis.ConditionSucceeded) == nil || trs.GetCondition(apis.ConditionSucceeded).Status == corev1.ConditionUnknown {
 		// If the taskRunStatus doesn't exist yet, it's because we just started running
@@ -106,6 +132,11 @@ func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev
 	sortPodContainerStatuses(pod.Status.ContainerStatuses, pod.Spec.Containers)
 
 	complete := areStepsComplete(pod) || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+	if complete && pod.Status.Phase == corev1.PodRunning && isResultsSidecarPending(pod) {
+		// The results are not available until the results sidecar is done
+		// printing them.
+		complete = false
+	}
 
 	if complete {
 		updateCompletedTaskRunStatus(logger, trs, pod)
@@ -128,22 +159,34 @@ func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev
 	}
 
 	var merr *multierror.Error
-	if err := setTaskRunStatusBasedOnStepStatus(logger, stepStatuses, &tr); err != nil {
+	if err := setTaskRunStatusBasedOnStepStatus(logger, stepStatuses, &tr, hermeticSteps(&tr, pod)); err != nil {
 		merr = multierror.Append(merr, err)
 	}
 
 	setTaskRunStatusBasedOnSidecarStatus(sidecarStatuses, trs)
 
+	if tr.IsSuccessful() && IsResultsSidecarTerminated(pod) {
+		taskResults, _, _, err := filterResultsAndResources(sidecarLogResults, taskResultTypes(trs))
+		if err != nil {
+			merr = multierror.Append(merr, err)
+		}
+		trs.TaskRunResults = append(trs.TaskRunResults, taskResults...)
+	}
+
 	trs.TaskRunResults = removeDuplicateResults(trs.TaskRunResults)
 
 	return *trs, merr.ErrorOrNil()
 }
 
-func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses []corev1.ContainerStatus, tr *v1beta1.TaskRun) *multierror.Error {
+func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses []corev1.ContainerStatus, tr *v1beta1.TaskRun, hermetic map[string]bool) *multierror.Error {
 	trs := &tr.Status
 	var merr *multierror.Error
+	resultTypes := taskResultTypes(trs)
 
 	for _, s := range stepStatuses {
+		var exitCode *int32
+		var stepResults []v1beta1.TaskRunResult
+		var resourceUsage *v1beta1.StepResourceUsage
 		if s.State.Terminated != nil && len(s.State.Terminated.Message) != 0 {
 			msg := s.State.Terminated.Message
 
@@ -157,7 +200,22 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 					logger.Errorf("error setting the start time of step %q in taskrun %q: %v", s.Name, tr.Name, err)
 					merr = multierror.Append(merr, err)
 				}
-				taskResults, pipelineResourceResults, filteredResults := filterResultsAndResources(results)
+				exitCode, err = extractExitCodeFromResults(results)
+				if err != nil {
+					logger.Errorf("error extracting the exit code of step %q in taskrun %q: %v", s.Name, tr.Name, err)
+					merr = multierror.Append(merr, err)
+				}
+				resourceUsage, err = extractResourceUsageFromResults(results)
+				if err != nil {
+					logger.Errorf("error extracting the resource usage of step %q in taskrun %q: %v", s.Name, tr.Name, err)
+					merr = multierror.Append(merr, err)
+				}
+				stepResults = extractStepResultsFromResults(results)
+				taskResults, pipelineResourceResults, filteredResults, err := filterResultsAndResources(results, resultTypes)
+				if err != nil {
+					logger.Errorf("error extracting the results of step %q in taskrun %q: %v", s.Name, tr.Name, err)
+					merr = multierror.Append(merr, err)
+				}
 				if tr.IsSuccessful() {
 					trs.TaskRunResults = append(trs.TaskRunResults, taskResults...)
 					trs.ResourcesResult = append(trs.ResourcesResult, pipelineResourceResults...)
@@ -174,11 +232,21 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 				}
 			}
 		}
+		state := s.State.DeepCopy()
+		if exitCode != nil && *exitCode != 0 {
+			// The container itself succeeded, but the step failed and was
+			// allowed to continue, so report the step's own exit code.
+			state.Terminated.ExitCode = *exitCode
+			state.Terminated.Reason = ReasonStepFailedContinued
+		}
 		trs.Steps = append(trs.Steps, v1beta1.StepState{
-			ContainerState: *s.State.DeepCopy(),
+			ContainerState: *state,
 			Name:           trimStepPrefix(s.Name),
 			ContainerName:  s.Name,
 			ImageID:        s.ImageID,
+			Hermetic:       hermetic[s.Name] && (s.State.Running != nil || s.State.Terminated != nil),
+			Results:        stepResults,
+			ResourceUsage:  resourceUsage,
 		})
 	}
 
@@ -208,22 +276,68 @@ func createMessageFromResults(results []v1beta1.PipelineResourceResult) (string,
 	return string(bytes), nil
 }
 
-func filterResultsAndResources(results []v1beta1.PipelineResourceResult) ([]v1beta1.TaskRunResult, []v1beta1.PipelineResourceResult, []v1beta1.PipelineResourceResult) {
+// taskResultTypes returns the types of the results declared by the Task, by
+// name. The results which are not declared are strings.
+func taskResultTypes(trs *v1beta1.TaskRunStatus) map[string]v1beta1.ResultsType {
+	resultTypes := map[string]v1beta1.ResultsType{}
+	if trs.TaskSpec == nil {
+		return resultTypes
+	}
+	for _, r := range trs.TaskSpec.Results {
+		if r.Type != "" {
+			resultTypes[r.Name] = r.Type
+		}
+	}
+	return resultTypes
+}
+
+// taskRunResultValue parses the value of a Task result of the given type, as
+// written in the termination message or the logs of the results sidecar.
+func taskRunResultValue(value string, resultType v1beta1.ResultsType) (v1beta1.ArrayOrString, error) {
+	switch resultType {
+	case v1beta1.ResultsTypeArray:
+		var arrayVal []string
+		if err := json.Unmarshal([]byte(value), &arrayVal); err != nil {
+			return v1beta1.ArrayOrString{}, err
+		}
+		return v1beta1.ArrayOrString{Type: v1beta1.ParamTypeArray, ArrayVal: arrayVal}, nil
+	case v1beta1.ResultsTypeObject:
+		var objectVal map[string]string
+		if err := json.Unmarshal([]byte(value), &objectVal); err != nil {
+			return v1beta1.ArrayOrString{}, err
+		}
+		return *v1beta1.NewObject(objectVal), nil
+	default:
+		return *v1beta1.NewArrayOrString(value), nil
+	}
+}
+
+func filterResultsAndResources(results []v1beta1.PipelineResourceResult, resultTypes map[string]v1beta1.ResultsType) ([]v1beta1.TaskRunResult, []v1beta1.PipelineResourceResult, []v1beta1.PipelineResourceResult, error) {
 	var taskResults []v1beta1.TaskRunResult
 	var pipelineResourceResults []v1beta1.PipelineResourceResult
 	var filteredResults []v1beta1.PipelineResourceResult
+	var merr *multierror.Error
 	for _, r := range results {
 		switch r.ResultType {
 		case v1beta1.TaskRunResultType:
+			value, err := taskRunResultValue(r.Value, resultTypes[r.Key])
+			if err != nil {
+				merr = multierror.Append(merr, fmt.Errorf("invalid value of %s result %q: %w", resultTypes[r.Key], r.Key, err))
+				continue
+			}
 			taskRunResult := v1beta1.TaskRunResult{
 				Name:  r.Key,
-				Value: r.Value,
+				Type:  resultTypes[r.Key],
+				Value: value,
 			}
 			taskResults = append(taskResults, taskRunResult)
 			filteredResults = append(filteredResults, r)
 		case v1beta1.InternalTektonResultType:
 			// Internal messages are ignored because they're not used as external result
 			continue
+		case v1beta1.StepResultType:
+			// The results of a step are only reported in the state of the step.
+			filteredResults = append(filteredResults, r)
 		case v1beta1.PipelineResourceResultType:
 			fallthrough
 		default:
@@ -232,7 +346,7 @@ func filterResultsAndResources(results []v1beta1.PipelineResourceResult) ([]v1be
 		}
 	}
 
-	return taskResults, pipelineResourceResults, filteredResults
+	return taskResults, pipelineResourceResults, filteredResults, merr.ErrorOrNil()
 }
 
 func removeDuplicateResults(taskRunResult []v1beta1.TaskRunResult) []v1beta1.TaskRunResult {
@@ -268,10 +382,117 @@ func extractStartedAtTimeFromResults(results []v1beta1.PipelineResourceResult) (
 	return nil, nil
 }
 
+func extractExitCodeFromResults(results []v1beta1.PipelineResourceResult) (*int32, error) {
+	for _, result := range results {
+		if result.Key == "ExitCode" {
+			// We could just pass the string through but this provides extra validation
+			i, err := strconv.ParseInt(result.Value, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse int value %q in ExitCode field: %w", result.Value, err)
+			}
+			exitCode := int32(i)
+			return &exitCode, nil
+		}
+	}
+	return nil, nil
+}
+
+// hermeticSteps returns the names of the step containers of the Pod which the
+// entrypoint runs without network access. It is derived from the TaskRun and
+// the Pod specs, never from the termination messages, which the steps write.
+func hermeticSteps(tr *v1beta1.TaskRun, pod *corev1.Pod) map[string]bool {
+	steps := map[string]bool{}
+	if !tr.IsHermetic() {
+		return steps
+	}
+	for _, c := range pod.Spec.Containers {
+		if !IsContainerStep(c.Name) {
+			continue
+		}
+		// The flags of the entrypoint all come before the command of the step.
+		for _, arg := range c.Args {
+			if arg == "-entrypoint" {
+				break
+			}
+			if arg == dropNetworkingFlag {
+				steps[c.Name] = true
+				break
+			}
+		}
+	}
+	return steps
+}
+
+// extractResourceUsageFromResults returns the resource usage of the step
+// reported by the entrypoint, or nil if it was not reported.
+func extractResourceUsageFromResults(results []v1beta1.PipelineResourceResult) (*v1beta1.StepResourceUsage, error) {
+	usage := &v1beta1.StepResourceUsage{}
+	reported := false
+	for _, result := range results {
+		if result.ResultType != v1beta1.InternalTektonResultType {
+			continue
+		}
+		var d *metav1.Duration
+		switch result.Key {
+		case "MaxRSS":
+			maxRSS, err := strconv.ParseInt(result.Value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse int value %q in MaxRSS field: %w", result.Value, err)
+			}
+			usage.MaxRSS = *resource.NewQuantity(maxRSS, resource.BinarySI)
+			reported = true
+			continue
+		case "UserCPUTime":
+			d = &usage.UserCPUTime
+		case "SystemCPUTime":
+			d = &usage.SystemCPUTime
+		case "WallTime":
+			d = &usage.WallTime
+		default:
+			continue
+		}
+		duration, err := time.ParseDuration(result.Value)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse duration value %q in %s field: %w", result.Value, result.Key, err)
+		}
+		d.Duration = duration
+		reported = true
+	}
+	if !reported {
+		return nil, nil
+	}
+	return usage, nil
+}
+
+// extractStepResultsFromResults returns the results written by the step.
+func extractStepResultsFromResults(results []v1beta1.PipelineResourceResult) []v1beta1.TaskRunResult {
+	var stepResults []v1beta1.TaskRunResult
+	for _, result := range results {
+		if result.ResultType == v1beta1.StepResultType {
+			stepResults = append(stepResults, v1beta1.TaskRunResult{
+				Name:  result.Key,
+				Value: *v1beta1.NewArrayOrString(result.Value),
+			})
+		}
+	}
+	return stepResults
+}
+
 func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
 	if DidTaskRunFail(pod) {
 		msg := getFailureMessage(logger, pod)
-		MarkStatusFailure(trs, msg)
+		if pod.Status.Reason == evicted {
+			// Surface evictions with their own reason, so that they can be told apart
+			// from failing steps, e.g. to only retry the former
+			trs.SetCondition(&apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  ReasonPodEvicted,
+				Message: msg,
+			})
+		} else {
+			MarkStatusFailure(trs, msg)
+		}
 	} else {
 		MarkStatusSuccess(trs)
 	}
@@ -283,6 +504,10 @@ func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1beta1.TaskRu
 func updateIncompleteTaskRunStatus(trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
 	switch pod.Status.Phase {
 	case corev1.PodRunning:
+		if step, paused := pausedStep(pod); paused {
+			MarkStatusRunning(trs, v1beta1.TaskRunReasonPausedAtBreakpoint.String(), fmt.Sprintf("Step %q failed and is paused at a breakpoint: run %s or %s in its container to resume it", step, filepath.Join(debugScriptsDir, "debug-continue"), filepath.Join(debugScriptsDir, "debug-fail-continue")))
+			return
+		}
 		MarkStatusRunning(trs, v1beta1.TaskRunReasonRunning.String(), "Not all Steps in the Task have finished executing")
 	case corev1.PodPending:
 		var reason, msg string
@@ -389,6 +614,21 @@ func IsPodHitConfigError(pod *corev1.Pod) bool {
 	return false
 }
 
+// ImagePullFailure returns a message describing the failure and true if the
+// image of a step of the pod couldn't be pulled.
+func ImagePullFailure(pod *corev1.Pod) (string, bool) {
+	for _, s := range pod.Status.ContainerStatuses {
+		if !IsContainerStep(s.Name) || s.State.Waiting == nil {
+			continue
+		}
+		switch s.State.Waiting.Reason {
+		case errImagePull, imagePullBackOff:
+			return fmt.Sprintf("the image %q of step %q couldn't be pulled: %s", s.Image, trimStepPrefix(s.Name), s.State.Waiting.Message), true
+		}
+	}
+	return "", false
+}
+
 func getWaitingMessage(pod *corev1.Pod) string {
 	// First, try to surface reason for pending/unknown about the actual build step.
 	for _, status := range pod.Status.ContainerStatuses {
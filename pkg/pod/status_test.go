@@ -25,6 +25,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
@@ -34,11 +35,20 @@ import (
 var ignoreVolatileTime = cmp.Comparer(func(_, _ apis.VolatileTime) bool { return true })
 
 func TestMakeTaskRunStatus(t *testing.T) {
+	hermeticContainers := []corev1.Container{{
+		Name: "step-git-source",
+		Args: []string{"-post_file", "/tekton/tools/0", "-entrypoint", "git", "--"},
+	}, {
+		Name: "step-build",
+		Args: []string{"-wait_file", "/tekton/tools/0", "-drop_networking", "-entrypoint", "make", "--"},
+	}}
 	for _, c := range []struct {
-		desc      string
-		podStatus corev1.PodStatus
-		pod       corev1.Pod
-		want      v1beta1.TaskRunStatus
+		desc          string
+		podStatus     corev1.PodStatus
+		pod           corev1.Pod
+		podSpec       corev1.PodSpec
+		executionMode v1beta1.TaskRunExecutionMode
+		want          v1beta1.TaskRunStatus
 	}{{
 		desc:      "empty",
 		podStatus: corev1.PodStatus{},
@@ -222,6 +232,29 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "pod evicted",
+		podStatus: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "Evicted",
+			Message: "The node was low on resource: memory.",
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{{
+					Type:    apis.ConditionSucceeded,
+					Status:  corev1.ConditionFalse,
+					Reason:  ReasonPodEvicted,
+					Message: "The node was low on resource: memory.",
+				}},
+			},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps:    []v1beta1.StepState{},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
 	}, {
 		desc: "failed with OOM",
 		podStatus: corev1.PodStatus{
@@ -573,7 +606,7 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				}},
 				TaskRunResults: []v1beta1.TaskRunResult{{
 					Name:  "resultName",
-					Value: "resultValue",
+					Value: *v1beta1.NewArrayOrString("resultValue"),
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -611,7 +644,7 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				}},
 				TaskRunResults: []v1beta1.TaskRunResult{{
 					Name:  "resultName",
-					Value: "resultValue",
+					Value: *v1beta1.NewArrayOrString("resultValue"),
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -658,10 +691,10 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				Sidecars: []v1beta1.SidecarState{},
 				TaskRunResults: []v1beta1.TaskRunResult{{
 					Name:  "resultNameOne",
-					Value: "resultValueThree",
+					Value: *v1beta1.NewArrayOrString("resultValueThree"),
 				}, {
 					Name:  "resultNameTwo",
-					Value: "resultValueTwo",
+					Value: *v1beta1.NewArrayOrString("resultValueTwo"),
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -751,8 +784,214 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				}},
 				TaskRunResults: []v1beta1.TaskRunResult{{
 					Name:  "resultNameThree",
-					Value: "",
+					Value: *v1beta1.NewArrayOrString(""),
+				}},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "step failed but continued",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-lint",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Reason:  "Completed",
+						Message: `[{"key":"ExitCode","value":"11","type":"InternalTektonResult"}]`,
+					},
+				},
+			}, {
+				Name: "step-report",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Reason: "Completed",
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 11,
+							Reason:   ReasonStepFailedContinued,
+						}},
+					Name:          "lint",
+					ContainerName: "step-lint",
+				}, {
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason: "Completed",
+						}},
+					Name:          "report",
+					ContainerName: "step-report",
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc:          "hermetic step",
+		podSpec:       corev1.PodSpec{Containers: hermeticContainers},
+		executionMode: v1beta1.TaskRunExecutionModeHermetic,
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-git-source",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Reason: "Completed",
+					},
+				},
+			}, {
+				Name: "step-build",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Reason: "Completed",
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason: "Completed",
+						}},
+					Name:          "git-source",
+					ContainerName: "step-git-source",
+				}, {
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason: "Completed",
+						}},
+					Name:          "build",
+					ContainerName: "step-build",
+					Hermetic:      true,
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "hermetic key of the termination message is ignored",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-git-source",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Reason: "Completed",
+					},
+				},
+			}, {
+				Name: "step-build",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Reason:  "Completed",
+						Message: `[{"key":"Hermetic","value":"true","type":"InternalTektonResult"}]`,
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason: "Completed",
+						}},
+					Name:          "git-source",
+					ContainerName: "step-git-source",
+				}, {
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason: "Completed",
+						}},
+					Name:          "build",
+					ContainerName: "step-build",
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "step results",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-clone",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Reason:  "Completed",
+						Message: `[{"key":"commit","value":"abc123","type":"StepResult"}]`,
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason:  "Completed",
+							Message: `[{"key":"commit","value":"abc123","type":"StepResult"}]`,
+						}},
+					Name:          "clone",
+					ContainerName: "step-clone",
+					Results: []v1beta1.TaskRunResult{{
+						Name:  "commit",
+						Value: *v1beta1.NewArrayOrString("abc123"),
+					}},
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "step resource usage",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-build",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Reason:  "Completed",
+						Message: `[{"key":"MaxRSS","value":"10485760","type":"InternalTektonResult"},{"key":"UserCPUTime","value":"1.5s","type":"InternalTektonResult"},{"key":"SystemCPUTime","value":"250ms","type":"InternalTektonResult"},{"key":"WallTime","value":"3s","type":"InternalTektonResult"}]`,
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason: "Completed",
+						}},
+					Name:          "build",
+					ContainerName: "step-build",
+					ResourceUsage: &v1beta1.StepResourceUsage{
+						MaxRSS:        resource.MustParse("10Mi"),
+						UserCPUTime:   metav1.Duration{Duration: 1500 * time.Millisecond},
+						SystemCPUTime: metav1.Duration{Duration: 250 * time.Millisecond},
+						WallTime:      metav1.Duration{Duration: 3 * time.Second},
+					},
 				}},
+				Sidecars: []v1beta1.SidecarState{},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
@@ -851,6 +1090,7 @@ func TestMakeTaskRunStatus(t *testing.T) {
 						Namespace:         "foo",
 						CreationTimestamp: now,
 					},
+					Spec:   c.podSpec,
 					Status: c.podStatus,
 				}
 			}
@@ -861,6 +1101,9 @@ func TestMakeTaskRunStatus(t *testing.T) {
 					Name:      "task-run",
 					Namespace: "foo",
 				},
+				Spec: v1beta1.TaskRunSpec{
+					ExecutionMode: c.executionMode,
+				},
 				Status: v1beta1.TaskRunStatus{
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						StartTime: &metav1.Time{Time: startTime},
@@ -869,7 +1112,7 @@ func TestMakeTaskRunStatus(t *testing.T) {
 			}
 
 			logger, _ := logging.NewLogger("", "status")
-			got, err := MakeTaskRunStatus(logger, tr, &c.pod)
+			got, err := MakeTaskRunStatus(logger, tr, &c.pod, nil)
 			if err != nil {
 				t.Errorf("MakeTaskRunResult: %s", err)
 			}
@@ -894,6 +1137,233 @@ func TestMakeTaskRunStatus(t *testing.T) {
 	}
 }
 
+func TestMakeTaskRunStatusSidecarLogResults(t *testing.T) {
+	sidecarLogResults := []v1beta1.PipelineResourceResult{{
+		Key:        "sbom",
+		Value:      "large sbom",
+		ResultType: v1beta1.TaskRunResultType,
+	}}
+	for _, c := range []struct {
+		desc        string
+		sidecar     corev1.ContainerState
+		wantStatus  corev1.ConditionStatus
+		wantResults []v1beta1.TaskRunResult
+	}{{
+		desc:       "results sidecar still running",
+		sidecar:    corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		wantStatus: corev1.ConditionUnknown,
+	}, {
+		desc:       "results sidecar terminated",
+		sidecar:    corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+		wantStatus: corev1.ConditionTrue,
+		wantResults: []v1beta1.TaskRunResult{{
+			Name:  "sbom",
+			Value: *v1beta1.NewArrayOrString("large sbom"),
+		}},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod",
+					Namespace: "foo",
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: "step-build",
+					}, {
+						Name: ResultsSidecarContainerName,
+					}},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name: "step-build",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{},
+						},
+					}, {
+						Name:  ResultsSidecarContainerName,
+						State: c.sidecar,
+					}},
+				},
+			}
+			tr := v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "task-run",
+					Namespace: "foo",
+				},
+			}
+
+			logger, _ := logging.NewLogger("", "status")
+			got, err := MakeTaskRunStatus(logger, tr, pod, sidecarLogResults)
+			if err != nil {
+				t.Errorf("MakeTaskRunResult: %s", err)
+			}
+			if status := got.GetCondition(apis.ConditionSucceeded).Status; status != c.wantStatus {
+				t.Errorf("Expected TaskRun condition status %q but got %q", c.wantStatus, status)
+			}
+			if d := cmp.Diff(c.wantResults, got.TaskRunResults); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestMakeTaskRunStatusTypedResults(t *testing.T) {
+	taskSpec := &v1beta1.TaskSpec{
+		Results: []v1beta1.TaskResult{{
+			Name: "commit",
+		}, {
+			Name: "files",
+			Type: v1beta1.ResultsTypeArray,
+		}, {
+			Name: "image",
+			Type: v1beta1.ResultsTypeObject,
+		}},
+	}
+	for _, c := range []struct {
+		desc        string
+		message     string
+		wantResults []v1beta1.TaskRunResult
+		wantErr     bool
+	}{{
+		desc:    "typed results",
+		message: `[{"key":"commit","value":"abc123","type":"TaskRunResult"},{"key":"files","value":"[\"a.txt\",\"b.txt\"]","type":"TaskRunResult"},{"key":"image","value":"{\"url\":\"gcr.io/foo/bar\"}","type":"TaskRunResult"}]`,
+		wantResults: []v1beta1.TaskRunResult{{
+			Name:  "commit",
+			Value: *v1beta1.NewArrayOrString("abc123"),
+		}, {
+			Name:  "files",
+			Type:  v1beta1.ResultsTypeArray,
+			Value: *v1beta1.NewArrayOrString("a.txt", "b.txt"),
+		}, {
+			Name:  "image",
+			Type:  v1beta1.ResultsTypeObject,
+			Value: *v1beta1.NewObject(map[string]string{"url": "gcr.io/foo/bar"}),
+		}},
+	}, {
+		desc:    "array result which is not a JSON array",
+		message: `[{"key":"commit","value":"abc123","type":"TaskRunResult"},{"key":"files","value":"a.txt","type":"TaskRunResult"}]`,
+		wantResults: []v1beta1.TaskRunResult{{
+			Name:  "commit",
+			Value: *v1beta1.NewArrayOrString("abc123"),
+		}},
+		wantErr: true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod",
+					Namespace: "foo",
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodSucceeded,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name: "step-build",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								Message: c.message,
+							},
+						},
+					}},
+				},
+			}
+			tr := v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "task-run",
+					Namespace: "foo",
+				},
+				Status: v1beta1.TaskRunStatus{
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						TaskSpec: taskSpec,
+					},
+				},
+			}
+
+			logger, _ := logging.NewLogger("", "status")
+			got, err := MakeTaskRunStatus(logger, tr, pod, nil)
+			if c.wantErr && err == nil {
+				t.Error("expected an error making the TaskRun status, got none")
+			} else if !c.wantErr && err != nil {
+				t.Errorf("MakeTaskRunResult: %s", err)
+			}
+			if d := cmp.Diff(c.wantResults, got.TaskRunResults); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestMakeTaskRunStatusPausedAtBreakpoint(t *testing.T) {
+	withBreakpoint, err := addBreakpoints([]corev1.Container{{}})
+	if err != nil {
+		t.Fatalf("addBreakpoints: %v", err)
+	}
+	for _, c := range []struct {
+		desc       string
+		probe      *corev1.Probe
+		ready      bool
+		wantReason string
+	}{{
+		desc:       "step with a breakpoint is paused",
+		probe:      withBreakpoint[0].ReadinessProbe,
+		ready:      true,
+		wantReason: v1beta1.TaskRunReasonPausedAtBreakpoint.String(),
+	}, {
+		desc:       "step with a breakpoint is not paused",
+		probe:      withBreakpoint[0].ReadinessProbe,
+		wantReason: v1beta1.TaskRunReasonRunning.String(),
+	}, {
+		desc:       "ready step without a breakpoint",
+		ready:      true,
+		wantReason: v1beta1.TaskRunReasonRunning.String(),
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod",
+					Namespace: "foo",
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:           "step-build",
+						ReadinessProbe: c.probe,
+					}},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:  "step-build",
+						Ready: c.ready,
+						State: corev1.ContainerState{
+							Running: &corev1.ContainerStateRunning{},
+						},
+					}},
+				},
+			}
+			tr := v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "task-run",
+					Namespace: "foo",
+				},
+			}
+
+			logger, _ := logging.NewLogger("", "status")
+			got, err := MakeTaskRunStatus(logger, tr, pod, nil)
+			if err != nil {
+				t.Errorf("MakeTaskRunResult: %s", err)
+			}
+			condition := got.GetCondition(apis.ConditionSucceeded)
+			if condition.Status != corev1.ConditionUnknown {
+				t.Errorf("Expected TaskRun condition status %q but got %q", corev1.ConditionUnknown, condition.Status)
+			}
+			if condition.Reason != c.wantReason {
+				t.Errorf("Expected TaskRun condition reason %q but got %q", c.wantReason, condition.Reason)
+			}
+		})
+	}
+}
+
 func TestMakeRunStatusJSONError(t *testing.T) {
 
 	pod := &corev1.Pod{
@@ -989,7 +1459,7 @@ func TestMakeRunStatusJSONError(t *testing.T) {
 	}
 
 	logger, _ := logging.NewLogger("", "status")
-	gotTr, err := MakeTaskRunStatus(logger, tr, pod)
+	gotTr, err := MakeTaskRunStatus(logger, tr, pod, nil)
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -1096,6 +1566,42 @@ func TestSidecarsReady(t *testing.T) {
 	}
 }
 
+func TestImagePullFailure(t *testing.T) {
+	waiting := func(name, reason string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:  name,
+			Image: "image",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: "not found"}},
+		}
+	}
+	for _, c := range []struct {
+		desc     string
+		statuses []corev1.ContainerStatus
+		want     string
+	}{{
+		desc:     "step initializing",
+		statuses: []corev1.ContainerStatus{waiting("step-build", "PodInitializing")},
+	}, {
+		desc:     "step failing to pull its image",
+		statuses: []corev1.ContainerStatus{waiting("step-build", "ErrImagePull")},
+		want:     `the image "image" of step "build" couldn't be pulled: not found`,
+	}, {
+		desc:     "step backing off pulling its image",
+		statuses: []corev1.ContainerStatus{waiting("step-ignore-me", "PodInitializing"), waiting("step-build", "ImagePullBackOff")},
+		want:     `the image "image" of step "build" couldn't be pulled: not found`,
+	}, {
+		desc:     "sidecar failing to pull its image",
+		statuses: []corev1.ContainerStatus{waiting("sidecar-registry", "ImagePullBackOff")},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got, failed := ImagePullFailure(&corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: c.statuses}})
+			if failed != (c.want != "") || got != c.want {
+				t.Errorf("ImagePullFailure got (%q, %t), want %q", got, failed, c.want)
+			}
+		})
+	}
+}
+
 func TestMarkStatusRunning(t *testing.T) {
 	trs := v1beta1.TaskRunStatus{}
 	MarkStatusRunning(&trs, v1beta1.TaskRunReasonRunning.String(), "Not all Steps in the Task have finished executing")
@@ -57,15 +57,15 @@ func TestWorkingDirInit(t *testing.T) {
 		}},
 		want: &corev1.Container{
 			Name:         "working-dir-initializer",
-			Image:        images.ShellImage,
-			Command:      []string{"sh"},
-			Args:         []string{"-c", "mkdir -p /workspace/bbb aaa zzz"},
+			Image:        images.EntrypointImage,
+			Command:      []string{"/ko-app/entrypoint", "mkdirs"},
+			Args:         []string{"/workspace/bbb", "aaa", "zzz"},
 			WorkingDir:   pipeline.WorkspaceDir,
 			VolumeMounts: implicitVolumeMounts,
 		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
-			got := workingDirInit(images.ShellImage, c.stepContainers)
+			got := workingDirInit(images.EntrypointImage, c.stepContainers)
 			if d := cmp.Diff(c.want, got); d != "" {
 				t.Fatalf("Diff %s", diff.PrintWantGot(d))
 			}
@@ -27,6 +27,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	"go.uber.org/zap"
 	jsonpatch "gomodules.xyz/jsonpatch/v2"
 	corev1 "k8s.io/api/core/v1"
@@ -35,7 +36,7 @@ import (
 	"knative.dev/pkg/apis"
 )
 
-var cancelTaskRunPatchBytes, cancelRunPatchBytes []byte
+var cancelTaskRunPatchBytes, cancelRunPatchBytes, cancelPipelineRunPatchBytes []byte
 
 func init() {
 	var err error
@@ -55,15 +56,27 @@ func init() {
 	if err != nil {
 		log.Fatalf("failed to marshal Run cancel patch bytes: %v", err)
 	}
+	cancelPipelineRunPatchBytes, err = json.Marshal([]jsonpatch.JsonPatchOperation{{
+		Operation: "add",
+		Path:      "/spec/status",
+		Value:     v1beta1.PipelineRunSpecStatusCancelled,
+	}})
+	if err != nil {
+		log.Fatalf("failed to marshal PipelineRun cancel patch bytes: %v", err)
+	}
 }
 
-// cancelPipelineRun marks the PipelineRun as cancelled and any resolved TaskRun(s) too.
+// cancelPipelineRun marks the PipelineRun as cancelled and any resolved TaskRun(s), Run(s) and child PipelineRun(s) too.
 func cancelPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clientSet clientset.Interface) error {
 	errs := []string{}
 
 	// Loop over the TaskRuns in the PipelineRun status.
 	// If a TaskRun is not in the status yet we should not cancel it anyways.
-	for taskRunName := range pr.Status.TaskRuns {
+	for taskRunName, prtrs := range pr.Status.TaskRuns {
+		if prtrs != nil && prtrs.ReusedFrom != "" {
+			// reused TaskRuns belong to the PipelineRun this one resumed from and have completed
+			continue
+		}
 		logger.Infof("cancelling TaskRun %s", taskRunName)
 
 		if _, err := clientSet.TektonV1beta1().TaskRuns(pr.Namespace).Patch(ctx, taskRunName, types.JSONPatchType, cancelTaskRunPatchBytes, metav1.PatchOptions{}, ""); err != nil {
@@ -80,7 +93,17 @@ func cancelPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1bet
 			continue
 		}
 	}
-	// If we successfully cancelled all the TaskRuns and Runs, we can consider the PipelineRun cancelled.
+	// Loop over the child PipelineRuns in the PipelineRun status.
+	// Cancelling a child PipelineRun cascades to its own TaskRuns, Runs and PipelineRuns.
+	for pipelineRunName := range pr.Status.PipelineRuns {
+		logger.Infof("cancelling PipelineRun %s", pipelineRunName)
+
+		if _, err := clientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Patch(ctx, pipelineRunName, types.JSONPatchType, cancelPipelineRunPatchBytes, metav1.PatchOptions{}, ""); err != nil {
+			errs = append(errs, fmt.Errorf("Failed to patch PipelineRun `%s` with cancellation: %s", pipelineRunName, err).Error())
+			continue
+		}
+	}
+	// If we successfully cancelled all the TaskRuns, Runs and PipelineRuns, we can consider the PipelineRun cancelled.
 	if len(errs) == 0 {
 		pr.Status.SetCondition(&apis.Condition{
 			Type:    apis.ConditionSucceeded,
@@ -103,3 +126,47 @@ func cancelPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1bet
 	}
 	return nil
 }
+
+// cancelPipelineTasks cancels the TaskRun(s), Run or child PipelineRun of each of the given
+// PipelineTasks which is still running, without cancelling the PipelineRun itself.
+func cancelPipelineTasks(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clientSet clientset.Interface, rprts resources.PipelineRunState) error {
+	errs := []string{}
+	for _, rprt := range rprts {
+		switch {
+		case rprt.IsCustomTask():
+			if rprt.Run == nil || rprt.Run.IsDone() {
+				continue
+			}
+			logger.Infof("cancelling Run %s", rprt.RunName)
+			if _, err := clientSet.TektonV1alpha1().Runs(pr.Namespace).Patch(ctx, rprt.RunName, types.JSONPatchType, cancelRunPatchBytes, metav1.PatchOptions{}, ""); err != nil {
+				errs = append(errs, fmt.Errorf("Failed to patch Run `%s` with cancellation: %s", rprt.RunName, err).Error())
+			}
+		case rprt.IsChildPipeline():
+			if rprt.PipelineRun == nil || rprt.PipelineRun.IsDone() {
+				continue
+			}
+			logger.Infof("cancelling PipelineRun %s", rprt.PipelineRunName)
+			if _, err := clientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Patch(ctx, rprt.PipelineRunName, types.JSONPatchType, cancelPipelineRunPatchBytes, metav1.PatchOptions{}, ""); err != nil {
+				errs = append(errs, fmt.Errorf("Failed to patch PipelineRun `%s` with cancellation: %s", rprt.PipelineRunName, err).Error())
+			}
+		default:
+			taskRuns := rprt.TaskRuns
+			if !rprt.IsMatrixed() {
+				taskRuns = []*v1beta1.TaskRun{rprt.TaskRun}
+			}
+			for _, tr := range taskRuns {
+				if tr == nil || tr.IsDone() {
+					continue
+				}
+				logger.Infof("cancelling TaskRun %s", tr.Name)
+				if _, err := clientSet.TektonV1beta1().TaskRuns(pr.Namespace).Patch(ctx, tr.Name, types.JSONPatchType, cancelTaskRunPatchBytes, metav1.PatchOptions{}, ""); err != nil {
+					errs = append(errs, fmt.Errorf("Failed to patch TaskRun `%s` with cancellation: %s", tr.Name, err).Error())
+				}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("error(s) from cancelling tasks of PipelineRun %s: %s", pr.Name, strings.Join(errs, "\n"))
+	}
+	return nil
+}
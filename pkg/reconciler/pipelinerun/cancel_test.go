@@ -35,6 +35,7 @@ func TestCancelPipelineRun(t *testing.T) {
 		pipelineRun *v1beta1.PipelineRun
 		taskRuns    []*v1beta1.TaskRun
 		runs        []*v1alpha1.Run
+		children    []*v1beta1.PipelineRun
 	}{{
 		name: "no-resolved-taskrun",
 		pipelineRun: &v1beta1.PipelineRun{
@@ -77,6 +78,25 @@ func TestCancelPipelineRun(t *testing.T) {
 			{ObjectMeta: metav1.ObjectMeta{Name: "t1"}},
 			{ObjectMeta: metav1.ObjectMeta{Name: "t2"}},
 		},
+	}, {
+		name: "reused-taskrun",
+		pipelineRun: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-cancelled"},
+			Spec: v1beta1.PipelineRunSpec{
+				Status:     v1beta1.PipelineRunSpecStatusCancelled,
+				ResumeFrom: "previous",
+			},
+			Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				TaskRuns: map[string]*v1beta1.PipelineRunTaskRunStatus{
+					"t1": {PipelineTaskName: "task-1", ReusedFrom: "previous"},
+					"t2": {PipelineTaskName: "task-2"},
+				},
+			}},
+		},
+		taskRuns: []*v1beta1.TaskRun{
+			{ObjectMeta: metav1.ObjectMeta{Name: "t1"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "t2"}},
+		},
 	}, {
 		name: "multiple-runs",
 		pipelineRun: &v1beta1.PipelineRun{
@@ -95,12 +115,30 @@ func TestCancelPipelineRun(t *testing.T) {
 			{ObjectMeta: metav1.ObjectMeta{Name: "t1"}},
 			{ObjectMeta: metav1.ObjectMeta{Name: "t2"}},
 		},
+	}, {
+		name: "child-pipelineruns",
+		pipelineRun: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-cancelled"},
+			Spec: v1beta1.PipelineRunSpec{
+				Status: v1beta1.PipelineRunSpecStatusCancelled,
+			},
+			Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				PipelineRuns: map[string]*v1beta1.PipelineRunPipelineRunStatus{
+					"p1": {PipelineTaskName: "child-1"},
+					"p2": {PipelineTaskName: "child-2"},
+				},
+			}},
+		},
+		children: []*v1beta1.PipelineRun{
+			{ObjectMeta: metav1.ObjectMeta{Name: "p1"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "p2"}},
+		},
 	}}
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			d := test.Data{
-				PipelineRuns: []*v1beta1.PipelineRun{tc.pipelineRun},
+				PipelineRuns: append([]*v1beta1.PipelineRun{tc.pipelineRun}, tc.children...),
 				TaskRuns:     tc.taskRuns,
 				Runs:         tc.runs,
 			}
@@ -122,6 +160,12 @@ func TestCancelPipelineRun(t *testing.T) {
 					t.Fatal(err)
 				}
 				for _, tr := range l.Items {
+					if tc.pipelineRun.Status.TaskRuns[tr.Name].ReusedFrom != "" {
+						if tr.Spec.Status == v1beta1.TaskRunSpecStatusCancelled {
+							t.Errorf("expected reused task %q not to be marked as cancelled", tr.Name)
+						}
+						continue
+					}
 					if tr.Spec.Status != v1beta1.TaskRunSpecStatusCancelled {
 						t.Errorf("expected task %q to be marked as cancelled, was %q", tr.Name, tr.Spec.Status)
 					}
@@ -138,6 +182,15 @@ func TestCancelPipelineRun(t *testing.T) {
 					}
 				}
 			}
+			for _, child := range tc.children {
+				p, err := c.Pipeline.TektonV1beta1().PipelineRuns("").Get(ctx, child.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if p.Spec.Status != v1beta1.PipelineRunSpecStatusCancelled {
+					t.Errorf("expected PipelineRun %q to be marked as cancelled, was %q", p.Name, p.Spec.Status)
+				}
+			}
 		})
 	}
 }
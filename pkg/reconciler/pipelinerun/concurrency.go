@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/substitution"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+)
+
+// concurrencyRequeueInterval is how often a queued PipelineRun checks again
+// whether it can start, in case it was not enqueued when an earlier
+// PipelineRun of its group finished.
+const concurrencyRequeueInterval = 30 * time.Second
+
+// concurrencyKey returns the resolved concurrency key of the PipelineRun,
+// with its parameters substituted.
+func concurrencyKey(pr *v1beta1.PipelineRun) string {
+	replacements := map[string]string{}
+	for _, p := range pr.Spec.Params {
+		if p.Value.Type == v1beta1.ParamTypeString {
+			replacements[fmt.Sprintf("params.%s", p.Name)] = p.Value.StringVal
+		}
+	}
+	return substitution.ApplyReplacements(pr.Spec.Concurrency.Key, replacements)
+}
+
+// concurrencyLabelValue returns the value of the concurrency key label of the
+// PipelineRuns in the concurrency group of pr. It is a hash of the resolved
+// key, which may not be a valid label value.
+func concurrencyLabelValue(pr *v1beta1.PipelineRun) string {
+	sum := sha256.Sum256([]byte(concurrencyKey(pr)))
+	return hex.EncodeToString(sum[:])[:63]
+}
+
+// labelConcurrencyGroup sets the concurrency key label of pr, so that the
+// PipelineRuns of its concurrency group can be listed by label.
+func labelConcurrencyGroup(pr *v1beta1.PipelineRun) {
+	if pr.Labels == nil {
+		pr.Labels = map[string]string{}
+	}
+	pr.Labels[pipeline.GroupName+pipeline.ConcurrencyKeyLabelKey] = concurrencyLabelValue(pr)
+}
+
+// concurrencyGroup returns the PipelineRuns, other than pr, which belong to
+// the concurrency group of pr and are neither pending nor done yet, oldest first.
+func (c *Reconciler) concurrencyGroup(pr *v1beta1.PipelineRun) ([]*v1beta1.PipelineRun, error) {
+	selector := labels.SelectorFromSet(labels.Set{pipeline.GroupName + pipeline.ConcurrencyKeyLabelKey: concurrencyLabelValue(pr)})
+	prs, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+	key := concurrencyKey(pr)
+	var group []*v1beta1.PipelineRun
+	for _, other := range prs {
+		if other.Name == pr.Name || other.Spec.Concurrency == nil || other.IsDone() || other.IsPending() {
+			continue
+		}
+		if concurrencyKey(other) == key {
+			group = append(group, other)
+		}
+	}
+	sort.Slice(group, func(i, j int) bool {
+		return isOlder(group[i], group[j])
+	})
+	return group, nil
+}
+
+// isOlder returns true if a was created before b, using the names to break ties.
+func isOlder(a, b *v1beta1.PipelineRun) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
+
+// shouldQueue enforces the concurrency limit of a PipelineRun which has not
+// started yet. It returns true if the PipelineRun must wait for earlier
+// PipelineRuns of its group to finish. With the CancelOlder strategy, the
+// oldest PipelineRuns of the group are cancelled to make room for it instead.
+func (c *Reconciler) shouldQueue(ctx context.Context, pr *v1beta1.PipelineRun) (bool, error) {
+	logger := logging.FromContext(ctx)
+	group, err := c.concurrencyGroup(pr)
+	if err != nil {
+		return false, err
+	}
+	maxRuns := pr.Spec.Concurrency.MaxRuns
+	if maxRuns < 1 {
+		maxRuns = 1
+	}
+
+	if pr.Spec.Concurrency.Strategy == v1beta1.PipelineRunConcurrencyStrategyCancelOlder {
+		var older []*v1beta1.PipelineRun
+		for _, other := range group {
+			if isOlder(other, pr) && !other.IsCancelled() {
+				older = append(older, other)
+			}
+		}
+		var errs []string
+		for i := 0; i < len(older)-maxRuns+1; i++ {
+			logger.Infof("Cancelling PipelineRun %s to make room for PipelineRun %s in concurrency group %q", older[i].Name, pr.Name, concurrencyKey(pr))
+			if _, err := c.PipelineClientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Patch(ctx, older[i].Name, types.JSONPatchType, cancelPipelineRunPatchBytes, metav1.PatchOptions{}, ""); err != nil {
+				errs = append(errs, fmt.Errorf("Failed to patch PipelineRun `%s` with cancellation: %s", older[i].Name, err).Error())
+			}
+		}
+		if len(errs) > 0 {
+			return false, fmt.Errorf("error(s) from cancelling older PipelineRuns of concurrency group %q: %s", concurrencyKey(pr), strings.Join(errs, "\n"))
+		}
+		return false, nil
+	}
+
+	// PipelineRuns are started in order: a PipelineRun waits for the running
+	// PipelineRuns of its group as well as for the older queued ones.
+	ahead := 0
+	for _, other := range group {
+		if other.HasStarted() || isOlder(other, pr) {
+			ahead++
+		}
+	}
+	return ahead >= maxRuns, nil
+}
+
+// requeueConcurrencyGroup enqueues the queued PipelineRuns of the concurrency
+// group of pr, so that they can start as soon as pr is done.
+func (c *Reconciler) requeueConcurrencyGroup(pr *v1beta1.PipelineRun) error {
+	group, err := c.concurrencyGroup(pr)
+	if err != nil {
+		return err
+	}
+	for _, other := range group {
+		if !other.HasStarted() {
+			c.snooze(other, 0)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+func concurrencyPipelineRun(name string, created time.Time, concurrency *v1beta1.PipelineRunConcurrency, started bool) *v1beta1.PipelineRun {
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "foo",
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			Params: []v1beta1.Param{{
+				Name:  "branch",
+				Value: *v1beta1.NewArrayOrString("main"),
+			}},
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{
+					Name: "hello",
+					TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+						Steps: []v1beta1.Step{{Container: corev1.Container{Name: "hello", Image: "busybox"}}},
+					}},
+				}},
+			},
+			Concurrency: concurrency,
+		},
+	}
+	if concurrency != nil {
+		// The PipelineRun was reconciled before, which labelled it.
+		labelConcurrencyGroup(pr)
+	}
+	if started {
+		pr.Status = v1beta1.PipelineRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionUnknown,
+					Reason: v1beta1.PipelineRunReasonRunning.String(),
+				}},
+			},
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				StartTime: &metav1.Time{Time: created},
+			},
+		}
+	}
+	return pr
+}
+
+func countTaskRunCreations(actions []ktesting.Action) int {
+	count := 0
+	for _, a := range actions {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			count++
+		}
+	}
+	return count
+}
+
+func TestReconcile_ConcurrencyQueue(t *testing.T) {
+	now := time.Now()
+	concurrency := &v1beta1.PipelineRunConcurrency{
+		Key:      "deploy-$(params.branch)",
+		MaxRuns:  1,
+		Strategy: v1beta1.PipelineRunConcurrencyStrategyQueue,
+	}
+	for _, tc := range []struct {
+		name       string
+		maxRuns    int
+		others     []*v1beta1.PipelineRun
+		wantQueued bool
+	}{{
+		name:       "no other PipelineRun in the group",
+		wantQueued: false,
+	}, {
+		name: "running PipelineRun in the group",
+		others: []*v1beta1.PipelineRun{
+			concurrencyPipelineRun("running", now.Add(-time.Minute), concurrency, true),
+		},
+		wantQueued: true,
+	}, {
+		name: "older queued PipelineRun in the group",
+		others: []*v1beta1.PipelineRun{
+			concurrencyPipelineRun("queued", now.Add(-time.Minute), concurrency, false),
+		},
+		wantQueued: true,
+	}, {
+		name: "newer queued PipelineRun in the group",
+		others: []*v1beta1.PipelineRun{
+			concurrencyPipelineRun("queued", now.Add(time.Minute), concurrency, false),
+		},
+		wantQueued: false,
+	}, {
+		name: "running PipelineRun in another group",
+		others: []*v1beta1.PipelineRun{
+			concurrencyPipelineRun("running", now.Add(-time.Minute), &v1beta1.PipelineRunConcurrency{
+				Key:     "release",
+				MaxRuns: 1,
+			}, true),
+		},
+		wantQueued: false,
+	}, {
+		name:    "room left in the group",
+		maxRuns: 2,
+		others: []*v1beta1.PipelineRun{
+			concurrencyPipelineRun("running", now.Add(-time.Minute), concurrency, true),
+		},
+		wantQueued: false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			c := concurrency.DeepCopy()
+			if tc.maxRuns != 0 {
+				c.MaxRuns = tc.maxRuns
+			}
+			pr := concurrencyPipelineRun("test-pipeline-run", now, c, false)
+			// The PipelineRun is reconciled for the first time.
+			pr.Labels = nil
+			d := test.Data{
+				PipelineRuns: append([]*v1beta1.PipelineRun{pr}, tc.others...),
+			}
+			prt := NewPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", nil, false)
+
+			if got, want := reconciledRun.Labels["tekton.dev/concurrencyKey"], concurrencyLabelValue(pr); got != want {
+				t.Errorf("Expected concurrency key label %q, got %q", want, got)
+			}
+
+			condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+			if condition == nil || condition.Status != corev1.ConditionUnknown {
+				t.Fatalf("Expected PipelineRun to be running or queued, got condition %v", condition)
+			}
+			taskRuns := countTaskRunCreations(clients.Pipeline.Actions())
+			if tc.wantQueued {
+				if condition.Reason != v1beta1.PipelineRunReasonQueued.String() {
+					t.Errorf("Expected reason %q, got %q", v1beta1.PipelineRunReasonQueued, condition.Reason)
+				}
+				if reconciledRun.Status.StartTime != nil {
+					t.Errorf("Expected queued PipelineRun not to have a start time, got %v", reconciledRun.Status.StartTime)
+				}
+				if taskRuns != 0 {
+					t.Errorf("Expected no TaskRun to be created for a queued PipelineRun, got %d", taskRuns)
+				}
+			} else {
+				if condition.Reason == v1beta1.PipelineRunReasonQueued.String() {
+					t.Errorf("Expected PipelineRun not to be queued")
+				}
+				if reconciledRun.Status.StartTime == nil {
+					t.Errorf("Expected PipelineRun to have a start time")
+				}
+				if taskRuns != 1 {
+					t.Errorf("Expected one TaskRun to be created, got %d", taskRuns)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcile_ConcurrencyCancelOlder(t *testing.T) {
+	names.TestingSeed()
+	now := time.Now()
+	concurrency := &v1beta1.PipelineRunConcurrency{
+		Key:      "deploy-$(params.branch)",
+		MaxRuns:  1,
+		Strategy: v1beta1.PipelineRunConcurrencyStrategyCancelOlder,
+	}
+	pr := concurrencyPipelineRun("test-pipeline-run", now, concurrency, false)
+	older := concurrencyPipelineRun("older", now.Add(-time.Minute), concurrency, true)
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{pr, older},
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", nil, false)
+
+	var patched []string
+	for _, action := range clients.Pipeline.Actions() {
+		if action.GetVerb() == "patch" && action.GetResource().Resource == "pipelineruns" {
+			patched = append(patched, action.(ktesting.PatchAction).GetName())
+		}
+	}
+	if len(patched) != 1 || patched[0] != "older" {
+		t.Errorf("Expected PipelineRun older to be cancelled, got patches for %v", patched)
+	}
+	if reconciledRun.Status.StartTime == nil {
+		t.Errorf("Expected PipelineRun to have started")
+	}
+	if taskRuns := countTaskRunCreations(clients.Pipeline.Actions()); taskRuns != 1 {
+		t.Errorf("Expected one TaskRun to be created, got %d", taskRuns)
+	}
+}
+
+func TestConcurrencyKey(t *testing.T) {
+	pr := concurrencyPipelineRun("test-pipeline-run", time.Now(), &v1beta1.PipelineRunConcurrency{
+		Key: "$(params.branch)-$(params.missing)",
+	}, false)
+	if got, want := concurrencyKey(pr), "main-$(params.missing)"; got != want {
+		t.Errorf("concurrencyKey() = %q, want %q", got, want)
+	}
+}
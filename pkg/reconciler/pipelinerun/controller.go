@@ -22,6 +22,7 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
 	conditioninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/condition"
 	runinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/run"
@@ -101,6 +102,13 @@ func NewController(namespace string, images pipeline.Images) func(context.Contex
 			UpdateFunc: controller.PassNew(impl.Enqueue),
 			DeleteFunc: impl.Enqueue,
 		})
+		// Child PipelineRuns created for PipelineTasks targeting a Pipeline enqueue their parent PipelineRun.
+		pipelineRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: controller.FilterControllerGK(v1beta1.Kind("PipelineRun")),
+			Handler: cache.ResourceEventHandlerFuncs{
+				UpdateFunc: controller.PassNew(impl.EnqueueControllerOf),
+			},
+		})
 
 		c.tracker = tracker.New(impl.EnqueueKey, 30*time.Minute)
 		taskRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -0,0 +1,165 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// pipelineIdentity describes the Pipeline run by one PipelineRun of a chain of
+// nested PipelineRuns.
+type pipelineIdentity struct {
+	// name is the name in the pipelineRef of the PipelineRun, empty for an
+	// embedded PipelineSpec or a Pipeline fetched by a resolver.
+	name string
+	// keys identify the Pipeline both by the way it is referenced and by the
+	// content of its resolved spec.
+	keys []string
+}
+
+// pipelineAncestry returns the identities of the Pipelines run by the PipelineRuns
+// pr is a child of and by pr itself, outermost first. The ancestors are found by
+// following the controller owner references of the PipelineRuns, so neither the
+// recursion check nor the depth limit depend on the tekton.dev/pipelineAncestry
+// annotation, which users can set themselves. The walk stops at a parent which
+// no longer exists and after maxPipelineNesting PipelineRuns.
+func (c *Reconciler) pipelineAncestry(pr *v1beta1.PipelineRun) ([]pipelineIdentity, error) {
+	ancestry := []pipelineIdentity{identityOf(pr)}
+	current := pr
+	for len(ancestry) <= maxPipelineNesting {
+		owner := metav1.GetControllerOf(current)
+		if owner == nil || owner.Kind != pipeline.PipelineRunControllerName {
+			break
+		}
+		parent, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(owner.Name)
+		if errors.IsNotFound(err) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if parent.UID != owner.UID {
+			break
+		}
+		ancestry = append([]pipelineIdentity{identityOf(parent)}, ancestry...)
+		current = parent
+	}
+	return ancestry, nil
+}
+
+// identityOf returns the identity of the Pipeline run by pr.
+func identityOf(pr *v1beta1.PipelineRun) pipelineIdentity {
+	id := pipelineIdentity{}
+	if pr.Spec.PipelineRef != nil && pr.Spec.PipelineRef.Bundle == "" && !pr.Spec.PipelineRef.ResolverRef.HasResolver() {
+		id.name = pr.Spec.PipelineRef.Name
+	}
+	for _, key := range []string{pipelineRefKey(pr.Spec.PipelineRef), pipelineSpecKey(pr.Spec.PipelineSpec), pipelineSpecKey(pr.Status.PipelineSpec)} {
+		if key != "" {
+			id.keys = append(id.keys, key)
+		}
+	}
+	return id
+}
+
+// pipelineRefKey returns a key identifying the Pipeline ref points to, including
+// the bundle or the resolver and its params the Pipeline is fetched with.
+func pipelineRefKey(ref *v1beta1.PipelineRef) string {
+	switch {
+	case ref == nil:
+		return ""
+	case ref.ResolverRef.HasResolver():
+		b, err := json.Marshal(ref.ResolverRef)
+		if err != nil {
+			return ""
+		}
+		return "resolver:" + string(b)
+	case ref.Bundle != "":
+		return "bundle:" + ref.Bundle + "#" + ref.Name
+	case ref.Name != "":
+		return "name:" + ref.Name
+	}
+	return ""
+}
+
+// pipelineSpecKey returns a key identifying the content of spec.
+func pipelineSpecKey(spec *v1beta1.PipelineSpec) string {
+	if spec == nil {
+		return ""
+	}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return "spec:" + hex.EncodeToString(sum[:])
+}
+
+// ancestryAnnotation returns the value of the tekton.dev/pipelineAncestry
+// annotation recorded on the child PipelineRuns of the last PipelineRun of ancestry.
+func ancestryAnnotation(ancestry []pipelineIdentity) string {
+	names := make([]string, 0, len(ancestry))
+	for _, id := range ancestry {
+		names = append(names, id.name)
+	}
+	return strings.Join(names, ",")
+}
+
+// checkPipelineNesting returns an error, and the reason to fail the PipelineRun
+// with, if the child PipelineRun of the PipelineTask would run a Pipeline which
+// one of its ancestors already runs or would be nested too deep.
+func checkPipelineNesting(ancestry []pipelineIdentity, pt *v1beta1.PipelineTask) (string, error) {
+	if len(ancestry) >= maxPipelineNesting {
+		return ReasonPipelineNestingTooDeep, fmt.Errorf("PipelineRuns can't be nested more than %d levels deep", maxPipelineNesting)
+	}
+	key := pipelineRefKey(pt.PipelineRef)
+	if key == "" {
+		key = pipelineSpecKey(pt.PipelineSpec)
+	}
+	if key == "" {
+		return "", nil
+	}
+	for _, id := range ancestry {
+		for _, k := range id.keys {
+			if k == key {
+				return ReasonRecursivePipeline, fmt.Errorf("Pipeline %s is already run by this PipelineRun or one of its parents", describePipeline(pt))
+			}
+		}
+	}
+	return "", nil
+}
+
+// describePipeline returns a human readable description of the Pipeline pt runs.
+func describePipeline(pt *v1beta1.PipelineTask) string {
+	switch {
+	case pt.PipelineRef != nil && pt.PipelineRef.ResolverRef.HasResolver():
+		return fmt.Sprintf("from resolver %s", pt.PipelineRef.Resolver)
+	case pt.PipelineRef != nil && pt.PipelineRef.Bundle != "":
+		return fmt.Sprintf("%s from bundle %s", pt.PipelineRef.Name, pt.PipelineRef.Bundle)
+	case pt.PipelineRef != nil:
+		return pt.PipelineRef.Name
+	}
+	return fmt.Sprintf("embedded in pipeline task %s", pt.Name)
+}
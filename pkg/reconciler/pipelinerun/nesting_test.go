@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckPipelineNesting(t *testing.T) {
+	gitRef := &v1beta1.PipelineRef{ResolverRef: v1beta1.ResolverRef{
+		Resolver: "git",
+		Params: []v1beta1.Param{{
+			Name:  "path",
+			Value: *v1beta1.NewArrayOrString("pipeline.yaml"),
+		}},
+	}}
+	otherGitRef := &v1beta1.PipelineRef{ResolverRef: v1beta1.ResolverRef{
+		Resolver: "git",
+		Params: []v1beta1.Param{{
+			Name:  "path",
+			Value: *v1beta1.NewArrayOrString("other.yaml"),
+		}},
+	}}
+	spec := &v1beta1.PipelineSpec{Tasks: []v1beta1.PipelineTask{{
+		Name:    "task",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+	}}}
+	pipelineRun := func(ref *v1beta1.PipelineRef, spec, resolved *v1beta1.PipelineSpec) *v1beta1.PipelineRun {
+		return &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+			Spec:       v1beta1.PipelineRunSpec{PipelineRef: ref, PipelineSpec: spec},
+			Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				PipelineSpec: resolved,
+			}},
+		}
+	}
+	for _, tc := range []struct {
+		name       string
+		ancestor   *v1beta1.PipelineRun
+		pt         *v1beta1.PipelineTask
+		wantReason string
+	}{{
+		name:       "same name",
+		ancestor:   pipelineRun(&v1beta1.PipelineRef{Name: "p"}, nil, nil),
+		pt:         &v1beta1.PipelineTask{Name: "child", PipelineRef: &v1beta1.PipelineRef{Name: "p"}},
+		wantReason: ReasonRecursivePipeline,
+	}, {
+		name:       "same bundle",
+		ancestor:   pipelineRun(&v1beta1.PipelineRef{Name: "p", Bundle: "registry/p:v1"}, nil, nil),
+		pt:         &v1beta1.PipelineTask{Name: "child", PipelineRef: &v1beta1.PipelineRef{Name: "p", Bundle: "registry/p:v1"}},
+		wantReason: ReasonRecursivePipeline,
+	}, {
+		name:     "same name in another bundle",
+		ancestor: pipelineRun(&v1beta1.PipelineRef{Name: "p", Bundle: "registry/p:v1"}, nil, nil),
+		pt:       &v1beta1.PipelineTask{Name: "child", PipelineRef: &v1beta1.PipelineRef{Name: "p", Bundle: "registry/p:v2"}},
+	}, {
+		name:       "same resolver ref",
+		ancestor:   pipelineRun(gitRef, nil, nil),
+		pt:         &v1beta1.PipelineTask{Name: "child", PipelineRef: gitRef},
+		wantReason: ReasonRecursivePipeline,
+	}, {
+		name:     "resolver ref with other params",
+		ancestor: pipelineRun(gitRef, nil, nil),
+		pt:       &v1beta1.PipelineTask{Name: "child", PipelineRef: otherGitRef},
+	}, {
+		name:       "same embedded spec",
+		ancestor:   pipelineRun(nil, spec, spec),
+		pt:         &v1beta1.PipelineTask{Name: "child", PipelineSpec: spec},
+		wantReason: ReasonRecursivePipeline,
+	}, {
+		name:       "embedded spec of a referenced pipeline",
+		ancestor:   pipelineRun(&v1beta1.PipelineRef{Name: "p"}, nil, spec),
+		pt:         &v1beta1.PipelineTask{Name: "child", PipelineSpec: spec},
+		wantReason: ReasonRecursivePipeline,
+	}, {
+		name:     "other embedded spec",
+		ancestor: pipelineRun(nil, spec, spec),
+		pt:       &v1beta1.PipelineTask{Name: "child", PipelineSpec: &v1beta1.PipelineSpec{}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			reason, err := checkPipelineNesting([]pipelineIdentity{identityOf(tc.ancestor)}, tc.pt)
+			if reason != tc.wantReason {
+				t.Errorf("Expected reason %q, got %q (error: %v)", tc.wantReason, reason, err)
+			}
+			if (err != nil) != (tc.wantReason != "") {
+				t.Errorf("Expected an error: %t, got %v", tc.wantReason != "", err)
+			}
+		})
+	}
+}
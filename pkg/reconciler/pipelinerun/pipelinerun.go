@@ -25,7 +25,6 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-multierror"
-	"github.com/tektoncd/pipeline/pkg/apis/config"
 	apisconfig "github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
@@ -45,6 +44,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun"
 	tresources "github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
+	"github.com/tektoncd/pipeline/pkg/remote"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
@@ -64,6 +64,9 @@ const (
 	// ReasonCouldntGetPipeline indicates that the reason for the failure status is that the
 	// associated Pipeline couldn't be retrieved
 	ReasonCouldntGetPipeline = "CouldntGetPipeline"
+	// ReasonFailedRemoteResolution indicates that the reason for the failure status is that the
+	// associated Pipeline or one of its Tasks couldn't be fetched by its resolver
+	ReasonFailedRemoteResolution = "RemoteResolutionFailed"
 	// ReasonInvalidBindings indicates that the reason for the failure status is that the
 	// PipelineResources bound in the PipelineRun didn't match those declared in the Pipeline
 	ReasonInvalidBindings = "InvalidPipelineResourceBindings"
@@ -76,6 +79,9 @@ const (
 	// parameter(s) declared in the PipelineRun do not have the some declared type as the
 	// parameters(s) declared in the Pipeline that they are supposed to override.
 	ReasonParameterTypeMismatch = "ParameterTypeMismatch"
+	// ReasonObjectParameterMissKeys indicates that the object param value provided from PipelineRun spec
+	// misses some keys required for the object param declared in Pipeline spec.
+	ReasonObjectParameterMissKeys = "ObjectParameterMissKeys"
 	// ReasonCouldntGetTask indicates that the reason for the failure status is that the
 	// associated Pipeline's Tasks couldn't all be retrieved
 	ReasonCouldntGetTask = "CouldntGetTask"
@@ -102,6 +108,22 @@ const (
 	// ReasonInvalidTaskResultReference indicates a task result was declared
 	// but was not initialized by that task
 	ReasonInvalidTaskResultReference = "InvalidTaskResultReference"
+	// ReasonWhenExpressionEvaluationFailed indicates that the reason for the failure status is
+	// that the CEL expression of a When Expression couldn't be evaluated
+	ReasonWhenExpressionEvaluationFailed = "WhenExpressionEvaluationFailed"
+	// ReasonCouldntResume indicates that the PipelineRun referenced by
+	// spec.resumeFrom couldn't be retrieved, hasn't completed yet or ran another Pipeline
+	ReasonCouldntResume = "PipelineRunCouldntResume"
+	// ReasonRecursivePipeline indicates that a PipelineTask targets a Pipeline
+	// which the PipelineRun or one of the PipelineRuns it is a child of runs
+	ReasonRecursivePipeline = "RecursivePipeline"
+	// ReasonPipelineNestingTooDeep indicates that a PipelineTask targets a
+	// Pipeline whose PipelineRun would be nested deeper than maxPipelineNesting
+	ReasonPipelineNestingTooDeep = "PipelineNestingTooDeep"
+
+	// maxPipelineNesting is the maximum number of nested PipelineRuns, including
+	// the outermost one
+	maxPipelineNesting = 10
 )
 
 // Reconciler implements controller.Reconciler for Configuration resources.
@@ -142,6 +164,31 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 	// Read the initial condition
 	before := pr.Status.GetCondition(apis.ConditionSucceeded)
 
+	if pr.Spec.Concurrency != nil {
+		labelConcurrencyGroup(pr)
+	}
+
+	// A pending PipelineRun is not started until its spec status is cleared.
+	if !pr.HasStarted() && pr.IsPending() {
+		pr.Status.MarkRunning(v1beta1.PipelineRunReasonPending.String(), "PipelineRun %q is pending", pr.Name)
+		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, nil)
+	}
+
+	// A PipelineRun with a concurrency limit only starts once there is room for
+	// it in its concurrency group.
+	if !pr.HasStarted() && !pr.IsCancelled() && pr.Spec.Concurrency != nil {
+		queued, err := c.shouldQueue(ctx, pr)
+		if err != nil {
+			logger.Errorf("Failed to enforce the concurrency limit of PipelineRun %s: %v", pr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
+		if queued {
+			pr.Status.MarkRunning(v1beta1.PipelineRunReasonQueued.String(), "Waiting for PipelineRuns of concurrency group %q to finish", concurrencyKey(pr))
+			c.snooze(pr, concurrencyRequeueInterval)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, nil)
+		}
+	}
+
 	if !pr.HasStarted() {
 		pr.Status.InitializeConditions()
 		// In case node time was not synchronized, when controller has been scheduled to other nodes.
@@ -191,6 +238,16 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 			logger.Errorf("Failed to update Run status for PipelineRun %s: %v", pr.Name, err)
 			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
 		}
+		if err := c.updatePipelineRunsStatusDirectly(pr); err != nil {
+			logger.Errorf("Failed to update child PipelineRun status for PipelineRun %s: %v", pr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
+		if pr.Spec.Concurrency != nil {
+			if err := c.requeueConcurrencyGroup(pr); err != nil {
+				logger.Errorf("Failed to requeue the concurrency group of PipelineRun %s: %v", pr.Name, err)
+				return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+			}
+		}
 		go func(metrics *Recorder) {
 			err := metrics.DurationAndCount(pr)
 			if err != nil {
@@ -231,6 +288,15 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 		elapsed := time.Since(pr.Status.StartTime.Time)
 		// Snooze this resource until the timeout has elapsed.
 		c.snooze(pr, pr.GetTimeout(ctx)-elapsed)
+		// Snooze it until the tasks or finally timeout instead, if they elapse earlier.
+		if timeout := pr.TasksTimeout(); timeout != nil && pr.Status.FinallyStartTime == nil && timeout.Duration-elapsed > 0 {
+			c.snooze(pr, timeout.Duration-elapsed)
+		}
+		if timeout := pr.FinallyTimeout(); timeout != nil && pr.Status.FinallyStartTime != nil {
+			if remaining := timeout.Duration - time.Since(pr.Status.FinallyStartTime.Time); remaining > 0 {
+				c.snooze(pr, remaining)
+			}
+		}
 	}()
 
 	// Reconcile this copy of the pipelinerun and then write back any status or label
@@ -288,12 +354,24 @@ func (c *Reconciler) resolvePipelineState(
 			func(name string) (*v1alpha1.Run, error) {
 				return c.runLister.Runs(pr.Namespace).Get(name)
 			},
+			func(name string) (*v1beta1.PipelineRun, error) {
+				return c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(name)
+			},
 			func(name string) (*v1alpha1.Condition, error) {
 				return c.conditionLister.Conditions(pr.Namespace).Get(name)
 			},
 			task, providedResources,
 		)
 		if err != nil {
+			if remote.IsResolutionError(err) && remote.IsTransientError(err) && !pr.HasTimedOut() {
+				return nil, err
+			}
+			if remote.IsResolutionError(err) {
+				pr.Status.MarkFailed(ReasonFailedRemoteResolution,
+					"Pipeline %s/%s can't be Run; task %s could not be fetched: %s",
+					pipelineMeta.Namespace, pipelineMeta.Name, task.Name, err)
+				return nil, controller.NewPermanentError(err)
+			}
 			switch err := err.(type) {
 			case *resources.TaskNotFoundError:
 				pr.Status.MarkFailed(ReasonCouldntGetTask,
@@ -324,7 +402,14 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	pipelineMeta, pipelineSpec, err := resources.GetPipelineData(ctx, pr, getPipelineFunc)
 	if err != nil {
 		logger.Errorf("Failed to determine Pipeline spec to use for pipelinerun %s: %v", pr.Name, err)
-		pr.Status.MarkFailed(ReasonCouldntGetPipeline,
+		if remote.IsResolutionError(err) && remote.IsTransientError(err) && !pr.HasTimedOut() {
+			return err
+		}
+		reason := ReasonCouldntGetPipeline
+		if remote.IsResolutionError(err) {
+			reason = ReasonFailedRemoteResolution
+		}
+		pr.Status.MarkFailed(reason,
 			"Error retrieving pipeline for pipelinerun %s/%s: %s",
 			pr.Namespace, pr.Name, err)
 		return controller.NewPermanentError(err)
@@ -426,6 +511,15 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		return controller.NewPermanentError(err)
 	}
 
+	// Ensure that the keys of an object param declared in PipelineSpec are not missed in the PipelineRunSpec
+	if err = resources.ValidateObjectParamRequiredKeys(pipelineSpec.Params, pr.Spec.Params); err != nil {
+		// This Run has failed, so we need to mark it as failed and stop reconciling it
+		pr.Status.MarkFailed(ReasonObjectParameterMissKeys,
+			"PipelineRun %s/%s parameters is missing object keys required by Pipeline %s/%s's parameters: %s",
+			pr.Namespace, pr.Name, pr.Namespace, pipelineMeta.Name, err)
+		return controller.NewPermanentError(err)
+	}
+
 	// Ensure that the workspaces expected by the Pipeline are provided by the PipelineRun.
 	if err := resources.ValidateWorkspaceBindings(pipelineSpec, pr); err != nil {
 		pr.Status.MarkFailed(ReasonInvalidWorkspaceBinding,
@@ -453,6 +547,17 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 
 	// Apply parameter substitution from the PipelineRun
 	pipelineSpec = resources.ApplyParameters(pipelineSpec, pr)
+
+	// Reuse the successful TaskRuns of the PipelineRun this one resumes from,
+	// before any TaskRun of its own is created.
+	if pr.Spec.ResumeFrom != "" && len(pr.Status.TaskRuns) == 0 {
+		if err := c.resumeFrom(ctx, pr, pipelineSpec); err != nil {
+			pr.Status.MarkFailed(ReasonCouldntResume,
+				"PipelineRun %s/%s can't resume from PipelineRun %s: %s",
+				pr.Namespace, pr.Name, pr.Spec.ResumeFrom, err)
+			return controller.NewPermanentError(err)
+		}
+	}
 	pipelineSpec = resources.ApplyContexts(pipelineSpec, pipelineMeta.Name, pr)
 	pipelineSpec = resources.ApplyWorkspaces(pipelineSpec, pr)
 
@@ -472,14 +577,30 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	// Build PipelineRunFacts with a list of resolved pipeline tasks,
 	// dag tasks graph and final tasks graph
 	pipelineRunFacts := &resources.PipelineRunFacts{
-		State:           pipelineRunState,
-		TasksGraph:      d,
-		FinalTasksGraph: dfinally,
+		State:            pipelineRunState,
+		TasksGraph:       d,
+		FinalTasksGraph:  dfinally,
+		TasksTimedOut:    pr.HaveTasksTimedOut(),
+		FinallyTimedOut:  pr.HasFinallyTimedOut(),
+		SpecStatus:       pr.Spec.Status,
+		WhenCELVariables: resources.WhenCELVariables(pipelineSpec, pipelineMeta.Name, pr),
 	}
 
 	for _, rprt := range pipelineRunFacts.State {
-		if !rprt.IsCustomTask() {
-			err := taskrun.ValidateResolvedTaskResources(rprt.PipelineTask.Params, rprt.ResolvedTaskResources)
+		if !rprt.IsCustomTask() && !rprt.IsChildPipeline() {
+			params := rprt.PipelineTask.Params
+			if rprt.IsMatrixed() {
+				combinations := rprt.PipelineTask.FanOut()
+				if len(combinations) == 0 {
+					err := fmt.Errorf("matrix of pipeline task %s has no combinations to fan out", rprt.PipelineTask.Name)
+					logger.Errorf("Failed to validate pipelinerun %q with error %v", pr.Name, err)
+					pr.Status.MarkFailed(ReasonFailedValidation, err.Error())
+					return controller.NewPermanentError(err)
+				}
+				// every combination declares the same parameters, validating one of them is enough
+				params = append(append([]v1beta1.Param{}, params...), combinations[0]...)
+			}
+			err := taskrun.ValidateResolvedTaskResources(params, rprt.ResolvedTaskResources)
 			if err != nil {
 				logger.Errorf("Failed to validate pipelinerun %q with error %v", pr.Name, err)
 				pr.Status.MarkFailed(ReasonFailedValidation, err.Error())
@@ -518,6 +639,15 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		return controller.NewPermanentError(err)
 	}
 
+	// Stop the tasks which are still running after the timeout of their section of the Pipeline,
+	// or after the PipelineRun was gracefully cancelled
+	if tasksToCancel := pipelineRunFacts.GetTasksToCancel(); len(tasksToCancel) > 0 {
+		logger.Infof("PipelineRun %s reached a timeout or was gracefully cancelled, cancelling %d running tasks", pr.Name, len(tasksToCancel))
+		if err := cancelPipelineTasks(ctx, logger, pr, c.PipelineClientSet, tasksToCancel); err != nil {
+			return err
+		}
+	}
+
 	if err := c.runNextSchedulableTask(ctx, pr, pipelineRunFacts, as); err != nil {
 		return err
 	}
@@ -525,7 +655,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	// Reset the skipped status to trigger recalculation
 	pipelineRunFacts.ResetSkippedCache()
 
-	after := pipelineRunFacts.GetPipelineConditionStatus(pr, logger)
+	after := pipelineRunFacts.GetPipelineConditionStatus(ctx, pr, logger)
 	switch after.Status {
 	case corev1.ConditionTrue:
 		pr.Status.MarkSucceeded(after.Reason, after.Message)
@@ -539,10 +669,11 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	pr.Status.StartTime = pipelineRunFacts.State.AdjustStartTime(pr.Status.StartTime)
 	pr.Status.TaskRuns = pipelineRunFacts.State.GetTaskRunsStatus(pr)
 	pr.Status.Runs = pipelineRunFacts.State.GetRunsStatus(pr)
+	pr.Status.PipelineRuns = pipelineRunFacts.State.GetPipelineRunsStatus(pr)
 	pr.Status.SkippedTasks = pipelineRunFacts.GetSkippedTasks()
 
 	if after.Status == corev1.ConditionTrue {
-		pr.Status.PipelineResults = resources.ApplyTaskResultsToPipelineResults(pipelineSpec.Results, pr.Status.TaskRuns, pr.Status.Runs)
+		pr.Status.PipelineResults = resources.ApplyTaskResultsToPipelineResults(pipelineSpec.Results, pr.Status.TaskRuns, pr.Status.Runs, pr.Status.PipelineRuns)
 	}
 
 	logger.Infof("PipelineRun %s status is being set to %s", pr.Name, after)
@@ -579,6 +710,10 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.Pip
 	// GetFinalTasks only returns tasks when a DAG is complete
 	fnextRprts := pipelineRunFacts.GetFinalTasks()
 	if len(fnextRprts) != 0 {
+		// the finally timeout starts when the first final tasks are scheduled
+		if pr.Status.FinallyStartTime == nil {
+			pr.Status.FinallyStartTime = &metav1.Time{Time: time.Now()}
+		}
 		// apply the runtime context just before creating taskRuns for final tasks in queue
 		resources.ApplyPipelineTaskContext(fnextRprts, pipelineRunFacts.GetPipelineTaskStatus(ctx))
 
@@ -591,10 +726,27 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.Pip
 				continue
 			}
 			resources.ApplyTaskResults(resources.PipelineRunState{rprt}, resolvedResultRefs)
+			if rprt.IsFinallySkipped(pipelineRunFacts) {
+				logger.Infof("Final task %q is not executed as its when expressions evaluated to false for %q", rprt.PipelineTask.Name, pr.Name)
+				continue
+			}
 			nextRprts = append(nextRprts, rprt)
 		}
 	}
 
+	// Fail the PipelineRun before running any task if one of the CEL expressions
+	// of the When Expressions of the tasks to run can't be evaluated
+	for _, rprt := range nextRprts {
+		if rprt == nil || rprt.Skip(pipelineRunFacts) {
+			continue
+		}
+		if _, err := rprt.EvaluateWhenCEL(pipelineRunFacts); err != nil {
+			logger.Infof("Failed to evaluate the when expressions of pipeline task %q for %q with error %v", rprt.PipelineTask.Name, pr.Name, err)
+			pr.Status.MarkFailed(ReasonWhenExpressionEvaluationFailed, "The when expressions of pipeline task %s couldn't be evaluated: %v", rprt.PipelineTask.Name, err)
+			return controller.NewPermanentError(err)
+		}
+	}
+
 	for _, rprt := range nextRprts {
 		if rprt == nil || rprt.Skip(pipelineRunFacts) {
 			continue
@@ -606,8 +758,32 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.Pip
 					recorder.Eventf(pr, corev1.EventTypeWarning, "RunCreationFailed", "Failed to create Run %q: %v", rprt.RunName, err)
 					return fmt.Errorf("error creating Run called %s for PipelineTask %s from PipelineRun %s: %w", rprt.RunName, rprt.PipelineTask.Name, pr.Name, err)
 				}
+			} else if rprt.IsChildPipeline() {
+				ancestry, err := c.pipelineAncestry(pr)
+				if err != nil {
+					return fmt.Errorf("error getting the ancestors of PipelineRun %s: %w", pr.Name, err)
+				}
+				if reason, err := checkPipelineNesting(ancestry, rprt.PipelineTask); err != nil {
+					logger.Errorf("Pipeline task %s of PipelineRun %s can't run its Pipeline: %v", rprt.PipelineTask.Name, pr.Name, err)
+					pr.Status.MarkFailed(reason, "PipelineRun %s/%s can't run pipeline task %s: %s", pr.Namespace, pr.Name, rprt.PipelineTask.Name, err)
+					return controller.NewPermanentError(err)
+				}
+				rprt.PipelineRun, err = c.createPipelineRun(ctx, rprt, pr)
+				if err != nil {
+					recorder.Eventf(pr, corev1.EventTypeWarning, "PipelineRunCreationFailed", "Failed to create PipelineRun %q: %v", rprt.PipelineRunName, err)
+					return fmt.Errorf("error creating PipelineRun called %s for PipelineTask %s from PipelineRun %s: %w", rprt.PipelineRunName, rprt.PipelineTask.Name, pr.Name, err)
+				}
+			} else if rprt.IsMatrixed() {
+				if err := c.createTaskRuns(ctx, rprt, pr, as.StorageBasePath(pr)); err != nil {
+					return err
+				}
 			} else {
-				rprt.TaskRun, err = c.createTaskRun(ctx, rprt, pr, as.StorageBasePath(pr))
+				if delay := retryDelay(rprt.PipelineTask, rprt.TaskRun); delay > 0 {
+					logger.Infof("Retry of TaskRun %s for pipeline task %s is delayed by %s", rprt.TaskRunName, rprt.PipelineTask.Name, delay)
+					c.snooze(pr, delay)
+					continue
+				}
+				rprt.TaskRun, err = c.createTaskRun(ctx, rprt.TaskRunName, rprt.PipelineTask.Params, rprt, pr, as.StorageBasePath(pr))
 				if err != nil {
 					recorder.Eventf(pr, corev1.EventTypeWarning, "TaskRunCreationFailed", "Failed to create TaskRun %q: %v", rprt.TaskRunName, err)
 					return fmt.Errorf("error creating TaskRun called %s for PipelineTask %s from PipelineRun %s: %w", rprt.TaskRunName, rprt.PipelineTask.Name, pr.Name, err)
@@ -658,12 +834,54 @@ func (c *Reconciler) updateRunsStatusDirectly(pr *v1beta1.PipelineRun) error {
 	return nil
 }
 
-func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, storageBasePath string) (*v1beta1.TaskRun, error) {
+func (c *Reconciler) updatePipelineRunsStatusDirectly(pr *v1beta1.PipelineRun) error {
+	for pipelineRunName := range pr.Status.PipelineRuns {
+		prPipelineRunStatus := pr.Status.PipelineRuns[pipelineRunName]
+		childPipelineRun, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(pipelineRunName)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving PipelineRun %s: %w", pipelineRunName, err)
+			}
+		} else {
+			prPipelineRunStatus.Status = &childPipelineRun.Status
+		}
+	}
+	return nil
+}
+
+// createTaskRuns creates a TaskRun for each combination of the matrix of the PipelineTask
+// which doesn't have a TaskRun yet or whose TaskRun has failed and can still be retried
+func (c *Reconciler) createTaskRuns(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, storageBasePath string) error {
+	logger := logging.FromContext(ctx)
+	recorder := controller.GetEventRecorder(ctx)
+	for i, combination := range rprt.PipelineTask.FanOut() {
+		taskRunName := rprt.TaskRunNames[i]
+		if !rprt.IsTaskRunSchedulable(rprt.TaskRuns[i]) {
+			continue
+		}
+		if delay := retryDelay(rprt.PipelineTask, rprt.TaskRuns[i]); delay > 0 {
+			logger.Infof("Retry of TaskRun %s for pipeline task %s is delayed by %s", taskRunName, rprt.PipelineTask.Name, delay)
+			c.snooze(pr, delay)
+			continue
+		}
+		params := append(append([]v1beta1.Param{}, rprt.PipelineTask.Params...), combination...)
+		tr, err := c.createTaskRun(ctx, taskRunName, params, rprt, pr, storageBasePath)
+		if err != nil {
+			recorder.Eventf(pr, corev1.EventTypeWarning, "TaskRunCreationFailed", "Failed to create TaskRun %q: %v", taskRunName, err)
+			return fmt.Errorf("error creating TaskRun called %s for PipelineTask %s from PipelineRun %s: %w", taskRunName, rprt.PipelineTask.Name, pr.Name, err)
+		}
+		rprt.TaskRuns[i] = tr
+	}
+	return nil
+}
+
+func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, params []v1beta1.Param, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun, storageBasePath string) (*v1beta1.TaskRun, error) {
 	logger := logging.FromContext(ctx)
 
-	tr, _ := c.taskRunLister.TaskRuns(pr.Namespace).Get(rprt.TaskRunName)
+	tr, _ := c.taskRunLister.TaskRuns(pr.Namespace).Get(taskRunName)
 	if tr != nil {
 		//is a retry
+		logger.Infof("Retrying TaskRun %s for pipeline task %s after failure: %s", taskRunName, rprt.PipelineTask.Name, tr.Status.GetCondition(apis.ConditionSucceeded).GetReason())
 		addRetryHistory(tr)
 		clearStatus(tr)
 		tr.Status.SetCondition(&apis.Condition{
@@ -676,17 +894,20 @@ func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.Resolved
 	taskRunSpec := pr.GetTaskRunSpec(rprt.PipelineTask.Name)
 	tr = &v1beta1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            rprt.TaskRunName,
+			Name:            taskRunName,
 			Namespace:       pr.Namespace,
 			OwnerReferences: []metav1.OwnerReference{pr.GetOwnerReference()},
 			Labels:          combineTaskRunAndTaskSpecLabels(pr, rprt.PipelineTask),
 			Annotations:     combineTaskRunAndTaskSpecAnnotations(pr, rprt.PipelineTask),
 		},
 		Spec: v1beta1.TaskRunSpec{
-			Params:             rprt.PipelineTask.Params,
+			Params:             params,
 			ServiceAccountName: taskRunSpec.TaskServiceAccountName,
 			Timeout:            getTaskRunTimeout(ctx, pr, rprt),
 			PodTemplate:        taskRunSpec.TaskPodTemplate,
+			ComputeResources:   taskRunSpec.ComputeResources,
+			StepOverrides:      taskRunSpec.StepOverrides,
+			SidecarOverrides:   taskRunSpec.SidecarOverrides,
 		}}
 
 	if rprt.ResolvedTaskResources.TaskName != "" {
@@ -708,7 +929,7 @@ func (c *Reconciler) createTaskRun(ctx context.Context, rprt *resources.Resolved
 	}
 
 	resources.WrapSteps(&tr.Spec, rprt.PipelineTask, rprt.ResolvedTaskResources.Inputs, rprt.ResolvedTaskResources.Outputs, storageBasePath)
-	logger.Infof("Creating a new TaskRun object %s for pipeline task %s", rprt.TaskRunName, rprt.PipelineTask.Name)
+	logger.Infof("Creating a new TaskRun object %s for pipeline task %s", taskRunName, rprt.PipelineTask.Name)
 	return c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).Create(ctx, tr, metav1.CreateOptions{})
 }
 
@@ -748,6 +969,44 @@ func (c *Reconciler) createRun(ctx context.Context, rprt *resources.ResolvedPipe
 	return c.PipelineClientSet.TektonV1alpha1().Runs(pr.Namespace).Create(ctx, r, metav1.CreateOptions{})
 }
 
+// createPipelineRun creates a child PipelineRun for a PipelineTask targeting a Pipeline. Params, workspaces,
+// service account and pod template are propagated from the PipelineRun as they would be to a TaskRun.
+func (c *Reconciler) createPipelineRun(ctx context.Context, rprt *resources.ResolvedPipelineRunTask, pr *v1beta1.PipelineRun) (*v1beta1.PipelineRun, error) {
+	logger := logging.FromContext(ctx)
+	taskRunSpec := pr.GetTaskRunSpec(rprt.PipelineTask.Name)
+	annotations := getTaskrunAnnotations(pr)
+	ancestry, err := c.pipelineAncestry(pr)
+	if err != nil {
+		return nil, err
+	}
+	annotations[pipeline.GroupName+pipeline.PipelineAncestryAnnotationKey] = ancestryAnnotation(ancestry)
+	childPipelineRun := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rprt.PipelineRunName,
+			Namespace:       pr.Namespace,
+			OwnerReferences: []metav1.OwnerReference{pr.GetOwnerReference()},
+			Labels:          getTaskrunLabels(pr, rprt.PipelineTask.Name, true),
+			Annotations:     annotations,
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef:        rprt.PipelineTask.PipelineRef,
+			PipelineSpec:       rprt.PipelineTask.PipelineSpec,
+			Params:             rprt.PipelineTask.Params,
+			ServiceAccountName: taskRunSpec.TaskServiceAccountName,
+			Timeout:            getTaskRunTimeout(ctx, pr, rprt),
+			PodTemplate:        taskRunSpec.TaskPodTemplate,
+		},
+	}
+
+	childPipelineRun.Spec.Workspaces, _, err = getTaskrunWorkspaces(pr, rprt)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Creating a new PipelineRun object %s for pipeline task %s", rprt.PipelineRunName, rprt.PipelineTask.Name)
+	return c.PipelineClientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Create(ctx, childPipelineRun, metav1.CreateOptions{})
+}
+
 func getTaskrunWorkspaces(pr *v1beta1.PipelineRun, rprt *resources.ResolvedPipelineRunTask) ([]v1beta1.WorkspaceBinding, string, error) {
 	var workspaces []v1beta1.WorkspaceBinding
 	var pipelinePVCWorkspaceName string
@@ -812,6 +1071,27 @@ func combinedSubPath(workspaceSubPath string, pipelineTaskSubPath string) string
 	return filepath.Join(workspaceSubPath, pipelineTaskSubPath)
 }
 
+// retryDelay returns how long the retry of the failed TaskRun still has to wait
+// according to the retry policy of the PipelineTask
+func retryDelay(pt *v1beta1.PipelineTask, tr *v1beta1.TaskRun) time.Duration {
+	if pt.RetryPolicy == nil || tr == nil {
+		return 0
+	}
+	c := tr.Status.GetCondition(apis.ConditionSucceeded)
+	if c == nil || !c.IsFalse() {
+		return 0
+	}
+	failedAt := c.LastTransitionTime.Inner.Time
+	if tr.Status.CompletionTime != nil {
+		failedAt = tr.Status.CompletionTime.Time
+	}
+	remaining := pt.RetryPolicy.Delay(len(tr.Status.RetriesStatus)) - time.Since(failedAt)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
 func addRetryHistory(tr *v1beta1.TaskRun) {
 	newStatus := *tr.Status.DeepCopy()
 	newStatus.RetriesStatus = nil
@@ -894,17 +1174,9 @@ func combineTaskRunAndTaskSpecAnnotations(pr *v1beta1.PipelineRun, pipelineTask
 
 func getTaskRunTimeout(ctx context.Context, pr *v1beta1.PipelineRun, rprt *resources.ResolvedPipelineRunTask) *metav1.Duration {
 	var taskRunTimeout = &metav1.Duration{Duration: apisconfig.NoTimeoutDuration}
-
-	var timeout time.Duration
-	if pr.Spec.Timeout == nil {
-		defaultTimeout := time.Duration(config.FromContextOrDefaults(ctx).Defaults.DefaultTimeoutMinutes)
-		timeout = defaultTimeout * time.Minute
-	} else {
-		timeout = pr.Spec.Timeout.Duration
-	}
+	timeout := pr.GetTimeout(ctx)
 
 	// If the value of the timeout is 0 for any resource, there is no timeout.
-	// It is impossible for pr.Spec.Timeout to be nil, since SetDefault always assigns it with a value.
 	if timeout != apisconfig.NoTimeoutDuration {
 		pTimeoutTime := pr.Status.StartTime.Add(timeout)
 		if time.Now().After(pTimeoutTime) {
@@ -929,9 +1201,36 @@ func getTaskRunTimeout(ctx context.Context, pr *v1beta1.PipelineRun, rprt *resou
 		taskRunTimeout = &metav1.Duration{Duration: rprt.PipelineTask.Timeout.Duration}
 	}
 
+	// The TaskRun must not outlive the tasks or finally timeout of the PipelineRun.
+	if remaining, ok := remainingTasksOrFinallyTimeout(pr); ok {
+		if taskRunTimeout.Duration == apisconfig.NoTimeoutDuration || remaining < taskRunTimeout.Duration {
+			taskRunTimeout = &metav1.Duration{Duration: remaining}
+		}
+	}
+
 	return taskRunTimeout
 }
 
+// remainingTasksOrFinallyTimeout returns the time left in the timeout of the tasks of the
+// PipelineRun or, once its finally tasks started, in the timeout of its finally tasks. It
+// returns false if the tasks being run have no such timeout.
+func remainingTasksOrFinallyTimeout(pr *v1beta1.PipelineRun) (time.Duration, bool) {
+	timeout, startTime := pr.TasksTimeout(), pr.Status.StartTime
+	if pr.Status.FinallyStartTime != nil {
+		timeout, startTime = pr.FinallyTimeout(), pr.Status.FinallyStartTime
+	}
+	if timeout == nil || timeout.Duration == apisconfig.NoTimeoutDuration || startTime == nil {
+		return 0, false
+	}
+	remaining := timeout.Duration - time.Since(startTime.Time)
+	if remaining < time.Second {
+		// Just in case we're creating the TaskRun after the timeout elapsed,
+		// set the timeout to 1 second, as for the timeout of the PipelineRun.
+		remaining = time.Second
+	}
+	return remaining, true
+}
+
 func (c *Reconciler) updateLabelsAndAnnotations(ctx context.Context, pr *v1beta1.PipelineRun) (*v1beta1.PipelineRun, error) {
 	newPr, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(pr.Name)
 	if err != nil {
@@ -1024,6 +1323,13 @@ func (c *Reconciler) updatePipelineRunStatusFromInformer(ctx context.Context, pr
 	}
 	updatePipelineRunStatusFromRuns(logger, pr, runs)
 
+	pipelineRuns, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).List(labels.SelectorFromSet(pipelineRunLabels))
+	if err != nil {
+		logger.Errorf("could not list PipelineRuns %#v", err)
+		return err
+	}
+	updatePipelineRunStatusFromChildPipelineRuns(logger, pr, pipelineRuns)
+
 	return nil
 }
 
@@ -1144,3 +1450,31 @@ func updatePipelineRunStatusFromRuns(logger *zap.SugaredLogger, pr *v1beta1.Pipe
 		}
 	}
 }
+
+func updatePipelineRunStatusFromChildPipelineRuns(logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, childPipelineRuns []*v1beta1.PipelineRun) {
+	// If no child PipelineRun was found, nothing to be done. We never remove child pipelineruns from the status
+	if len(childPipelineRuns) == 0 {
+		return
+	}
+	if pr.Status.PipelineRuns == nil {
+		pr.Status.PipelineRuns = make(map[string]*v1beta1.PipelineRunPipelineRunStatus)
+	}
+	// Loop over all the child PipelineRuns associated to Tasks
+	for _, childPipelineRun := range childPipelineRuns {
+		// Only process PipelineRuns that are owned by this PipelineRun.
+		if len(childPipelineRun.OwnerReferences) < 1 || childPipelineRun.OwnerReferences[0].UID != pr.ObjectMeta.UID {
+			logger.Debugf("Found a PipelineRun %s that is not owned by this PipelineRun", childPipelineRun.Name)
+			continue
+		}
+		lbls := childPipelineRun.GetLabels()
+		pipelineTaskName := lbls[pipeline.GroupName+pipeline.PipelineTaskLabelKey]
+		if _, ok := pr.Status.PipelineRuns[childPipelineRun.Name]; !ok {
+			// This child pipelinerun was missing from the status.
+			logger.Infof("Found a PipelineRun %s that was missing from the PipelineRun status", childPipelineRun.Name)
+			pr.Status.PipelineRuns[childPipelineRun.Name] = &v1beta1.PipelineRunPipelineRunStatus{
+				PipelineTaskName: pipelineTaskName,
+				Status:           &childPipelineRun.Status,
+			}
+		}
+	}
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/tektoncd/pipeline/test/names"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
@@ -59,6 +60,7 @@ import (
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/ptr"
 	"knative.dev/pkg/reconciler"
 	"knative.dev/pkg/system"
 
@@ -648,6 +650,211 @@ func TestReconcile_CustomTask(t *testing.T) {
 	}
 }
 
+func TestReconcile_ChildPipeline(t *testing.T) {
+	// TestReconcile_ChildPipeline runs "Reconcile" on a PipelineRun with a PipelineTask that targets a Pipeline.
+	// It verifies that a child PipelineRun is created and that the parent status references it.
+	names.TestingSeed()
+	const pipelineRunName = "test-pipelinerun"
+	const pipelineTaskName = "child"
+	const namespace = "namespace"
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pipelineRunName,
+			Namespace: namespace,
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{
+					Name: pipelineTaskName,
+					Params: []v1beta1.Param{{
+						Name:  "param1",
+						Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: "value1"},
+					}},
+					PipelineRef: &v1beta1.PipelineRef{Name: "child-pipeline"},
+				}},
+			},
+		},
+	}
+	wantPipelineRun := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pipelinerun-child-9l9zj",
+			Namespace: namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         "tekton.dev/v1beta1",
+				Kind:               "PipelineRun",
+				Name:               pipelineRunName,
+				Controller:         &trueb,
+				BlockOwnerDeletion: &trueb,
+			}},
+			Labels: map[string]string{
+				"tekton.dev/pipeline":     pipelineRunName,
+				"tekton.dev/pipelineRun":  pipelineRunName,
+				"tekton.dev/pipelineTask": pipelineTaskName,
+			},
+			Annotations: map[string]string{
+				"tekton.dev/pipelineAncestry": "",
+			},
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "child-pipeline"},
+			Params: []v1beta1.Param{{
+				Name:  "param1",
+				Value: v1beta1.ArrayOrString{Type: v1beta1.ParamTypeString, StringVal: "value1"},
+			}},
+			ServiceAccountName: "default",
+			Timeout:            &metav1.Duration{Duration: config.DefaultTimeoutMinutes * time.Minute},
+		},
+	}
+
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{pr},
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0",
+	}
+	reconciledRun, clients := prt.reconcileRun(namespace, pipelineRunName, wantEvents, false)
+
+	actions := clients.Pipeline.Actions()
+	if len(actions) < 2 {
+		t.Fatalf("Expected client to have at least two action implementation but it has %d", len(actions))
+	}
+
+	// Check that the expected child PipelineRun was created.
+	actual := actions[0].(ktesting.CreateAction).GetObject()
+	if d := cmp.Diff(wantPipelineRun, actual); d != "" {
+		t.Errorf("expected to see child PipelineRun created: %s", diff.PrintWantGot(d))
+	}
+
+	if len(reconciledRun.Status.PipelineRuns) != 1 {
+		t.Errorf("Expected PipelineRun status to include one child PipelineRun status, got %d", len(reconciledRun.Status.PipelineRuns))
+	}
+	if _, exists := reconciledRun.Status.PipelineRuns[wantPipelineRun.Name]; !exists {
+		t.Errorf("Expected PipelineRun status to include child PipelineRun status but was %v", reconciledRun.Status.PipelineRuns)
+	}
+}
+
+func TestReconcile_RecursiveChildPipeline(t *testing.T) {
+	// TestReconcile_RecursiveChildPipeline runs "Reconcile" on PipelineRuns whose PipelineTask targets a Pipeline
+	// which the PipelineRun or one of its parents already runs, or which would be nested too deep.
+	// It verifies that no child PipelineRun is created and that the PipelineRun fails.
+	const namespace = "foo"
+	recursive := &v1beta1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "recursive", Namespace: namespace},
+		Spec: v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name:        "child",
+				PipelineRef: &v1beta1.PipelineRef{Name: "recursive"},
+			}},
+		},
+	}
+	other := &v1beta1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: namespace},
+		Spec: v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name:        "child",
+				PipelineRef: &v1beta1.PipelineRef{Name: "recursive"},
+			}},
+		},
+	}
+	deepParents := make([]string, maxPipelineNesting-1)
+	for i := range deepParents {
+		deepParents[i] = fmt.Sprintf("parent-%d", i)
+	}
+	for _, tc := range []struct {
+		name       string
+		pipeline   string
+		parents    []string
+		ancestry   *string
+		wantReason string
+	}{{
+		name:       "pipeline running itself",
+		pipeline:   "recursive",
+		wantReason: ReasonRecursivePipeline,
+	}, {
+		name:       "pipeline running one of its ancestors",
+		pipeline:   "other",
+		parents:    []string{"recursive", "another"},
+		wantReason: ReasonRecursivePipeline,
+	}, {
+		name:       "pipeline nested too deep",
+		pipeline:   "other",
+		parents:    deepParents,
+		wantReason: ReasonPipelineNestingTooDeep,
+	}, {
+		name:     "ancestry annotation set by the user is ignored",
+		pipeline: "other",
+		ancestry: ptr.String("recursive," + strings.Join(deepParents, ",")),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			var prs []*v1beta1.PipelineRun
+			for i, parent := range tc.parents {
+				parentRun := &v1beta1.PipelineRun{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("parent-run-%d", i),
+						Namespace: namespace,
+						UID:       types.UID(fmt.Sprintf("parent-uid-%d", i)),
+					},
+					Spec: v1beta1.PipelineRunSpec{
+						PipelineRef: &v1beta1.PipelineRef{Name: parent},
+					},
+				}
+				if i > 0 {
+					parentRun.OwnerReferences = []metav1.OwnerReference{prs[i-1].GetOwnerReference()}
+				}
+				prs = append(prs, parentRun)
+			}
+			pr := &v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pipelinerun", Namespace: namespace},
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineRef: &v1beta1.PipelineRef{Name: tc.pipeline},
+				},
+			}
+			if len(prs) > 0 {
+				pr.OwnerReferences = []metav1.OwnerReference{prs[len(prs)-1].GetOwnerReference()}
+			}
+			if tc.ancestry != nil {
+				pr.Annotations = map[string]string{"tekton.dev/pipelineAncestry": *tc.ancestry}
+			}
+			d := test.Data{
+				PipelineRuns: append(prs, pr),
+				Pipelines:    []*v1beta1.Pipeline{recursive, other},
+			}
+			prt := NewPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun(namespace, "test-pipelinerun", nil, tc.wantReason != "")
+
+			var created []*v1beta1.PipelineRun
+			for _, a := range clients.Pipeline.Actions() {
+				if a.GetVerb() == "create" && a.GetResource().Resource == "pipelineruns" {
+					created = append(created, a.(ktesting.CreateAction).GetObject().(*v1beta1.PipelineRun))
+				}
+			}
+			if tc.wantReason == "" {
+				if len(created) != 1 {
+					t.Fatalf("Expected one child PipelineRun to be created, got %d", len(created))
+				}
+				if got := created[0].Annotations["tekton.dev/pipelineAncestry"]; got != "other" {
+					t.Errorf("Expected the child PipelineRun ancestry annotation to be %q, got %q", "other", got)
+				}
+				return
+			}
+			if len(created) != 0 {
+				t.Errorf("Expected no child PipelineRun to be created, got %v", created)
+			}
+			condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+			if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != tc.wantReason {
+				t.Errorf("Expected PipelineRun to fail with reason %s, got %v", tc.wantReason, condition)
+			}
+		})
+	}
+}
+
 func TestReconcile_PipelineSpecTaskSpec(t *testing.T) {
 	// TestReconcile_PipelineSpecTaskSpec runs "Reconcile" on a PipelineRun that has an embedded PipelineSpec that has an embedded TaskSpec.
 	// It verifies that a TaskRun is created, it checks the resulting API actions, status and events.
@@ -1426,6 +1633,206 @@ func TestReconcileOnCancelledPipelineRun(t *testing.T) {
 	}
 }
 
+func TestReconcileOnPendingPipelineRun(t *testing.T) {
+	// TestReconcileOnPendingPipelineRun runs "Reconcile" on a PipelineRun that is pending.
+	// It verifies that reconcile is successful, that the PipelineRun is not started and that no TaskRun is created.
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-pending",
+		tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccountName("test-sa"),
+			tb.PipelineRunPending,
+		),
+	)}
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))}
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-pending", nil, false)
+
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsUnknown() || condition.Reason != v1beta1.PipelineRunReasonPending.String() {
+		t.Errorf("Expected PipelineRun condition to indicate the pending state, but was %v", condition)
+	}
+	if reconciledRun.Status.StartTime != nil {
+		t.Errorf("Expected a pending PipelineRun not to have a StartTime, but was %v", reconciledRun.Status.StartTime)
+	}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			t.Errorf("Expected no TaskRun to be created for a pending PipelineRun, got %v", a)
+		}
+	}
+}
+
+func TestReconcileOnPipelineRunNoLongerPending(t *testing.T) {
+	// TestReconcileOnPipelineRunNoLongerPending runs "Reconcile" on a PipelineRun that was pending
+	// and whose spec status was cleared. It verifies that the PipelineRun starts normally.
+	names.TestingSeed()
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-pending",
+		tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccountName("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+			Reason: v1beta1.PipelineRunReasonPending.String(),
+		})),
+	)}
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))}
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-pending", nil, false)
+
+	if reconciledRun.Status.StartTime == nil {
+		t.Errorf("Expected the PipelineRun to have a StartTime once it is no longer pending")
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition.Reason != v1beta1.PipelineRunReasonRunning.String() {
+		t.Errorf("Expected PipelineRun to be running, but condition was %v", condition)
+	}
+	if len(getTaskRunCreations(t, clients.Pipeline.Actions())) != 1 {
+		t.Errorf("Expected one TaskRun to be created once the PipelineRun is no longer pending")
+	}
+}
+
+func TestReconcileOnGracefullyCancelledOrStoppedPipelineRun(t *testing.T) {
+	// TestReconcileOnGracefullyCancelledOrStoppedPipelineRun runs "Reconcile" on a PipelineRun which was
+	// gracefully cancelled or stopped. It verifies that the running DAG task is cancelled or awaited
+	// as requested, and that the finally task is scheduled once the DAG task is done.
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+		tb.PipelineTask("hello-world-2", "hello-world", tb.RunAfter("hello-world-1")),
+		tb.FinalPipelineTask("final-task-1", "hello-world"),
+	))}
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+	prName := "test-pipeline-run-graceful"
+	taskRunName := prName + "-hello-world-1"
+	running := tb.StatusCondition(apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: v1beta1.TaskRunReasonRunning.String(),
+	})
+
+	for _, tc := range []struct {
+		name          string
+		specStatus    v1beta1.PipelineRunSpecStatus
+		taskRunStatus tb.TaskRunStatusOp
+		wantCancelled bool
+		wantFinally   bool
+		wantReason    v1beta1.PipelineRunReason
+	}{{
+		name:          "gracefully cancelled with dag task running",
+		specStatus:    v1beta1.PipelineRunSpecStatusCancelledRunFinally,
+		taskRunStatus: running,
+		wantCancelled: true,
+		wantReason:    v1beta1.PipelineRunReasonCancelledRunningFinally,
+	}, {
+		name:       "gracefully cancelled with dag task cancelled",
+		specStatus: v1beta1.PipelineRunSpecStatusCancelledRunFinally,
+		taskRunStatus: tb.StatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: v1beta1.TaskRunReasonCancelled.String(),
+		}),
+		wantFinally: true,
+		wantReason:  v1beta1.PipelineRunReasonCancelledRunningFinally,
+	}, {
+		name:          "gracefully stopped with dag task running",
+		specStatus:    v1beta1.PipelineRunSpecStatusStoppedRunFinally,
+		taskRunStatus: running,
+		wantReason:    v1beta1.PipelineRunReasonStoppedRunningFinally,
+	}, {
+		name:       "gracefully stopped with dag task succeeded",
+		specStatus: v1beta1.PipelineRunSpecStatusStoppedRunFinally,
+		taskRunStatus: tb.StatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionTrue,
+		}),
+		wantFinally: true,
+		wantReason:  v1beta1.PipelineRunReasonStoppedRunningFinally,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1beta1.PipelineRun{tb.PipelineRun(prName,
+				tb.PipelineRunNamespace("foo"),
+				tb.PipelineRunSpec("test-pipeline",
+					tb.PipelineRunServiceAccountName("test-sa"),
+				),
+				tb.PipelineRunStatus(
+					tb.PipelineRunStartTime(time.Now()),
+					tb.PipelineRunTaskRunsStatus(taskRunName, &v1beta1.PipelineRunTaskRunStatus{
+						PipelineTaskName: "hello-world-1",
+						Status:           &v1beta1.TaskRunStatus{},
+					}),
+				),
+			)}
+			prs[0].Spec.Status = tc.specStatus
+			trs := []*v1beta1.TaskRun{
+				tb.TaskRun(taskRunName,
+					tb.TaskRunNamespace("foo"),
+					tb.TaskRunOwnerReference("PipelineRun", prName),
+					tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline"),
+					tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, prName),
+					tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "hello-world-1"),
+					tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+					tb.TaskRunStatus(tc.taskRunStatus),
+				),
+			}
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     trs,
+			}
+			prt := NewPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", prName, []string{"Normal Started"}, false)
+
+			cancelled := false
+			var created []string
+			for _, action := range clients.Pipeline.Actions() {
+				switch {
+				case action.Matches("patch", "taskruns"):
+					if action.(ktesting.PatchAction).GetName() == taskRunName {
+						cancelled = true
+					}
+				case action.Matches("create", "taskruns"):
+					created = append(created, action.(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun).Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey])
+				}
+			}
+			if cancelled != tc.wantCancelled {
+				t.Errorf("Expected TaskRun %s to be cancelled: %t, but got %t", taskRunName, tc.wantCancelled, cancelled)
+			}
+			var wantCreated []string
+			if tc.wantFinally {
+				wantCreated = []string{"final-task-1"}
+			}
+			if d := cmp.Diff(wantCreated, created); d != "" {
+				t.Errorf("Unexpected TaskRuns created %s", diff.PrintWantGot(d))
+			}
+			if reason := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Reason; reason != tc.wantReason.String() {
+				t.Errorf("Expected PipelineRun condition reason %s, but got %s", tc.wantReason, reason)
+			}
+		})
+	}
+}
+
 func TestReconcileWithTimeout(t *testing.T) {
 	// TestReconcileWithTimeout runs "Reconcile" on a PipelineRun that has timed out.
 	// It verifies that reconcile is successful, the pipeline status updated and events generated.
@@ -1479,6 +1886,109 @@ func TestReconcileWithTimeout(t *testing.T) {
 	}
 }
 
+func TestReconcileWithTasksTimeout(t *testing.T) {
+	// TestReconcileWithTasksTimeout runs "Reconcile" on a PipelineRun whose tasks timeout was reached.
+	// It verifies that the running DAG task is cancelled, and that the finally task is scheduled once
+	// the DAG task is done.
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+		tb.FinalPipelineTask("final-task-1", "hello-world"),
+	))}
+	ts := []*v1beta1.Task{tb.Task("hello-world", tb.TaskNamespace("foo"))}
+	prName := "test-pipeline-run-with-tasks-timeout"
+	taskRunName := prName + "-hello-world-1"
+
+	for _, tc := range []struct {
+		name          string
+		taskRunStatus tb.TaskRunStatusOp
+		wantCancelled bool
+		wantFinally   bool
+	}{{
+		name: "dag task running",
+		taskRunStatus: tb.StatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+			Reason: v1beta1.TaskRunReasonRunning.String(),
+		}),
+		wantCancelled: true,
+	}, {
+		name: "dag task cancelled",
+		taskRunStatus: tb.StatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: v1beta1.TaskRunReasonCancelled.String(),
+		}),
+		wantFinally: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1beta1.PipelineRun{tb.PipelineRun(prName,
+				tb.PipelineRunNamespace("foo"),
+				tb.PipelineRunSpec("test-pipeline",
+					tb.PipelineRunServiceAccountName("test-sa"),
+					tb.PipelineRunTimeouts(v1beta1.TimeoutFields{
+						Pipeline: &metav1.Duration{Duration: time.Hour},
+						Tasks:    &metav1.Duration{Duration: 10 * time.Minute},
+					}),
+				),
+				tb.PipelineRunStatus(
+					tb.PipelineRunStartTime(time.Now().Add(-20*time.Minute)),
+					tb.PipelineRunTaskRunsStatus(taskRunName, &v1beta1.PipelineRunTaskRunStatus{
+						PipelineTaskName: "hello-world-1",
+						Status:           &v1beta1.TaskRunStatus{},
+					}),
+				),
+			)}
+			trs := []*v1beta1.TaskRun{
+				tb.TaskRun(taskRunName,
+					tb.TaskRunNamespace("foo"),
+					tb.TaskRunOwnerReference("PipelineRun", prName),
+					tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineLabelKey, "test-pipeline"),
+					tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, prName),
+					tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "hello-world-1"),
+					tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+					tb.TaskRunStatus(tc.taskRunStatus),
+				),
+			}
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     trs,
+			}
+			prt := NewPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", prName, []string{"Normal Started"}, false)
+
+			cancelled := false
+			var created []string
+			for _, action := range clients.Pipeline.Actions() {
+				switch {
+				case action.Matches("patch", "taskruns"):
+					if action.(ktesting.PatchAction).GetName() == taskRunName {
+						cancelled = true
+					}
+				case action.Matches("create", "taskruns"):
+					created = append(created, action.(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun).Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey])
+				}
+			}
+			if cancelled != tc.wantCancelled {
+				t.Errorf("Expected TaskRun %s to be cancelled: %t, but got %t", taskRunName, tc.wantCancelled, cancelled)
+			}
+			var wantCreated []string
+			if tc.wantFinally {
+				wantCreated = []string{"final-task-1"}
+			}
+			if d := cmp.Diff(wantCreated, created); d != "" {
+				t.Errorf("Unexpected TaskRuns created %s", diff.PrintWantGot(d))
+			}
+			if (reconciledRun.Status.FinallyStartTime != nil) != tc.wantFinally {
+				t.Errorf("Expected FinallyStartTime to be set: %t, but got %v", tc.wantFinally, reconciledRun.Status.FinallyStartTime)
+			}
+		})
+	}
+}
+
 func TestReconcileWithoutPVC(t *testing.T) {
 	// TestReconcileWithoutPVC runs "Reconcile" on a PipelineRun that has two unrelated tasks.
 	// It verifies that reconcile is successful and that no PVC is created
@@ -1902,6 +2412,122 @@ func TestReconcileWithTimeoutAndRetry(t *testing.T) {
 	}
 }
 
+func TestReconcileWithRetryPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		retryPolicy        *v1beta1.RetryPolicy
+		reason             string
+		failedAgo          time.Duration
+		retries            int
+		conditionSucceeded corev1.ConditionStatus
+	}{{
+		name:               "retry is delayed",
+		retryPolicy:        &v1beta1.RetryPolicy{Count: 2, InitialDelay: &metav1.Duration{Duration: 10 * time.Second}},
+		reason:             v1beta1.TaskRunReasonFailed.String(),
+		failedAgo:          15 * time.Second,
+		retries:            1,
+		conditionSucceeded: corev1.ConditionFalse,
+	}, {
+		name:               "retry after the delay",
+		retryPolicy:        &v1beta1.RetryPolicy{Count: 2, InitialDelay: &metav1.Duration{Duration: 10 * time.Second}},
+		reason:             v1beta1.TaskRunReasonFailed.String(),
+		failedAgo:          time.Minute,
+		retries:            2,
+		conditionSucceeded: corev1.ConditionUnknown,
+	}, {
+		name:               "retried reason",
+		retryPolicy:        &v1beta1.RetryPolicy{Count: 2, Reasons: []string{"PodEvicted"}},
+		reason:             "PodEvicted",
+		failedAgo:          time.Minute,
+		retries:            2,
+		conditionSucceeded: corev1.ConditionUnknown,
+	}, {
+		name:               "reason not retried",
+		retryPolicy:        &v1beta1.RetryPolicy{Count: 2, Reasons: []string{"PodEvicted"}},
+		reason:             v1beta1.TaskRunReasonFailed.String(),
+		failedAgo:          time.Minute,
+		retries:            1,
+		conditionSucceeded: corev1.ConditionFalse,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := []*v1beta1.Pipeline{{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-retry", Namespace: "foo"},
+				Spec: v1beta1.PipelineSpec{
+					Tasks: []v1beta1.PipelineTask{{
+						Name:        "hello-world-1",
+						TaskRef:     &v1beta1.TaskRef{Name: "hello-world"},
+						RetryPolicy: tc.retryPolicy,
+					}},
+				},
+			}}
+			prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-retry-run", tb.PipelineRunNamespace("foo"),
+				tb.PipelineRunSpec("test-pipeline-retry",
+					tb.PipelineRunServiceAccountName("test-sa"),
+				),
+				tb.PipelineRunStatus(
+					tb.PipelineRunStartTime(time.Now().Add(-time.Hour))),
+			)}
+
+			ts := []*v1beta1.Task{
+				tb.Task("hello-world", tb.TaskNamespace("foo")),
+			}
+			trs := []*v1beta1.TaskRun{
+				tb.TaskRun("hello-world-1",
+					tb.TaskRunNamespace("foo"),
+					tb.TaskRunStatus(
+						tb.PodName("my-pod-name"),
+						tb.StatusCondition(apis.Condition{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionFalse,
+							Reason: tc.reason,
+						}),
+						tb.TaskRunCompletionTime(time.Now().Add(-tc.failedAgo)),
+						tb.Retry(v1beta1.TaskRunStatus{
+							Status: duckv1beta1.Status{
+								Conditions: []apis.Condition{{
+									Type:   apis.ConditionSucceeded,
+									Status: corev1.ConditionFalse,
+									Reason: tc.reason,
+								}},
+							},
+						}),
+					)),
+			}
+
+			prs[0].Status.TaskRuns = map[string]*v1beta1.PipelineRunTaskRunStatus{
+				"hello-world-1": {
+					PipelineTaskName: "hello-world-1",
+					Status:           &trs[0].Status,
+				},
+			}
+
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     trs,
+			}
+			prt := NewPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-retry-run", []string{}, false)
+
+			status := reconciledRun.Status.TaskRuns["hello-world-1"].Status
+			if len(status.RetriesStatus) != tc.retries {
+				t.Fatalf("%d retries expected but got %d", tc.retries, len(status.RetriesStatus))
+			}
+			if got := status.GetCondition(apis.ConditionSucceeded).Status; got != tc.conditionSucceeded {
+				t.Fatalf("Succeeded expected to be %s but is %s", tc.conditionSucceeded, got)
+			}
+			for i, retry := range status.RetriesStatus {
+				if got := retry.GetCondition(apis.ConditionSucceeded).GetReason(); got != tc.reason {
+					t.Errorf("expected reason %q for attempt %d but got %q", tc.reason, i, got)
+				}
+			}
+		})
+	}
+}
+
 func TestReconcilePropagateAnnotations(t *testing.T) {
 	names.TestingSeed()
 
@@ -2046,6 +2672,77 @@ func TestGetTaskRunTimeout(t *testing.T) {
 	}
 }
 
+func TestGetTaskRunTimeout_TasksAndFinallyTimeouts(t *testing.T) {
+	now := time.Now()
+	for _, tc := range []struct {
+		name             string
+		timeouts         *v1beta1.TimeoutFields
+		startTime        time.Time
+		finallyStartTime *metav1.Time
+		taskTimeout      *metav1.Duration
+		expected         time.Duration
+	}{{
+		name:      "capped by the time left in the tasks timeout",
+		timeouts:  &v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: time.Hour}, Tasks: &metav1.Duration{Duration: 10 * time.Minute}},
+		startTime: now.Add(-4 * time.Minute),
+		expected:  6 * time.Minute,
+	}, {
+		name:        "PipelineTask timeout shorter than the time left in the tasks timeout",
+		timeouts:    &v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: time.Hour}, Tasks: &metav1.Duration{Duration: 10 * time.Minute}},
+		startTime:   now.Add(-4 * time.Minute),
+		taskTimeout: &metav1.Duration{Duration: 2 * time.Minute},
+		expected:    2 * time.Minute,
+	}, {
+		name:        "PipelineTask timeout longer than the time left in the tasks timeout",
+		timeouts:    &v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: time.Hour}, Tasks: &metav1.Duration{Duration: 10 * time.Minute}},
+		startTime:   now.Add(-4 * time.Minute),
+		taskTimeout: &metav1.Duration{Duration: 8 * time.Minute},
+		expected:    6 * time.Minute,
+	}, {
+		name:      "tasks timeout computed from the pipeline and finally timeouts",
+		timeouts:  &v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: time.Hour}, Finally: &metav1.Duration{Duration: 20 * time.Minute}},
+		startTime: now.Add(-30 * time.Minute),
+		expected:  10 * time.Minute,
+	}, {
+		name:      "tasks timeout elapsed",
+		timeouts:  &v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: time.Hour}, Tasks: &metav1.Duration{Duration: 10 * time.Minute}},
+		startTime: now.Add(-11 * time.Minute),
+		expected:  time.Second,
+	}, {
+		name:             "capped by the time left in the finally timeout",
+		timeouts:         &v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: time.Hour}, Finally: &metav1.Duration{Duration: 5 * time.Minute}},
+		startTime:        now.Add(-20 * time.Minute),
+		finallyStartTime: &metav1.Time{Time: now.Add(-time.Minute)},
+		expected:         4 * time.Minute,
+	}, {
+		name:             "finally tasks without a timeout of their own",
+		timeouts:         &v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: time.Hour}, Tasks: &metav1.Duration{Duration: 10 * time.Minute}, Finally: &metav1.Duration{Duration: 0}},
+		startTime:        now.Add(-20 * time.Minute),
+		finallyStartTime: &metav1.Time{Time: now.Add(-time.Minute)},
+		expected:         time.Hour,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-timeouts", Namespace: "foo"},
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+					Timeouts:    tc.timeouts,
+				},
+			}
+			pr.Status.StartTime = &metav1.Time{Time: tc.startTime}
+			pr.Status.FinallyStartTime = tc.finallyStartTime
+			rprt := &resources.ResolvedPipelineRunTask{
+				PipelineTask: &v1beta1.PipelineTask{Timeout: tc.taskTimeout},
+			}
+			// The time left in the timeouts decreases while the test runs.
+			got := getTaskRunTimeout(context.TODO(), pr, rprt).Duration
+			if got > tc.expected || got < tc.expected-time.Second {
+				t.Errorf("Unexpected task run timeout: expected about %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
 // TestReconcileAndPropagateCustomPipelineTaskRunSpec tests that custom PipelineTaskRunSpec declared
 // in PipelineRun is propagated to created TaskRuns
 func TestReconcileAndPropagateCustomPipelineTaskRunSpec(t *testing.T) {
@@ -2066,6 +2763,19 @@ func TestReconcileAndPropagateCustomPipelineTaskRunSpec(t *testing.T) {
 						"workloadtype": "tekton",
 					},
 				},
+				ComputeResources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				},
+				StepOverrides: []v1beta1.TaskRunStepOverride{{
+					Name: "hello",
+					Env:  []corev1.EnvVar{{Name: "GREETING", Value: "bonjour"}},
+				}},
+				SidecarOverrides: []v1beta1.TaskRunSidecarOverride{{
+					Name: "proxy",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+					},
+				}},
 			}}),
 		),
 	)}
@@ -2106,6 +2816,19 @@ func TestReconcileAndPropagateCustomPipelineTaskRunSpec(t *testing.T) {
 					"workloadtype": "tekton",
 				},
 			}),
+			tb.TaskRunComputeResources(&corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			}),
+			tb.TaskRunStepOverrides(v1beta1.TaskRunStepOverride{
+				Name: "hello",
+				Env:  []corev1.EnvVar{{Name: "GREETING", Value: "bonjour"}},
+			}),
+			tb.TaskRunSidecarOverrides(v1beta1.TaskRunSidecarOverride{
+				Name: "proxy",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+				},
+			}),
 		),
 	)
 
@@ -2500,7 +3223,8 @@ func TestReconcileWithWhenExpressionsWithParameters(t *testing.T) {
 
 	actualSkippedTasks := pipelineRun.Status.SkippedTasks
 	expectedSkippedTasks := []v1beta1.SkippedTask{{
-		Name: "hello-world-2",
+		Name:   "hello-world-2",
+		Reason: v1beta1.WhenExpressionsSkip,
 		WhenExpressions: v1beta1.WhenExpressions{{
 			Input:    "yes",
 			Operator: "notin",
@@ -2640,14 +3364,16 @@ func TestReconcileWithWhenExpressionsWithTaskResults(t *testing.T) {
 
 	actualSkippedTasks := pipelineRun.Status.SkippedTasks
 	expectedSkippedTasks := []v1beta1.SkippedTask{{
-		Name: "c-task",
+		Name:   "c-task",
+		Reason: v1beta1.WhenExpressionsSkip,
 		WhenExpressions: v1beta1.WhenExpressions{{
 			Input:    "aResultValue",
 			Operator: "in",
 			Values:   []string{"missing"},
 		}},
 	}, {
-		Name: "d-task",
+		Name:   "d-task",
+		Reason: v1beta1.ParentTasksSkip,
 	}}
 	if d := cmp.Diff(actualSkippedTasks, expectedSkippedTasks); d != "" {
 		t.Errorf("expected to find Skipped Tasks %v. Diff %s", expectedSkippedTasks, diff.PrintWantGot(d))
@@ -2669,6 +3395,121 @@ func TestReconcileWithWhenExpressionsWithTaskResults(t *testing.T) {
 	}
 }
 
+func TestReconcileWithWhenExpressionsWithCEL(t *testing.T) {
+	names.TestingSeed()
+	celWhen := func(expression string) tb.PipelineTaskOp {
+		return func(pt *v1beta1.PipelineTask) {
+			pt.WhenExpressions = append(pt.WhenExpressions, v1beta1.WhenExpression{CEL: expression})
+		}
+	}
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineParamSpec("branch", v1beta1.ParamTypeString),
+		tb.PipelineTask("a-task", "a-task"),
+		tb.PipelineTask("b-task", "b-task",
+			celWhen("params.branch == 'main' && int(tasks['a-task'].results.coverage) > 80"),
+		),
+		tb.PipelineTask("c-task", "c-task",
+			celWhen("context.pipelineRun.namespace != 'foo'"),
+		),
+	))}
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-cel", tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunParam("branch", "main"),
+		),
+	)}
+	ts := []*v1beta1.Task{
+		tb.Task("a-task", tb.TaskNamespace("foo")),
+		tb.Task("b-task", tb.TaskNamespace("foo")),
+		tb.Task("c-task", tb.TaskNamespace("foo")),
+	}
+	trs := []*v1beta1.TaskRun{
+		tb.TaskRun("test-pipeline-run-cel-a-task-xxyyy",
+			tb.TaskRunNamespace("foo"),
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-cel",
+				tb.OwnerReferenceAPIVersion("tekton.dev/v1beta1"),
+				tb.Controller, tb.BlockOwnerDeletion,
+			),
+			tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
+			tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-cel"),
+			tb.TaskRunLabel("tekton.dev/pipelineTask", "a-task"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("a-task")),
+			tb.TaskRunStatus(
+				tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}),
+				tb.TaskRunResult("coverage", "85"),
+			),
+		),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 1 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 1",
+	}
+	pipelineRun, clients := prt.reconcileRun("foo", "test-pipeline-run-cel", wantEvents, false)
+
+	for pipelineTask, want := range map[string]int{"b-task": 1, "c-task": 0} {
+		actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("tekton.dev/pipelineTask=%s,tekton.dev/pipelineRun=test-pipeline-run-cel", pipelineTask),
+		})
+		if err != nil {
+			t.Fatalf("Failure to list TaskRun's %s", err)
+		}
+		if len(actual.Items) != want {
+			t.Errorf("Expected %d TaskRuns for %s, got %d", want, pipelineTask, len(actual.Items))
+		}
+	}
+
+	expectedSkippedTasks := []v1beta1.SkippedTask{{
+		Name:            "c-task",
+		Reason:          v1beta1.WhenExpressionsSkip,
+		WhenExpressions: v1beta1.WhenExpressions{{CEL: "context.pipelineRun.namespace != 'foo'"}},
+	}}
+	if d := cmp.Diff(expectedSkippedTasks, pipelineRun.Status.SkippedTasks); d != "" {
+		t.Errorf("expected to find Skipped Tasks %v. Diff %s", expectedSkippedTasks, diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcileWithWhenExpressionCELEvaluationFailed(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1beta1.Pipeline{tb.Pipeline("test-pipeline", tb.PipelineNamespace("foo"), tb.PipelineSpec(
+		tb.PipelineTask("a-task", "a-task", func(pt *v1beta1.PipelineTask) {
+			pt.WhenExpressions = v1beta1.WhenExpressions{{CEL: "params.missing == 'main'"}}
+		}),
+	))}
+	prs := []*v1beta1.PipelineRun{tb.PipelineRun("test-pipeline-run-cel", tb.PipelineRunNamespace("foo"),
+		tb.PipelineRunSpec("test-pipeline"),
+	)}
+	ts := []*v1beta1.Task{tb.Task("a-task", tb.TaskNamespace("foo"))}
+
+	prt := NewPipelineRunTest(test.Data{PipelineRuns: prs, Pipelines: ps, Tasks: ts}, t)
+	defer prt.Cancel()
+
+	pipelineRun, clients := prt.reconcileRun("foo", "test-pipeline-run-cel", nil, true)
+
+	condition := pipelineRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != ReasonWhenExpressionEvaluationFailed {
+		t.Errorf("Expected PipelineRun to fail with reason %s, got %v", ReasonWhenExpressionEvaluationFailed, condition)
+	}
+	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failure to list TaskRun's %s", err)
+	}
+	if len(actual.Items) != 0 {
+		t.Errorf("Expected no TaskRun to be created, got %d", len(actual.Items))
+	}
+}
+
 // TestReconcileWithAffinityAssistantStatefulSet tests that given a pipelineRun with workspaces,
 // an Affinity Assistant StatefulSet is created for each PVC workspace and
 // that the Affinity Assistant names is propagated to TaskRuns.
@@ -4729,7 +5570,7 @@ func TestReconcileWithTaskResultsInFinalTasks(t *testing.T) {
 				}},
 			},
 			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
-				TaskRunResults: []v1beta1.TaskRunResult{{Name: "aResult", Value: "aResultValue"}},
+				TaskRunResults: []v1beta1.TaskRunResult{{Name: "aResult", Value: *v1beta1.NewArrayOrString("aResultValue")}},
 			},
 		},
 	}, {
@@ -4817,7 +5658,8 @@ func TestReconcileWithTaskResultsInFinalTasks(t *testing.T) {
 		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRunName, diff.PrintWantGot(d))
 	}
 	expectedSkippedTasks := []v1beta1.SkippedTask{{
-		Name: "final-task-2",
+		Name:   "final-task-2",
+		Reason: v1beta1.MissingResultsSkip,
 	}}
 
 	if d := cmp.Diff(expectedSkippedTasks, reconciledRun.Status.SkippedTasks); d != "" {
@@ -5116,6 +5958,108 @@ func TestReconcile_OptionalWorkspacesOmitted(t *testing.T) {
 	}
 }
 
+// TestReconcile_Matrix runs "Reconcile" on a PipelineRun with a matrixed PipelineTask.
+// It verifies that a TaskRun is created for every combination of the matrix parameters and
+// that all the TaskRuns are tracked in the status under the same PipelineTask.
+func TestReconcile_Matrix(t *testing.T) {
+	names.TestingSeed()
+
+	prs := []*v1beta1.PipelineRun{{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-matrix", Namespace: "foo"},
+		Spec: v1beta1.PipelineRunSpec{
+			ServiceAccountName: "test-sa",
+			Params: []v1beta1.Param{{
+				Name:  "platforms",
+				Value: *v1beta1.NewArrayOrString("linux", "mac"),
+			}},
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Params: []v1beta1.ParamSpec{{
+					Name: "platforms",
+					Type: v1beta1.ParamTypeArray,
+				}},
+				Tasks: []v1beta1.PipelineTask{{
+					Name: "build",
+					TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+						Params: []v1beta1.ParamSpec{{
+							Name: "platform",
+						}, {
+							Name: "version",
+						}},
+						Steps: []v1beta1.Step{{
+							Container: corev1.Container{
+								Image: "foo:latest",
+							},
+						}},
+					}},
+					Matrix: []v1beta1.Param{{
+						Name: "platform",
+						Value: v1beta1.ArrayOrString{
+							Type:     v1beta1.ParamTypeArray,
+							ArrayVal: []string{"$(params.platforms[*])"},
+						},
+					}, {
+						Name:  "version",
+						Value: *v1beta1.NewArrayOrString("1.15", "1.16"),
+					}},
+				}},
+			},
+		},
+	}}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: prs[0].Spec.ServiceAccountName, Namespace: "foo"},
+		}},
+	}
+
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-matrix", wantEvents, false)
+
+	var gotParams [][]v1beta1.Param
+	for _, tr := range getTaskRunCreations(t, clients.Pipeline.Actions()) {
+		if tr.Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey] != "build" {
+			t.Errorf("Expected TaskRun %s to be labelled with pipeline task build but got %v", tr.Name, tr.Labels)
+		}
+		gotParams = append(gotParams, tr.Spec.Params)
+	}
+	wantParams := [][]v1beta1.Param{{
+		{Name: "platform", Value: *v1beta1.NewArrayOrString("linux")},
+		{Name: "version", Value: *v1beta1.NewArrayOrString("1.15")},
+	}, {
+		{Name: "platform", Value: *v1beta1.NewArrayOrString("linux")},
+		{Name: "version", Value: *v1beta1.NewArrayOrString("1.16")},
+	}, {
+		{Name: "platform", Value: *v1beta1.NewArrayOrString("mac")},
+		{Name: "version", Value: *v1beta1.NewArrayOrString("1.15")},
+	}, {
+		{Name: "platform", Value: *v1beta1.NewArrayOrString("mac")},
+		{Name: "version", Value: *v1beta1.NewArrayOrString("1.16")},
+	}}
+	if d := cmp.Diff(wantParams, gotParams); d != "" {
+		t.Errorf("Expected a TaskRun for every combination of the matrix. Diff %s", diff.PrintWantGot(d))
+	}
+
+	wantTaskRunNames := resources.GetNamesOfTaskRuns("build", "test-pipeline-run-matrix", 4)
+	if len(reconciledRun.Status.TaskRuns) != len(wantTaskRunNames) {
+		t.Errorf("Expected PipelineRun status to include %d TaskRun status items but got %v", len(wantTaskRunNames), reconciledRun.Status.TaskRuns)
+	}
+	for _, taskRunName := range wantTaskRunNames {
+		trs, exists := reconciledRun.Status.TaskRuns[taskRunName]
+		if !exists {
+			t.Errorf("Expected PipelineRun status to include TaskRun %s but was %v", taskRunName, reconciledRun.Status.TaskRuns)
+		} else if trs.PipelineTaskName != "build" {
+			t.Errorf("Expected TaskRun %s to be tracked under pipeline task build but got %s", taskRunName, trs.PipelineTaskName)
+		}
+	}
+}
+
 func getTaskRunWithTaskSpec(tr, pr, p, t string, labels, annotations map[string]string) *v1beta1.TaskRun {
 	return tb.TaskRun(tr,
 		tb.TaskRunNamespace("foo"),
@@ -29,31 +29,40 @@ import (
 func ApplyParameters(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) *v1beta1.PipelineSpec {
 	// This assumes that the PipelineRun inputs have been validated against what the Pipeline requests.
 
-	// stringReplacements is used for standard single-string stringReplacements, while arrayReplacements contains arrays
-	// that need to be further processed.
+	// stringReplacements is used for standard single-string stringReplacements, including the keys of objects,
+	// while arrayReplacements and objectReplacements contain arrays and objects that need to be further processed.
 	stringReplacements := map[string]string{}
 	arrayReplacements := map[string][]string{}
+	objectReplacements := map[string]map[string]string{}
 
 	// Set all the default stringReplacements
 	for _, p := range p.Params {
 		if p.Default != nil {
-			if p.Default.Type == v1beta1.ParamTypeString {
-				stringReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Default.StringVal
-			} else {
-				arrayReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Default.ArrayVal
-			}
+			addReplacements(fmt.Sprintf("params.%s", p.Name), *p.Default, stringReplacements, arrayReplacements, objectReplacements)
 		}
 	}
 	// Set and overwrite params with the ones from the PipelineRun
 	for _, p := range pr.Spec.Params {
-		if p.Value.Type == v1beta1.ParamTypeString {
-			stringReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Value.StringVal
-		} else {
-			arrayReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Value.ArrayVal
-		}
+		addReplacements(fmt.Sprintf("params.%s", p.Name), p.Value, stringReplacements, arrayReplacements, objectReplacements)
 	}
 
-	return ApplyReplacements(p, stringReplacements, arrayReplacements)
+	return ApplyReplacements(p, stringReplacements, arrayReplacements, objectReplacements)
+}
+
+// addReplacements adds the replacements for the variable key holding value to the replacements of its type.
+// The keys of an object are also added to stringReplacements, e.g. "params.foo.bar".
+func addReplacements(key string, value v1beta1.ArrayOrString, stringReplacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) {
+	switch value.Type {
+	case v1beta1.ParamTypeString:
+		stringReplacements[key] = value.StringVal
+	case v1beta1.ParamTypeObject:
+		objectReplacements[key] = value.ObjectVal
+		for k, v := range value.ObjectVal {
+			stringReplacements[fmt.Sprintf("%s.%s", key, k)] = v
+		}
+	default:
+		arrayReplacements[key] = value.ArrayVal
+	}
 }
 
 // ApplyContexts applies the substitution from $(context.(pipelineRun|pipeline).*) with the specified values.
@@ -65,22 +74,23 @@ func ApplyContexts(spec *v1beta1.PipelineSpec, pipelineName string, pr *v1beta1.
 		"context.pipelineRun.namespace": pr.Namespace,
 		"context.pipelineRun.uid":       string(pr.ObjectMeta.UID),
 	}
-	return ApplyReplacements(spec, replacements, map[string][]string{})
+	return ApplyReplacements(spec, replacements, map[string][]string{}, map[string]map[string]string{})
 }
 
 // ApplyTaskResults applies the ResolvedResultRef to each PipelineTask.Params and Pipeline.WhenExpressions in targets
 func ApplyTaskResults(targets PipelineRunState, resolvedResultRefs ResolvedResultRefs) {
-	stringReplacements := resolvedResultRefs.getStringReplacements()
+	stringReplacements, arrayReplacements, objectReplacements := resolvedResultRefs.getReplacements()
 	for _, resolvedPipelineRunTask := range targets {
 		// also make substitution for resolved condition checks
 		for _, resolvedConditionCheck := range resolvedPipelineRunTask.ResolvedConditionChecks {
 			pipelineTaskCondition := resolvedConditionCheck.PipelineTaskCondition.DeepCopy()
-			pipelineTaskCondition.Params = replaceParamValues(pipelineTaskCondition.Params, stringReplacements, nil)
+			pipelineTaskCondition.Params = replaceParamValues(pipelineTaskCondition.Params, stringReplacements, arrayReplacements, objectReplacements)
 			resolvedConditionCheck.PipelineTaskCondition = pipelineTaskCondition
 		}
 		if resolvedPipelineRunTask.PipelineTask != nil {
 			pipelineTask := resolvedPipelineRunTask.PipelineTask.DeepCopy()
-			pipelineTask.Params = replaceParamValues(pipelineTask.Params, stringReplacements, nil)
+			pipelineTask.Params = replaceParamValues(pipelineTask.Params, stringReplacements, arrayReplacements, objectReplacements)
+			pipelineTask.Matrix = replaceParamValues(pipelineTask.Matrix, stringReplacements, arrayReplacements, objectReplacements)
 			pipelineTask.WhenExpressions = pipelineTask.WhenExpressions.ReplaceWhenExpressionsVariables(stringReplacements)
 			resolvedPipelineRunTask.PipelineTask = pipelineTask
 		}
@@ -92,7 +102,8 @@ func ApplyPipelineTaskContext(state PipelineRunState, replacements map[string]st
 	for _, resolvedPipelineRunTask := range state {
 		if resolvedPipelineRunTask.PipelineTask != nil {
 			pipelineTask := resolvedPipelineRunTask.PipelineTask.DeepCopy()
-			pipelineTask.Params = replaceParamValues(pipelineTask.Params, replacements, nil)
+			pipelineTask.Params = replaceParamValues(pipelineTask.Params, replacements, nil, nil)
+			pipelineTask.WhenExpressions = pipelineTask.WhenExpressions.ReplaceWhenExpressionsVariables(replacements)
 			resolvedPipelineRunTask.PipelineTask = pipelineTask
 		}
 	}
@@ -111,45 +122,48 @@ func ApplyWorkspaces(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) *v1beta1.
 		key := fmt.Sprintf("workspaces.%s.bound", boundWorkspace.Name)
 		replacements[key] = "true"
 	}
-	return ApplyReplacements(p, replacements, map[string][]string{})
+	return ApplyReplacements(p, replacements, map[string][]string{}, map[string]map[string]string{})
 }
 
 // ApplyReplacements replaces placeholders for declared parameters with the specified replacements.
-func ApplyReplacements(p *v1beta1.PipelineSpec, replacements map[string]string, arrayReplacements map[string][]string) *v1beta1.PipelineSpec {
+func ApplyReplacements(p *v1beta1.PipelineSpec, replacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) *v1beta1.PipelineSpec {
 	p = p.DeepCopy()
 
 	for i := range p.Tasks {
-		p.Tasks[i].Params = replaceParamValues(p.Tasks[i].Params, replacements, arrayReplacements)
+		p.Tasks[i].Params = replaceParamValues(p.Tasks[i].Params, replacements, arrayReplacements, objectReplacements)
+		p.Tasks[i].Matrix = replaceParamValues(p.Tasks[i].Matrix, replacements, arrayReplacements, objectReplacements)
 		for j := range p.Tasks[i].Conditions {
 			c := p.Tasks[i].Conditions[j]
-			c.Params = replaceParamValues(c.Params, replacements, arrayReplacements)
+			c.Params = replaceParamValues(c.Params, replacements, arrayReplacements, objectReplacements)
 		}
 		p.Tasks[i].WhenExpressions = p.Tasks[i].WhenExpressions.ReplaceWhenExpressionsVariables(replacements)
 	}
 
 	for i := range p.Finally {
-		p.Finally[i].Params = replaceParamValues(p.Finally[i].Params, replacements, arrayReplacements)
+		p.Finally[i].Params = replaceParamValues(p.Finally[i].Params, replacements, arrayReplacements, objectReplacements)
+		p.Finally[i].Matrix = replaceParamValues(p.Finally[i].Matrix, replacements, arrayReplacements, objectReplacements)
 	}
 
 	return p
 }
 
-func replaceParamValues(params []v1beta1.Param, stringReplacements map[string]string, arrayReplacements map[string][]string) []v1beta1.Param {
+func replaceParamValues(params []v1beta1.Param, stringReplacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) []v1beta1.Param {
 	for i := range params {
-		params[i].Value.ApplyReplacements(stringReplacements, arrayReplacements)
+		params[i].Value.ApplyReplacements(stringReplacements, arrayReplacements, objectReplacements)
 	}
 	return params
 }
 
-// ApplyTaskResultsToPipelineResults applies the results of completed TasksRuns and Runs to a Pipeline's
-// list of PipelineResults, returning the computed set of PipelineRunResults. References to
-// non-existent TaskResults or failed TaskRuns or Runs result in a PipelineResult being considered invalid
+// ApplyTaskResultsToPipelineResults applies the results of completed TasksRuns, Runs and child PipelineRuns
+// to a Pipeline's list of PipelineResults, returning the computed set of PipelineRunResults. References to
+// non-existent TaskResults or failed TaskRuns, Runs or PipelineRuns result in a PipelineResult being considered invalid
 // and omitted from the returned slice. A nil slice is returned if no results are passed in or all
 // results are invalid.
 func ApplyTaskResultsToPipelineResults(
 	results []v1beta1.PipelineResult,
 	taskRunStatuses map[string]*v1beta1.PipelineRunTaskRunStatus,
-	runStatuses map[string]*v1beta1.PipelineRunRunStatus) []v1beta1.PipelineRunResult {
+	runStatuses map[string]*v1beta1.PipelineRunRunStatus,
+	pipelineRunStatuses map[string]*v1beta1.PipelineRunPipelineRunStatus) []v1beta1.PipelineRunResult {
 
 	taskStatuses := map[string]*v1beta1.PipelineRunTaskRunStatus{}
 	for _, trStatus := range taskRunStatuses {
@@ -159,6 +173,10 @@ func ApplyTaskResultsToPipelineResults(
 	for _, runStatus := range runStatuses {
 		customTaskStatuses[runStatus.PipelineTaskName] = runStatus
 	}
+	childPipelineStatuses := map[string]*v1beta1.PipelineRunPipelineRunStatus{}
+	for _, prStatus := range pipelineRunStatuses {
+		childPipelineStatuses[prStatus.PipelineTaskName] = prStatus
+	}
 
 	var runResults []v1beta1.PipelineRunResult = nil
 	stringReplacements := map[string]string{}
@@ -176,6 +194,8 @@ func ApplyTaskResultsToPipelineResults(
 					stringReplacements[variable] = *resultValue
 				} else if resultValue := runResultValue(taskName, resultName, customTaskStatuses); resultValue != nil {
 					stringReplacements[variable] = *resultValue
+				} else if resultValue := pipelineRunResultValue(taskName, resultName, childPipelineStatuses); resultValue != nil {
+					stringReplacements[variable] = *resultValue
 				} else {
 					validPipelineResult = false
 				}
@@ -200,8 +220,12 @@ func ApplyTaskResultsToPipelineResults(
 }
 
 // taskResultValue checks if a TaskRun result exists for a given pipeline task and result name.
+// The result name may reference an element of an array result, e.g. "files[1]", but whole
+// array and object results cannot be used as the value of a PipelineResult.
 // A nil pointer is returned if the variable is invalid for any reason.
 func taskResultValue(taskName string, resultName string, taskStatuses map[string]*v1beta1.PipelineRunTaskRunStatus) *string {
+	index, indexed := v1beta1.ParseResultIndex(resultName)
+	resultName = strings.SplitN(resultName, "[", 2)[0]
 
 	status, taskExists := taskStatuses[taskName]
 	if !taskExists || status.Status == nil {
@@ -214,9 +238,16 @@ func taskResultValue(taskName string, resultName string, taskStatuses map[string
 	}
 
 	for _, trResult := range status.Status.TaskRunResults {
-		if trResult.Name == resultName {
-			return &trResult.Value
+		if trResult.Name != resultName {
+			continue
+		}
+		switch {
+		case indexed && trResult.Value.Type == v1beta1.ParamTypeArray && index < len(trResult.Value.ArrayVal):
+			return &trResult.Value.ArrayVal[index]
+		case !indexed && trResult.Value.Type == v1beta1.ParamTypeString:
+			return &trResult.Value.StringVal
 		}
+		return nil
 	}
 	return nil
 }
@@ -242,3 +273,25 @@ func runResultValue(taskName string, resultName string, runStatuses map[string]*
 	}
 	return nil
 }
+
+// pipelineRunResultValue checks if a child PipelineRun result exists for a given pipeline task and result name.
+// A nil pointer is returned if the variable is invalid for any reason.
+func pipelineRunResultValue(taskName string, resultName string, pipelineRunStatuses map[string]*v1beta1.PipelineRunPipelineRunStatus) *string {
+
+	status, pipelineRunExists := pipelineRunStatuses[taskName]
+	if !pipelineRunExists || status.Status == nil {
+		return nil
+	}
+
+	cond := status.Status.GetCondition(apis.ConditionSucceeded)
+	if cond == nil || cond.Status != corev1.ConditionTrue {
+		return nil
+	}
+
+	for _, pipelineRunResult := range status.Status.PipelineResults {
+		if pipelineRunResult.Name == resultName {
+			return &pipelineRunResult.Value
+		}
+	}
+	return nil
+}
@@ -251,6 +251,47 @@ func TestApplyParameters(t *testing.T) {
 				},
 			}},
 		},
+	}, {
+		name: "object parameters",
+		original: v1beta1.PipelineSpec{
+			Params: []v1beta1.ParamSpec{
+				{Name: "first-param", Type: v1beta1.ParamTypeObject, Default: v1beta1.NewObject(map[string]string{"url": "default-url", "revision": "default-revision"})},
+				{Name: "second-param", Type: v1beta1.ParamTypeObject},
+			},
+			Tasks: []v1beta1.PipelineTask{{
+				Params: []v1beta1.Param{
+					{Name: "first-task-first-param", Value: *v1beta1.NewArrayOrString("$(params.first-param.url)")},
+					{Name: "first-task-second-param", Value: *v1beta1.NewArrayOrString("$(params.second-param[*])")},
+					{Name: "first-task-third-param", Value: *v1beta1.NewObject(map[string]string{"url": "$(params.second-param.url)", "revision": "static"})},
+					{Name: "first-task-fourth-param", Value: *v1beta1.NewArrayOrString("$(params.first-param.revision)", "$(params.second-param.revision)")},
+				},
+				WhenExpressions: []v1beta1.WhenExpression{{
+					Input:    "$(params.second-param.revision)",
+					Operator: selection.In,
+					Values:   []string{"$(params.first-param.revision)"},
+				}},
+			}},
+		},
+		params: []v1beta1.Param{{Name: "second-param", Value: *v1beta1.NewObject(map[string]string{"url": "second-url", "revision": "second-revision"})}},
+		expected: v1beta1.PipelineSpec{
+			Params: []v1beta1.ParamSpec{
+				{Name: "first-param", Type: v1beta1.ParamTypeObject, Default: v1beta1.NewObject(map[string]string{"url": "default-url", "revision": "default-revision"})},
+				{Name: "second-param", Type: v1beta1.ParamTypeObject},
+			},
+			Tasks: []v1beta1.PipelineTask{{
+				Params: []v1beta1.Param{
+					{Name: "first-task-first-param", Value: *v1beta1.NewArrayOrString("default-url")},
+					{Name: "first-task-second-param", Value: *v1beta1.NewObject(map[string]string{"url": "second-url", "revision": "second-revision"})},
+					{Name: "first-task-third-param", Value: *v1beta1.NewObject(map[string]string{"url": "second-url", "revision": "static"})},
+					{Name: "first-task-fourth-param", Value: *v1beta1.NewArrayOrString("default-revision", "second-revision")},
+				},
+				WhenExpressions: []v1beta1.WhenExpression{{
+					Input:    "second-revision",
+					Operator: selection.In,
+					Values:   []string{"default-revision"},
+				}},
+			}},
+		},
 	}} {
 		tt := tt // capture range variable
 		t.Run(tt.name, func(t *testing.T) {
@@ -426,6 +467,89 @@ func TestApplyTaskResults_EmbeddedExpression(t *testing.T) {
 	}
 }
 
+func TestApplyTaskResults_ArrayResults(t *testing.T) {
+	resolvedResultRefs := ResolvedResultRefs{{
+		Value: *v1beta1.NewArrayOrString("first", "second"),
+		ResultReference: v1beta1.ResultRef{
+			PipelineTask: "aTask",
+			Result:       "aResult",
+		},
+		FromTaskRun: "aTaskRun",
+	}}
+	for _, tt := range []struct {
+		name    string
+		targets PipelineRunState
+		want    PipelineRunState
+	}{{
+		name: "Test whole array result substitution - params",
+		targets: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "bTask",
+				TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+				Params: []v1beta1.Param{{
+					Name:  "bParam",
+					Value: *v1beta1.NewArrayOrString("$(tasks.aTask.results.aResult[*])"),
+				}, {
+					Name:  "cParam",
+					Value: *v1beta1.NewArrayOrString("zeroth", "$(tasks.aTask.results.aResult[*])"),
+				}},
+			},
+		}},
+		want: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "bTask",
+				TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+				Params: []v1beta1.Param{{
+					Name:  "bParam",
+					Value: *v1beta1.NewArrayOrString("first", "second"),
+				}, {
+					Name:  "cParam",
+					Value: *v1beta1.NewArrayOrString("zeroth", "first", "second"),
+				}},
+			},
+		}},
+	}, {
+		name: "Test array element result substitution - params and when expressions",
+		targets: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "bTask",
+				TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+				Params: []v1beta1.Param{{
+					Name:  "bParam",
+					Value: *v1beta1.NewArrayOrString("Result value --> $(tasks.aTask.results.aResult[1])"),
+				}},
+				WhenExpressions: []v1beta1.WhenExpression{{
+					Input:    "$(tasks.aTask.results.aResult[0])",
+					Operator: selection.In,
+					Values:   []string{"first"},
+				}},
+			},
+		}},
+		want: PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "bTask",
+				TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+				Params: []v1beta1.Param{{
+					Name:  "bParam",
+					Value: *v1beta1.NewArrayOrString("Result value --> second"),
+				}},
+				WhenExpressions: []v1beta1.WhenExpression{{
+					Input:    "first",
+					Operator: selection.In,
+					Values:   []string{"first"},
+				}},
+			},
+		}},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			ApplyTaskResults(tt.targets, resolvedResultRefs)
+			if d := cmp.Diff(tt.want, tt.targets); d != "" {
+				t.Fatalf("ApplyTaskResults() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestApplyTaskResults_Conditions(t *testing.T) {
 	for _, tt := range []struct {
 		name               string
@@ -618,11 +742,12 @@ func TestApplyWorkspaces(t *testing.T) {
 
 func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 	for _, tc := range []struct {
-		description string
-		results     []v1beta1.PipelineResult
-		statuses    map[string]*v1beta1.PipelineRunTaskRunStatus
-		runStatuses map[string]*v1beta1.PipelineRunRunStatus
-		expected    []v1beta1.PipelineRunResult
+		description         string
+		results             []v1beta1.PipelineResult
+		statuses            map[string]*v1beta1.PipelineRunTaskRunStatus
+		runStatuses         map[string]*v1beta1.PipelineRunRunStatus
+		pipelineRunStatuses map[string]*v1beta1.PipelineRunPipelineRunStatus
+		expected            []v1beta1.PipelineRunResult
 	}{{
 		description: "no-pipeline-results-no-returned-results",
 		results:     []v1beta1.PipelineResult{},
@@ -639,7 +764,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "foo",
-							Value: "bar",
+							Value: *v1beta1.NewArrayOrString("bar"),
 						}},
 					},
 				},
@@ -665,7 +790,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "foo",
-							Value: "bar",
+							Value: *v1beta1.NewArrayOrString("bar"),
 						}},
 					},
 				},
@@ -714,7 +839,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "foo",
-							Value: "bar",
+							Value: *v1beta1.NewArrayOrString("bar"),
 						}},
 					},
 				},
@@ -740,7 +865,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "definitely-not-foo",
-							Value: "bar",
+							Value: *v1beta1.NewArrayOrString("bar"),
 						}},
 					},
 				},
@@ -766,7 +891,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "foo",
-							Value: "bar",
+							Value: *v1beta1.NewArrayOrString("bar"),
 						}},
 					},
 				},
@@ -795,7 +920,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "foo",
-							Value: "do",
+							Value: *v1beta1.NewArrayOrString("do"),
 						}},
 					},
 				},
@@ -812,7 +937,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "bar",
-							Value: "rae",
+							Value: *v1beta1.NewArrayOrString("rae"),
 						}},
 					},
 				},
@@ -844,10 +969,10 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "foo",
-							Value: "do",
+							Value: *v1beta1.NewArrayOrString("do"),
 						}, {
 							Name:  "bar",
-							Value: "mi",
+							Value: *v1beta1.NewArrayOrString("mi"),
 						}},
 					},
 				},
@@ -864,7 +989,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "baz",
-							Value: "rae",
+							Value: *v1beta1.NewArrayOrString("rae"),
 						}},
 					},
 				},
@@ -1022,7 +1147,7 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
 						TaskRunResults: []v1beta1.TaskRunResult{{
 							Name:  "baz",
-							Value: "rae",
+							Value: *v1beta1.NewArrayOrString("rae"),
 						}},
 					},
 				},
@@ -1035,9 +1160,94 @@ func TestApplyTaskResultsToPipelineResults(t *testing.T) {
 			Name:  "pipeline-result-2",
 			Value: "do, rae, mi, rae, do",
 		}},
+	}, {
+		description: "results-from-child-pipelinerun",
+		results: []v1beta1.PipelineResult{{
+			Name:  "pipeline-result-1",
+			Value: "$(tasks.childpipeline.results.foo)",
+		}, {
+			Name:  "pipeline-result-2",
+			Value: "$(tasks.failedchildpipeline.results.foo)",
+		}},
+		pipelineRunStatuses: map[string]*v1beta1.PipelineRunPipelineRunStatus{
+			"pr1": {
+				PipelineTaskName: "childpipeline",
+				Status: &v1beta1.PipelineRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: []apis.Condition{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionTrue,
+						}},
+					},
+					PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+						PipelineResults: []v1beta1.PipelineRunResult{{
+							Name:  "foo",
+							Value: "do",
+						}},
+					},
+				},
+			},
+			"pr2": {
+				PipelineTaskName: "failedchildpipeline",
+				Status: &v1beta1.PipelineRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: []apis.Condition{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionFalse,
+						}},
+					},
+					PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+						PipelineResults: []v1beta1.PipelineRunResult{{
+							Name:  "foo",
+							Value: "re",
+						}},
+					},
+				},
+			},
+		},
+		expected: []v1beta1.PipelineRunResult{{
+			Name:  "pipeline-result-1",
+			Value: "do",
+		}},
+	}, {
+		description: "array-result-elements-returned-whole-array-omitted",
+		results: []v1beta1.PipelineResult{{
+			Name:  "first-file",
+			Value: "$(tasks.pt1.results.files[0])",
+		}, {
+			Name:  "out-of-bounds-file",
+			Value: "$(tasks.pt1.results.files[2])",
+		}, {
+			Name:  "all-files",
+			Value: "$(tasks.pt1.results.files[*])",
+		}},
+		statuses: map[string]*v1beta1.PipelineRunTaskRunStatus{
+			"task1": {
+				PipelineTaskName: "pt1",
+				Status: &v1beta1.TaskRunStatus{
+					Status: duckv1beta1.Status{
+						Conditions: []apis.Condition{{
+							Type:   apis.ConditionSucceeded,
+							Status: corev1.ConditionTrue,
+						}},
+					},
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						TaskRunResults: []v1beta1.TaskRunResult{{
+							Name:  "files",
+							Type:  v1beta1.ResultsTypeArray,
+							Value: *v1beta1.NewArrayOrString("a.txt", "b.txt"),
+						}},
+					},
+				},
+			},
+		},
+		expected: []v1beta1.PipelineRunResult{{
+			Name:  "first-file",
+			Value: "a.txt",
+		}},
 	}} {
 		t.Run(tc.description, func(t *testing.T) {
-			received := ApplyTaskResultsToPipelineResults(tc.results, tc.statuses, tc.runStatuses)
+			received := ApplyTaskResultsToPipelineResults(tc.results, tc.statuses, tc.runStatuses, tc.pipelineRunStatuses)
 			if d := cmp.Diff(tc.expected, received); d != "" {
 				t.Errorf(diff.PrintWantGot(d))
 			}
@@ -1050,6 +1260,7 @@ func TestApplyTaskRunContext(t *testing.T) {
 	r := map[string]string{
 		"tasks.task1.status": "succeeded",
 		"tasks.task3.status": "none",
+		"tasks.status":       "completed",
 	}
 	state := PipelineRunState{{
 		PipelineTask: &v1beta1.PipelineTask{
@@ -1062,6 +1273,11 @@ func TestApplyTaskRunContext(t *testing.T) {
 				Name:  "task3",
 				Value: *v1beta1.NewArrayOrString("$(tasks.task3.status)"),
 			}},
+			WhenExpressions: v1beta1.WhenExpressions{{
+				Input:    "$(tasks.status)",
+				Operator: selection.In,
+				Values:   []string{"$(tasks.task1.status)"},
+			}},
 		},
 	}}
 	expectedState := PipelineRunState{{
@@ -1075,6 +1291,11 @@ func TestApplyTaskRunContext(t *testing.T) {
 				Name:  "task3",
 				Value: *v1beta1.NewArrayOrString("none"),
 			}},
+			WhenExpressions: v1beta1.WhenExpressions{{
+				Input:    "completed",
+				Operator: selection.In,
+				Values:   []string{"succeeded"},
+			}},
 		},
 	}}
 	ApplyPipelineTaskContext(state, r)
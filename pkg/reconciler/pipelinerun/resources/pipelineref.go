@@ -25,8 +25,11 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/remote"
 	"github.com/tektoncd/pipeline/pkg/remote/oci"
+	"github.com/tektoncd/pipeline/pkg/remote/resolvers"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -38,6 +41,25 @@ func GetPipelineFunc(ctx context.Context, k8s kubernetes.Interface, tekton clien
 	pr := pipelineRun.Spec.PipelineRef
 	namespace := pipelineRun.Namespace
 	switch {
+	case pr != nil && pr.HasResolver():
+		// Return an inline function that implements GetPipeline by asking the named resolver for the pipeline and
+		// casting it to a PipelineObject.
+		return func(ctx context.Context, name string) (v1beta1.PipelineObject, error) {
+			resolver, err := resolvers.Resolver(ctx, pr.Resolver, pr.ParamsMap(), remote.Options{
+				Namespace:          namespace,
+				ServiceAccountName: pipelineRun.Spec.ServiceAccountName,
+				KubeClient:         k8s,
+				TektonClient:       tekton,
+			})
+			if err != nil {
+				return nil, err
+			}
+			obj, err := resolver.Get("pipeline", name)
+			if err != nil {
+				return nil, &remote.ResolutionError{Resolver: pr.Resolver, Err: err}
+			}
+			return readRuntimeObjectAsPipeline(ctx, obj)
+		}, nil
 	case cfg.FeatureFlags.EnableTektonOCIBundles && pr != nil && pr.Bundle != "":
 		// Return an inline function that implements GetTask by calling Resolver.Get with the specified task type and
 		// casting it to a PipelineObject.
@@ -56,17 +78,7 @@ func GetPipelineFunc(ctx context.Context, k8s kubernetes.Interface, tekton clien
 			if err != nil {
 				return nil, err
 			}
-			if pipeline, ok := obj.(v1beta1.PipelineObject); ok {
-				return pipeline, nil
-			}
-
-			if pipeline, ok := obj.(*v1alpha1.Pipeline); ok {
-				betaPipeline := &v1beta1.Pipeline{}
-				err := pipeline.ConvertTo(ctx, betaPipeline)
-				return betaPipeline, err
-			}
-
-			return nil, fmt.Errorf("failed to convert obj %s into Pipeline", obj.GetObjectKind().GroupVersionKind().String())
+			return readRuntimeObjectAsPipeline(ctx, obj)
 		}, nil
 	default:
 		// Even if there is no task ref, we should try to return a local resolver.
@@ -78,6 +90,22 @@ func GetPipelineFunc(ctx context.Context, k8s kubernetes.Interface, tekton clien
 	}
 }
 
+// readRuntimeObjectAsPipeline coerces a resolved object into a v1beta1.PipelineObject, converting v1alpha1
+// Pipelines if needed.
+func readRuntimeObjectAsPipeline(ctx context.Context, obj runtime.Object) (v1beta1.PipelineObject, error) {
+	if pipeline, ok := obj.(v1beta1.PipelineObject); ok {
+		return pipeline, nil
+	}
+
+	if pipeline, ok := obj.(*v1alpha1.Pipeline); ok {
+		betaPipeline := &v1beta1.Pipeline{}
+		err := pipeline.ConvertTo(ctx, betaPipeline)
+		return betaPipeline, err
+	}
+
+	return nil, fmt.Errorf("failed to convert obj %s into Pipeline", obj.GetObjectKind().GroupVersionKind().String())
+}
+
 // LocalPipelineRefResolver uses the current cluster to resolve a pipeline reference.
 type LocalPipelineRefResolver struct {
 	Namespace    string
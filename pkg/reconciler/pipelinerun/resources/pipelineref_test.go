@@ -31,11 +31,13 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
 	logtesting "knative.dev/pkg/logging/testing"
 )
 
@@ -112,7 +114,8 @@ func TestGetPipelineFunc(t *testing.T) {
 	cfg.OnConfigChanged(&corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName()},
 		Data: map[string]string{
-			"enable-tekton-oci-bundles": "true",
+			"enable-tekton-oci-bundles":     "true",
+			"enable-git-and-http-resolvers": "true",
 		},
 	})
 	ctx = cfg.ToContext(ctx)
@@ -146,6 +149,24 @@ func TestGetPipelineFunc(t *testing.T) {
 			Name: "simple",
 		},
 		expected: tb.Pipeline("simple", tb.PipelineType, tb.PipelineNamespace("default"), tb.PipelineSpec(tb.PipelineTask("something", "something"))),
+	}, {
+		name: "cluster-resolver-pipeline",
+		localPipelines: []runtime.Object{
+			tb.Pipeline("simple", tb.PipelineType, tb.PipelineNamespace("other"), tb.PipelineSpec(tb.PipelineTask("something", "something"))),
+		},
+		ref: &v1beta1.PipelineRef{
+			ResolverRef: v1beta1.ResolverRef{
+				Resolver: "cluster",
+				Params: []v1beta1.Param{{
+					Name:  "name",
+					Value: *v1beta1.NewArrayOrString("simple"),
+				}, {
+					Name:  "namespace",
+					Value: *v1beta1.NewArrayOrString("other"),
+				}},
+			},
+		},
+		expected: tb.Pipeline("simple", tb.PipelineType, tb.PipelineNamespace("other"), tb.PipelineSpec(tb.PipelineTask("something", "something"))),
 	},
 	}
 
@@ -158,6 +179,10 @@ func TestGetPipelineFunc(t *testing.T) {
 					Name:      "default",
 				},
 			})
+			// Allow the service account of the run to read the other namespace of the cluster resolver.
+			kubeclient.PrependReactor("create", "subjectaccessreviews", func(ktesting.Action) (bool, runtime.Object, error) {
+				return true, &authorizationv1.SubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: true}}, nil
+			})
 
 			_, err := test.CreateImage(u.Host+"/"+tc.name, tc.remotePipelines...)
 			if err != nil {
@@ -175,7 +200,7 @@ func TestGetPipelineFunc(t *testing.T) {
 				t.Fatalf("failed to get pipeline fn: %s", err.Error())
 			}
 
-			pipeline, err := fn(context.Background(), tc.ref.Name)
+			pipeline, err := fn(ctx, tc.ref.Name)
 			if err != nil {
 				t.Fatalf("failed to call pipelinefn: %s", err.Error())
 			}
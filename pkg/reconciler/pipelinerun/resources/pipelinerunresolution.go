@@ -28,9 +28,13 @@ import (
 	"github.com/tektoncd/pipeline/pkg/contexts"
 	"github.com/tektoncd/pipeline/pkg/list"
 	"github.com/tektoncd/pipeline/pkg/names"
+	"github.com/tektoncd/pipeline/pkg/pod"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"github.com/tektoncd/pipeline/pkg/remote"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmeta"
 )
 
 const (
@@ -64,6 +68,10 @@ func (e *ConditionNotFoundError) Error() string {
 type ResolvedPipelineRunTask struct {
 	TaskRunName string
 	TaskRun     *v1beta1.TaskRun
+	// If the PipelineTask is matrixed, TaskRunNames and TaskRuns hold one entry for
+	// each combination of the matrix parameters, in the order returned by FanOut.
+	TaskRunNames []string
+	TaskRuns     []*v1beta1.TaskRun
 	// If the PipelineTask is a Custom Task, RunName and Run will be set.
 	CustomTask            bool
 	RunName               string
@@ -72,6 +80,9 @@ type ResolvedPipelineRunTask struct {
 	ResolvedTaskResources *resources.ResolvedTaskResources
 	// ConditionChecks ~~TaskRuns but for evaling conditions
 	ResolvedConditionChecks TaskConditionCheckState // Could also be a TaskRun or maybe just a Pod?
+	// If the PipelineTask targets a Pipeline, PipelineRunName and PipelineRun will be set.
+	PipelineRunName string
+	PipelineRun     *v1beta1.PipelineRun
 }
 
 // IsDone returns true only if the task is skipped, succeeded or failed
@@ -84,29 +95,110 @@ func (t ResolvedPipelineRunTask) IsCustomTask() bool {
 	return t.CustomTask
 }
 
+// IsChildPipeline returns true if the PipelineTask targets a Pipeline executed by a child PipelineRun.
+func (t ResolvedPipelineRunTask) IsChildPipeline() bool {
+	return t.PipelineTask.IsChildPipeline()
+}
+
+// IsMatrixed returns true if the PipelineTask fans out into multiple TaskRuns using a Matrix.
+func (t ResolvedPipelineRunTask) IsMatrixed() bool {
+	return t.PipelineTask.IsMatrixed()
+}
+
 // IsSuccessful returns true only if the run has completed successfully
+// A matrixed task is successful only if all of its TaskRuns have completed successfully
 func (t ResolvedPipelineRunTask) IsSuccessful() bool {
 	if t.IsCustomTask() {
 		return t.Run != nil && t.Run.IsSuccessful()
 	}
+	if t.IsChildPipeline() {
+		return t.PipelineRun != nil && t.PipelineRun.Status.GetCondition(apis.ConditionSucceeded).IsTrue()
+	}
+	if t.IsMatrixed() {
+		if len(t.TaskRuns) == 0 || len(t.TaskRuns) != len(t.TaskRunNames) {
+			return false
+		}
+		for _, taskRun := range t.TaskRuns {
+			if taskRun == nil || !taskRun.IsSuccessful() {
+				return false
+			}
+		}
+		return true
+	}
 	return t.TaskRun != nil && t.TaskRun.IsSuccessful()
 }
 
 // IsFailure returns true only if the run has failed and will not be retried.
+// A matrixed task has failed when at least one of its TaskRuns has failed and will not be
+// retried, and none of its other TaskRuns are still running.
 func (t ResolvedPipelineRunTask) IsFailure() bool {
 	if t.IsCustomTask() {
 		return t.Run != nil && t.Run.IsDone() && !t.Run.IsSuccessful()
 	}
-	if t.TaskRun == nil {
+	if t.IsChildPipeline() {
+		return t.PipelineRun != nil && t.PipelineRun.Status.GetCondition(apis.ConditionSucceeded).IsFalse()
+	}
+	if t.IsMatrixed() {
+		return t.anyMatrixedTaskRun(t.isTaskRunFailure) && !t.anyMatrixedTaskRun(isTaskRunRunning)
+	}
+	return t.isTaskRunFailure(t.TaskRun)
+}
+
+// isTaskRunFailure returns true only if the TaskRun has failed and will not be retried.
+// A cancelled TaskRun is never retried, nor is a failure which the retry policy does not cover.
+func (t ResolvedPipelineRunTask) isTaskRunFailure(tr *v1beta1.TaskRun) bool {
+	if tr == nil {
 		return false
 	}
-	c := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
-	retriesDone := len(t.TaskRun.Status.RetriesStatus)
-	retries := t.PipelineTask.Retries
-	return c.IsFalse() && retriesDone >= retries
+	c := tr.Status.GetCondition(apis.ConditionSucceeded)
+	retriesDone := len(tr.Status.RetriesStatus)
+	retries := t.PipelineTask.RetryCount()
+	return c.IsFalse() && (retriesDone >= retries || isTaskRunCancelled(tr) || !t.isRetryable(tr))
+}
+
+// isRetryable returns true if the failure of the TaskRun is covered by the retry policy
+// of the PipelineTask, i.e. it failed with one of the retried reasons or the step which
+// failed it terminated with one of the retried exit codes. Without a filter, any failure
+// is retried.
+func (t ResolvedPipelineRunTask) isRetryable(tr *v1beta1.TaskRun) bool {
+	rp := t.PipelineTask.RetryPolicy
+	if rp == nil || (len(rp.Reasons) == 0 && len(rp.ExitCodes) == 0) {
+		return true
+	}
+	if c := tr.Status.GetCondition(apis.ConditionSucceeded); c != nil {
+		for _, reason := range rp.Reasons {
+			if c.Reason == reason {
+				return true
+			}
+		}
+	}
+	if step := failedStep(tr); step != nil {
+		for _, exitCode := range rp.ExitCodes {
+			if step.ExitCode == exitCode {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// failedStep returns the terminated state of the step which failed the TaskRun, i.e. the
+// first step which terminated with a non-zero exit code and was not allowed to continue,
+// or nil if there is none. The steps which follow it are skipped, so their exit codes
+// have nothing to do with the failure.
+func failedStep(tr *v1beta1.TaskRun) *corev1.ContainerStateTerminated {
+	for _, step := range tr.Status.Steps {
+		if step.Terminated == nil || step.Terminated.ExitCode == 0 || step.Terminated.Reason == pod.ReasonStepFailedContinued {
+			continue
+		}
+		return step.Terminated
+	}
+	return nil
 }
 
 // IsCancelled returns true only if the run is cancelled
+// A matrixed task is cancelled when at least one of its TaskRuns is cancelled and none of
+// its other TaskRuns are still running.
 func (t ResolvedPipelineRunTask) IsCancelled() bool {
 	if t.IsCustomTask() {
 		if t.Run == nil {
@@ -115,35 +207,100 @@ func (t ResolvedPipelineRunTask) IsCancelled() bool {
 		c := t.Run.Status.GetCondition(apis.ConditionSucceeded)
 		return c != nil && c.IsFalse() && c.Reason == v1alpha1.RunReasonCancelled
 	}
-	if t.TaskRun == nil {
+	if t.IsChildPipeline() {
+		if t.PipelineRun == nil {
+			return false
+		}
+		c := t.PipelineRun.Status.GetCondition(apis.ConditionSucceeded)
+		return c != nil && c.IsFalse() && (c.Reason == v1beta1.PipelineRunReasonCancelled.String() || t.PipelineRun.IsCancelled())
+	}
+	if t.IsMatrixed() {
+		return t.anyMatrixedTaskRun(isTaskRunCancelled) && !t.anyMatrixedTaskRun(isTaskRunRunning)
+	}
+	return isTaskRunCancelled(t.TaskRun)
+}
+
+func isTaskRunCancelled(tr *v1beta1.TaskRun) bool {
+	if tr == nil {
 		return false
 	}
-	c := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
+	c := tr.Status.GetCondition(apis.ConditionSucceeded)
 	return c != nil && c.IsFalse() && c.Reason == v1beta1.TaskRunReasonCancelled.String()
 }
 
+// isTaskRunRunning returns true if the TaskRun exists and has not finished yet
+func isTaskRunRunning(tr *v1beta1.TaskRun) bool {
+	return tr != nil && !tr.IsDone()
+}
+
 // IsStarted returns true only if the PipelineRunTask itself has a TaskRun or
 // Run associated that has a Succeeded-type condition.
+// A matrixed task is started as soon as any of its TaskRuns has started.
 func (t ResolvedPipelineRunTask) IsStarted() bool {
 	if t.IsCustomTask() {
 		return t.Run != nil && t.Run.Status.GetCondition(apis.ConditionSucceeded) != nil
 
 	}
-	return t.TaskRun != nil && t.TaskRun.Status.GetCondition(apis.ConditionSucceeded) != nil
+	if t.IsChildPipeline() {
+		return t.PipelineRun != nil && t.PipelineRun.Status.GetCondition(apis.ConditionSucceeded) != nil
+	}
+	if t.IsMatrixed() {
+		return t.anyMatrixedTaskRun(isTaskRunStarted)
+	}
+	return isTaskRunStarted(t.TaskRun)
+}
+
+func isTaskRunStarted(tr *v1beta1.TaskRun) bool {
+	return tr != nil && tr.Status.GetCondition(apis.ConditionSucceeded) != nil
 }
 
 // IsConditionStatusFalse returns true when a task has succeeded condition with status set to false
 // it includes task failed after retries are exhausted, cancelled tasks, and time outs
+// For a matrixed task, it returns true when any of its TaskRuns has such a condition.
 func (t ResolvedPipelineRunTask) IsConditionStatusFalse() bool {
 	if t.IsStarted() {
 		if t.IsCustomTask() {
 			return t.Run.Status.GetCondition(apis.ConditionSucceeded).IsFalse()
 		}
+		if t.IsChildPipeline() {
+			return t.PipelineRun.Status.GetCondition(apis.ConditionSucceeded).IsFalse()
+		}
+		if t.IsMatrixed() {
+			return t.anyMatrixedTaskRun(func(tr *v1beta1.TaskRun) bool {
+				return tr != nil && tr.Status.GetCondition(apis.ConditionSucceeded).IsFalse()
+			})
+		}
 		return t.TaskRun.Status.GetCondition(apis.ConditionSucceeded).IsFalse()
 	}
 	return false
 }
 
+// anyMatrixedTaskRun returns true if the condition holds for any of the TaskRuns of a matrixed task
+func (t ResolvedPipelineRunTask) anyMatrixedTaskRun(condition func(*v1beta1.TaskRun) bool) bool {
+	for _, taskRun := range t.TaskRuns {
+		if condition(taskRun) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTaskRunSchedulable returns true if a TaskRun needs to be created for the PipelineTask, either because
+// the TaskRun does not exist yet or because it has failed and can still be retried.
+func (t ResolvedPipelineRunTask) IsTaskRunSchedulable(tr *v1beta1.TaskRun) bool {
+	if tr == nil {
+		return true
+	}
+	status := tr.Status.GetCondition(apis.ConditionSucceeded)
+	if status == nil || !status.IsFalse() {
+		return false
+	}
+	if tr.IsCancelled() || status.Reason == v1beta1.TaskRunReasonCancelled.String() || status.Reason == ReasonConditionCheckFailed {
+		return false
+	}
+	return len(tr.Status.RetriesStatus) < t.PipelineTask.RetryCount() && t.isRetryable(tr)
+}
+
 func (t *ResolvedPipelineRunTask) checkParentsDone(facts *PipelineRunFacts) bool {
 	stateMap := facts.State.ToMap()
 	node := facts.TasksGraph.Nodes[t.PipelineTask.Name]
@@ -155,16 +312,30 @@ func (t *ResolvedPipelineRunTask) checkParentsDone(facts *PipelineRunFacts) bool
 	return true
 }
 
-func (t *ResolvedPipelineRunTask) skip(facts *PipelineRunFacts) bool {
+// skip returns the reason why the PipelineTask will not be run, or an empty
+// reason if it may be run
+func (t *ResolvedPipelineRunTask) skip(facts *PipelineRunFacts) v1beta1.SkippingReason {
 	if facts.isFinalTask(t.PipelineTask.Name) || t.IsStarted() {
-		return false
-	}
-
-	if t.conditionsSkip() || t.whenExpressionsSkip(facts) || t.parentTasksSkip(facts) || facts.IsStopping() {
-		return true
-	}
-
-	return false
+		return ""
+	}
+
+	switch {
+	case t.conditionsSkip():
+		return v1beta1.ConditionsSkip
+	case t.whenExpressionsSkip(facts):
+		return v1beta1.WhenExpressionsSkip
+	case t.parentTasksSkip(facts):
+		return v1beta1.ParentTasksSkip
+	case facts.IsStopping():
+		return v1beta1.StoppingSkip
+	case facts.TasksTimedOut:
+		return v1beta1.TasksTimedOutSkip
+	case facts.IsGracefullyCancelled():
+		return v1beta1.GracefullyCancelledSkip
+	case facts.IsGracefullyStopped():
+		return v1beta1.GracefullyStoppedSkip
+	}
+	return ""
 }
 
 // Skip returns true if a PipelineTask will not be run because
@@ -172,13 +343,20 @@ func (t *ResolvedPipelineRunTask) skip(facts *PipelineRunFacts) bool {
 // (2) its Condition Checks failed
 // (3) its parent task was skipped
 // (4) Pipeline is in stopping state (one of the PipelineTasks failed)
+// (5) the tasks timeout of the PipelineRun was reached
+// (6) the PipelineRun was gracefully cancelled or stopped
 // Note that this means Skip returns false if a conditionCheck is in progress
 func (t *ResolvedPipelineRunTask) Skip(facts *PipelineRunFacts) bool {
+	return t.skippingReason(facts) != ""
+}
+
+// skippingReason returns the reason why Skip returns true, or an empty reason
+func (t *ResolvedPipelineRunTask) skippingReason(facts *PipelineRunFacts) v1beta1.SkippingReason {
 	if facts.SkipCache == nil {
-		facts.SkipCache = make(map[string]bool)
+		facts.SkipCache = make(map[string]v1beta1.SkippingReason)
 	}
 	if _, cached := facts.SkipCache[t.PipelineTask.Name]; !cached {
-		facts.SkipCache[t.PipelineTask.Name] = t.skip(facts) // t.skip() is same as our existing t.Skip()
+		facts.SkipCache[t.PipelineTask.Name] = t.skip(facts)
 	}
 	return facts.SkipCache[t.PipelineTask.Name]
 }
@@ -199,6 +377,11 @@ func (t *ResolvedPipelineRunTask) whenExpressionsSkip(facts *PipelineRunFacts) b
 				if !t.PipelineTask.WhenExpressions.AllowsExecution() {
 					return true
 				}
+				// a CEL expression which can't be evaluated doesn't skip the task,
+				// the PipelineRun fails before it's run instead
+				if allowed, err := t.EvaluateWhenCEL(facts); err == nil && !allowed {
+					return true
+				}
 			}
 		}
 	}
@@ -216,22 +399,42 @@ func (t *ResolvedPipelineRunTask) parentTasksSkip(facts *PipelineRunFacts) bool
 	return false
 }
 
-// IsFinallySkipped returns true if a finally task is not executed and skipped due to task result validation failure
+// IsFinallySkipped returns true if a finally task is not executed and skipped due to task result validation failure,
+// or because its when expressions, which may consume the execution status of the dag tasks, evaluated to false
 func (t *ResolvedPipelineRunTask) IsFinallySkipped(facts *PipelineRunFacts) bool {
-	if t.IsStarted() {
-		return false
+	return t.finallySkippingReason(facts) != ""
+}
+
+// finallySkippingReason returns the reason why IsFinallySkipped returns true, or an empty reason
+func (t *ResolvedPipelineRunTask) finallySkippingReason(facts *PipelineRunFacts) v1beta1.SkippingReason {
+	if t.IsStarted() || !facts.isFinalTask(t.PipelineTask.Name) || !facts.checkDAGTasksDone() {
+		return ""
 	}
-	if facts.checkDAGTasksDone() && facts.isFinalTask(t.PipelineTask.Name) {
-		if _, err := ResolveResultRef(facts.State, t); err != nil {
-			return true
+	resolvedResultRefs, err := ResolveResultRef(facts.State, t)
+	if err != nil {
+		return v1beta1.MissingResultsSkip
+	}
+	if len(t.PipelineTask.WhenExpressions) > 0 {
+		replacements, _, _ := resolvedResultRefs.getReplacements()
+		for variable, status := range facts.pipelineTaskStatus() {
+			replacements[variable] = status
+		}
+		if !t.PipelineTask.WhenExpressions.ReplaceWhenExpressionsVariables(replacements).AllowsExecution() {
+			return v1beta1.WhenExpressionsSkip
+		}
+		if allowed, err := t.EvaluateWhenCEL(facts); err == nil && !allowed {
+			return v1beta1.WhenExpressionsSkip
 		}
 	}
-	return false
+	return ""
 }
 
 // GetRun is a function that will retrieve a Run by name.
 type GetRun func(name string) (*v1alpha1.Run, error)
 
+// GetPipelineRun is a function that will retrieve a child PipelineRun by name.
+type GetPipelineRun func(name string) (*v1beta1.PipelineRun, error)
+
 // GetResourcesFromBindings will retrieve all Resources bound in PipelineRun pr and return a map
 // from the declared name of the PipelineResource (which is how the PipelineResource will
 // be referred to in the PipelineRun) to the PipelineResource, obtained via getResource.
@@ -343,6 +546,7 @@ func ResolvePipelineRunTask(
 	getTask resources.GetTask,
 	getTaskRun resources.GetTaskRun,
 	getRun GetRun,
+	getPipelineRun GetPipelineRun,
 	getCondition GetCondition,
 	task v1beta1.PipelineTask,
 	providedResources map[string]*resourcev1alpha1.PipelineResource,
@@ -363,8 +567,19 @@ func ResolvePipelineRunTask(
 			return nil, fmt.Errorf("error retrieving Run %s: %w", rprt.RunName, err)
 		}
 		rprt.Run = run
+	} else if rprt.IsChildPipeline() {
+		rprt.PipelineRunName = GetPipelineRunName(pipelineRun.Status.PipelineRuns, task.Name, pipelineRun.Name)
+		childPipelineRun, err := getPipelineRun(rprt.PipelineRunName)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("error retrieving PipelineRun %s: %w", rprt.PipelineRunName, err)
+		}
+		rprt.PipelineRun = childPipelineRun
 	} else {
-		rprt.TaskRunName = GetTaskRunName(pipelineRun.Status.TaskRuns, task.Name, pipelineRun.Name)
+		if task.IsMatrixed() {
+			rprt.TaskRunNames = GetNamesOfTaskRuns(task.Name, pipelineRun.Name, len(task.FanOut()))
+		} else {
+			rprt.TaskRunName = GetTaskRunName(pipelineRun.Status.TaskRuns, task.Name, pipelineRun.Name)
+		}
 
 		// Find the Task that this PipelineTask is using
 		var (
@@ -378,6 +593,9 @@ func ResolvePipelineRunTask(
 		if task.TaskRef != nil {
 			t, err = getTask(ctx, task.TaskRef.Name)
 			if err != nil {
+				if remote.IsResolutionError(err) {
+					return nil, err
+				}
 				return nil, &TaskNotFoundError{
 					Name: task.TaskRef.Name,
 					Msg:  err.Error(),
@@ -397,6 +615,18 @@ func ResolvePipelineRunTask(
 
 		rprt.ResolvedTaskResources = rtr
 
+		if task.IsMatrixed() {
+			rprt.TaskRuns = make([]*v1beta1.TaskRun, len(rprt.TaskRunNames))
+			for i, taskRunName := range rprt.TaskRunNames {
+				taskRun, err := getTaskRun(taskRunName)
+				if err != nil && !errors.IsNotFound(err) {
+					return nil, fmt.Errorf("error retrieving TaskRun %s: %w", taskRunName, err)
+				}
+				rprt.TaskRuns[i] = taskRun
+			}
+			return &rprt, nil
+		}
+
 		taskRun, err := getTaskRun(rprt.TaskRunName)
 		if err != nil {
 			if !errors.IsNotFound(err) {
@@ -444,6 +674,17 @@ func GetTaskRunName(taskRunsStatus map[string]*v1beta1.PipelineRunTaskRunStatus,
 	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
 }
 
+// GetNamesOfTaskRuns returns the names of the TaskRuns created for each of the combinations of a
+// matrixed PipelineTask. The names are derived from the PipelineRun name, the PipelineTask name and
+// the index of the combination so that they are stable across reconciles.
+func GetNamesOfTaskRuns(ptName, prName string, combinationCount int) []string {
+	taskRunNames := make([]string, combinationCount)
+	for i := range taskRunNames {
+		taskRunNames[i] = kmeta.ChildName(prName, fmt.Sprintf("-%s-%d", ptName, i))
+	}
+	return taskRunNames
+}
+
 // GetRunName should return a unique name for a `Run` if one has not already
 // been defined, and the existing one otherwise.
 func GetRunName(runsStatus map[string]*v1beta1.PipelineRunRunStatus, ptName, prName string) string {
@@ -456,6 +697,18 @@ func GetRunName(runsStatus map[string]*v1beta1.PipelineRunRunStatus, ptName, prN
 	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
 }
 
+// GetPipelineRunName should return a unique name for a child `PipelineRun` if one has not already
+// been defined, and the existing one otherwise.
+func GetPipelineRunName(pipelineRunsStatus map[string]*v1beta1.PipelineRunPipelineRunStatus, ptName, prName string) string {
+	for k, v := range pipelineRunsStatus {
+		if v.PipelineTaskName == ptName {
+			return k
+		}
+	}
+
+	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
+}
+
 func resolveConditionChecks(pt *v1beta1.PipelineTask, taskRunStatus map[string]*v1beta1.PipelineRunTaskRunStatus, taskRunName string, getTaskRun resources.GetTaskRun, getCondition GetCondition, providedResources map[string]*resourcev1alpha1.PipelineResource) ([]*ResolvedConditionCheck, error) {
 	rccs := []*ResolvedConditionCheck{}
 	for i := range pt.Conditions {
@@ -30,6 +30,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/pod"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/test/diff"
@@ -47,6 +48,9 @@ import (
 func nopGetRun(string) (*v1alpha1.Run, error) {
 	return nil, errors.New("GetRun should not be called")
 }
+func nopGetPipelineRun(string) (*v1beta1.PipelineRun, error) {
+	return nil, errors.New("GetPipelineRun should not be called")
+}
 func nopGetTask(context.Context, string) (v1beta1.TaskObject, error) {
 	return nil, errors.New("GetTask should not be called")
 }
@@ -1135,7 +1139,7 @@ func TestResolvePipelineRun(t *testing.T) {
 
 	pipelineState := PipelineRunState{}
 	for _, task := range p.Spec.Tasks {
-		ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, task, providedResources)
+		ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, task, providedResources)
 		if err != nil {
 			t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 		}
@@ -1225,7 +1229,7 @@ func TestResolvePipelineRun_CustomTask(t *testing.T) {
 	})
 	ctx = cfg.ToContext(ctx)
 	for _, task := range pts {
-		ps, err := ResolvePipelineRunTask(ctx, pr, nopGetTask, nopGetTaskRun, getRun, nopGetCondition, task, nil)
+		ps, err := ResolvePipelineRunTask(ctx, pr, nopGetTask, nopGetTaskRun, getRun, nopGetPipelineRun, nopGetCondition, task, nil)
 		if err != nil {
 			t.Fatalf("ResolvePipelineRunTask: %v", err)
 		}
@@ -1271,7 +1275,7 @@ func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 	}
 	pipelineState := PipelineRunState{}
 	for _, task := range pts {
-		ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, task, providedResources)
+		ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, task, providedResources)
 		if err != nil {
 			t.Errorf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 		}
@@ -1316,7 +1320,7 @@ func TestResolvePipelineRun_TaskDoesntExist(t *testing.T) {
 			Name: "pipelinerun",
 		},
 	}
-	_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, providedResources)
+	_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 	switch err := err.(type) {
 	case nil:
 		t.Fatalf("Expected error getting non-existent Tasks for Pipeline %s but got none", p.Name)
@@ -1362,7 +1366,7 @@ func TestResolvePipelineRun_ResourceBindingsDontExist(t *testing.T) {
 				},
 			}
 			pipelineState := PipelineRunState{}
-			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, tt.p.Spec.Tasks[0], providedResources)
+			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, tt.p.Spec.Tasks[0], providedResources)
 			if err == nil {
 				t.Fatalf("Expected error when bindings are in incorrect state for Pipeline %s but got none: %s", p.ObjectMeta.Name, err)
 			}
@@ -1412,7 +1416,7 @@ func TestResolvePipelineRun_withExistingTaskRuns(t *testing.T) {
 	getTask := func(_ context.Context, name string) (v1beta1.TaskObject, error) { return task, nil }
 	getTaskRun := func(name string) (*v1beta1.TaskRun, error) { return nil, nil }
 	getCondition := func(name string) (*v1alpha1.Condition, error) { return nil, nil }
-	resolvedTask, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, p.Spec.Tasks[0], providedResources)
+	resolvedTask, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, p.Spec.Tasks[0], providedResources)
 	if err != nil {
 		t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 	}
@@ -1466,7 +1470,7 @@ func TestResolvedPipelineRun_PipelineTaskHasOptionalResources(t *testing.T) {
 	getTaskRun := func(name string) (*v1beta1.TaskRun, error) { return nil, nil }
 	getCondition := func(name string) (*v1alpha1.Condition, error) { return nil, nil }
 
-	actualTask, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, p.Spec.Tasks[0], providedResources)
+	actualTask, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, p.Spec.Tasks[0], providedResources)
 	if err != nil {
 		t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 	}
@@ -1563,7 +1567,7 @@ func TestResolveConditionChecks(t *testing.T) {
 		}},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
-			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, tc.getTaskRun, nopGetRun, getCondition, pt, providedResources)
+			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, tc.getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 			if err != nil {
 				t.Fatalf("Did not expect error when resolving PipelineRun without Conditions: %v", err)
 			}
@@ -1653,7 +1657,7 @@ func TestResolveConditionChecks_MultipleConditions(t *testing.T) {
 		ResolvedResources:     providedResources,
 	}}
 
-	ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, providedResources)
+	ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 	if err != nil {
 		t.Fatalf("Did not expect error when resolving PipelineRun without Conditions: %v", err)
 	}
@@ -1695,7 +1699,7 @@ func TestResolveConditionChecks_ConditionDoesNotExist(t *testing.T) {
 		},
 	}
 
-	_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, providedResources)
+	_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 
 	switch err := err.(type) {
 	case nil:
@@ -1763,7 +1767,7 @@ func TestResolveConditionCheck_UseExistingConditionCheckName(t *testing.T) {
 		},
 	}
 
-	ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, providedResources)
+	ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 	if err != nil {
 		t.Fatalf("Did not expect error when resolving PipelineRun without Conditions: %v", err)
 	}
@@ -1837,7 +1841,7 @@ func TestResolvedConditionCheck_WithResources(t *testing.T) {
 		wantErr:           true,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
-			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, tc.providedResources)
+			ps, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, tc.providedResources)
 
 			if tc.wantErr {
 				if err == nil {
@@ -2142,7 +2146,7 @@ func TestResolvePipeline_WhenExpressions(t *testing.T) {
 	}
 
 	t.Run("When Expressions exist", func(t *testing.T) {
-		_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, getCondition, pt, providedResources)
+		_, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, providedResources)
 		if err != nil {
 			t.Fatalf("Did not expect error when resolving PipelineRun: %v", err)
 		}
@@ -2198,7 +2202,7 @@ func TestIsCustomTask(t *testing.T) {
 				},
 			})
 			ctx = cfg.ToContext(ctx)
-			rprt, err := ResolvePipelineRunTask(ctx, pr, getTask, getTaskRun, getRun, getCondition, tc.pt, nil)
+			rprt, err := ResolvePipelineRunTask(ctx, pr, getTask, getTaskRun, getRun, nopGetPipelineRun, getCondition, tc.pt, nil)
 			if err != nil {
 				t.Fatalf("Did not expect error when resolving PipelineRun: %v", err)
 			}
@@ -2273,3 +2277,468 @@ func TestResolvedPipelineRunTask_IsFinallySkipped(t *testing.T) {
 		t.Fatal("Expected the finally task with an invalid result reference to be skipped but it was not skipped.")
 	}
 }
+
+func TestResolvedPipelineRunTask_IsFinallySkipped_WhenExpressions(t *testing.T) {
+	tr := tb.TaskRun("dag-task", tb.TaskRunStatus(
+		tb.StatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+		}),
+		tb.TaskRunResult("commit", "SHA2"),
+	))
+
+	state := PipelineRunState{{
+		TaskRunName: "dag-task",
+		TaskRun:     tr,
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "dag-task",
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+		},
+	}, {
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "final-task-on-failure",
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			WhenExpressions: v1beta1.WhenExpressions{{
+				Input:    "$(tasks.dag-task.status)",
+				Operator: selection.In,
+				Values:   []string{"Failed"},
+			}},
+		},
+	}, {
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "final-task-on-success",
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			WhenExpressions: v1beta1.WhenExpressions{{
+				Input:    "$(tasks.status)",
+				Operator: selection.In,
+				Values:   []string{"Succeeded"},
+			}},
+		},
+	}, {
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "final-task-on-not-completed",
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			WhenExpressions: v1beta1.WhenExpressions{{
+				Input:    "$(tasks.status)",
+				Operator: selection.NotIn,
+				Values:   []string{"Succeeded", "Completed"},
+			}},
+		},
+	}}
+
+	tasks := v1beta1.PipelineTaskList([]v1beta1.PipelineTask{*state[0].PipelineTask})
+	d, err := dag.Build(tasks, tasks.Deps())
+	if err != nil {
+		t.Fatalf("Could not get a dag from the dag tasks %#v: %v", state[0], err)
+	}
+
+	// build graph with finally tasks
+	pts := []v1beta1.PipelineTask{*state[1].PipelineTask, *state[2].PipelineTask, *state[3].PipelineTask}
+	dfinally, err := dag.Build(v1beta1.PipelineTaskList(pts), map[string][]string{})
+	if err != nil {
+		t.Fatalf("Could not get a dag from the finally tasks %#v: %v", pts, err)
+	}
+
+	facts := &PipelineRunFacts{
+		State:           state,
+		TasksGraph:      d,
+		FinalTasksGraph: dfinally,
+	}
+
+	for _, tc := range []struct {
+		rprt         *ResolvedPipelineRunTask
+		expectedSkip bool
+	}{{
+		rprt:         state[1],
+		expectedSkip: false,
+	}, {
+		rprt:         state[2],
+		expectedSkip: true,
+	}, {
+		rprt:         state[3],
+		expectedSkip: false,
+	}} {
+		if got := tc.rprt.IsFinallySkipped(facts); got != tc.expectedSkip {
+			t.Errorf("IsFinallySkipped() of %q = %t, want %t", tc.rprt.PipelineTask.Name, got, tc.expectedSkip)
+		}
+	}
+
+	expectedSkippedTasks := []v1beta1.SkippedTask{{
+		Name:            "final-task-on-success",
+		Reason:          v1beta1.WhenExpressionsSkip,
+		WhenExpressions: state[2].PipelineTask.WhenExpressions,
+	}}
+	if d := cmp.Diff(expectedSkippedTasks, facts.GetSkippedTasks()); d != "" {
+		t.Errorf("Mismatch skipped tasks %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolvePipelineRun_Matrix(t *testing.T) {
+	pt := v1beta1.PipelineTask{
+		Name:    "mytask",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Matrix: []v1beta1.Param{{
+			Name:  "platform",
+			Value: *v1beta1.NewArrayOrString("linux", "mac", "windows"),
+		}},
+	}
+	pr := v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+	}
+	existingTaskRun := makeSucceeded(v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-mytask-1"}})
+	getTask := func(_ context.Context, name string) (v1beta1.TaskObject, error) { return task, nil }
+	getTaskRun := func(name string) (*v1beta1.TaskRun, error) {
+		if name == existingTaskRun.Name {
+			return existingTaskRun, nil
+		}
+		return nil, kerrors.NewNotFound(v1beta1.Resource("taskrun"), name)
+	}
+	getCondition := func(name string) (*v1alpha1.Condition, error) { return nil, nil }
+
+	rprt, err := ResolvePipelineRunTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, getCondition, pt, nil)
+	if err != nil {
+		t.Fatalf("ResolvePipelineRunTask: %v", err)
+	}
+	expectedTask := &ResolvedPipelineRunTask{
+		PipelineTask: &pt,
+		TaskRunNames: []string{"pipelinerun-mytask-0", "pipelinerun-mytask-1", "pipelinerun-mytask-2"},
+		TaskRuns:     []*v1beta1.TaskRun{nil, existingTaskRun, nil},
+		ResolvedTaskResources: &resources.ResolvedTaskResources{
+			TaskName: task.Name,
+			TaskSpec: &task.Spec,
+			Inputs:   map[string]*resourcev1alpha1.PipelineResource{},
+			Outputs:  map[string]*resourcev1alpha1.PipelineResource{},
+		},
+	}
+	if d := cmp.Diff(expectedTask, rprt, cmpopts.IgnoreUnexported(v1beta1.TaskRunSpec{})); d != "" {
+		t.Errorf("Unexpected resolved pipeline task %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolvedPipelineRunTask_Matrixed(t *testing.T) {
+	pt := v1beta1.PipelineTask{
+		Name:    "mytask",
+		TaskRef: &v1beta1.TaskRef{Name: "task"},
+		Matrix: []v1beta1.Param{{
+			Name:  "platform",
+			Value: *v1beta1.NewArrayOrString("linux", "mac"),
+		}},
+	}
+	ptWithRetries := *pt.DeepCopy()
+	ptWithRetries.Retries = 1
+	taskRunNames := []string{"pipelinerun-mytask-0", "pipelinerun-mytask-1"}
+	for _, tc := range []struct {
+		name            string
+		rprt            ResolvedPipelineRunTask
+		wantStarted     bool
+		wantSuccessful  bool
+		wantFailure     bool
+		wantCancelled   bool
+		wantSchedulable []bool
+	}{{
+		name: "no taskruns",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &pt,
+			TaskRunNames: taskRunNames,
+			TaskRuns:     []*v1beta1.TaskRun{nil, nil},
+		},
+		wantSchedulable: []bool{true, true},
+	}, {
+		name: "one taskrun running",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &pt,
+			TaskRunNames: taskRunNames,
+			TaskRuns:     []*v1beta1.TaskRun{makeStarted(trs[0]), nil},
+		},
+		wantStarted:     true,
+		wantSchedulable: []bool{false, true},
+	}, {
+		name: "one taskrun succeeded",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &pt,
+			TaskRunNames: taskRunNames,
+			TaskRuns:     []*v1beta1.TaskRun{makeSucceeded(trs[0]), nil},
+		},
+		wantStarted:     true,
+		wantSchedulable: []bool{false, true},
+	}, {
+		name: "all taskruns succeeded",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &pt,
+			TaskRunNames: taskRunNames,
+			TaskRuns:     []*v1beta1.TaskRun{makeSucceeded(trs[0]), makeSucceeded(trs[1])},
+		},
+		wantStarted:     true,
+		wantSuccessful:  true,
+		wantSchedulable: []bool{false, false},
+	}, {
+		name: "one taskrun failed while another is running",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &pt,
+			TaskRunNames: taskRunNames,
+			TaskRuns:     []*v1beta1.TaskRun{makeFailed(trs[0]), makeStarted(trs[1])},
+		},
+		wantStarted:     true,
+		wantSchedulable: []bool{false, false},
+	}, {
+		name: "one taskrun failed and another succeeded",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &pt,
+			TaskRunNames: taskRunNames,
+			TaskRuns:     []*v1beta1.TaskRun{makeFailed(trs[0]), makeSucceeded(trs[1])},
+		},
+		wantStarted:     true,
+		wantFailure:     true,
+		wantSchedulable: []bool{false, false},
+	}, {
+		name: "one taskrun failed with retries left",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &ptWithRetries,
+			TaskRunNames: taskRunNames,
+			TaskRuns:     []*v1beta1.TaskRun{makeFailed(trs[0]), makeSucceeded(trs[1])},
+		},
+		wantStarted:     true,
+		wantSchedulable: []bool{true, false},
+	}, {
+		name: "one taskrun cancelled",
+		rprt: ResolvedPipelineRunTask{
+			PipelineTask: &pt,
+			TaskRunNames: taskRunNames,
+			TaskRuns:     []*v1beta1.TaskRun{withCancelled(makeFailed(trs[0])), makeSucceeded(trs[1])},
+		},
+		wantStarted:     true,
+		wantFailure:     true,
+		wantCancelled:   true,
+		wantSchedulable: []bool{false, false},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.rprt.IsStarted(); got != tc.wantStarted {
+				t.Errorf("expected IsStarted: %t but got %t", tc.wantStarted, got)
+			}
+			if got := tc.rprt.IsSuccessful(); got != tc.wantSuccessful {
+				t.Errorf("expected IsSuccessful: %t but got %t", tc.wantSuccessful, got)
+			}
+			if got := tc.rprt.IsFailure(); got != tc.wantFailure {
+				t.Errorf("expected IsFailure: %t but got %t", tc.wantFailure, got)
+			}
+			if got := tc.rprt.IsCancelled(); got != tc.wantCancelled {
+				t.Errorf("expected IsCancelled: %t but got %t", tc.wantCancelled, got)
+			}
+			for i, taskRun := range tc.rprt.TaskRuns {
+				if got := tc.rprt.IsTaskRunSchedulable(taskRun); got != tc.wantSchedulable[i] {
+					t.Errorf("expected IsTaskRunSchedulable for TaskRun %d: %t but got %t", i, tc.wantSchedulable[i], got)
+				}
+			}
+		})
+	}
+}
+
+func TestResolvePipelineRun_ChildPipeline(t *testing.T) {
+	names.TestingSeed()
+	pts := []v1beta1.PipelineTask{{
+		Name:        "child",
+		PipelineRef: &v1beta1.PipelineRef{Name: "build-pipeline"},
+	}, {
+		Name: "child-exists",
+		PipelineSpec: &v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{Name: "unit", TaskRef: &v1beta1.TaskRef{Name: "unit-test"}}},
+		},
+	}}
+	pr := v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+	}
+	childPipelineRun := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-child-exists-mz4c7"}}
+	getPipelineRun := func(name string) (*v1beta1.PipelineRun, error) {
+		if name == "pipelinerun-child-exists-mz4c7" {
+			return childPipelineRun, nil
+		}
+		return nil, kerrors.NewNotFound(v1beta1.Resource("pipelinerun"), name)
+	}
+	nopGetCondition := func(string) (*v1alpha1.Condition, error) { return nil, errors.New("GetCondition should not be called") }
+	pipelineState := PipelineRunState{}
+	for _, task := range pts {
+		ps, err := ResolvePipelineRunTask(context.Background(), pr, nopGetTask, nopGetTaskRun, nopGetRun, getPipelineRun, nopGetCondition, task, nil)
+		if err != nil {
+			t.Fatalf("ResolvePipelineRunTask: %v", err)
+		}
+		pipelineState = append(pipelineState, ps)
+	}
+
+	expectedState := PipelineRunState{{
+		PipelineTask:    &pts[0],
+		PipelineRunName: "pipelinerun-child-9l9zj",
+	}, {
+		PipelineTask:    &pts[1],
+		PipelineRunName: "pipelinerun-child-exists-mz4c7",
+		PipelineRun:     childPipelineRun,
+	}}
+	if d := cmp.Diff(expectedState, pipelineState); d != "" {
+		t.Errorf("Unexpected pipeline state: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolvedPipelineRunTask_ChildPipeline(t *testing.T) {
+	pt := v1beta1.PipelineTask{
+		Name:        "child",
+		PipelineRef: &v1beta1.PipelineRef{Name: "build-pipeline"},
+	}
+	childPipelineRun := func(status corev1.ConditionStatus, reason string) *v1beta1.PipelineRun {
+		childPipelineRun := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-child"}}
+		childPipelineRun.Status.SetCondition(&apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: status,
+			Reason: reason,
+		})
+		return childPipelineRun
+	}
+	for _, tc := range []struct {
+		name           string
+		pipelineRun    *v1beta1.PipelineRun
+		wantStarted    bool
+		wantSuccessful bool
+		wantFailure    bool
+		wantCancelled  bool
+	}{{
+		name: "no child pipelinerun",
+	}, {
+		name:        "child pipelinerun running",
+		pipelineRun: childPipelineRun(corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String()),
+		wantStarted: true,
+	}, {
+		name:           "child pipelinerun succeeded",
+		pipelineRun:    childPipelineRun(corev1.ConditionTrue, v1beta1.PipelineRunReasonSuccessful.String()),
+		wantStarted:    true,
+		wantSuccessful: true,
+	}, {
+		name:        "child pipelinerun failed",
+		pipelineRun: childPipelineRun(corev1.ConditionFalse, v1beta1.PipelineRunReasonFailed.String()),
+		wantStarted: true,
+		wantFailure: true,
+	}, {
+		name:          "child pipelinerun cancelled",
+		pipelineRun:   childPipelineRun(corev1.ConditionFalse, v1beta1.PipelineRunReasonCancelled.String()),
+		wantStarted:   true,
+		wantFailure:   true,
+		wantCancelled: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rprt := ResolvedPipelineRunTask{
+				PipelineTask:    &pt,
+				PipelineRunName: "pipelinerun-child",
+				PipelineRun:     tc.pipelineRun,
+			}
+			if got := rprt.IsStarted(); got != tc.wantStarted {
+				t.Errorf("expected IsStarted: %t but got %t", tc.wantStarted, got)
+			}
+			if got := rprt.IsSuccessful(); got != tc.wantSuccessful {
+				t.Errorf("expected IsSuccessful: %t but got %t", tc.wantSuccessful, got)
+			}
+			if got := rprt.IsFailure(); got != tc.wantFailure {
+				t.Errorf("expected IsFailure: %t but got %t", tc.wantFailure, got)
+			}
+			if got := rprt.IsCancelled(); got != tc.wantCancelled {
+				t.Errorf("expected IsCancelled: %t but got %t", tc.wantCancelled, got)
+			}
+		})
+	}
+}
+
+func TestResolvedPipelineRunTask_RetryPolicy(t *testing.T) {
+	failedTaskRunWithSteps := func(reason string, retries int, steps ...corev1.ContainerStateTerminated) *v1beta1.TaskRun {
+		tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-mytask"}}
+		tr.Status.SetCondition(&apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: reason,
+		})
+		for i := range steps {
+			tr.Status.Steps = append(tr.Status.Steps, v1beta1.StepState{
+				ContainerState: corev1.ContainerState{Terminated: &steps[i]},
+			})
+		}
+		for i := 0; i < retries; i++ {
+			tr.Status.RetriesStatus = append(tr.Status.RetriesStatus, v1beta1.TaskRunStatus{})
+		}
+		return tr
+	}
+	failedTaskRun := func(reason string, exitCode int32, retries int) *v1beta1.TaskRun {
+		return failedTaskRunWithSteps(reason, retries, corev1.ContainerStateTerminated{ExitCode: exitCode})
+	}
+	for _, tc := range []struct {
+		name            string
+		retryPolicy     *v1beta1.RetryPolicy
+		taskRun         *v1beta1.TaskRun
+		wantSchedulable bool
+		wantFailure     bool
+	}{{
+		name:            "any failure is retried",
+		retryPolicy:     &v1beta1.RetryPolicy{Count: 2},
+		taskRun:         failedTaskRun(v1beta1.TaskRunReasonFailed.String(), 1, 1),
+		wantSchedulable: true,
+	}, {
+		name:        "retries exhausted",
+		retryPolicy: &v1beta1.RetryPolicy{Count: 2},
+		taskRun:     failedTaskRun(v1beta1.TaskRunReasonFailed.String(), 1, 2),
+		wantFailure: true,
+	}, {
+		name:            "retried reason",
+		retryPolicy:     &v1beta1.RetryPolicy{Count: 2, Reasons: []string{"PodEvicted"}},
+		taskRun:         failedTaskRun("PodEvicted", 0, 0),
+		wantSchedulable: true,
+	}, {
+		name:        "reason not retried",
+		retryPolicy: &v1beta1.RetryPolicy{Count: 2, Reasons: []string{"PodEvicted"}},
+		taskRun:     failedTaskRun(v1beta1.TaskRunReasonFailed.String(), 1, 0),
+		wantFailure: true,
+	}, {
+		name:            "retried exit code",
+		retryPolicy:     &v1beta1.RetryPolicy{Count: 2, Reasons: []string{"PodEvicted"}, ExitCodes: []int32{137}},
+		taskRun:         failedTaskRun(v1beta1.TaskRunReasonFailed.String(), 137, 0),
+		wantSchedulable: true,
+	}, {
+		name:        "exit code not retried",
+		retryPolicy: &v1beta1.RetryPolicy{Count: 2, ExitCodes: []int32{137}},
+		taskRun:     failedTaskRun(v1beta1.TaskRunReasonFailed.String(), 1, 0),
+		wantFailure: true,
+	}, {
+		name:        "retried exit code of a step skipped after the failure",
+		retryPolicy: &v1beta1.RetryPolicy{Count: 2, ExitCodes: []int32{137}},
+		taskRun: failedTaskRunWithSteps(v1beta1.TaskRunReasonFailed.String(), 0,
+			corev1.ContainerStateTerminated{ExitCode: 0},
+			corev1.ContainerStateTerminated{ExitCode: 1},
+			corev1.ContainerStateTerminated{ExitCode: 137}),
+		wantFailure: true,
+	}, {
+		name:        "retried exit code of a step allowed to continue",
+		retryPolicy: &v1beta1.RetryPolicy{Count: 2, ExitCodes: []int32{137}},
+		taskRun: failedTaskRunWithSteps(v1beta1.TaskRunReasonFailed.String(), 0,
+			corev1.ContainerStateTerminated{ExitCode: 137, Reason: pod.ReasonStepFailedContinued},
+			corev1.ContainerStateTerminated{ExitCode: 1}),
+		wantFailure: true,
+	}, {
+		name:        "retried exit code of the step which failed after a step allowed to continue",
+		retryPolicy: &v1beta1.RetryPolicy{Count: 2, ExitCodes: []int32{137}},
+		taskRun: failedTaskRunWithSteps(v1beta1.TaskRunReasonFailed.String(), 0,
+			corev1.ContainerStateTerminated{ExitCode: 1, Reason: pod.ReasonStepFailedContinued},
+			corev1.ContainerStateTerminated{ExitCode: 137}),
+		wantSchedulable: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rprt := ResolvedPipelineRunTask{
+				PipelineTask: &v1beta1.PipelineTask{
+					Name:        "mytask",
+					TaskRef:     &v1beta1.TaskRef{Name: "task"},
+					RetryPolicy: tc.retryPolicy,
+				},
+				TaskRunName: "pipelinerun-mytask",
+				TaskRun:     tc.taskRun,
+			}
+			if got := rprt.IsTaskRunSchedulable(tc.taskRun); got != tc.wantSchedulable {
+				t.Errorf("expected IsTaskRunSchedulable: %t but got %t", tc.wantSchedulable, got)
+			}
+			if got := rprt.IsFailure(); got != tc.wantFailure {
+				t.Errorf("expected IsFailure: %t but got %t", tc.wantFailure, got)
+			}
+		})
+	}
+}
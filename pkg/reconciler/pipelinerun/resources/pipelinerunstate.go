@@ -48,15 +48,30 @@ type PipelineRunFacts struct {
 	TasksGraph      *dag.Graph
 	FinalTasksGraph *dag.Graph
 
+	// TasksTimedOut is set when the tasks timeout of the PipelineRun was reached
+	// before its finally tasks started: the DAG tasks which have not started yet
+	// are skipped and the running ones are stopped.
+	TasksTimedOut bool
+	// FinallyTimedOut is set when the finally timeout of the PipelineRun was
+	// reached: the running finally tasks are stopped.
+	FinallyTimedOut bool
+	// SpecStatus is the status requested by the user in the spec of the PipelineRun,
+	// which tells whether the PipelineRun was gracefully cancelled or stopped.
+	SpecStatus v1beta1.PipelineRunSpecStatus
+	// WhenCELVariables holds the params and context variables the CEL expressions
+	// of the When Expressions are evaluated with.
+	WhenCELVariables map[string]interface{}
+
 	// SkipCache is a hash of PipelineTask names that stores whether a task will be
 	// executed or not, because it's either not reachable via the DAG due to the pipeline
-	// state, or because it has failed conditions.
+	// state, or because it has failed conditions. It holds the reason why the task is
+	// skipped, which is empty if the task is not skipped.
 	// We cache this data along the state, because it's expensive to compute, it requires
 	// traversing potentially the whole graph; this way it can built incrementally, when
 	// needed, via the `Skip` method in pipelinerunresolution.go
 	// The skip data is sensitive to changes in the state. The ResetSkippedCache method
 	// can be used to clean the cache and force re-computation when needed.
-	SkipCache map[string]bool
+	SkipCache map[string]v1beta1.SkippingReason
 }
 
 // pipelineRunStatusCount holds the count of successful, failed, cancelled, skipped, and incomplete tasks
@@ -75,7 +90,7 @@ type pipelineRunStatusCount struct {
 
 // ResetSkippedCache resets the skipped cache in the facts map
 func (facts *PipelineRunFacts) ResetSkippedCache() {
-	facts.SkipCache = make(map[string]bool)
+	facts.SkipCache = make(map[string]v1beta1.SkippingReason)
 }
 
 // ToMap returns a map that maps pipeline task name to the resolved pipeline run task
@@ -92,8 +107,12 @@ func (state PipelineRunState) IsBeforeFirstTaskRun() bool {
 	for _, t := range state {
 		if t.IsCustomTask() && t.Run != nil {
 			return false
+		} else if t.IsChildPipeline() && t.PipelineRun != nil {
+			return false
 		} else if t.TaskRun != nil {
 			return false
+		} else if t.anyMatrixedTaskRun(func(tr *v1beta1.TaskRun) bool { return tr != nil }) {
+			return false
 		}
 	}
 	return true
@@ -121,6 +140,14 @@ func (state PipelineRunState) AdjustStartTime(unadjustedStartTime *metav1.Time)
 				adjustedStartTime = &rprt.TaskRun.CreationTimestamp
 			}
 		}
+		for _, taskRun := range rprt.TaskRuns {
+			if taskRun != nil && taskRun.CreationTimestamp.Time.Before(adjustedStartTime.Time) {
+				adjustedStartTime = &taskRun.CreationTimestamp
+			}
+		}
+		if rprt.PipelineRun != nil && rprt.PipelineRun.CreationTimestamp.Time.Before(adjustedStartTime.Time) {
+			adjustedStartTime = &rprt.PipelineRun.CreationTimestamp
+		}
 	}
 	return adjustedStartTime.DeepCopy()
 }
@@ -131,7 +158,25 @@ func (state PipelineRunState) AdjustStartTime(unadjustedStartTime *metav1.Time)
 func (state PipelineRunState) GetTaskRunsStatus(pr *v1beta1.PipelineRun) map[string]*v1beta1.PipelineRunTaskRunStatus {
 	status := make(map[string]*v1beta1.PipelineRunTaskRunStatus)
 	for _, rprt := range state {
-		if rprt.IsCustomTask() {
+		if rprt.IsCustomTask() || rprt.IsChildPipeline() {
+			continue
+		}
+		if rprt.IsMatrixed() {
+			// all the TaskRuns of a matrixed task are tracked under the same pipeline task name
+			for _, taskRun := range rprt.TaskRuns {
+				if taskRun == nil {
+					continue
+				}
+				prtrs := pr.Status.TaskRuns[taskRun.Name]
+				if prtrs == nil {
+					prtrs = &v1beta1.PipelineRunTaskRunStatus{
+						PipelineTaskName: rprt.PipelineTask.Name,
+						WhenExpressions:  rprt.PipelineTask.WhenExpressions,
+					}
+				}
+				prtrs.Status = &taskRun.Status
+				status[taskRun.Name] = prtrs
+			}
 			continue
 		}
 		if rprt.TaskRun == nil && rprt.ResolvedConditionChecks == nil {
@@ -216,23 +261,47 @@ func (state PipelineRunState) GetRunsStatus(pr *v1beta1.PipelineRun) map[string]
 	return status
 }
 
+// GetPipelineRunsStatus returns a map of child pipelinerun name and the child pipelinerun.
+// Ignore a nil child pipelinerun in pipelineRunState, otherwise, capture the child pipelinerun
+// object from PipelineRun Status.
+// Update the child pipelinerun status based on the pipelineRunState before returning it in the map.
+func (state PipelineRunState) GetPipelineRunsStatus(pr *v1beta1.PipelineRun) map[string]*v1beta1.PipelineRunPipelineRunStatus {
+	status := map[string]*v1beta1.PipelineRunPipelineRunStatus{}
+	for _, rprt := range state {
+		if !rprt.IsChildPipeline() || rprt.PipelineRun == nil {
+			continue
+		}
+
+		prprs := pr.Status.PipelineRuns[rprt.PipelineRunName]
+		if prprs == nil {
+			prprs = &v1beta1.PipelineRunPipelineRunStatus{
+				PipelineTaskName: rprt.PipelineTask.Name,
+				WhenExpressions:  rprt.PipelineTask.WhenExpressions,
+			}
+		}
+		prprs.Status = &rprt.PipelineRun.Status
+		status[rprt.PipelineRunName] = prprs
+	}
+	return status
+}
+
 // getNextTasks returns a list of tasks which should be executed next i.e.
 // a list of tasks from candidateTasks which aren't yet indicated in state to be running and
 // a list of cancelled/failed tasks from candidateTasks which haven't exhausted their retries
+// a matrixed task is returned as long as any of its TaskRuns is yet to be created or retried
 func (state PipelineRunState) getNextTasks(candidateTasks sets.String) []*ResolvedPipelineRunTask {
 	tasks := []*ResolvedPipelineRunTask{}
 	for _, t := range state {
 		if _, ok := candidateTasks[t.PipelineTask.Name]; ok {
-			if t.TaskRun == nil && t.Run == nil {
+			if t.IsMatrixed() {
+				if len(t.TaskRuns) != len(t.TaskRunNames) || t.anyMatrixedTaskRun(t.IsTaskRunSchedulable) {
+					tasks = append(tasks, t)
+				}
+			} else if t.TaskRun == nil && t.Run == nil && t.PipelineRun == nil {
 				tasks = append(tasks, t)
 			} else if t.TaskRun != nil { // only TaskRun currently supports retry
-				status := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
-				if status != nil && status.IsFalse() {
-					if !(t.TaskRun.IsCancelled() || status.Reason == v1beta1.TaskRunReasonCancelled.String() || status.Reason == ReasonConditionCheckFailed) {
-						if len(t.TaskRun.Status.RetriesStatus) < t.PipelineTask.Retries {
-							tasks = append(tasks, t)
-						}
-					}
+				if t.IsTaskRunSchedulable(t.TaskRun) {
+					tasks = append(tasks, t)
 				}
 			}
 		}
@@ -256,12 +325,24 @@ func (facts *PipelineRunFacts) IsStopping() bool {
 	return false
 }
 
+// IsGracefullyCancelled returns true if the PipelineRun was cancelled by the user
+// with the request to execute its finally tasks
+func (facts *PipelineRunFacts) IsGracefullyCancelled() bool {
+	return facts.SpecStatus == v1beta1.PipelineRunSpecStatusCancelledRunFinally
+}
+
+// IsGracefullyStopped returns true if the PipelineRun was stopped by the user
+// with the request to execute its finally tasks
+func (facts *PipelineRunFacts) IsGracefullyStopped() bool {
+	return facts.SpecStatus == v1beta1.PipelineRunSpecStatusStoppedRunFinally
+}
+
 // DAGExecutionQueue returns a list of DAG tasks which needs to be scheduled next
 func (facts *PipelineRunFacts) DAGExecutionQueue() (PipelineRunState, error) {
 	tasks := PipelineRunState{}
-	// when pipeline run is stopping, do not schedule any new task and only
-	// wait for all running tasks to complete and report their status
-	if !facts.IsStopping() {
+	// when pipeline run is stopping, or was gracefully cancelled or stopped, do not
+	// schedule any new task and only wait for all running tasks to complete and report their status
+	if !facts.IsStopping() && !facts.IsGracefullyCancelled() && !facts.IsGracefullyStopped() {
 		// candidateTasks is initialized to DAG root nodes to start pipeline execution
 		// candidateTasks is derived based on successfully finished tasks and/or skipped tasks
 		candidateTasks, err := dag.GetSchedulable(facts.TasksGraph, facts.successfulOrSkippedDAGTasks()...)
@@ -293,9 +374,26 @@ func (facts *PipelineRunFacts) GetFinalTasks() PipelineRunState {
 	return tasks
 }
 
+// GetTasksToCancel returns the PipelineTasks which are still running even though the
+// timeout of their section of the Pipeline, tasks or finally, was reached, or the
+// PipelineRun was gracefully cancelled in the case of DAG tasks.
+func (facts *PipelineRunFacts) GetTasksToCancel() PipelineRunState {
+	tasks := PipelineRunState{}
+	for _, t := range facts.State {
+		if !t.IsStarted() || t.IsSuccessful() || t.IsFailure() || t.IsCancelled() {
+			continue
+		}
+		if ((facts.TasksTimedOut || facts.IsGracefullyCancelled()) && facts.isDAGTask(t.PipelineTask.Name)) ||
+			(facts.FinallyTimedOut && facts.isFinalTask(t.PipelineTask.Name)) {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
 // GetPipelineConditionStatus will return the Condition that the PipelineRun prName should be
 // updated with, based on the status of the TaskRuns in state.
-func (facts *PipelineRunFacts) GetPipelineConditionStatus(pr *v1beta1.PipelineRun, logger *zap.SugaredLogger) *apis.Condition {
+func (facts *PipelineRunFacts) GetPipelineConditionStatus(ctx context.Context, pr *v1beta1.PipelineRun, logger *zap.SugaredLogger) *apis.Condition {
 	// We have 4 different states here:
 	// 1. Timed out -> Failed
 	// 2. All tasks are done and at least one has failed or has been cancelled -> Failed
@@ -306,7 +404,7 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(pr *v1beta1.PipelineRu
 			Type:    apis.ConditionSucceeded,
 			Status:  corev1.ConditionFalse,
 			Reason:  v1beta1.PipelineRunReasonTimedOut.String(),
-			Message: fmt.Sprintf("PipelineRun %q failed to finish within %q", pr.Name, pr.Spec.Timeout.Duration.String()),
+			Message: fmt.Sprintf("PipelineRun %q failed to finish within %q", pr.Name, pr.GetTimeout(ctx).String()),
 		}
 	}
 
@@ -338,6 +436,40 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(pr *v1beta1.PipelineRu
 			status = corev1.ConditionFalse
 		}
 		logger.Infof("All TaskRuns have finished for PipelineRun %s so it has finished", pr.Name)
+		// Report the timeout which prevented the tasks or the finally tasks from completing
+		if facts.dagTasksTimedOut() {
+			return &apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  v1beta1.PipelineRunReasonTasksTimedOut.String(),
+				Message: fmt.Sprintf("PipelineRun %q failed to finish its tasks within %q", pr.Name, pr.TasksTimeout().Duration.String()),
+			}
+		}
+		if facts.finalTasksTimedOut() {
+			return &apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  v1beta1.PipelineRunReasonFinallyTimedOut.String(),
+				Message: fmt.Sprintf("PipelineRun %q failed to finish its finally tasks within %q", pr.Name, pr.FinallyTimeout().Duration.String()),
+			}
+		}
+		// Report the graceful cancellation or stop of the PipelineRun, unless one of its tasks failed
+		if s.Failed == 0 && facts.IsGracefullyCancelled() {
+			return &apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  v1beta1.PipelineRunReasonCancelledRunFinally.String(),
+				Message: fmt.Sprintf("PipelineRun %q was cancelled after running its finally tasks", pr.Name),
+			}
+		}
+		if s.Failed == 0 && facts.IsGracefullyStopped() {
+			return &apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  v1beta1.PipelineRunReasonStoppedRunFinally.String(),
+				Message: fmt.Sprintf("PipelineRun %q was stopped after running its finally tasks", pr.Name),
+			}
+		}
 		return &apis.Condition{
 			Type:   apis.ConditionSucceeded,
 			Status: status,
@@ -351,7 +483,13 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(pr *v1beta1.PipelineRu
 	// transition pipeline into stopping state when one of the tasks(dag/final) cancelled or one of the dag tasks failed
 	// for a pipeline with final tasks, single dag task failure does not transition to interim stopping state
 	// pipeline stays in running state until all final tasks are done before transitioning to failed state
-	if s.Cancelled > 0 || (s.Failed > 0 && facts.checkFinalTasksDone()) {
+	// a gracefully cancelled or stopped pipeline reports it until its final tasks are done
+	switch {
+	case facts.IsGracefullyCancelled():
+		reason = v1beta1.PipelineRunReasonCancelledRunningFinally.String()
+	case facts.IsGracefullyStopped():
+		reason = v1beta1.PipelineRunReasonStoppedRunningFinally.String()
+	case s.Cancelled > 0 || (s.Failed > 0 && facts.checkFinalTasksDone()):
 		reason = v1beta1.PipelineRunReasonStopping.String()
 	}
 
@@ -369,16 +507,21 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(pr *v1beta1.PipelineRu
 func (facts *PipelineRunFacts) GetSkippedTasks() []v1beta1.SkippedTask {
 	var skipped []v1beta1.SkippedTask
 	for _, rprt := range facts.State {
-		if rprt.Skip(facts) {
+		if reason := rprt.skippingReason(facts); reason != "" {
 			skippedTask := v1beta1.SkippedTask{
 				Name:            rprt.PipelineTask.Name,
+				Reason:          reason,
 				WhenExpressions: rprt.PipelineTask.WhenExpressions,
 			}
 			skipped = append(skipped, skippedTask)
 		}
-		if rprt.IsFinallySkipped(facts) {
+		if reason := rprt.finallySkippingReason(facts); reason != "" {
 			skippedTask := v1beta1.SkippedTask{
-				Name: rprt.PipelineTask.Name,
+				Name:   rprt.PipelineTask.Name,
+				Reason: reason,
+			}
+			if reason == v1beta1.WhenExpressionsSkip {
+				skippedTask.WhenExpressions = rprt.PipelineTask.WhenExpressions
 			}
 			skipped = append(skipped, skippedTask)
 		}
@@ -388,29 +531,60 @@ func (facts *PipelineRunFacts) GetSkippedTasks() []v1beta1.SkippedTask {
 
 // GetPipelineTaskStatus returns the status of a PipelineTask depending on its taskRun
 // the checks are implemented such that the finally tasks are requesting status of the dag tasks
+// the aggregate status of all the dag tasks is returned as tasks.status
 func (facts *PipelineRunFacts) GetPipelineTaskStatus(ctx context.Context) map[string]string {
+	return facts.pipelineTaskStatus()
+}
+
+func (facts *PipelineRunFacts) pipelineTaskStatus() map[string]string {
 	// construct a map of tasks.<pipelineTask>.status and its state
 	tStatus := make(map[string]string)
 	for _, t := range facts.State {
 		if facts.isDAGTask(t.PipelineTask.Name) {
-			var s string
-			switch {
-			// execution status is Succeeded when a task has succeeded condition with status set to true
-			case t.IsSuccessful():
-				s = v1beta1.TaskRunReasonSuccessful.String()
-			// execution status is Failed when a task has succeeded condition with status set to false
-			case t.IsConditionStatusFalse():
-				s = v1beta1.TaskRunReasonFailed.String()
-			default:
-				// None includes skipped as well
-				s = PipelineTaskStateNone
-			}
-			tStatus[PipelineTaskStatusPrefix+t.PipelineTask.Name+PipelineTaskStatusSuffix] = s
+			tStatus[PipelineTaskStatusPrefix+t.PipelineTask.Name+PipelineTaskStatusSuffix] = t.executionStatus()
 		}
 	}
+	tStatus[v1beta1.PipelineTasksAggregateStatus] = facts.aggregateDAGTasksStatus()
 	return tStatus
 }
 
+// executionStatus returns the execution status of the PipelineTask, which the
+// When Expressions of the finally tasks can consume
+func (t *ResolvedPipelineRunTask) executionStatus() string {
+	switch {
+	// execution status is Succeeded when a task has succeeded condition with status set to true
+	case t.IsSuccessful():
+		return v1beta1.TaskRunReasonSuccessful.String()
+	// execution status is Failed when a task has succeeded condition with status set to false
+	case t.IsConditionStatusFalse():
+		return v1beta1.TaskRunReasonFailed.String()
+	}
+	// None includes skipped as well
+	return PipelineTaskStateNone
+}
+
+// aggregateDAGTasksStatus returns the aggregate execution status of the dag tasks:
+// Failed if any of them failed, Completed if none failed but some were skipped,
+// Succeeded if all of them succeeded, and None if they are not all done
+func (facts *PipelineRunFacts) aggregateDAGTasksStatus() string {
+	if !facts.checkDAGTasksDone() {
+		return PipelineTaskStateNone
+	}
+	aggregateStatus := v1beta1.PipelineRunReasonSuccessful.String()
+	for _, t := range facts.State {
+		if !facts.isDAGTask(t.PipelineTask.Name) {
+			continue
+		}
+		if t.IsFailure() {
+			return v1beta1.PipelineRunReasonFailed.String()
+		}
+		if t.Skip(facts) {
+			aggregateStatus = v1beta1.PipelineRunReasonCompleted.String()
+		}
+	}
+	return aggregateStatus
+}
+
 // successfulOrSkippedTasks returns a list of the names of all of the PipelineTasks in state
 // which have successfully completed or skipped
 func (facts *PipelineRunFacts) successfulOrSkippedDAGTasks() []string {
@@ -425,6 +599,34 @@ func (facts *PipelineRunFacts) successfulOrSkippedDAGTasks() []string {
 	return tasks
 }
 
+// dagTasksTimedOut returns true if the tasks timeout was reached before all the DAG tasks
+// which were not skipped by their own when expressions or conditions completed successfully
+func (facts *PipelineRunFacts) dagTasksTimedOut() bool {
+	if !facts.TasksTimedOut {
+		return false
+	}
+	for _, t := range facts.State {
+		if facts.isDAGTask(t.PipelineTask.Name) && !t.IsSuccessful() && !t.conditionsSkip() && !t.whenExpressionsSkip(facts) {
+			return true
+		}
+	}
+	return false
+}
+
+// finalTasksTimedOut returns true if the finally timeout was reached before all the
+// finally tasks which could be executed completed successfully
+func (facts *PipelineRunFacts) finalTasksTimedOut() bool {
+	if !facts.FinallyTimedOut {
+		return false
+	}
+	for _, t := range facts.State {
+		if facts.isFinalTask(t.PipelineTask.Name) && !t.IsSuccessful() && !t.IsFinallySkipped(facts) {
+			return true
+		}
+	}
+	return false
+}
+
 // checkTasksDone returns true if all tasks from the specified graph are finished executing
 // a task is considered done if it has failed/succeeded/skipped
 func (facts *PipelineRunFacts) checkTasksDone(d *dag.Graph) bool {
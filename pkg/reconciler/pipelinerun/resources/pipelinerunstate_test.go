@@ -1055,7 +1055,7 @@ func TestGetPipelineConditionStatus(t *testing.T) {
 				TasksGraph:      d,
 				FinalTasksGraph: &dag.Graph{},
 			}
-			c := facts.GetPipelineConditionStatus(pr, zap.NewNop().Sugar())
+			c := facts.GetPipelineConditionStatus(context.Background(), pr, zap.NewNop().Sugar())
 			wantCondition := &apis.Condition{
 				Type:   apis.ConditionSucceeded,
 				Status: tc.expectedStatus,
@@ -1192,7 +1192,7 @@ func TestGetPipelineConditionStatus_WithFinalTasks(t *testing.T) {
 				TasksGraph:      d,
 				FinalTasksGraph: df,
 			}
-			c := facts.GetPipelineConditionStatus(pr, zap.NewNop().Sugar())
+			c := facts.GetPipelineConditionStatus(context.Background(), pr, zap.NewNop().Sugar())
 			wantCondition := &apis.Condition{
 				Type:   apis.ConditionSucceeded,
 				Status: tc.expectedStatus,
@@ -1229,7 +1229,7 @@ func TestGetPipelineConditionStatus_PipelineTimeouts(t *testing.T) {
 		TasksGraph:      d,
 		FinalTasksGraph: &dag.Graph{},
 	}
-	c := facts.GetPipelineConditionStatus(pr, zap.NewNop().Sugar())
+	c := facts.GetPipelineConditionStatus(context.Background(), pr, zap.NewNop().Sugar())
 	if c.Status != corev1.ConditionFalse && c.Reason != v1beta1.PipelineRunReasonTimedOut.String() {
 		t.Fatalf("Expected to get status %s but got %s for state %v", corev1.ConditionFalse, c.Status, oneFinishedState)
 	}
@@ -1354,6 +1354,7 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
 			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			v1beta1.PipelineTasksAggregateStatus:                              PipelineTaskStateNone,
 		},
 	}, {
 		name:     "one-task-started",
@@ -1362,6 +1363,7 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
 			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			v1beta1.PipelineTasksAggregateStatus:                              PipelineTaskStateNone,
 		},
 	}, {
 		name:     "one-task-finished",
@@ -1370,6 +1372,7 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix: v1beta1.TaskRunReasonSuccessful.String(),
 			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			v1beta1.PipelineTasksAggregateStatus:                              PipelineTaskStateNone,
 		},
 	}, {
 		name:     "one-task-failed",
@@ -1378,6 +1381,7 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix: v1beta1.TaskRunReasonFailed.String(),
 			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			v1beta1.PipelineTasksAggregateStatus:                              v1beta1.PipelineRunReasonFailed.String(),
 		},
 	}, {
 		name:     "all-finished",
@@ -1386,6 +1390,7 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix: v1beta1.TaskRunReasonSuccessful.String(),
 			PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskStatusSuffix: v1beta1.TaskRunReasonSuccessful.String(),
+			v1beta1.PipelineTasksAggregateStatus:                              v1beta1.PipelineRunReasonSuccessful.String(),
 		},
 	}, {
 		name: "task-with-when-expressions-passed",
@@ -1400,6 +1405,7 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		dagTasks: []v1beta1.PipelineTask{pts[9]},
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[9].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			v1beta1.PipelineTasksAggregateStatus:                              PipelineTaskStateNone,
 		},
 	}, {
 		name: "tasks-when-expression-failed-and-task-skipped",
@@ -1413,6 +1419,7 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		dagTasks: []v1beta1.PipelineTask{pts[10]},
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			v1beta1.PipelineTasksAggregateStatus:                               v1beta1.PipelineRunReasonCompleted.String(),
 		},
 	}, {
 		name: "when-expression-task-with-parent-started",
@@ -1433,6 +1440,7 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskStatusSuffix:  PipelineTaskStateNone,
 			PipelineTaskStatusPrefix + pts[11].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			v1beta1.PipelineTasksAggregateStatus:                               PipelineTaskStateNone,
 		},
 	}, {
 		name:     "task-cancelled",
@@ -1440,6 +1448,7 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 		dagTasks: []v1beta1.PipelineTask{pts[4]},
 		expectedStatus: map[string]string{
 			PipelineTaskStatusPrefix + pts[4].Name + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+			v1beta1.PipelineTasksAggregateStatus:                              PipelineTaskStateNone,
 		},
 	}}
 	for _, tc := range tcs {
@@ -1469,7 +1478,7 @@ func TestPipelineRunFacts_GetSkippedTasks(t *testing.T) {
 	}, {
 		PipelineTask: &pts[14],
 	}}
-	expectedSkippedTasks := []v1beta1.SkippedTask{{Name: pts[14].Name}}
+	expectedSkippedTasks := []v1beta1.SkippedTask{{Name: pts[14].Name, Reason: v1beta1.MissingResultsSkip}}
 	d, err := dag.Build(v1beta1.PipelineTaskList{pts[0]}, v1beta1.PipelineTaskList{pts[0]}.Deps())
 	if err != nil {
 		t.Fatalf("Unexpected error while building graph for DAG tasks %v: %v", v1beta1.PipelineTaskList{pts[0]}, err)
@@ -1488,3 +1497,323 @@ func TestPipelineRunFacts_GetSkippedTasks(t *testing.T) {
 		t.Fatalf("Mismatch skipped tasks %s", diff.PrintWantGot(d))
 	}
 }
+
+func TestGetPipelineConditionStatus_TasksAndFinallyTimeouts(t *testing.T) {
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-timeouts"},
+		Spec: v1beta1.PipelineRunSpec{
+			Timeouts: &v1beta1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: 1 * time.Hour},
+				Tasks:    &metav1.Duration{Duration: 40 * time.Minute},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name            string
+		state           PipelineRunState
+		tasksTimedOut   bool
+		finallyTimedOut bool
+		wantCondition   *apis.Condition
+	}{{
+		name: "tasks timed out, finally succeeded",
+		state: PipelineRunState{{
+			TaskRunName:  "task0taskrun",
+			PipelineTask: &pts[0],
+			TaskRun:      withCancelled(makeFailed(trs[0])),
+		}, {
+			TaskRunName:  "task1taskrun",
+			PipelineTask: &pts[1],
+			TaskRun:      makeSucceeded(trs[1]),
+		}},
+		tasksTimedOut: true,
+		wantCondition: &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1beta1.PipelineRunReasonTasksTimedOut.String(),
+			Message: `PipelineRun "pipelinerun-timeouts" failed to finish its tasks within "40m0s"`,
+		},
+	}, {
+		name: "tasks timed out, finally running",
+		state: PipelineRunState{{
+			TaskRunName:  "task0taskrun",
+			PipelineTask: &pts[0],
+			TaskRun:      withCancelled(makeFailed(trs[0])),
+		}, {
+			TaskRunName:  "task1taskrun",
+			PipelineTask: &pts[1],
+			TaskRun:      makeStarted(trs[1]),
+		}},
+		tasksTimedOut: true,
+		wantCondition: &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  v1beta1.PipelineRunReasonStopping.String(),
+			Message: "Tasks Completed: 1 (Failed: 0, Cancelled 1), Incomplete: 1, Skipped: 0",
+		},
+	}, {
+		name: "finally timed out",
+		state: PipelineRunState{{
+			TaskRunName:  "task0taskrun",
+			PipelineTask: &pts[0],
+			TaskRun:      makeSucceeded(trs[0]),
+		}, {
+			TaskRunName:  "task1taskrun",
+			PipelineTask: &pts[1],
+			TaskRun:      withCancelled(makeFailed(trs[1])),
+		}},
+		finallyTimedOut: true,
+		wantCondition: &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1beta1.PipelineRunReasonFinallyTimedOut.String(),
+			Message: `PipelineRun "pipelinerun-timeouts" failed to finish its finally tasks within "20m0s"`,
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := dag.Build(v1beta1.PipelineTaskList{pts[0]}, map[string][]string{})
+			if err != nil {
+				t.Fatalf("Unexpected error while buildig graph for DAG tasks: %v", err)
+			}
+			df, err := dag.Build(v1beta1.PipelineTaskList{pts[1]}, map[string][]string{})
+			if err != nil {
+				t.Fatalf("Unexpected error while buildig graph for final tasks: %v", err)
+			}
+			facts := PipelineRunFacts{
+				State:           tc.state,
+				TasksGraph:      d,
+				FinalTasksGraph: df,
+				TasksTimedOut:   tc.tasksTimedOut,
+				FinallyTimedOut: tc.finallyTimedOut,
+			}
+			c := facts.GetPipelineConditionStatus(context.Background(), pr, zap.NewNop().Sugar())
+			if d := cmp.Diff(tc.wantCondition, c); d != "" {
+				t.Fatalf("Mismatch in condition %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGetTasksToCancel(t *testing.T) {
+	state := PipelineRunState{{
+		TaskRunName:  "task0taskrun",
+		PipelineTask: &pts[0],
+		TaskRun:      makeStarted(trs[0]),
+	}, {
+		TaskRunName:  "task1taskrun",
+		PipelineTask: &pts[1],
+		TaskRun:      makeSucceeded(trs[1]),
+	}, {
+		TaskRunName:  "task2taskrun",
+		PipelineTask: &pts[2],
+		TaskRun:      makeStarted(trs[0]),
+	}}
+	d, err := dag.Build(v1beta1.PipelineTaskList{pts[0], pts[1]}, map[string][]string{})
+	if err != nil {
+		t.Fatalf("Unexpected error while buildig graph for DAG tasks: %v", err)
+	}
+	df, err := dag.Build(v1beta1.PipelineTaskList{pts[2]}, map[string][]string{})
+	if err != nil {
+		t.Fatalf("Unexpected error while buildig graph for final tasks: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name            string
+		tasksTimedOut   bool
+		finallyTimedOut bool
+		specStatus      v1beta1.PipelineRunSpecStatus
+		want            []string
+	}{{
+		name: "no timeout",
+	}, {
+		name:          "tasks timed out",
+		tasksTimedOut: true,
+		want:          []string{"task0taskrun"},
+	}, {
+		name:            "finally timed out",
+		finallyTimedOut: true,
+		want:            []string{"task2taskrun"},
+	}, {
+		name:       "gracefully cancelled",
+		specStatus: v1beta1.PipelineRunSpecStatusCancelledRunFinally,
+		want:       []string{"task0taskrun"},
+	}, {
+		name:       "gracefully stopped",
+		specStatus: v1beta1.PipelineRunSpecStatusStoppedRunFinally,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			facts := PipelineRunFacts{
+				State:           state,
+				TasksGraph:      d,
+				FinalTasksGraph: df,
+				TasksTimedOut:   tc.tasksTimedOut,
+				FinallyTimedOut: tc.finallyTimedOut,
+				SpecStatus:      tc.specStatus,
+			}
+			var got []string
+			for _, rprt := range facts.GetTasksToCancel() {
+				got = append(got, rprt.TaskRunName)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("GetTasksToCancel() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGetPipelineConditionStatus_GracefullyCancelledOrStopped(t *testing.T) {
+	pr := tb.PipelineRun("pipelinerun-graceful")
+
+	for _, tc := range []struct {
+		name          string
+		state         PipelineRunState
+		specStatus    v1beta1.PipelineRunSpecStatus
+		wantCondition *apis.Condition
+	}{{
+		name: "gracefully cancelled, finally running",
+		state: PipelineRunState{{
+			TaskRunName:  "task0taskrun",
+			PipelineTask: &pts[0],
+			TaskRun:      withCancelled(makeFailed(trs[0])),
+		}, {
+			TaskRunName:  "task1taskrun",
+			PipelineTask: &pts[1],
+			TaskRun:      makeStarted(trs[1]),
+		}},
+		specStatus: v1beta1.PipelineRunSpecStatusCancelledRunFinally,
+		wantCondition: &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  v1beta1.PipelineRunReasonCancelledRunningFinally.String(),
+			Message: "Tasks Completed: 1 (Failed: 0, Cancelled 1), Incomplete: 1, Skipped: 0",
+		},
+	}, {
+		name: "gracefully cancelled, finally done",
+		state: PipelineRunState{{
+			TaskRunName:  "task0taskrun",
+			PipelineTask: &pts[0],
+			TaskRun:      withCancelled(makeFailed(trs[0])),
+		}, {
+			TaskRunName:  "task1taskrun",
+			PipelineTask: &pts[1],
+			TaskRun:      makeSucceeded(trs[1]),
+		}},
+		specStatus: v1beta1.PipelineRunSpecStatusCancelledRunFinally,
+		wantCondition: &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1beta1.PipelineRunReasonCancelledRunFinally.String(),
+			Message: `PipelineRun "pipelinerun-graceful" was cancelled after running its finally tasks`,
+		},
+	}, {
+		name: "gracefully stopped, dag task running",
+		state: PipelineRunState{{
+			TaskRunName:  "task0taskrun",
+			PipelineTask: &pts[0],
+			TaskRun:      makeStarted(trs[0]),
+		}, {
+			PipelineTask: &pts[1],
+		}},
+		specStatus: v1beta1.PipelineRunSpecStatusStoppedRunFinally,
+		wantCondition: &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  v1beta1.PipelineRunReasonStoppedRunningFinally.String(),
+			Message: "Tasks Completed: 0 (Failed: 0, Cancelled 0), Incomplete: 2, Skipped: 0",
+		},
+	}, {
+		name: "gracefully stopped, finally done",
+		state: PipelineRunState{{
+			TaskRunName:  "task0taskrun",
+			PipelineTask: &pts[0],
+			TaskRun:      makeSucceeded(trs[0]),
+		}, {
+			TaskRunName:  "task1taskrun",
+			PipelineTask: &pts[1],
+			TaskRun:      makeSucceeded(trs[1]),
+		}},
+		specStatus: v1beta1.PipelineRunSpecStatusStoppedRunFinally,
+		wantCondition: &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1beta1.PipelineRunReasonStoppedRunFinally.String(),
+			Message: `PipelineRun "pipelinerun-graceful" was stopped after running its finally tasks`,
+		},
+	}, {
+		name: "gracefully stopped, finally failed",
+		state: PipelineRunState{{
+			TaskRunName:  "task0taskrun",
+			PipelineTask: &pts[0],
+			TaskRun:      makeSucceeded(trs[0]),
+		}, {
+			TaskRunName:  "task1taskrun",
+			PipelineTask: &pts[1],
+			TaskRun:      makeFailed(trs[1]),
+		}},
+		specStatus: v1beta1.PipelineRunSpecStatusStoppedRunFinally,
+		wantCondition: &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1beta1.PipelineRunReasonFailed.String(),
+			Message: "Tasks Completed: 2 (Failed: 1, Cancelled 0), Skipped: 0",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := dag.Build(v1beta1.PipelineTaskList{pts[0]}, map[string][]string{})
+			if err != nil {
+				t.Fatalf("Unexpected error while buildig graph for DAG tasks: %v", err)
+			}
+			df, err := dag.Build(v1beta1.PipelineTaskList{pts[1]}, map[string][]string{})
+			if err != nil {
+				t.Fatalf("Unexpected error while buildig graph for final tasks: %v", err)
+			}
+			facts := PipelineRunFacts{
+				State:           tc.state,
+				TasksGraph:      d,
+				FinalTasksGraph: df,
+				SpecStatus:      tc.specStatus,
+			}
+			c := facts.GetPipelineConditionStatus(context.Background(), pr, zap.NewNop().Sugar())
+			if d := cmp.Diff(tc.wantCondition, c); d != "" {
+				t.Fatalf("Mismatch in condition %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestDAGExecutionQueue_GracefullyCancelledOrStopped(t *testing.T) {
+	state := PipelineRunState{{
+		TaskRunName:  "task0taskrun",
+		PipelineTask: &pts[0],
+		TaskRun:      makeSucceeded(trs[0]),
+	}, {
+		PipelineTask: &pts[1],
+	}}
+	d, err := dag.Build(v1beta1.PipelineTaskList{pts[0], pts[1]}, map[string][]string{})
+	if err != nil {
+		t.Fatalf("Unexpected error while buildig graph for DAG tasks: %v", err)
+	}
+	for _, specStatus := range []v1beta1.PipelineRunSpecStatus{
+		v1beta1.PipelineRunSpecStatusCancelledRunFinally,
+		v1beta1.PipelineRunSpecStatusStoppedRunFinally,
+	} {
+		t.Run(string(specStatus), func(t *testing.T) {
+			facts := PipelineRunFacts{
+				State:           state,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+				SpecStatus:      specStatus,
+			}
+			queue, err := facts.DAGExecutionQueue()
+			if err != nil {
+				t.Fatalf("Unexpected error getting DAG execution queue: %v", err)
+			}
+			if len(queue) != 0 {
+				t.Errorf("Expected no task to be scheduled but got %d", len(queue))
+			}
+			if !state[1].Skip(&facts) {
+				t.Errorf("Expected task %s to be skipped", state[1].PipelineTask.Name)
+			}
+		})
+	}
+}
@@ -35,6 +35,7 @@ type ResolvedResultRef struct {
 	ResultReference v1beta1.ResultRef
 	FromTaskRun     string
 	FromRun         string
+	FromPipelineRun string
 }
 
 // ResolveResultRef resolves any ResultReference that are found in the target ResolvedPipelineRunTask
@@ -70,18 +71,38 @@ func extractResultRefsForParam(pipelineRunState PipelineRunState, param v1beta1.
 }
 
 func extractResultRefs(expressions []string, pipelineRunState PipelineRunState) (ResolvedResultRefs, error) {
-	resultRefs := v1beta1.NewResultRefs(expressions)
 	var resolvedResultRefs ResolvedResultRefs
-	for _, resultRef := range resultRefs {
-		resolvedResultRef, err := resolveResultRef(pipelineRunState, resultRef)
-		if err != nil {
-			return nil, err
+	for _, expression := range expressions {
+		for _, resultRef := range v1beta1.NewResultRefs([]string{expression}) {
+			resolvedResultRef, err := resolveResultRef(pipelineRunState, resultRef)
+			if err != nil {
+				return nil, err
+			}
+			if err := validateResultIndex(expression, resolvedResultRef); err != nil {
+				return nil, err
+			}
+			resolvedResultRefs = append(resolvedResultRefs, resolvedResultRef)
 		}
-		resolvedResultRefs = append(resolvedResultRefs, resolvedResultRef)
 	}
 	return removeDup(resolvedResultRefs), nil
 }
 
+// validateResultIndex checks that the element of an array result referenced by
+// the expression, e.g. "tasks.a.results.b[1]", exists.
+func validateResultIndex(expression string, resolvedResultRef *ResolvedResultRef) error {
+	index, ok := v1beta1.ParseResultIndex(expression)
+	if !ok {
+		return nil
+	}
+	if resolvedResultRef.Value.Type != v1beta1.ParamTypeArray {
+		return fmt.Errorf("result %q of task %q is not an array and cannot be indexed", resolvedResultRef.ResultReference.Result, resolvedResultRef.ResultReference.PipelineTask)
+	}
+	if index >= len(resolvedResultRef.Value.ArrayVal) {
+		return fmt.Errorf("index %d is out of bounds of result %q of task %q, which has %d elements", index, resolvedResultRef.ResultReference.Result, resolvedResultRef.ResultReference.PipelineTask, len(resolvedResultRef.Value.ArrayVal))
+	}
+	return nil
+}
+
 func removeDup(refs ResolvedResultRefs) ResolvedResultRefs {
 	if refs == nil {
 		return nil
@@ -180,15 +201,24 @@ func resolveResultRef(pipelineState PipelineRunState, resultRef *v1beta1.ResultR
 		return nil, fmt.Errorf("task %q referenced by result was not successful", referencedPipelineTask.PipelineTask.Name)
 	}
 
-	var runName, taskRunName, resultValue string
-	var err error
+	var runName, taskRunName, pipelineRunName string
+	var resultValue v1beta1.ArrayOrString
 	if referencedPipelineTask.IsCustomTask() {
 		runName = referencedPipelineTask.Run.Name
-		resultValue, err = findRunResultForParam(referencedPipelineTask.Run, resultRef)
+		value, err := findRunResultForParam(referencedPipelineTask.Run, resultRef)
 		if err != nil {
 			return nil, err
 		}
+		resultValue = *v1beta1.NewArrayOrString(value)
+	} else if referencedPipelineTask.IsChildPipeline() {
+		pipelineRunName = referencedPipelineTask.PipelineRun.Name
+		value, err := findPipelineRunResultForParam(referencedPipelineTask.PipelineRun, resultRef)
+		if err != nil {
+			return nil, err
+		}
+		resultValue = *v1beta1.NewArrayOrString(value)
 	} else {
+		var err error
 		taskRunName = referencedPipelineTask.TaskRun.Name
 		resultValue, err = findTaskResultForParam(referencedPipelineTask.TaskRun, resultRef)
 		if err != nil {
@@ -197,9 +227,10 @@ func resolveResultRef(pipelineState PipelineRunState, resultRef *v1beta1.ResultR
 	}
 
 	return &ResolvedResultRef{
-		Value:           *v1beta1.NewArrayOrString(resultValue),
+		Value:           resultValue,
 		FromTaskRun:     taskRunName,
 		FromRun:         runName,
+		FromPipelineRun: pipelineRunName,
 		ResultReference: *resultRef,
 	}, nil
 }
@@ -214,8 +245,8 @@ func findRunResultForParam(run *v1alpha1.Run, reference *v1beta1.ResultRef) (str
 	return "", fmt.Errorf("Could not find result with name %s for task %s", reference.Result, reference.PipelineTask)
 }
 
-func findTaskResultForParam(taskRun *v1beta1.TaskRun, reference *v1beta1.ResultRef) (string, error) {
-	results := taskRun.Status.TaskRunStatusFields.TaskRunResults
+func findPipelineRunResultForParam(pipelineRun *v1beta1.PipelineRun, reference *v1beta1.ResultRef) (string, error) {
+	results := pipelineRun.Status.PipelineResults
 	for _, result := range results {
 		if result.Name == reference.Result {
 			return result.Value, nil
@@ -224,13 +255,33 @@ func findTaskResultForParam(taskRun *v1beta1.TaskRun, reference *v1beta1.ResultR
 	return "", fmt.Errorf("Could not find result with name %s for task %s", reference.Result, reference.PipelineTask)
 }
 
-func (rs ResolvedResultRefs) getStringReplacements() map[string]string {
-	replacements := map[string]string{}
+func findTaskResultForParam(taskRun *v1beta1.TaskRun, reference *v1beta1.ResultRef) (v1beta1.ArrayOrString, error) {
+	results := taskRun.Status.TaskRunStatusFields.TaskRunResults
+	for _, result := range results {
+		if result.Name == reference.Result {
+			return result.Value, nil
+		}
+	}
+	return v1beta1.ArrayOrString{}, fmt.Errorf("Could not find result with name %s for task %s", reference.Result, reference.PipelineTask)
+}
+
+// getReplacements returns the replacements of the resolved results, by type.
+// The elements of array results are also string replacements, e.g.
+// "tasks.a.results.b[1]".
+func (rs ResolvedResultRefs) getReplacements() (map[string]string, map[string][]string, map[string]map[string]string) {
+	stringReplacements := map[string]string{}
+	arrayReplacements := map[string][]string{}
+	objectReplacements := map[string]map[string]string{}
 	for _, r := range rs {
 		replaceTarget := r.getReplaceTarget()
-		replacements[replaceTarget] = r.Value.StringVal
+		addReplacements(replaceTarget, r.Value, stringReplacements, arrayReplacements, objectReplacements)
+		if r.Value.Type == v1beta1.ParamTypeArray {
+			for i, v := range r.Value.ArrayVal {
+				stringReplacements[fmt.Sprintf("%s[%d]", replaceTarget, i)] = v
+			}
+		}
 	}
-	return replacements
+	return stringReplacements, arrayReplacements, objectReplacements
 }
 
 func (r *ResolvedResultRef) getReplaceTarget() string {
@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/selection"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 )
 
 var (
@@ -30,6 +31,39 @@ var (
 )
 
 func TestTaskParamResolver_ResolveResultRefs(t *testing.T) {
+	arrayResultState := PipelineRunState{{
+		TaskRunName: "aTaskRun",
+		TaskRun: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "aTaskRun"},
+			Status: v1beta1.TaskRunStatus{
+				Status: duckv1beta1.Status{
+					Conditions: duckv1beta1.Conditions{successCondition},
+				},
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					TaskRunResults: []v1beta1.TaskRunResult{{
+						Name:  "aResult",
+						Type:  v1beta1.ResultsTypeArray,
+						Value: *v1beta1.NewArrayOrString("first", "second"),
+					}, {
+						Name:  "bResult",
+						Value: *v1beta1.NewArrayOrString("aString"),
+					}},
+				},
+			},
+		},
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "aTask",
+			TaskRef: &v1beta1.TaskRef{Name: "aTask"},
+		},
+	}}
+	resolvedArrayResult := ResolvedResultRefs{{
+		Value: *v1beta1.NewArrayOrString("first", "second"),
+		ResultReference: v1beta1.ResultRef{
+			PipelineTask: "aTask",
+			Result:       "aResult",
+		},
+		FromTaskRun: "aTaskRun",
+	}}
 
 	for _, tt := range []struct {
 		name             string
@@ -268,6 +302,40 @@ func TestTaskParamResolver_ResolveResultRefs(t *testing.T) {
 		},
 		want:    nil,
 		wantErr: true,
+	}, {
+		name:             "successful resolution: using whole array result",
+		pipelineRunState: arrayResultState,
+		param: v1beta1.Param{
+			Name:  "targetParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aTask.results.aResult[*])"),
+		},
+		want: resolvedArrayResult,
+	}, {
+		name:             "successful resolution: using element of array result",
+		pipelineRunState: arrayResultState,
+		param: v1beta1.Param{
+			Name:  "targetParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aTask.results.aResult[1])"),
+		},
+		want: resolvedArrayResult,
+	}, {
+		name:             "failed resolution: index out of bounds of array result",
+		pipelineRunState: arrayResultState,
+		param: v1beta1.Param{
+			Name:  "targetParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aTask.results.aResult[2])"),
+		},
+		want:    nil,
+		wantErr: true,
+	}, {
+		name:             "failed resolution: indexing string result",
+		pipelineRunState: arrayResultState,
+		param: v1beta1.Param{
+			Name:  "targetParam",
+			Value: *v1beta1.NewArrayOrString("$(tasks.aTask.results.bResult[0])"),
+		},
+		want:    nil,
+		wantErr: true,
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Logf("test name: %s\n", tt.name)
@@ -392,6 +460,35 @@ func TestResolveResultRefs(t *testing.T) {
 				Value: *v1beta1.NewArrayOrString("$(tasks.aCustomPipelineTask.results.aResult)"),
 			}},
 		},
+	}, {
+		PipelineRunName: "aPipelineRun",
+		PipelineRun: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "aPipelineRun"},
+			Status: v1beta1.PipelineRunStatus{
+				Status: duckv1beta1.Status{
+					Conditions: []apis.Condition{successCondition},
+				},
+				PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+					PipelineResults: []v1beta1.PipelineRunResult{{
+						Name:  "aResult",
+						Value: "aResultValue",
+					}},
+				},
+			},
+		},
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:        "aChildPipelineTask",
+			PipelineRef: &v1beta1.PipelineRef{Name: "aPipeline"},
+		},
+	}, {
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "bTask",
+			TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+			Params: []v1beta1.Param{{
+				Name:  "bParam",
+				Value: *v1beta1.NewArrayOrString("$(tasks.aChildPipelineTask.results.aResult)"),
+			}},
+		},
 	}}
 
 	for _, tt := range []struct {
@@ -469,6 +566,21 @@ func TestResolveResultRefs(t *testing.T) {
 			FromRun: "aRun",
 		}},
 		wantErr: false,
+	}, {
+		name:             "Test successful result references resolution - params - child PipelineRun",
+		pipelineRunState: pipelineRunState,
+		targets: PipelineRunState{
+			pipelineRunState[8],
+		},
+		want: ResolvedResultRefs{{
+			Value: *v1beta1.NewArrayOrString("aResultValue"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aChildPipelineTask",
+				Result:       "aResult",
+			},
+			FromPipelineRun: "aPipelineRun",
+		}},
+		wantErr: false,
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := ResolveResultRefs(tt.pipelineRunState, tt.targets)
@@ -48,6 +48,31 @@ func ValidateParamTypesMatching(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun
 	return nil
 }
 
+// ValidateObjectParamRequiredKeys validates that the object parameters provided by the PipelineRun
+// hold all the keys declared in the properties of the corresponding Pipeline parameters.
+func ValidateObjectParamRequiredKeys(pipelineParameters []v1beta1.ParamSpec, pipelineRunParameters []v1beta1.Param) error {
+	paramSpecs := map[string]v1beta1.ParamSpec{}
+	for _, param := range pipelineParameters {
+		if param.Type == v1beta1.ParamTypeObject {
+			paramSpecs[param.Name] = param
+		}
+	}
+
+	missingKeys := map[string][]string{}
+	for _, param := range pipelineRunParameters {
+		if paramSpec, ok := paramSpecs[param.Name]; ok {
+			if keys := paramSpec.MissingObjectKeys(param.Value.ObjectVal); len(keys) != 0 {
+				missingKeys[param.Name] = keys
+			}
+		}
+	}
+
+	if len(missingKeys) != 0 {
+		return fmt.Errorf("PipelineRun missing object keys for parameters: %v", missingKeys)
+	}
+	return nil
+}
+
 // ValidateRequiredParametersProvided validates that all the parameters expected by the Pipeline are provided by the PipelineRun.
 // Extra Parameters are allowed, the Pipeline will use the Parameters it needs and ignore the other Parameters.
 func ValidateRequiredParametersProvided(pipelineParameters *[]v1beta1.ParamSpec, pipelineRunParameters *[]v1beta1.Param) error {
@@ -188,3 +188,50 @@ func TestValidateRequiredParametersProvided_Invalid(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateObjectParamRequiredKeys_Valid(t *testing.T) {
+	pp := []v1beta1.ParamSpec{{
+		Name: "git",
+		Type: v1beta1.ParamTypeObject,
+		Properties: map[string]v1beta1.PropertySpec{
+			"url":      {Type: v1beta1.ParamTypeString},
+			"revision": {Type: v1beta1.ParamTypeString},
+		},
+	}, {
+		Name: "string-param",
+		Type: v1beta1.ParamTypeString,
+	}}
+	prp := []v1beta1.Param{{
+		Name:  "git",
+		Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline", "revision": "main", "extra": "key"}),
+	}, {
+		Name:  "string-param",
+		Value: *v1beta1.NewArrayOrString("stringValue"),
+	}}
+	if err := ValidateObjectParamRequiredKeys(pp, prp); err != nil {
+		t.Errorf("Didn't expect to see error when validating valid PipelineRun object parameters but got: %v", err)
+	}
+}
+
+func TestValidateObjectParamRequiredKeys_Invalid(t *testing.T) {
+	pp := []v1beta1.ParamSpec{{
+		Name: "git",
+		Type: v1beta1.ParamTypeObject,
+		Properties: map[string]v1beta1.PropertySpec{
+			"url":      {Type: v1beta1.ParamTypeString},
+			"revision": {Type: v1beta1.ParamTypeString},
+		},
+	}}
+	prp := []v1beta1.Param{{
+		Name:  "git",
+		Value: *v1beta1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"}),
+	}}
+	err := ValidateObjectParamRequiredKeys(pp, prp)
+	if err == nil {
+		t.Fatal("Expected to see error when validating PipelineRun object parameters with missing keys but saw none")
+	}
+	want := "PipelineRun missing object keys for parameters: map[git:[revision]]"
+	if err.Error() != want {
+		t.Errorf("Expected error %q but got %q", want, err.Error())
+	}
+}
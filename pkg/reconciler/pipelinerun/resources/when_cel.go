@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+// WhenCELVariables returns the params and context variables the CEL expressions
+// of the When Expressions of the PipelineRun are evaluated with. The params hold
+// the values from the PipelineRun, or the defaults of the Pipeline.
+func WhenCELVariables(spec *v1beta1.PipelineSpec, pipelineName string, pr *v1beta1.PipelineRun) map[string]interface{} {
+	params := map[string]interface{}{}
+	for _, p := range spec.Params {
+		if p.Default != nil {
+			params[p.Name] = celValue(*p.Default)
+		}
+	}
+	for _, p := range pr.Spec.Params {
+		params[p.Name] = celValue(p.Value)
+	}
+	return map[string]interface{}{
+		v1beta1.WhenCELParams: params,
+		v1beta1.WhenCELContext: map[string]interface{}{
+			"pipelineRun": map[string]interface{}{
+				"name":      pr.Name,
+				"namespace": pr.Namespace,
+				"uid":       string(pr.UID),
+			},
+			"pipeline": map[string]interface{}{
+				"name": pipelineName,
+			},
+		},
+	}
+}
+
+// EvaluateWhenCEL evaluates the CEL expressions of the When Expressions of the
+// PipelineTask, with the results and execution status of the PipelineTasks of
+// the PipelineRun, and returns whether they allow the PipelineTask to run.
+func (t *ResolvedPipelineRunTask) EvaluateWhenCEL(facts *PipelineRunFacts) (bool, error) {
+	if !t.PipelineTask.WhenExpressions.HaveCEL() {
+		return true, nil
+	}
+	variables := map[string]interface{}{
+		v1beta1.WhenCELParams:  map[string]interface{}{},
+		v1beta1.WhenCELContext: map[string]interface{}{},
+		v1beta1.WhenCELTasks:   facts.celTasks(),
+	}
+	for name, value := range facts.WhenCELVariables {
+		variables[name] = value
+	}
+	return t.PipelineTask.WhenExpressions.EvaluateCEL(variables)
+}
+
+// celTasks returns the value of the tasks variable of the CEL expressions: the
+// results and the execution status of each DAG task, e.g. tasks.test.status
+func (facts *PipelineRunFacts) celTasks() map[string]interface{} {
+	tasks := map[string]interface{}{}
+	for _, t := range facts.State {
+		if !facts.isDAGTask(t.PipelineTask.Name) {
+			continue
+		}
+		tasks[t.PipelineTask.Name] = map[string]interface{}{
+			"results": t.celResults(),
+			"status":  t.executionStatus(),
+		}
+	}
+	return tasks
+}
+
+// celResults returns the results produced by the run of the PipelineTask so far.
+func (t *ResolvedPipelineRunTask) celResults() map[string]interface{} {
+	results := map[string]interface{}{}
+	switch {
+	case t.IsCustomTask():
+		if t.Run != nil {
+			for _, r := range t.Run.Status.Results {
+				results[r.Name] = r.Value
+			}
+		}
+	case t.IsChildPipeline():
+		if t.PipelineRun != nil {
+			for _, r := range t.PipelineRun.Status.PipelineResults {
+				results[r.Name] = r.Value
+			}
+		}
+	case t.TaskRun != nil:
+		for _, r := range t.TaskRun.Status.TaskRunResults {
+			results[r.Name] = celValue(r.Value)
+		}
+	}
+	return results
+}
+
+// celValue converts a param or result value to the matching CEL value.
+func celValue(value v1beta1.ArrayOrString) interface{} {
+	switch value.Type {
+	case v1beta1.ParamTypeArray:
+		return value.ArrayVal
+	case v1beta1.ParamTypeObject:
+		return value.ObjectVal
+	}
+	return value.StringVal
+}
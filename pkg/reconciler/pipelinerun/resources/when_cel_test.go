@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestResolvedPipelineRunTask_EvaluateWhenCEL(t *testing.T) {
+	tr := tb.TaskRun("build", tb.TaskRunStatus(
+		tb.StatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionTrue,
+		}),
+		tb.TaskRunResult("coverage", "85"),
+	))
+	spec := &v1beta1.PipelineSpec{
+		Params: []v1beta1.ParamSpec{{
+			Name:    "branch",
+			Type:    v1beta1.ParamTypeString,
+			Default: v1beta1.NewArrayOrString("main"),
+		}, {
+			Name: "platforms",
+			Type: v1beta1.ParamTypeArray,
+		}},
+		Tasks: []v1beta1.PipelineTask{{
+			Name:    "build",
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+		}},
+	}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "dev"},
+		Spec: v1beta1.PipelineRunSpec{Params: []v1beta1.Param{{
+			Name:  "platforms",
+			Value: *v1beta1.NewArrayOrString("linux", "mac"),
+		}}},
+	}
+	state := PipelineRunState{{
+		TaskRunName:  "build",
+		TaskRun:      tr,
+		PipelineTask: &spec.Tasks[0],
+	}}
+	d, err := dag.Build(v1beta1.PipelineTaskList(spec.Tasks), v1beta1.PipelineTaskList(spec.Tasks).Deps())
+	if err != nil {
+		t.Fatalf("Could not get a dag from the dag tasks %#v: %v", spec.Tasks, err)
+	}
+	facts := &PipelineRunFacts{
+		State:            state,
+		TasksGraph:       d,
+		FinalTasksGraph:  &dag.Graph{},
+		WhenCELVariables: WhenCELVariables(spec, "pipeline", pr),
+	}
+
+	for _, tc := range []struct {
+		name     string
+		cel      string
+		expected bool
+		wantErr  bool
+	}{{
+		name:     "param default",
+		cel:      "params.branch == 'main'",
+		expected: true,
+	}, {
+		name:     "array param of the PipelineRun",
+		cel:      "'mac' in params.platforms",
+		expected: true,
+	}, {
+		name:     "result",
+		cel:      "int(tasks.build.results.coverage) > 90",
+		expected: false,
+	}, {
+		name:     "execution status",
+		cel:      "tasks.build.status == 'Succeeded'",
+		expected: true,
+	}, {
+		name:     "context",
+		cel:      "context.pipelineRun.namespace == 'dev' && context.pipeline.name == 'pipeline'",
+		expected: true,
+	}, {
+		name:    "missing result",
+		cel:     "tasks.build.results.missing == ''",
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rprt := &ResolvedPipelineRunTask{PipelineTask: &v1beta1.PipelineTask{
+				Name:            "final",
+				WhenExpressions: v1beta1.WhenExpressions{{CEL: tc.cel}},
+			}}
+			got, err := rprt.EvaluateWhenCEL(facts)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Expected an error: %t, got %v", tc.wantErr, err)
+			}
+			if got != tc.expected {
+				t.Errorf("Expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	tresources "github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	jsonpatch "gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+)
+
+// resumeFrom reuses the successful TaskRuns of the PipelineRun that pr resumes
+// from, which must have run the same Pipeline. They are added to the status of
+// pr, so that they are resolved as the TaskRuns of their PipelineTasks and only
+// the failed, skipped or not started PipelineTasks are scheduled, and pr becomes
+// one of their owners, so that they outlive the PipelineRun they were created
+// by. A TaskRun is only reused if its PipelineTask is defined the same way in
+// both PipelineRuns once their params are substituted, its Task still resolves
+// to the spec the TaskRun ran, the PipelineTask has neither a matrix nor
+// conditions, and the TaskRuns of all the PipelineTasks it depends on are reused
+// too. Final tasks are never reused. pipelineSpec must have the params of pr
+// substituted.
+func (c *Reconciler) resumeFrom(ctx context.Context, pr *v1beta1.PipelineRun, pipelineSpec *v1beta1.PipelineSpec) error {
+	logger := logging.FromContext(ctx)
+	previous, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(pr.Spec.ResumeFrom)
+	if err != nil {
+		return err
+	}
+	if !previous.IsDone() {
+		return fmt.Errorf("PipelineRun %s has not completed yet", previous.Name)
+	}
+	if pipelineRefKey(previous.Spec.PipelineRef) != pipelineRefKey(pr.Spec.PipelineRef) {
+		return fmt.Errorf("PipelineRun %s ran another Pipeline", previous.Name)
+	}
+	if previous.Status.PipelineSpec == nil {
+		return nil
+	}
+
+	// Compare the PipelineTasks with the params of each PipelineRun substituted,
+	// so that a task is rerun when it is given other param values.
+	previousSpec := resources.ApplyParameters(previous.Status.PipelineSpec, previous)
+	previousTasks := make(map[string]v1beta1.PipelineTask, len(previousSpec.Tasks))
+	for _, pt := range previousSpec.Tasks {
+		previousTasks[pt.Name] = pt
+	}
+	reusable := make(map[string]*v1beta1.PipelineTask)
+	for i, pt := range pipelineSpec.Tasks {
+		if pt.IsMatrixed() || len(pt.Conditions) > 0 {
+			continue
+		}
+		if previousTask, ok := previousTasks[pt.Name]; ok && equality.Semantic.DeepEqual(previousTask, pt) {
+			reusable[pt.Name] = &pipelineSpec.Tasks[i]
+		}
+	}
+	for _, prtrs := range pr.Status.TaskRuns {
+		// this PipelineTask already has a TaskRun
+		delete(reusable, prtrs.PipelineTaskName)
+	}
+
+	successful := make(map[string]*v1beta1.PipelineRunTaskRunStatus)
+	for taskRunName, prtrs := range previous.Status.TaskRuns {
+		if prtrs == nil || reusable[prtrs.PipelineTaskName] == nil {
+			continue
+		}
+		tr, err := c.taskRunLister.TaskRuns(pr.Namespace).Get(taskRunName)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !tr.IsSuccessful() {
+			continue
+		}
+		if ok, err := c.runsSameTask(ctx, pr, reusable[prtrs.PipelineTaskName], tr); err != nil || !ok {
+			logger.Infof("Not reusing TaskRun %s of PipelineRun %s: its Task changed (%v)", taskRunName, previous.Name, err)
+			continue
+		}
+		successful[taskRunName] = &v1beta1.PipelineRunTaskRunStatus{
+			PipelineTaskName: prtrs.PipelineTaskName,
+			Status:           &tr.Status,
+			WhenExpressions:  prtrs.WhenExpressions,
+			ReusedFrom:       previous.Name,
+		}
+	}
+
+	// A PipelineTask is only reused if all the PipelineTasks it depends on are
+	// reused too, as the others are rerun and may produce other results.
+	reused := make(map[string]bool, len(successful))
+	for _, prtrs := range successful {
+		reused[prtrs.PipelineTaskName] = true
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, pt := range pipelineSpec.Tasks {
+			if !reused[pt.Name] {
+				continue
+			}
+			for _, dep := range pt.Deps() {
+				if !reused[dep] {
+					delete(reused, pt.Name)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	if pr.Status.TaskRuns == nil {
+		pr.Status.TaskRuns = make(map[string]*v1beta1.PipelineRunTaskRunStatus)
+	}
+	for taskRunName, prtrs := range successful {
+		if !reused[prtrs.PipelineTaskName] {
+			continue
+		}
+		if err := c.addOwner(ctx, pr, taskRunName); err != nil {
+			return err
+		}
+		logger.Infof("Reusing TaskRun %s of PipelineRun %s for pipeline task %s", taskRunName, previous.Name, prtrs.PipelineTaskName)
+		pr.Status.TaskRuns[taskRunName] = prtrs
+	}
+	return nil
+}
+
+// runsSameTask returns true if tr ran the spec the Task of pt resolves to now.
+// A Task fetched by reference may have changed since tr ran.
+func (c *Reconciler) runsSameTask(ctx context.Context, pr *v1beta1.PipelineRun, pt *v1beta1.PipelineTask, tr *v1beta1.TaskRun) (bool, error) {
+	if tr.Status.TaskSpec == nil {
+		return false, nil
+	}
+	var spec v1beta1.TaskSpec
+	if pt.TaskRef != nil {
+		fn, _, err := tresources.GetTaskFunc(ctx, c.KubeClientSet, c.PipelineClientSet, pt.TaskRef, pr.Namespace, pr.Spec.ServiceAccountName)
+		if err != nil {
+			return false, err
+		}
+		t, err := fn(ctx, pt.TaskRef.Name)
+		if err != nil {
+			return false, err
+		}
+		spec = t.TaskSpec()
+	} else if pt.TaskSpec != nil {
+		spec = pt.TaskSpec.TaskSpec
+	}
+	ran := tr.Status.TaskSpec.DeepCopy()
+	ran.SetDefaults(ctx)
+	spec.SetDefaults(ctx)
+	return equality.Semantic.DeepEqual(*ran, spec), nil
+}
+
+// addOwner adds pr to the owners of the reused TaskRun, without making it its
+// controller: the TaskRun is not deleted with the PipelineRun it was created by
+// as long as pr exists.
+func (c *Reconciler) addOwner(ctx context.Context, pr *v1beta1.PipelineRun, taskRunName string) error {
+	tr, err := c.taskRunLister.TaskRuns(pr.Namespace).Get(taskRunName)
+	if err != nil {
+		return err
+	}
+	for _, ref := range tr.OwnerReferences {
+		if ref.UID == pr.UID {
+			return nil
+		}
+	}
+	owner := pr.GetOwnerReference()
+	owner.Controller = nil
+	op := jsonpatch.JsonPatchOperation{
+		Operation: "add",
+		Path:      "/metadata/ownerReferences/-",
+		Value:     owner,
+	}
+	if len(tr.OwnerReferences) == 0 {
+		op.Path = "/metadata/ownerReferences"
+		op.Value = []metav1.OwnerReference{owner}
+	}
+	patch, err := json.Marshal([]jsonpatch.JsonPatchOperation{op})
+	if err != nil {
+		return err
+	}
+	_, err = c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).Patch(ctx, taskRunName, types.JSONPatchType, patch, metav1.PatchOptions{})
+	return err
+}
@@ -0,0 +1,363 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+func resumePipelineSpec(image string) *v1beta1.PipelineSpec {
+	return &v1beta1.PipelineSpec{
+		Params: []v1beta1.ParamSpec{{
+			Name:    "revision",
+			Type:    v1beta1.ParamTypeString,
+			Default: v1beta1.NewArrayOrString("main"),
+		}},
+		Tasks: []v1beta1.PipelineTask{{
+			Name: "clone",
+			TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+				Params:  []v1beta1.ParamSpec{{Name: "revision", Type: v1beta1.ParamTypeString}},
+				Results: []v1beta1.TaskResult{{Name: "commit"}},
+				Steps:   []v1beta1.Step{{Container: corev1.Container{Name: "clone", Image: "busybox"}}},
+			}},
+			Params: []v1beta1.Param{{
+				Name:  "revision",
+				Value: *v1beta1.NewArrayOrString("$(params.revision)"),
+			}},
+		}, {
+			Name: "build",
+			TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+				Params: []v1beta1.ParamSpec{{Name: "commit", Type: v1beta1.ParamTypeString}},
+				Steps:  []v1beta1.Step{{Container: corev1.Container{Name: "build", Image: "busybox"}}},
+			}},
+			Params: []v1beta1.Param{{
+				Name:  "commit",
+				Value: *v1beta1.NewArrayOrString("$(tasks.clone.results.commit)"),
+			}},
+		}, {
+			Name: "lint",
+			TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{Container: corev1.Container{Name: "lint", Image: image}}},
+			}},
+		}, {
+			Name:     "report",
+			RunAfter: []string{"lint"},
+			TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{Container: corev1.Container{Name: "report", Image: "busybox"}}},
+			}},
+		}},
+	}
+}
+
+func resumeTaskRun(name, pipelineRunName, pipelineTaskName string, status corev1.ConditionStatus) *v1beta1.TaskRun {
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "foo",
+			Labels: map[string]string{
+				pipeline.GroupName + pipeline.PipelineRunLabelKey:  pipelineRunName,
+				pipeline.GroupName + pipeline.PipelineTaskLabelKey: pipelineTaskName,
+			},
+		},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: status,
+				}},
+			},
+		},
+	}
+	if status == corev1.ConditionTrue {
+		tr.Status.TaskRunResults = []v1beta1.TaskRunResult{{
+			Name:  "commit",
+			Value: *v1beta1.NewArrayOrString("SHA"),
+		}}
+	}
+	return tr
+}
+
+// previousPipelineRun returns a failed PipelineRun of spec with the default
+// revision, in which the clone, lint and report tasks succeeded and the build
+// task failed. The lint task is run as lintSpec.
+func previousPipelineRun(t *testing.T, done bool, spec *v1beta1.PipelineSpec, lintSpec v1beta1.TaskSpec) (*v1beta1.PipelineRun, []*v1beta1.TaskRun) {
+	t.Helper()
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "previous", Namespace: "foo", UID: "previous-uid"},
+		Spec:       v1beta1.PipelineRunSpec{PipelineSpec: spec},
+	}
+	pr.SetDefaults(context.Background())
+	status := corev1.ConditionFalse
+	if !done {
+		status = corev1.ConditionUnknown
+	}
+	pr.Status = v1beta1.PipelineRunStatus{
+		Status: duckv1beta1.Status{
+			Conditions: duckv1beta1.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: status,
+			}},
+		},
+		PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+			PipelineSpec: pr.Spec.PipelineSpec.DeepCopy(),
+			TaskRuns: map[string]*v1beta1.PipelineRunTaskRunStatus{
+				"previous-clone":  {PipelineTaskName: "clone"},
+				"previous-build":  {PipelineTaskName: "build"},
+				"previous-lint":   {PipelineTaskName: "lint"},
+				"previous-report": {PipelineTaskName: "report"},
+			},
+		},
+	}
+	trs := []*v1beta1.TaskRun{
+		resumeTaskRun("previous-clone", "previous", "clone", corev1.ConditionTrue),
+		resumeTaskRun("previous-build", "previous", "build", corev1.ConditionFalse),
+		resumeTaskRun("previous-lint", "previous", "lint", corev1.ConditionTrue),
+		resumeTaskRun("previous-report", "previous", "report", corev1.ConditionTrue),
+	}
+	for _, tr := range trs {
+		tr.OwnerReferences = []metav1.OwnerReference{pr.GetOwnerReference()}
+		for _, pt := range spec.Tasks {
+			if pt.TaskSpec != nil && pt.Name == tr.Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey] {
+				tr.Status.TaskSpec = pt.TaskSpec.TaskSpec.DeepCopy()
+			}
+		}
+	}
+	trs[2].Status.TaskSpec = lintSpec.DeepCopy()
+	return pr, trs
+}
+
+// lintTaskSpec is the spec of the lint task of resumePipelineSpec("busybox").
+var lintTaskSpec = *resumePipelineSpec("busybox").Tasks[2].TaskSpec.TaskSpec.DeepCopy()
+
+func ownersAdded(actions []ktesting.Action) []string {
+	var names []string
+	for _, a := range actions {
+		if a.GetVerb() == "patch" && a.GetResource().Resource == "taskruns" {
+			names = append(names, a.(ktesting.PatchAction).GetName())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func createdTaskRuns(actions []ktesting.Action) []*v1beta1.TaskRun {
+	var trs []*v1beta1.TaskRun
+	for _, a := range actions {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			trs = append(trs, a.(ktesting.CreateAction).GetObject().(*v1beta1.TaskRun))
+		}
+	}
+	return trs
+}
+
+func TestReconcile_ResumeFrom(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		lintImage          string
+		params             []v1beta1.Param
+		wantPipelineTasks  []string
+		wantReusedTaskRuns []string
+	}{{
+		name:               "successful tasks are reused",
+		lintImage:          "busybox",
+		wantPipelineTasks:  []string{"build"},
+		wantReusedTaskRuns: []string{"previous-clone", "previous-lint", "previous-report"},
+	}, {
+		name:               "same param values are reused",
+		lintImage:          "busybox",
+		params:             []v1beta1.Param{{Name: "revision", Value: *v1beta1.NewArrayOrString("main")}},
+		wantPipelineTasks:  []string{"build"},
+		wantReusedTaskRuns: []string{"previous-clone", "previous-lint", "previous-report"},
+	}, {
+		name:      "changed tasks and the tasks after them are not reused",
+		lintImage: "golangci-lint",
+		// report waits for lint to run again
+		wantPipelineTasks:  []string{"build", "lint"},
+		wantReusedTaskRuns: []string{"previous-clone"},
+	}, {
+		name:      "tasks given other param values and the tasks consuming their results are not reused",
+		lintImage: "busybox",
+		params:    []v1beta1.Param{{Name: "revision", Value: *v1beta1.NewArrayOrString("release")}},
+		// build waits for clone to run again
+		wantPipelineTasks:  []string{"clone"},
+		wantReusedTaskRuns: []string{"previous-lint", "previous-report"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			previous, trs := previousPipelineRun(t, true, resumePipelineSpec("busybox"), lintTaskSpec)
+			pr := &v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run", Namespace: "foo", UID: "test-uid"},
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineSpec: resumePipelineSpec(tc.lintImage),
+					Params:       tc.params,
+					ResumeFrom:   "previous",
+				},
+			}
+			d := test.Data{
+				PipelineRuns: []*v1beta1.PipelineRun{pr, previous},
+				TaskRuns:     trs,
+			}
+			prt := NewPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", nil, false)
+
+			var pipelineTasks []string
+			for _, tr := range createdTaskRuns(clients.Pipeline.Actions()) {
+				pipelineTask := tr.Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey]
+				pipelineTasks = append(pipelineTasks, pipelineTask)
+				if pipelineTask == "build" {
+					wantParams := []v1beta1.Param{{Name: "commit", Value: *v1beta1.NewArrayOrString("SHA")}}
+					if d := cmp.Diff(wantParams, tr.Spec.Params); d != "" {
+						t.Errorf("Expected the result of the reused TaskRun to be passed on %s", diff.PrintWantGot(d))
+					}
+				}
+			}
+			sort.Strings(pipelineTasks)
+			if d := cmp.Diff(tc.wantPipelineTasks, pipelineTasks); d != "" {
+				t.Errorf("Unexpected TaskRuns created %s", diff.PrintWantGot(d))
+			}
+
+			var reused []string
+			for name, prtrs := range reconciledRun.Status.TaskRuns {
+				if prtrs.ReusedFrom == "" {
+					continue
+				}
+				if prtrs.ReusedFrom != "previous" {
+					t.Errorf("Expected TaskRun %s to be reused from PipelineRun previous, got %q", name, prtrs.ReusedFrom)
+				}
+				reused = append(reused, name)
+			}
+			sort.Strings(reused)
+			if d := cmp.Diff(tc.wantReusedTaskRuns, reused); d != "" {
+				t.Errorf("Unexpected reused TaskRuns %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantReusedTaskRuns, ownersAdded(clients.Pipeline.Actions())); d != "" {
+				t.Errorf("Expected the PipelineRun to be added to the owners of the reused TaskRuns %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconcile_ResumeFromChangedTask(t *testing.T) {
+	names.TestingSeed()
+	// lint references a Task which was updated since the previous PipelineRun ran it
+	spec := resumePipelineSpec("busybox")
+	spec.Tasks[2].TaskSpec = nil
+	spec.Tasks[2].TaskRef = &v1beta1.TaskRef{Name: "lint"}
+	previous, trs := previousPipelineRun(t, true, spec, lintTaskSpec)
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run", Namespace: "foo", UID: "test-uid"},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: spec.DeepCopy(),
+			ResumeFrom:   "previous",
+		},
+	}
+	lint := &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "lint", Namespace: "foo"},
+		Spec:       *resumePipelineSpec("golangci-lint").Tasks[2].TaskSpec.TaskSpec.DeepCopy(),
+	}
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{pr, previous},
+		TaskRuns:     trs,
+		Tasks:        []*v1beta1.Task{lint},
+	}
+	prt := NewPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	_, clients := prt.reconcileRun("foo", "test-pipeline-run", nil, false)
+
+	var pipelineTasks []string
+	for _, tr := range createdTaskRuns(clients.Pipeline.Actions()) {
+		pipelineTasks = append(pipelineTasks, tr.Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey])
+	}
+	sort.Strings(pipelineTasks)
+	// report waits for lint to run again
+	if d := cmp.Diff([]string{"build", "lint"}, pipelineTasks); d != "" {
+		t.Errorf("Unexpected TaskRuns created %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff([]string{"previous-clone"}, ownersAdded(clients.Pipeline.Actions())); d != "" {
+		t.Errorf("Unexpected reused TaskRuns %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcile_ResumeFromFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		previous      bool
+		done          bool
+		otherPipeline bool
+	}{{
+		name:     "PipelineRun to resume from does not exist",
+		previous: false,
+	}, {
+		name:     "PipelineRun to resume from is still running",
+		previous: true,
+		done:     false,
+	}, {
+		name:          "PipelineRun to resume from ran another Pipeline",
+		previous:      true,
+		done:          true,
+		otherPipeline: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			pr := &v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run", Namespace: "foo"},
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineSpec: resumePipelineSpec("busybox"),
+					ResumeFrom:   "previous",
+				},
+			}
+			d := test.Data{PipelineRuns: []*v1beta1.PipelineRun{pr}}
+			if tc.previous {
+				previous, trs := previousPipelineRun(t, tc.done, resumePipelineSpec("busybox"), lintTaskSpec)
+				if tc.otherPipeline {
+					previous.Spec = v1beta1.PipelineRunSpec{PipelineRef: &v1beta1.PipelineRef{Name: "other"}}
+				}
+				d.PipelineRuns = append(d.PipelineRuns, previous)
+				d.TaskRuns = trs
+			}
+			prt := NewPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", nil, true)
+
+			condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+			if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != ReasonCouldntResume {
+				t.Errorf("Expected PipelineRun to fail with reason %s, got %v", ReasonCouldntResume, condition)
+			}
+			if trs := createdTaskRuns(clients.Pipeline.Actions()); len(trs) != 0 {
+				t.Errorf("Expected no TaskRun to be created, got %d", len(trs))
+			}
+		})
+	}
+}
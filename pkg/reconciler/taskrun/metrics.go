@@ -62,6 +62,22 @@ var (
 	cloudEvents = stats.Int64("cloudevent_count",
 		"number of cloud events sent including retries",
 		stats.UnitDimensionless)
+
+	stepMaxRSS = stats.Float64("taskrun_step_max_rss_bytes",
+		"The maximum resident set size of the taskrun's steps in bytes",
+		stats.UnitBytes)
+	stepMaxRSSDistribution = view.Distribution(16<<20, 64<<20, 128<<20, 256<<20, 512<<20, 1<<30, 2<<30, 4<<30, 8<<30, 16<<30)
+
+	stepUserCPUTime = stats.Float64("taskrun_step_user_cpu_seconds",
+		"The CPU time spent by the taskrun's steps in user mode in seconds",
+		stats.UnitDimensionless)
+	stepSystemCPUTime = stats.Float64("taskrun_step_system_cpu_seconds",
+		"The CPU time spent by the taskrun's steps in kernel mode in seconds",
+		stats.UnitDimensionless)
+	stepWallTime = stats.Float64("taskrun_step_wall_seconds",
+		"The execution time of the taskrun's steps in seconds",
+		stats.UnitDimensionless)
+	stepTimeDistribution = view.Distribution(1, 10, 30, 60, 300, 900, 1800, 3600, 5400, 10800, 21600, 43200, 86400)
 )
 
 type Recorder struct {
@@ -74,6 +90,7 @@ type Recorder struct {
 	pipeline    tag.Key
 	pipelineRun tag.Key
 	pod         tag.Key
+	step        tag.Key
 
 	ReportingPeriod time.Duration
 }
@@ -130,6 +147,12 @@ func NewRecorder() (*Recorder, error) {
 	}
 	r.pod = pod
 
+	step, err := tag.NewKey("step")
+	if err != nil {
+		return nil, err
+	}
+	r.step = step
+
 	err = view.Register(
 		&view.View{
 			Description: trDuration.Description(),
@@ -166,6 +189,30 @@ func NewRecorder() (*Recorder, error) {
 			Aggregation: view.Sum(),
 			TagKeys:     []tag.Key{r.task, r.taskRun, r.namespace, r.status, r.pipeline, r.pipelineRun},
 		},
+		&view.View{
+			Description: stepMaxRSS.Description(),
+			Measure:     stepMaxRSS,
+			Aggregation: stepMaxRSSDistribution,
+			TagKeys:     []tag.Key{r.task, r.taskRun, r.namespace, r.step},
+		},
+		&view.View{
+			Description: stepUserCPUTime.Description(),
+			Measure:     stepUserCPUTime,
+			Aggregation: stepTimeDistribution,
+			TagKeys:     []tag.Key{r.task, r.taskRun, r.namespace, r.step},
+		},
+		&view.View{
+			Description: stepSystemCPUTime.Description(),
+			Measure:     stepSystemCPUTime,
+			Aggregation: stepTimeDistribution,
+			TagKeys:     []tag.Key{r.task, r.taskRun, r.namespace, r.step},
+		},
+		&view.View{
+			Description: stepWallTime.Description(),
+			Measure:     stepWallTime,
+			Aggregation: stepTimeDistribution,
+			TagKeys:     []tag.Key{r.task, r.taskRun, r.namespace, r.step},
+		},
 	)
 
 	if err != nil {
@@ -370,6 +417,44 @@ func (r *Recorder) CloudEvents(tr *v1beta1.TaskRun) error {
 	return nil
 }
 
+// StepResourceUsage logs the resource usage of the steps of the TaskRun, for
+// the steps which reported it
+// returns an error if it fails to log the metrics
+func (r *Recorder) StepResourceUsage(tr *v1beta1.TaskRun) error {
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", tr.Name)
+	}
+
+	taskName := "anonymous"
+	if tr.Spec.TaskRef != nil {
+		taskName = tr.Spec.TaskRef.Name
+	}
+
+	for _, step := range tr.Status.Steps {
+		if step.ResourceUsage == nil {
+			continue
+		}
+		ctx, err := tag.New(
+			context.Background(),
+			tag.Insert(r.task, taskName),
+			tag.Insert(r.taskRun, tr.Name),
+			tag.Insert(r.namespace, tr.Namespace),
+			tag.Insert(r.step, step.Name),
+		)
+		if err != nil {
+			return err
+		}
+
+		usage := step.ResourceUsage
+		metrics.Record(ctx, stepMaxRSS.M(float64(usage.MaxRSS.Value())))
+		metrics.Record(ctx, stepUserCPUTime.M(usage.UserCPUTime.Seconds()))
+		metrics.Record(ctx, stepSystemCPUTime.M(usage.SystemCPUTime.Seconds()))
+		metrics.Record(ctx, stepWallTime.M(usage.WallTime.Seconds()))
+	}
+
+	return nil
+}
+
 func sentCloudEvents(tr *v1beta1.TaskRun) int64 {
 	var sent int64
 	for _, event := range tr.Status.CloudEvents {
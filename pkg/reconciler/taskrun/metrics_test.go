@@ -26,6 +26,7 @@ import (
 	faketaskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun/fake"
 	"github.com/tektoncd/pipeline/pkg/names"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
@@ -54,6 +55,9 @@ func TestUninitializedMetrics(t *testing.T) {
 	if err := metrics.CloudEvents(&v1beta1.TaskRun{}); err == nil {
 		t.Error("Cloud Events recording expected to return error but got nil")
 	}
+	if err := metrics.StepResourceUsage(&v1beta1.TaskRun{}); err == nil {
+		t.Error("Step resource usage recording expected to return error but got nil")
+	}
 }
 
 func TestRecordTaskRunDurationCount(t *testing.T) {
@@ -451,6 +455,52 @@ func TestRecordCloudEvents(t *testing.T) {
 	}
 }
 
+func TestRecordStepResourceUsage(t *testing.T) {
+	unregisterMetrics()
+	taskRun := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun-1", Namespace: "ns"},
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "task-1"},
+		},
+		Status: v1beta1.TaskRunStatus{
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					Name: "build",
+					ResourceUsage: &v1beta1.StepResourceUsage{
+						MaxRSS:        resource.MustParse("64Mi"),
+						UserCPUTime:   metav1.Duration{Duration: 90 * time.Second},
+						SystemCPUTime: metav1.Duration{Duration: 15 * time.Second},
+						WallTime:      metav1.Duration{Duration: 2 * time.Minute},
+					},
+				}, {
+					// The resource usage of this step was not reported.
+					Name: "push",
+				}},
+			},
+		},
+	}
+	expectedTags := map[string]string{
+		"task":      "task-1",
+		"taskrun":   "taskrun-1",
+		"namespace": "ns",
+		"step":      "build",
+	}
+
+	metrics, err := NewRecorder()
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	if err := metrics.StepResourceUsage(taskRun); err != nil {
+		t.Fatalf("StepResourceUsage: %v", err)
+	}
+	metricstest.CheckDistributionData(t, "taskrun_step_max_rss_bytes", expectedTags, 1, 64<<20, 64<<20)
+	metricstest.CheckDistributionData(t, "taskrun_step_user_cpu_seconds", expectedTags, 1, 90, 90)
+	metricstest.CheckDistributionData(t, "taskrun_step_system_cpu_seconds", expectedTags, 1, 15, 15)
+	metricstest.CheckDistributionData(t, "taskrun_step_wall_seconds", expectedTags, 1, 120, 120)
+}
+
 func unregisterMetrics() {
-	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count")
+	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count",
+		"taskrun_step_max_rss_bytes", "taskrun_step_user_cpu_seconds", "taskrun_step_system_cpu_seconds", "taskrun_step_wall_seconds")
 }
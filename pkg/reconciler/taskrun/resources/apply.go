@@ -32,40 +32,43 @@ import (
 func ApplyParameters(spec *v1beta1.TaskSpec, tr *v1beta1.TaskRun, defaults ...v1beta1.ParamSpec) *v1beta1.TaskSpec {
 	// This assumes that the TaskRun inputs have been validated against what the Task requests.
 
-	// stringReplacements is used for standard single-string stringReplacements, while arrayReplacements contains arrays
-	// that need to be further processed.
+	// stringReplacements is used for standard single-string stringReplacements, including the keys of objects,
+	// while arrayReplacements contains arrays that need to be further processed.
 	stringReplacements := map[string]string{}
 	arrayReplacements := map[string][]string{}
 
 	// Set all the default stringReplacements
 	for _, p := range defaults {
 		if p.Default != nil {
-			if p.Default.Type == v1beta1.ParamTypeString {
-				stringReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Default.StringVal
-				// FIXME(vdemeester) Remove that with deprecating v1beta1
-				stringReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Default.StringVal
-			} else {
-				arrayReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Default.ArrayVal
-				// FIXME(vdemeester) Remove that with deprecating v1beta1
-				arrayReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Default.ArrayVal
-			}
+			addReplacements(p.Name, *p.Default, stringReplacements, arrayReplacements)
 		}
 	}
 	// Set and overwrite params with the ones from the TaskRun
 	for _, p := range tr.Spec.Params {
-		if p.Value.Type == v1beta1.ParamTypeString {
-			stringReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Value.StringVal
-			// FIXME(vdemeester) Remove that with deprecating v1beta1
-			stringReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Value.StringVal
-		} else {
-			arrayReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Value.ArrayVal
-			// FIXME(vdemeester) Remove that with deprecating v1beta1
-			arrayReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Value.ArrayVal
-		}
+		addReplacements(p.Name, p.Value, stringReplacements, arrayReplacements)
 	}
 	return ApplyReplacements(spec, stringReplacements, arrayReplacements)
 }
 
+// addReplacements adds the replacements for the parameter called name holding value. Objects can only be
+// referenced through their keys, e.g. "params.foo.bar", so each of their keys is added as a string replacement.
+func addReplacements(name string, value v1beta1.ArrayOrString, stringReplacements map[string]string, arrayReplacements map[string][]string) {
+	switch value.Type {
+	case v1beta1.ParamTypeString:
+		stringReplacements[fmt.Sprintf("params.%s", name)] = value.StringVal
+		// FIXME(vdemeester) Remove that with deprecating v1beta1
+		stringReplacements[fmt.Sprintf("inputs.params.%s", name)] = value.StringVal
+	case v1beta1.ParamTypeObject:
+		for k, v := range value.ObjectVal {
+			stringReplacements[fmt.Sprintf("params.%s.%s", name, k)] = v
+		}
+	default:
+		arrayReplacements[fmt.Sprintf("params.%s", name)] = value.ArrayVal
+		// FIXME(vdemeester) Remove that with deprecating v1beta1
+		arrayReplacements[fmt.Sprintf("inputs.params.%s", name)] = value.ArrayVal
+	}
+}
+
 // ApplyResources applies the substitution from values in resources which are referenced in spec as subitems
 // of the replacementStr.
 func ApplyResources(spec *v1beta1.TaskSpec, resolvedResources map[string]v1beta1.PipelineResourceInterface, replacementStr string) *v1beta1.TaskSpec {
@@ -155,6 +158,23 @@ func ApplyTaskResults(spec *v1beta1.TaskSpec) *v1beta1.TaskSpec {
 	return ApplyReplacements(spec, stringReplacements, map[string][]string{})
 }
 
+// ApplyStepResults applies the substitution of the paths of the results of each step, which are referenced in the
+// step as $(step.results.<name>.path).
+func ApplyStepResults(spec *v1beta1.TaskSpec) *v1beta1.TaskSpec {
+	spec = spec.DeepCopy()
+	for i, step := range spec.Steps {
+		if len(step.Results) == 0 {
+			continue
+		}
+		stringReplacements := map[string]string{}
+		for _, result := range step.Results {
+			stringReplacements[fmt.Sprintf("step.results.%s.path", result.Name)] = filepath.Join(pipeline.StepsDir, step.Name, "results", result.Name)
+		}
+		v1beta1.ApplyStepReplacements(&spec.Steps[i], stringReplacements, map[string][]string{})
+	}
+	return spec
+}
+
 // ApplyCredentialsPath applies a substitution of the key $(credentials.path) with the path that credentials
 // from annotated secrets are written to.
 func ApplyCredentialsPath(spec *v1beta1.TaskSpec, path string) *v1beta1.TaskSpec {
@@ -572,6 +572,45 @@ func TestApplyParameters(t *testing.T) {
 	}
 }
 
+func TestApplyObjectParameters(t *testing.T) {
+	tr := &v1beta1.TaskRun{
+		Spec: v1beta1.TaskRunSpec{
+			Params: []v1beta1.Param{{
+				Name:  "myobject",
+				Value: *v1beta1.NewObject(map[string]string{"key1": "taskrun-value1", "key2": "taskrun-value2"}),
+			}},
+		},
+	}
+	dp := []v1beta1.ParamSpec{{
+		Name:    "myobject",
+		Type:    v1beta1.ParamTypeObject,
+		Default: v1beta1.NewObject(map[string]string{"key1": "default-value1", "key2": "default-value2"}),
+	}, {
+		Name:    "mydefaultobject",
+		Type:    v1beta1.ParamTypeObject,
+		Default: v1beta1.NewObject(map[string]string{"key1": "default-value1"}),
+	}}
+	spec := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name:  "step1",
+				Image: "$(params.myobject.key1)",
+				Args:  []string{"--key2=$(params.myobject.key2)", "--default=$(params.mydefaultobject.key1)"},
+			},
+			Script: "echo $(params.myobject.key1)",
+		}},
+	}
+	want := applyMutation(spec, func(spec *v1beta1.TaskSpec) {
+		spec.Steps[0].Image = "taskrun-value1"
+		spec.Steps[0].Args = []string{"--key2=taskrun-value2", "--default=default-value1"}
+		spec.Steps[0].Script = "echo taskrun-value1"
+	})
+	got := resources.ApplyParameters(spec, tr, dp...)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyParameters() got diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestApplyResources(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1041,6 +1080,34 @@ func TestTaskResults(t *testing.T) {
 	}
 }
 
+func TestApplyStepResults(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name:  "clone",
+				Image: "alpine/git",
+				Args:  []string{"$(step.results.commit.path)"},
+			},
+			Script:  "git rev-parse HEAD > $(step.results.commit.path)",
+			Results: []v1beta1.StepResult{{Name: "commit"}},
+		}, {
+			Container: corev1.Container{
+				Name:  "print",
+				Image: "bash:latest",
+			},
+			Script: "cat $(step.results.commit.path)",
+		}},
+	}
+	want := applyMutation(ts, func(spec *v1beta1.TaskSpec) {
+		spec.Steps[0].Args[0] = "/tekton/steps/clone/results/commit"
+		spec.Steps[0].Script = "git rev-parse HEAD > /tekton/steps/clone/results/commit"
+	})
+	got := resources.ApplyStepResults(ts)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyStepResults() got diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestApplyCredentialsPath(t *testing.T) {
 	for _, tc := range []struct {
 		description string
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/remote/oci"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GetStepAction is a function used to retrieve the StepAction referenced by a step.
+type GetStepAction func(context.Context, *v1beta1.StepRef) (*v1alpha1.StepAction, error)
+
+// GetStepActionFunc returns a GetStepAction which fetches the referenced StepActions from the given namespace, or
+// from their Tekton Bundle if they reference one, authenticating with the given service account.
+func GetStepActionFunc(k8s kubernetes.Interface, tekton clientset.Interface, namespace, saName string) GetStepAction {
+	return func(ctx context.Context, ref *v1beta1.StepRef) (*v1alpha1.StepAction, error) {
+		if ref.Bundle == "" {
+			return tekton.TektonV1alpha1().StepActions(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		}
+		kc, err := k8schain.New(ctx, k8s, k8schain.Options{
+			Namespace:          namespace,
+			ServiceAccountName: saName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get keychain: %w", err)
+		}
+		obj, err := oci.NewResolver(ref.Bundle, kc).Get("stepaction", ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		sa, ok := obj.(*v1alpha1.StepAction)
+		if !ok {
+			return nil, fmt.Errorf("failed to convert obj %s into StepAction", obj.GetObjectKind().GroupVersionKind().String())
+		}
+		return sa, nil
+	}
+}
+
+// ResolveStepActions returns a copy of the TaskSpec in which the steps referencing a StepAction are replaced by the
+// step defined by the StepAction, with the params passed by the step applied. It also returns the provenance of the
+// StepActions used. The TaskSpec is returned as is if none of its steps references a StepAction.
+func ResolveStepActions(ctx context.Context, ts *v1beta1.TaskSpec, getStepAction GetStepAction) (*v1beta1.TaskSpec, []v1beta1.StepProvenance, error) {
+	var provenance []v1beta1.StepProvenance
+	var resolved *v1beta1.TaskSpec
+	for i, s := range ts.Steps {
+		if s.Ref == nil {
+			continue
+		}
+		if resolved == nil {
+			resolved = ts.DeepCopy()
+		}
+		sa, err := getStepAction(ctx, s.Ref)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get StepAction %q for step %q: %w", s.Ref.Name, s.Name, err)
+		}
+		step, err := inlineStepAction(s, sa)
+		if err != nil {
+			return nil, nil, err
+		}
+		resolved.Steps[i] = step
+		provenance = append(provenance, v1beta1.StepProvenance{
+			StepName:        s.Name,
+			Name:            sa.Name,
+			Bundle:          s.Ref.Bundle,
+			UID:             sa.UID,
+			ResourceVersion: sa.ResourceVersion,
+		})
+	}
+	if resolved == nil {
+		return ts, nil, nil
+	}
+	return resolved, provenance, nil
+}
+
+// inlineStepAction returns the step s with the content of the StepAction it references, in which the params of the
+// StepAction are replaced by the values passed by s or by their defaults.
+func inlineStepAction(s v1beta1.Step, sa *v1alpha1.StepAction) (v1beta1.Step, error) {
+	declared := map[string]bool{}
+	for _, p := range sa.Spec.Params {
+		declared[p.Name] = true
+	}
+	provided := map[string]bool{}
+	for _, p := range s.Params {
+		if !declared[p.Name] {
+			return v1beta1.Step{}, fmt.Errorf("step %q passes param %q which is not declared by StepAction %q", s.Name, p.Name, sa.Name)
+		}
+		provided[p.Name] = true
+	}
+
+	stringReplacements := map[string]string{}
+	arrayReplacements := map[string][]string{}
+	for _, p := range sa.Spec.Params {
+		if p.Default != nil {
+			addReplacements(p.Name, *p.Default, stringReplacements, arrayReplacements)
+		} else if !provided[p.Name] {
+			return v1beta1.Step{}, fmt.Errorf("step %q does not pass the required param %q of StepAction %q", s.Name, p.Name, sa.Name)
+		}
+	}
+	for _, p := range s.Params {
+		addReplacements(p.Name, p.Value, stringReplacements, arrayReplacements)
+	}
+
+	// The params of the StepAction are only substituted in its own content, and
+	// not in the fields set by the step referencing it.
+	spec := sa.Spec.DeepCopy()
+	content := v1beta1.Step{
+		Container: corev1.Container{
+			Image:   spec.Image,
+			Command: spec.Command,
+			Args:    spec.Args,
+			Env:     spec.Env,
+		},
+		Script: spec.Script,
+	}
+	v1beta1.ApplyStepReplacements(&content, stringReplacements, arrayReplacements)
+	s.Image = content.Image
+	s.Command = content.Command
+	s.Args = content.Args
+	s.Env = content.Env
+	s.Script = content.Script
+	s.Results = spec.Results
+	s.Ref = nil
+	s.Params = nil
+	return s, nil
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+var gitCloneStepAction = &v1alpha1.StepAction{
+	TypeMeta: metav1.TypeMeta{
+		APIVersion: "tekton.dev/v1alpha1",
+		Kind:       "StepAction",
+	},
+	ObjectMeta: metav1.ObjectMeta{
+		Name:            "git-clone",
+		Namespace:       "default",
+		UID:             "stepaction-uid",
+		ResourceVersion: "1",
+	},
+	Spec: v1alpha1.StepActionSpec{
+		Image: "alpine/git",
+		Args:  []string{"$(params.flags[*])"},
+		Env: []corev1.EnvVar{{
+			Name:  "REVISION",
+			Value: "$(params.revision)",
+		}},
+		Script: "git clone $(params.url) . && git rev-parse HEAD > $(step.results.commit.path)",
+		Params: []v1alpha1.ParamSpec{{
+			Name: "url",
+			Type: v1alpha1.ParamTypeString,
+		}, {
+			Name:    "revision",
+			Type:    v1alpha1.ParamTypeString,
+			Default: v1beta1.NewArrayOrString("main"),
+		}, {
+			Name:    "flags",
+			Type:    v1alpha1.ParamTypeArray,
+			Default: v1beta1.NewArrayOrString("--depth", "1"),
+		}},
+		Results: []v1beta1.StepResult{{Name: "commit"}},
+	},
+}
+
+func TestGetStepActionFunc(t *testing.T) {
+	// Set up a fake registry to push an image to.
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := test.CreateImage(u.Host+"/stepactions", gitCloneStepAction)
+	if err != nil {
+		t.Fatalf("failed to upload test image: %s", err.Error())
+	}
+
+	ctx := context.Background()
+	kubeclient := fakek8s.NewSimpleClientset(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default",
+			Namespace: "default",
+		},
+	})
+	getStepAction := resources.GetStepActionFunc(kubeclient, fake.NewSimpleClientset(gitCloneStepAction), "default", "default")
+	for _, tc := range []struct {
+		name string
+		ref  *v1beta1.StepRef
+	}{{
+		name: "local StepAction",
+		ref:  &v1beta1.StepRef{Name: "git-clone"},
+	}, {
+		name: "StepAction from a bundle",
+		ref:  &v1beta1.StepRef{Name: "git-clone", Bundle: ref},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			sa, err := getStepAction(ctx, tc.ref)
+			if err != nil {
+				t.Fatalf("failed to get StepAction: %v", err)
+			}
+			if d := cmp.Diff(gitCloneStepAction.Spec, sa.Spec); d != "" {
+				t.Errorf("StepAction spec did not match: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+
+	if _, err := getStepAction(ctx, &v1beta1.StepRef{Name: "missing"}); err == nil {
+		t.Error("expected an error getting a missing StepAction")
+	}
+}
+
+func TestResolveStepActions(t *testing.T) {
+	getStepAction := func(_ context.Context, ref *v1beta1.StepRef) (*v1alpha1.StepAction, error) {
+		if ref.Name != gitCloneStepAction.Name {
+			return nil, errors.New("not found")
+		}
+		return gitCloneStepAction, nil
+	}
+	ts := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name:       "clone",
+				WorkingDir: "$(params.url)",
+			},
+			Ref: &v1beta1.StepRef{Name: "git-clone"},
+			Params: []v1beta1.Param{{
+				Name:  "url",
+				Value: *v1beta1.NewArrayOrString("$(params.repo-url)"),
+			}, {
+				Name:  "flags",
+				Value: *v1beta1.NewArrayOrString("--depth", "10", "--quiet"),
+			}},
+		}, {
+			Container: corev1.Container{
+				Name:  "build",
+				Image: "golang",
+			},
+			Script: "go build ./...",
+		}},
+	}
+	want := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{
+				Name:       "clone",
+				Image:      "alpine/git",
+				Args:       []string{"--depth", "10", "--quiet"},
+				WorkingDir: "$(params.url)",
+				Env: []corev1.EnvVar{{
+					Name:  "REVISION",
+					Value: "main",
+				}},
+			},
+			Script:  "git clone $(params.repo-url) . && git rev-parse HEAD > $(step.results.commit.path)",
+			Results: []v1beta1.StepResult{{Name: "commit"}},
+		}, ts.Steps[1]},
+	}
+	wantProvenance := []v1beta1.StepProvenance{{
+		StepName:        "clone",
+		Name:            "git-clone",
+		UID:             "stepaction-uid",
+		ResourceVersion: "1",
+	}}
+
+	got, provenance, err := resources.ResolveStepActions(context.Background(), ts, getStepAction)
+	if err != nil {
+		t.Fatalf("ResolveStepActions() = %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("resolved TaskSpec did not match: %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(wantProvenance, provenance); d != "" {
+		t.Errorf("StepProvenance did not match: %s", diff.PrintWantGot(d))
+	}
+	if ts.Steps[0].Ref == nil {
+		t.Error("ResolveStepActions() modified the given TaskSpec")
+	}
+}
+
+func TestResolveStepActionsErrors(t *testing.T) {
+	getStepAction := func(_ context.Context, ref *v1beta1.StepRef) (*v1alpha1.StepAction, error) {
+		if ref.Name != gitCloneStepAction.Name {
+			return nil, errors.New("not found")
+		}
+		return gitCloneStepAction, nil
+	}
+	for _, tc := range []struct {
+		name string
+		step v1beta1.Step
+	}{{
+		name: "missing StepAction",
+		step: v1beta1.Step{
+			Container: corev1.Container{Name: "clone"},
+			Ref:       &v1beta1.StepRef{Name: "missing"},
+		},
+	}, {
+		name: "missing required param",
+		step: v1beta1.Step{
+			Container: corev1.Container{Name: "clone"},
+			Ref:       &v1beta1.StepRef{Name: "git-clone"},
+		},
+	}, {
+		name: "undeclared param",
+		step: v1beta1.Step{
+			Container: corev1.Container{Name: "clone"},
+			Ref:       &v1beta1.StepRef{Name: "git-clone"},
+			Params: []v1beta1.Param{{
+				Name:  "url",
+				Value: *v1beta1.NewArrayOrString("https://example.com/repo"),
+			}, {
+				Name:  "submodules",
+				Value: *v1beta1.NewArrayOrString("true"),
+			}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := &v1beta1.TaskSpec{Steps: []v1beta1.Step{tc.step}}
+			if _, _, err := resources.ResolveStepActions(context.Background(), ts, getStepAction); err == nil {
+				t.Error("expected an error resolving the StepActions")
+			}
+		})
+	}
+}
@@ -26,8 +26,11 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/remote"
 	"github.com/tektoncd/pipeline/pkg/remote/oci"
+	"github.com/tektoncd/pipeline/pkg/remote/resolvers"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -43,6 +46,25 @@ func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset
 	}
 
 	switch {
+	case tr != nil && tr.HasResolver():
+		// Return an inline function that implements GetTask by asking the named resolver for the task and casting
+		// it to a TaskObject.
+		return func(ctx context.Context, name string) (v1beta1.TaskObject, error) {
+			resolver, err := resolvers.Resolver(ctx, tr.Resolver, tr.ParamsMap(), remote.Options{
+				Namespace:          namespace,
+				ServiceAccountName: saName,
+				KubeClient:         k8s,
+				TektonClient:       tekton,
+			})
+			if err != nil {
+				return nil, err
+			}
+			obj, err := resolver.Get(strings.ToLower(string(kind)), name)
+			if err != nil {
+				return nil, &remote.ResolutionError{Resolver: tr.Resolver, Err: err}
+			}
+			return readRuntimeObjectAsTask(ctx, obj)
+		}, kind, nil
 	case cfg.FeatureFlags.EnableTektonOCIBundles && tr != nil && tr.Bundle != "":
 		// Return an inline function that implements GetTask by calling Resolver.Get with the specified task type and
 		// casting it to a TaskObject.
@@ -63,27 +85,7 @@ func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset
 			if err != nil {
 				return nil, err
 			}
-
-			// If the resolved object is already a v1beta1.{Cluster}Task, it should be returnable as a
-			// v1beta1.TaskObject.
-			if ti, ok := obj.(v1beta1.TaskObject); ok {
-				return ti, nil
-			}
-
-			// If this object is not already a v1beta1 object, figure out what type it is actually and try to coerce it
-			// into a v1beta1.TaskInterface compatible object.
-			switch tt := obj.(type) {
-			case *v1alpha1.Task:
-				betaTask := &v1beta1.Task{}
-				err := tt.ConvertTo(ctx, betaTask)
-				return betaTask, err
-			case *v1alpha1.ClusterTask:
-				betaTask := &v1beta1.ClusterTask{}
-				err := tt.ConvertTo(ctx, betaTask)
-				return betaTask, err
-			}
-
-			return nil, fmt.Errorf("failed to convert obj %s into Task", obj.GetObjectKind().GroupVersionKind().String())
+			return readRuntimeObjectAsTask(ctx, obj)
 		}, kind, nil
 	default:
 		// Even if there is no task ref, we should try to return a local resolver.
@@ -96,6 +98,31 @@ func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset
 	}
 }
 
+// readRuntimeObjectAsTask coerces a resolved object into a v1beta1.TaskObject, converting v1alpha1 Tasks and
+// ClusterTasks if needed.
+func readRuntimeObjectAsTask(ctx context.Context, obj runtime.Object) (v1beta1.TaskObject, error) {
+	// If the resolved object is already a v1beta1.{Cluster}Task, it should be returnable as a
+	// v1beta1.TaskObject.
+	if ti, ok := obj.(v1beta1.TaskObject); ok {
+		return ti, nil
+	}
+
+	// If this object is not already a v1beta1 object, figure out what type it is actually and try to coerce it
+	// into a v1beta1.TaskInterface compatible object.
+	switch tt := obj.(type) {
+	case *v1alpha1.Task:
+		betaTask := &v1beta1.Task{}
+		err := tt.ConvertTo(ctx, betaTask)
+		return betaTask, err
+	case *v1alpha1.ClusterTask:
+		betaTask := &v1beta1.ClusterTask{}
+		err := tt.ConvertTo(ctx, betaTask)
+		return betaTask, err
+	}
+
+	return nil, fmt.Errorf("failed to convert obj %s into Task", obj.GetObjectKind().GroupVersionKind().String())
+}
+
 // LocalTaskRefResolver uses the current cluster to resolve a task reference.
 type LocalTaskRefResolver struct {
 	Namespace    string
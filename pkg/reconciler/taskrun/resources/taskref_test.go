@@ -30,13 +30,16 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"github.com/tektoncd/pipeline/pkg/remote"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
 	logtesting "knative.dev/pkg/logging/testing"
 )
 
@@ -125,7 +128,8 @@ func TestGetTaskFunc(t *testing.T) {
 	cfg.OnConfigChanged(&corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName()},
 		Data: map[string]string{
-			"enable-tekton-oci-bundles": "true",
+			"enable-tekton-oci-bundles":     "true",
+			"enable-git-and-http-resolvers": "true",
 		},
 	})
 	ctx = cfg.ToContext(ctx)
@@ -208,6 +212,26 @@ func TestGetTaskFunc(t *testing.T) {
 			},
 			expected:     tb.ClusterTask("simple", tb.ClusterTaskType, tb.ClusterTaskSpec(tb.Step("something"))),
 			expectedKind: v1beta1.ClusterTaskKind,
+		}, {
+			name: "cluster-resolver-task",
+			localTasks: []runtime.Object{
+				tb.Task("simple", tb.TaskType, tb.TaskNamespace("default")),
+				tb.Task("simple", tb.TaskType, tb.TaskNamespace("other"), tb.TaskSpec(tb.Step("something"))),
+			},
+			ref: &v1beta1.TaskRef{
+				ResolverRef: v1beta1.ResolverRef{
+					Resolver: "cluster",
+					Params: []v1beta1.Param{{
+						Name:  "name",
+						Value: *v1beta1.NewArrayOrString("simple"),
+					}, {
+						Name:  "namespace",
+						Value: *v1beta1.NewArrayOrString("other"),
+					}},
+				},
+			},
+			expected:     tb.Task("simple", tb.TaskType, tb.TaskNamespace("other"), tb.TaskSpec(tb.Step("something"))),
+			expectedKind: v1beta1.NamespacedTaskKind,
 		},
 	}
 
@@ -220,6 +244,10 @@ func TestGetTaskFunc(t *testing.T) {
 					Name:      "default",
 				},
 			})
+			// Allow the service account of the run to read the other namespace of the cluster resolver.
+			kubeclient.PrependReactor("create", "subjectaccessreviews", func(ktesting.Action) (bool, runtime.Object, error) {
+				return true, &authorizationv1.SubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: true}}, nil
+			})
 
 			_, err := test.CreateImage(u.Host+"/"+tc.name, tc.remoteTasks...)
 			if err != nil {
@@ -246,3 +274,35 @@ func TestGetTaskFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTaskFunc_ResolutionError(t *testing.T) {
+	ctx := context.Background()
+	tektonclient := fake.NewSimpleClientset()
+	kubeclient := fakek8s.NewSimpleClientset()
+	ref := &v1beta1.TaskRef{
+		ResolverRef: v1beta1.ResolverRef{
+			Resolver: "cluster",
+			Params: []v1beta1.Param{{
+				Name:  "name",
+				Value: *v1beta1.NewArrayOrString("missing"),
+			}},
+		},
+	}
+
+	fn, _, err := resources.GetTaskFunc(ctx, kubeclient, tektonclient, ref, "default", "default")
+	if err != nil {
+		t.Fatalf("failed to get task fn: %s", err.Error())
+	}
+	if _, err := fn(ctx, ref.Name); !remote.IsResolutionError(err) {
+		t.Errorf("expected a resolution error but got %v", err)
+	}
+
+	ref.Resolver = "unknown"
+	fn, _, err = resources.GetTaskFunc(ctx, kubeclient, tektonclient, ref, "default", "default")
+	if err != nil {
+		t.Fatalf("failed to get task fn: %s", err.Error())
+	}
+	if _, err := fn(ctx, ref.Name); !remote.IsResolutionError(err) {
+		t.Errorf("expected a resolution error but got %v", err)
+	}
+}
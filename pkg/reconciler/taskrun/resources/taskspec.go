@@ -39,7 +39,7 @@ func GetTaskData(ctx context.Context, taskRun *v1beta1.TaskRun, getTask GetTask)
 	taskMeta := metav1.ObjectMeta{}
 	taskSpec := v1beta1.TaskSpec{}
 	switch {
-	case taskRun.Spec.TaskRef != nil && taskRun.Spec.TaskRef.Name != "":
+	case taskRun.Spec.TaskRef != nil && (taskRun.Spec.TaskRef.Name != "" || taskRun.Spec.TaskRef.HasResolver()):
 		// Get related task for taskrun
 		t, err := getTask(ctx, taskRun.Spec.TaskRef.Name)
 		if err != nil {
@@ -45,6 +45,8 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
+	"github.com/tektoncd/pipeline/pkg/remote"
+	"github.com/tektoncd/pipeline/pkg/sidecarlogresults"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -91,6 +93,12 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 	// Read the initial condition
 	before := tr.Status.GetCondition(apis.ConditionSucceeded)
 
+	// A pending TaskRun is not started until its spec status is cleared.
+	if !tr.HasStarted() && tr.IsPending() {
+		tr.Status.MarkResourceOngoing(v1beta1.TaskRunReasonPending, fmt.Sprintf("TaskRun %q is pending", tr.Name))
+		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, nil)
+	}
+
 	// If the TaskRun is just starting, this will also set the starttime,
 	// from which the timeout will immediately begin counting down.
 	if !tr.HasStarted() {
@@ -147,6 +155,10 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 			if err != nil {
 				logger.Warnf("Failed to log the metrics : %v", err)
 			}
+			err = metrics.StepResourceUsage(tr)
+			if err != nil {
+				logger.Warnf("Failed to log the metrics : %v", err)
+			}
 		}(c.metrics)
 		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, nil)
 	}
@@ -276,9 +288,29 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 	taskMeta, taskSpec, err := resources.GetTaskData(ctx, tr, getTaskfunc)
 	if err != nil {
 		logger.Errorf("Failed to determine Task spec to use for taskrun %s: %v", tr.Name, err)
+		if remote.IsResolutionError(err) && remote.IsTransientError(err) {
+			// Retry later, the TaskRun fails once it times out.
+			return nil, nil, err
+		}
+		if remote.IsResolutionError(err) {
+			tr.Status.MarkResourceFailed(podconvert.ReasonFailedRemoteResolution, err)
+		} else {
+			tr.Status.MarkResourceFailed(podconvert.ReasonFailedResolution, err)
+		}
+		return nil, nil, controller.NewPermanentError(err)
+	}
+
+	getStepActionFunc := resources.GetStepActionFunc(c.KubeClientSet, c.PipelineClientSet, tr.Namespace, tr.Spec.ServiceAccountName)
+	taskSpec, stepProvenance, err := resources.ResolveStepActions(ctx, taskSpec, getStepActionFunc)
+	if err != nil {
+		logger.Errorf("Failed to resolve the StepActions of taskrun %s: %v", tr.Name, err)
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedResolution, err)
 		return nil, nil, controller.NewPermanentError(err)
 	}
+	// Only store the provenance of the StepActions once, like the TaskSpec.
+	if tr.Status.StepProvenance == nil {
+		tr.Status.StepProvenance = stepProvenance
+	}
 
 	// Store the fetched TaskSpec on the TaskRun for auditing
 	if err := storeTaskSpec(ctx, tr, taskSpec); err != nil {
@@ -335,6 +367,18 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 		return nil, nil, controller.NewPermanentError(err)
 	}
 
+	if err := ValidateResultTypes(ctx, taskSpec); err != nil {
+		logger.Errorf("TaskRun %q results are invalid: %v", tr.Name, err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
+		return nil, nil, controller.NewPermanentError(err)
+	}
+
+	if err := ValidateOverrides(taskSpec, &tr.Spec); err != nil {
+		logger.Errorf("TaskRun %q overrides are invalid: %v", tr.Name, err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
+		return nil, nil, controller.NewPermanentError(err)
+	}
+
 	if err := c.updateTaskRunWithDefaultWorkspaces(ctx, tr, taskSpec); err != nil {
 		logger.Errorf("Failed to update taskrun %s with default workspace: %v", tr.Name, err)
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedResolution, err)
@@ -447,12 +491,32 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 		}
 	}
 
+	// Read the results from the logs of the results sidecar, if the Pod has one
+	// and it is done printing them.
+	var sidecarLogResults []v1beta1.PipelineResourceResult
+	if podconvert.IsResultsSidecarTerminated(pod) {
+		sidecarLogResults, err = sidecarlogresults.GetResultsFromSidecarLogs(ctx, c.KubeClientSet, tr.Namespace, pod.Name, podconvert.ResultsSidecarContainerName)
+		if errors.Is(err, sidecarlogresults.ErrInvalidResults) {
+			logger.Errorf("Failed to read the results of taskrun %q from sidecar logs: %v", tr.Name, err)
+			tr.Status.MarkResourceFailed(podconvert.ReasonFailedSidecarLogResults, err)
+			return controller.NewPermanentError(err)
+		} else if err != nil {
+			return err
+		}
+	}
+
 	// Convert the Pod's status to the equivalent TaskRun Status.
-	tr.Status, err = podconvert.MakeTaskRunStatus(logger, *tr, pod)
+	tr.Status, err = podconvert.MakeTaskRunStatus(logger, *tr, pod, sidecarLogResults)
 	if err != nil {
 		return err
 	}
 
+	// Fail the TaskRun instead of waiting for its timeout when the image of a
+	// step can't be pulled, so that the failure can be retried.
+	if message, failed := podconvert.ImagePullFailure(pod); failed {
+		return c.failTaskRun(ctx, tr, v1beta1.TaskRunReasonImagePullFailed, message)
+	}
+
 	logger.Infof("Successfully reconciled taskrun %s/%s with status: %#v", tr.Name, tr.Namespace, tr.Status.GetCondition(apis.ConditionSucceeded))
 	return nil
 }
@@ -651,6 +715,7 @@ func (c *Reconciler) createPod(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 
 	// Apply task result substitution
 	ts = resources.ApplyTaskResults(ts)
+	ts = resources.ApplyStepResults(ts)
 
 	ts, err = workspace.Apply(*ts, tr.Spec.Workspaces, workspaceVolumes)
 	if err != nil {
@@ -40,6 +40,8 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
+	"github.com/tektoncd/pipeline/pkg/remote"
+	"github.com/tektoncd/pipeline/pkg/remote/resolvers"
 	"github.com/tektoncd/pipeline/pkg/version"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	"github.com/tektoncd/pipeline/test"
@@ -1693,12 +1695,71 @@ func TestReconcile_DoesntChangeStartTime(t *testing.T) {
 	}
 }
 
+// unavailableResolver is a remote.Resolver whose server is unavailable.
+type unavailableResolver struct{}
+
+func (unavailableResolver) List() ([]remote.ResolvedObject, error) {
+	return nil, &remote.TransientError{Err: errors.New("server unavailable")}
+}
+
+func (unavailableResolver) Get(string, string) (runtime.Object, error) {
+	return nil, &remote.TransientError{Err: errors.New("server unavailable")}
+}
+
+func TestReconcileTaskRunWithTransientResolutionError(t *testing.T) {
+	resolvers.Default().Register("unavailable", func(map[string]string, remote.Options) (remote.Resolver, error) {
+		return unavailableResolver{}, nil
+	})
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun-with-unavailable-resolver", Namespace: "foo"},
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name:        "task",
+				ResolverRef: v1beta1.ResolverRef{Resolver: "unavailable"},
+			},
+		},
+	}
+	d := test.Data{TaskRuns: []*v1beta1.TaskRun{tr}}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+
+	// The TaskRun is retried instead of failed.
+	reconcileErr := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr))
+	if reconcileErr == nil {
+		t.Fatal("Expected to see an error when reconciling the TaskRun but none")
+	}
+	if controller.IsPermanentError(reconcileErr) {
+		t.Fatalf("Expected to see a transient error when reconciling the TaskRun, got %s instead", reconcileErr)
+	}
+	newTr, err := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns(tr.Namespace).Get(testAssets.Ctx, tr.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", tr.Name, err)
+	}
+	if condition := newTr.Status.GetCondition(apis.ConditionSucceeded); condition == nil || condition.Status != corev1.ConditionUnknown {
+		t.Errorf("Expected the TaskRun to still be running, but had %v", condition)
+	}
+}
+
 func TestReconcileInvalidTaskRuns(t *testing.T) {
 	noTaskRun := tb.TaskRun("notaskrun", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(tb.TaskRunTaskRef("notask")))
 	withWrongRef := tb.TaskRun("taskrun-with-wrong-ref", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(
 		tb.TaskRunTaskRef("taskrun-with-wrong-ref", tb.TaskRefKind(v1beta1.ClusterTaskKind)),
 	))
-	taskRuns := []*v1beta1.TaskRun{noTaskRun, withWrongRef}
+	withFailingResolver := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun-with-failing-resolver", Namespace: "foo"},
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				ResolverRef: v1beta1.ResolverRef{
+					Resolver: "cluster",
+					Params: []v1beta1.Param{{
+						Name:  "name",
+						Value: *v1beta1.NewArrayOrString("missing"),
+					}},
+				},
+			},
+		},
+	}
+	taskRuns := []*v1beta1.TaskRun{noTaskRun, withWrongRef, withFailingResolver}
 	tasks := []*v1beta1.Task{simpleTask}
 
 	d := test.Data{
@@ -1729,6 +1790,15 @@ func TestReconcileInvalidTaskRuns(t *testing.T) {
 			"Warning Failed",
 			"Warning InternalError",
 		},
+	}, {
+		name:    "task run with failing resolver",
+		taskRun: withFailingResolver,
+		reason:  podconvert.ReasonFailedRemoteResolution,
+		wantEvents: []string{
+			"Normal Started",
+			"Warning Failed",
+			"Warning InternalError",
+		},
 	}}
 
 	for _, tc := range testcases {
@@ -1970,6 +2040,59 @@ func TestReconcilePodUpdateStatus(t *testing.T) {
 	}
 }
 
+func TestReconcileImagePullFailure(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun-image-pull", tb.TaskRunNamespace("foo"), tb.TaskRunSpec(tb.TaskRunTaskRef(simpleTask.Name)))
+	pod, err := makePod(taskRun, simpleTask)
+	if err != nil {
+		t.Fatalf("MakePod: %v", err)
+	}
+	pod.Status = corev1.PodStatus{
+		Phase: corev1.PodPending,
+		ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "step-simple-step",
+			Image: "foo",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+				Reason:  "ImagePullBackOff",
+				Message: `Back-off pulling image "foo"`,
+			}},
+		}},
+	}
+	taskRun.Status = v1beta1.TaskRunStatus{
+		TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			PodName: pod.Name,
+		},
+	}
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{taskRun},
+		Tasks:    []*v1beta1.Task{simpleTask},
+		Pods:     []*corev1.Pod{pod},
+	}
+
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+		t.Fatalf("Unexpected error when Reconcile() : %v", err)
+	}
+	newTr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	if d := cmp.Diff(&apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionFalse,
+		Reason:  v1beta1.TaskRunReasonImagePullFailed.String(),
+		Message: `the image "foo" of step "simple-step" couldn't be pulled: Back-off pulling image "foo"`,
+	}, newTr.Status.GetCondition(apis.ConditionSucceeded), ignoreLastTransitionTime); d != "" {
+		t.Errorf("Did not get expected condition %s", diff.PrintWantGot(d))
+	}
+	if _, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, pod.Name, metav1.GetOptions{}); !k8sapierrors.IsNotFound(err) {
+		t.Errorf("Expected the pod of the TaskRun to be deleted, got %v", err)
+	}
+}
+
 func TestReconcileOnCompletedTaskRun(t *testing.T) {
 	taskSt := &apis.Condition{
 		Type:    apis.ConditionSucceeded,
@@ -2052,6 +2175,55 @@ func TestReconcileOnCancelledTaskRun(t *testing.T) {
 	}
 }
 
+func TestReconcileOnPendingTaskRun(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun-run-pending",
+		tb.TaskRunNamespace("foo"),
+		tb.TaskRunSpec(
+			tb.TaskRunTaskRef(simpleTask.Name),
+			tb.TaskRunPending,
+		))
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{taskRun},
+		Tasks:    []*v1beta1.Task{simpleTask},
+	}
+
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+		t.Fatalf("Unexpected error when reconciling pending TaskRun : %v", err)
+	}
+	newTr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected pending TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+
+	expectedStatus := &apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionUnknown,
+		Reason:  "TaskRunPending",
+		Message: `TaskRun "test-taskrun-run-pending" is pending`,
+	}
+	if d := cmp.Diff(expectedStatus, newTr.Status.GetCondition(apis.ConditionSucceeded), ignoreLastTransitionTime); d != "" {
+		t.Fatalf("Did not get expected condition %s", diff.PrintWantGot(d))
+	}
+	if newTr.Status.StartTime != nil {
+		t.Errorf("Expected a pending TaskRun not to have a StartTime, but was %v", newTr.Status.StartTime)
+	}
+	if newTr.Status.PodName != "" {
+		t.Errorf("Expected no Pod to be created for a pending TaskRun, but got %s", newTr.Status.PodName)
+	}
+	pods, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).List(testAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Error listing Pods: %v", err)
+	}
+	if len(pods.Items) != 0 {
+		t.Errorf("Expected no Pod to be created for a pending TaskRun, but got %d", len(pods.Items))
+	}
+}
+
 func TestReconcileTimeouts(t *testing.T) {
 	type testCase struct {
 		name           string
@@ -2635,6 +2807,124 @@ func TestReconcileValidDefaultWorkspace(t *testing.T) {
 	}
 }
 
+func TestReconcileStepActions(t *testing.T) {
+	stepAction := &resourcev1alpha1.StepAction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "git-clone",
+			Namespace: "foo",
+		},
+		Spec: resourcev1alpha1.StepActionSpec{
+			Image:  "alpine/git",
+			Script: "git clone $(params.url) . && git rev-parse HEAD > $(step.results.commit.path)",
+			Params: []resourcev1alpha1.ParamSpec{{
+				Name: "url",
+				Type: resourcev1alpha1.ParamTypeString,
+			}},
+			Results: []v1beta1.StepResult{{Name: "commit"}},
+		},
+	}
+	taskRun := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-taskrun-step-action",
+			Namespace: "foo",
+		},
+		Spec: v1beta1.TaskRunSpec{
+			TaskSpec: &v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{
+					Container: corev1.Container{Name: "clone"},
+					Ref:       &v1beta1.StepRef{Name: "git-clone"},
+					Params: []v1beta1.Param{{
+						Name:  "url",
+						Value: *v1beta1.NewArrayOrString("https://example.com/repo"),
+					}},
+				}},
+			},
+		},
+	}
+	d := test.Data{
+		TaskRuns:    []*v1beta1.TaskRun{taskRun},
+		StepActions: []*resourcev1alpha1.StepAction{stepAction},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	clients := testAssets.Clients
+	if _, err := clients.Kube.CoreV1().ServiceAccounts(taskRun.Namespace).Create(testAssets.Ctx, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default",
+			Namespace: taskRun.Namespace,
+		},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+		t.Errorf("expected no error. Got error %v", err)
+	}
+
+	tr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting updated taskrun: %v", err)
+	}
+	wantProvenance := []v1beta1.StepProvenance{{
+		StepName: "clone",
+		Name:     "git-clone",
+	}}
+	if d := cmp.Diff(wantProvenance, tr.Status.StepProvenance, cmpopts.IgnoreFields(v1beta1.StepProvenance{}, "ResourceVersion")); d != "" {
+		t.Errorf("StepProvenance diff %s", diff.PrintWantGot(d))
+	}
+	wantScript := "git clone https://example.com/repo . && git rev-parse HEAD > $(step.results.commit.path)"
+	if got := tr.Status.TaskSpec.Steps[0].Script; got != wantScript {
+		t.Errorf("stored step script = %q, want %q", got, wantScript)
+	}
+
+	pod, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, tr.Status.PodName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting pod: %v", err)
+	}
+	step := pod.Spec.Containers[0]
+	if step.Image != "alpine/git" {
+		t.Errorf("step image = %q, want %q", step.Image, "alpine/git")
+	}
+	if !strings.Contains(strings.Join(step.Args, " "), "-step_results commit -step_results_dir /tekton/steps/clone/results") {
+		t.Errorf("expected the step results flags in the step args, got %v", step.Args)
+	}
+}
+
+func TestReconcileStepActionNotFound(t *testing.T) {
+	taskRun := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-taskrun-missing-step-action",
+			Namespace: "foo",
+		},
+		Spec: v1beta1.TaskRunSpec{
+			TaskSpec: &v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{
+					Container: corev1.Container{Name: "clone"},
+					Ref:       &v1beta1.StepRef{Name: "git-clone"},
+				}},
+			},
+		},
+	}
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{taskRun},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	clients := testAssets.Clients
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); !controller.IsPermanentError(err) {
+		t.Fatalf("Expected to see a permanent error when reconciling the TaskRun, got %v instead", err)
+	}
+	tr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting updated taskrun: %v", err)
+	}
+	condition := tr.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != podconvert.ReasonFailedResolution {
+		t.Errorf("expected the TaskRun to fail with reason %s, got %v", podconvert.ReasonFailedResolution, condition)
+	}
+}
+
 // TestReconcileInvalidDefaultWorkspace tests a reconcile of a TaskRun that does
 // not include a Workspace that the Task is expecting, and gets an error updating
 // the TaskRun with an invalid default workspace.
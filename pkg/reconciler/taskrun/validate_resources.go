@@ -17,6 +17,7 @@ limitations under the License.
 package taskrun
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
@@ -67,10 +68,14 @@ func validateResources(requiredResources []v1beta1.TaskResource, providedResourc
 func validateParams(paramSpecs []v1beta1.ParamSpec, params []v1beta1.Param) error {
 	var neededParams []string
 	paramTypes := make(map[string]v1beta1.ParamType)
+	objectParamSpecs := make(map[string]v1beta1.ParamSpec)
 	neededParams = make([]string, 0, len(paramSpecs))
 	for _, inputResourceParam := range paramSpecs {
 		neededParams = append(neededParams, inputResourceParam.Name)
 		paramTypes[inputResourceParam.Name] = inputResourceParam.Type
+		if inputResourceParam.Type == v1beta1.ParamTypeObject {
+			objectParamSpecs[inputResourceParam.Name] = inputResourceParam
+		}
 	}
 	providedParams := make([]string, 0, len(params))
 	for _, param := range params {
@@ -105,6 +110,15 @@ func validateParams(paramSpecs []v1beta1.ParamSpec, params []v1beta1.Param) erro
 		return fmt.Errorf("param types don't match the user-specified type: %s", wrongTypeParamNames)
 	}
 
+	// Make sure each object param provides all the keys declared in the properties of its ParamSpec.
+	for _, param := range params {
+		if paramSpec, ok := objectParamSpecs[param.Name]; ok {
+			if missingKeys := paramSpec.MissingObjectKeys(param.Value.ObjectVal); len(missingKeys) != 0 {
+				return fmt.Errorf("missing keys for object param %s: %s", param.Name, missingKeys)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -128,3 +142,39 @@ func ValidateResolvedTaskResources(params []v1beta1.Param, rtr *resources.Resolv
 
 	return nil
 }
+
+// ValidateOverrides validates that the steps and sidecars overridden by the
+// TaskRun exist in the Task
+func ValidateOverrides(ts *v1beta1.TaskSpec, trs *v1beta1.TaskRunSpec) error {
+	stepNames := map[string]bool{}
+	for _, s := range ts.Steps {
+		stepNames[s.Name] = true
+	}
+	for _, o := range trs.StepOverrides {
+		if !stepNames[o.Name] {
+			return fmt.Errorf("invalid StepOverride: no step named %q", o.Name)
+		}
+	}
+	sidecarNames := map[string]bool{}
+	for _, s := range ts.Sidecars {
+		sidecarNames[s.Name] = true
+	}
+	for _, o := range trs.SidecarOverrides {
+		if !sidecarNames[o.Name] {
+			return fmt.Errorf("invalid SidecarOverride: no sidecar named %q", o.Name)
+		}
+	}
+	return nil
+}
+
+// ValidateResultTypes validates that the types of the results of the Task are
+// allowed by the feature flags: a Task fetched from a bundle or by a resolver
+// isn't validated by the webhook.
+func ValidateResultTypes(ctx context.Context, ts *v1beta1.TaskSpec) error {
+	for i, r := range ts.Results {
+		if err := v1beta1.ValidateResultType(ctx, r.Type); err != nil {
+			return err.ViaField("type").ViaFieldIndex("results", i)
+		}
+	}
+	return nil
+}
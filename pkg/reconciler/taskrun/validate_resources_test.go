@@ -17,13 +17,16 @@ limitations under the License.
 package taskrun_test
 
 import (
+	"context"
 	"testing"
 
 	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestValidateResolvedTaskResources_ValidResources(t *testing.T) {
@@ -111,6 +114,24 @@ func TestValidateResolvedTaskResources_InvalidParams(t *testing.T) {
 			Name:  "extra",
 			Value: *v1beta1.NewArrayOrString("i am an extra param"),
 		}},
+	}, {
+		name: "missing-object-keys",
+		rtr: &resources.ResolvedTaskResources{
+			TaskSpec: &v1beta1.TaskSpec{
+				Params: []v1beta1.ParamSpec{{
+					Name: "myobject",
+					Type: v1beta1.ParamTypeObject,
+					Properties: map[string]v1beta1.PropertySpec{
+						"key1": {Type: v1beta1.ParamTypeString},
+						"key2": {Type: v1beta1.ParamTypeString},
+					},
+				}},
+			},
+		},
+		params: []v1beta1.Param{{
+			Name:  "myobject",
+			Value: *v1beta1.NewObject(map[string]string{"key1": "val1"}),
+		}},
 	}}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -251,3 +272,76 @@ func TestValidateResolvedTaskResources_InvalidResources(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateOverrides(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Container: corev1.Container{Name: "step1"},
+		}, {
+			Container: corev1.Container{Name: "step2"},
+		}},
+		Sidecars: []v1beta1.Sidecar{{
+			Container: corev1.Container{Name: "sidecar1"},
+		}},
+	}
+	tcs := []struct {
+		name    string
+		trs     *v1beta1.TaskRunSpec
+		wantErr bool
+	}{{
+		name: "no overrides",
+		trs:  &v1beta1.TaskRunSpec{},
+	}, {
+		name: "valid overrides",
+		trs: &v1beta1.TaskRunSpec{
+			StepOverrides:    []v1beta1.TaskRunStepOverride{{Name: "step2"}},
+			SidecarOverrides: []v1beta1.TaskRunSidecarOverride{{Name: "sidecar1"}},
+		},
+	}, {
+		name: "missing step",
+		trs: &v1beta1.TaskRunSpec{
+			StepOverrides: []v1beta1.TaskRunStepOverride{{Name: "step1"}, {Name: "step3"}},
+		},
+		wantErr: true,
+	}, {
+		name: "missing sidecar",
+		trs: &v1beta1.TaskRunSpec{
+			SidecarOverrides: []v1beta1.TaskRunSidecarOverride{{Name: "step1"}},
+		},
+		wantErr: true,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := taskrun.ValidateOverrides(ts, tc.trs)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateOverrides() = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateResultTypes(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Results: []v1beta1.TaskResult{{Name: "commit"}, {Name: "files", Type: v1beta1.ResultsTypeArray}},
+	}
+	tcs := []struct {
+		name         string
+		featureFlags config.FeatureFlags
+		wantErr      bool
+	}{{
+		name:         "array and object results enabled",
+		featureFlags: config.FeatureFlags{EnableArrayAndObjectResults: true},
+	}, {
+		name:    "array and object results disabled",
+		wantErr: true,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := config.ToContext(context.Background(), &config.Config{FeatureFlags: &tc.featureFlags})
+			err := taskrun.ValidateResultTypes(ctx, ts)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateResultTypes() = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2021 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Cache holds resolved objects for a limited time, so that repeated reconciles of the same run do not fetch the
+// same resource from the remote location again.
+type Cache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	obj     runtime.Object
+	expires time.Time
+}
+
+// NewCache returns a Cache whose entries expire after the given ttl.
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{ttl: ttl, now: time.Now, entries: map[string]cacheEntry{}}
+}
+
+// Get returns a copy of the object stored under key, if it has not expired yet.
+func (c *Cache) Get(key string) (runtime.Object, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if c.now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.obj.DeepCopyObject(), true
+}
+
+// Set stores a copy of the object under key. Expired entries are evicted on every call.
+func (c *Cache) Set(key string, obj runtime.Object) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for k, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cacheEntry{obj: obj.DeepCopyObject(), expires: now.Add(c.ttl)}
+}
+
+// CacheKey builds a stable cache key from the kind and name of a requested object and the params identifying
+// its remote location.
+func CacheKey(kind, name string, params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := []string{kind, name}
+	for _, k := range keys {
+		parts = append(parts, k+"="+params[k])
+	}
+	return strings.Join(parts, "\x00")
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/remote"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ResolverName is the name used to select this resolver in a TaskRef or PipelineRef.
+	ResolverName = "cluster"
+	// NameParam is the name of the resource to fetch. It defaults to the name requested by the reference.
+	NameParam = "name"
+	// NamespaceParam is the namespace to fetch the resource from. It defaults to the namespace of the run.
+	NamespaceParam = "namespace"
+
+	defaultTimeout = 10 * time.Second
+	cacheTTL       = 30 * time.Second
+)
+
+var cache = remote.NewCache(cacheTTL)
+
+// Resolver implements the Resolver interface by fetching Tasks, ClusterTasks and Pipelines from any namespace of
+// the cluster the controller runs in. As the controller fetches them with its own credentials, resources of another
+// namespace than the one of the run are only returned if the service account of the run is allowed to get them.
+type Resolver struct {
+	name           string
+	namespace      string
+	runNamespace   string
+	serviceAccount string
+	kube           kubernetes.Interface
+	tekton         clientset.Interface
+	timeout        time.Duration
+	cache          *remote.Cache
+}
+
+// NewResolver returns a cluster resolver for the given params. It is a remote.Factory.
+func NewResolver(params map[string]string, opts remote.Options) (remote.Resolver, error) {
+	r := &Resolver{
+		name:           params[NameParam],
+		namespace:      params[NamespaceParam],
+		runNamespace:   opts.Namespace,
+		serviceAccount: opts.ServiceAccountName,
+		kube:           opts.KubeClient,
+		tekton:         opts.TektonClient,
+		timeout:        defaultTimeout,
+		cache:          cache,
+	}
+	if r.namespace == "" {
+		r.namespace = opts.Namespace
+	}
+	if r.serviceAccount == "" {
+		r.serviceAccount = "default"
+	}
+	if r.tekton == nil {
+		return nil, fmt.Errorf("no Tekton client available")
+	}
+	return r, nil
+}
+
+// List is not supported by the cluster resolver, as it would expose every resource of the namespace.
+func (r *Resolver) List() ([]remote.ResolvedObject, error) {
+	return nil, fmt.Errorf("listing resources is not supported")
+}
+
+func (r *Resolver) Get(kind, name string) (runtime.Object, error) {
+	if r.name != "" {
+		name = r.name
+	}
+	if name == "" {
+		return nil, fmt.Errorf("missing required param %q", NameParam)
+	}
+	kind = strings.ToLower(kind)
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	if err := r.authorize(ctx, kind, name); err != nil {
+		return nil, err
+	}
+
+	key := remote.CacheKey(kind, name, map[string]string{NamespaceParam: r.namespace})
+	if obj, ok := r.cache.Get(key); ok {
+		return obj, nil
+	}
+	obj, err := r.get(ctx, kind, name)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.Set(key, obj)
+	return obj, nil
+}
+
+func (r *Resolver) get(ctx context.Context, kind, name string) (runtime.Object, error) {
+	if kind == "clustertask" {
+		return r.tekton.TektonV1beta1().ClusterTasks().Get(ctx, name, metav1.GetOptions{})
+	}
+	if r.namespace == "" {
+		return nil, fmt.Errorf("missing required param %q", NamespaceParam)
+	}
+	switch kind {
+	case "task":
+		return r.tekton.TektonV1beta1().Tasks(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	case "pipeline":
+		return r.tekton.TektonV1beta1().Pipelines(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	return nil, fmt.Errorf("unsupported kind %q", kind)
+}
+
+// authorize checks, with a SubjectAccessReview, that the service account of the run may get the resource. ClusterTasks
+// and resources of the namespace of the run can be referenced without a resolver, so they aren't checked.
+func (r *Resolver) authorize(ctx context.Context, kind, name string) error {
+	if kind == "clustertask" || r.namespace == "" || r.namespace == r.runNamespace {
+		return nil
+	}
+	if r.kube == nil {
+		return fmt.Errorf("no Kubernetes client available to check access to namespace %q", r.namespace)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   fmt.Sprintf("system:serviceaccount:%s:%s", r.runNamespace, r.serviceAccount),
+			Groups: []string{"system:serviceaccounts", "system:serviceaccounts:" + r.runNamespace, "system:authenticated"},
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: r.namespace,
+				Verb:      "get",
+				Group:     "tekton.dev",
+				Resource:  kind + "s",
+				Name:      name,
+			},
+		},
+	}
+	review, err := r.kube.AuthorizationV1().SubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("could not check access to %s %q in namespace %q: %w", kind, name, r.namespace, err)
+	}
+	if !review.Status.Allowed {
+		return fmt.Errorf("service account %q of namespace %q is not allowed to get %s %q in namespace %q", r.serviceAccount, r.runNamespace, kind, name, r.namespace)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/pipeline/pkg/remote"
+	"github.com/tektoncd/pipeline/pkg/remote/cluster"
+	"github.com/tektoncd/pipeline/test/diff"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+)
+
+func TestClusterResolver(t *testing.T) {
+	tektonclient := fake.NewSimpleClientset(
+		tb.Task("build", tb.TaskNamespace("shared")),
+		tb.Task("build", tb.TaskNamespace("default"), tb.TaskSpec(tb.Step("local"))),
+		tb.ClusterTask("lint"),
+		tb.Pipeline("release", tb.PipelineNamespace("shared")),
+	)
+
+	// Only the default service account of the default namespace may read the shared namespace.
+	kubeclient := fakek8s.NewSimpleClientset()
+	kubeclient.PrependReactor("create", "subjectaccessreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+		review := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.User == "system:serviceaccount:default:default" && review.Spec.ResourceAttributes.Namespace == "shared"
+		return true, review, nil
+	})
+
+	testcases := []struct {
+		name           string
+		params         map[string]string
+		serviceAccount string
+		kind           string
+		refName        string
+		expected       runtime.Object
+		wantErr        bool
+	}{{
+		name:     "task-in-other-namespace",
+		params:   map[string]string{cluster.NameParam: "build", cluster.NamespaceParam: "shared"},
+		kind:     "task",
+		expected: tb.Task("build", tb.TaskNamespace("shared")),
+	}, {
+		name:     "task-in-run-namespace",
+		params:   map[string]string{},
+		kind:     "task",
+		refName:  "build",
+		expected: tb.Task("build", tb.TaskNamespace("default"), tb.TaskSpec(tb.Step("local"))),
+	}, {
+		name:     "cluster-task",
+		params:   map[string]string{cluster.NameParam: "lint"},
+		kind:     "clustertask",
+		expected: tb.ClusterTask("lint"),
+	}, {
+		name:     "pipeline",
+		params:   map[string]string{cluster.NameParam: "release", cluster.NamespaceParam: "shared"},
+		kind:     "pipeline",
+		expected: tb.Pipeline("release", tb.PipelineNamespace("shared")),
+	}, {
+		name:           "service-account-not-allowed",
+		params:         map[string]string{cluster.NameParam: "build", cluster.NamespaceParam: "shared"},
+		serviceAccount: "builder",
+		kind:           "task",
+		wantErr:        true,
+	}, {
+		name:    "missing-name",
+		params:  map[string]string{cluster.NamespaceParam: "shared"},
+		kind:    "task",
+		wantErr: true,
+	}, {
+		name:    "not-found",
+		params:  map[string]string{cluster.NameParam: "missing", cluster.NamespaceParam: "shared"},
+		kind:    "task",
+		wantErr: true,
+	}, {
+		name:    "unsupported-kind",
+		params:  map[string]string{cluster.NameParam: "build", cluster.NamespaceParam: "shared"},
+		kind:    "condition",
+		wantErr: true,
+	}}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver, err := cluster.NewResolver(tc.params, remote.Options{
+				Namespace:          "default",
+				ServiceAccountName: tc.serviceAccount,
+				KubeClient:         kubeclient,
+				TektonClient:       tektonclient,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error building resolver: %v", err)
+			}
+			obj, err := resolver.Get(tc.kind, tc.refName)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.expected, obj); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ResolutionError is returned when a named resolver fails to fetch a referenced resource.
+type ResolutionError struct {
+	Resolver string
+	Err      error
+}
+
+func (e *ResolutionError) Error() string {
+	return fmt.Sprintf("%s resolver: %v", e.Resolver, e.Err)
+}
+
+func (e *ResolutionError) Unwrap() error {
+	return e.Err
+}
+
+// IsResolutionError returns true if the error, or any error it wraps, is a ResolutionError.
+func IsResolutionError(err error) bool {
+	var re *ResolutionError
+	return errors.As(err, &re)
+}
+
+// TransientError is returned by a resolver when it failed to fetch a resource for a reason which may go away on its
+// own, such as an unreachable or overloaded server. Runs referencing the resource are retried instead of failed.
+type TransientError struct {
+	Err error
+}
+
+func (e *TransientError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TransientError) Unwrap() error {
+	return e.Err
+}
+
+// IsTransientError returns true if the error, or any error it wraps, is a TransientError, a timeout or a Kubernetes
+// API error that may succeed when retried. Errors reporting a missing or invalid resource are never transient.
+func IsTransientError(err error) bool {
+	var te *TransientError
+	if errors.As(err, &te) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return true
+	}
+	var se *apierrors.StatusError
+	if errors.As(err, &se) {
+		return apierrors.IsTimeout(se) || apierrors.IsServerTimeout(se) || apierrors.IsTooManyRequests(se) ||
+			apierrors.IsInternalError(se) || apierrors.IsServiceUnavailable(se) || apierrors.IsUnexpectedServerError(se)
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsTransientError(t *testing.T) {
+	tasks := schema.GroupResource{Group: "tekton.dev", Resource: "tasks"}
+	for _, tc := range []struct {
+		name string
+		err  error
+		want bool
+	}{{
+		name: "transient error",
+		err:  &ResolutionError{Resolver: "http", Err: &TransientError{Err: errors.New("unexpected status 503")}},
+		want: true,
+	}, {
+		name: "timeout",
+		err:  &ResolutionError{Resolver: "git", Err: fmt.Errorf("git fetch timed out: %w", context.DeadlineExceeded)},
+		want: true,
+	}, {
+		name: "api server unavailable",
+		err:  &ResolutionError{Resolver: "cluster", Err: apierrors.NewServiceUnavailable("try again")},
+		want: true,
+	}, {
+		name: "not found",
+		err:  &ResolutionError{Resolver: "cluster", Err: apierrors.NewNotFound(tasks, "build")},
+	}, {
+		name: "forbidden",
+		err:  &ResolutionError{Resolver: "cluster", Err: apierrors.NewForbidden(tasks, "build", errors.New("denied"))},
+	}, {
+		name: "invalid content",
+		err:  &ResolutionError{Resolver: "http", Err: errors.New("could not parse contents as a Tekton resource")},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsTransientError(tc.err); got != tc.want {
+				t.Errorf("IsTransientError(%v) = %t, want %t", tc.err, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/remote"
+)
+
+// AllowFileURLs lets the tests of the package fetch from local repositories through file:// urls.
+func AllowFileURLs(t *testing.T) {
+	t.Helper()
+	previous := allowedSchemes
+	allowedSchemes = append(append([]string{}, previous...), "file")
+	t.Cleanup(func() { allowedSchemes = previous })
+}
+
+func TestReadFile_URLIsNotParsedAsOption(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	AllowFileURLs(t)
+	dir, err := ioutil.TempDir("", "git-resolver-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	marker := filepath.Join(dir, "marker")
+
+	// Bypass NewResolver, which rejects such a url, to check that git never parses it as an option.
+	r := &Resolver{
+		url:      "--upload-pack=touch " + marker,
+		revision: defaultRevision,
+		path:     "task.yaml",
+		timeout:  10 * time.Second,
+		cache:    remote.NewCache(time.Minute),
+	}
+	if _, err := r.readFile(); err == nil {
+		t.Error("expected an error fetching from an invalid url")
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Errorf("expected the upload-pack command not to run, but %s exists", marker)
+	}
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2021 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/remote"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// ResolverName is the name used to select this resolver in a TaskRef or PipelineRef.
+	ResolverName = "git"
+	// URLParam is the https, ssh or git url of the repository to fetch. The scp-like syntax of ssh urls,
+	// e.g. git@github.com:tektoncd/catalog.git, is accepted too.
+	URLParam = "url"
+	// RevisionParam is the branch, tag or commit to read the file from. It defaults to the remote HEAD.
+	RevisionParam = "revision"
+	// PathParam is the path of the file holding the resource, relative to the root of the repository.
+	PathParam = "pathInRepo"
+
+	defaultRevision = "HEAD"
+	defaultTimeout  = time.Minute
+	cacheTTL        = 5 * time.Minute
+)
+
+var (
+	cache = remote.NewCache(cacheTTL)
+
+	// allowedSchemes are the transports git may use to fetch a repository. Local paths and file urls are not
+	// allowed as they would expose the filesystem of the controller, nor are remote helpers such as ext::.
+	allowedSchemes = []string{"https", "ssh", "git"}
+
+	// scpLikeURL matches the scp-like syntax of ssh urls, e.g. git@github.com:tektoncd/catalog.git.
+	scpLikeURL = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9][A-Za-z0-9.-]*:`)
+
+	// transientFailure matches the messages git prints when the server is unreachable or overloaded, as opposed
+	// to a missing repository or revision, so that the fetch is retried later.
+	transientFailure = regexp.MustCompile(`Connection timed out|Connection reset|Operation timed out|early EOF|The requested URL returned error: (5\d\d|429)`)
+)
+
+// Resolver implements the Resolver interface by reading a single file from a git repository.
+type Resolver struct {
+	url      string
+	revision string
+	path     string
+	timeout  time.Duration
+	cache    *remote.Cache
+}
+
+// NewResolver returns a git resolver for the given params. It is a remote.Factory.
+func NewResolver(params map[string]string, _ remote.Options) (remote.Resolver, error) {
+	for _, name := range []string{URLParam, RevisionParam, PathParam} {
+		// A leading dash would be parsed as an option by git.
+		if strings.HasPrefix(params[name], "-") {
+			return nil, fmt.Errorf("invalid param %q: must not start with \"-\"", name)
+		}
+	}
+	r := &Resolver{
+		url:      params[URLParam],
+		revision: params[RevisionParam],
+		path:     params[PathParam],
+		timeout:  defaultTimeout,
+		cache:    cache,
+	}
+	if r.url == "" {
+		return nil, fmt.Errorf("missing required param %q", URLParam)
+	}
+	if err := validateURL(r.url); err != nil {
+		return nil, err
+	}
+	if r.path == "" {
+		return nil, fmt.Errorf("missing required param %q", PathParam)
+	}
+	if r.revision == "" {
+		r.revision = defaultRevision
+	}
+	return r, nil
+}
+
+// validateURL checks that the url of the repository uses one of the allowed schemes.
+func validateURL(u string) error {
+	if scpLikeURL.MatchString(u) {
+		return checkScheme("ssh")
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return fmt.Errorf("invalid param %q: %w", URLParam, err)
+	}
+	if strings.HasPrefix(parsed.Host, "-") {
+		return fmt.Errorf("invalid param %q: host must not start with \"-\"", URLParam)
+	}
+	return checkScheme(parsed.Scheme)
+}
+
+func checkScheme(scheme string) error {
+	for _, s := range allowedSchemes {
+		if scheme == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid param %q: scheme must be one of %s", URLParam, strings.Join(allowedSchemes, ", "))
+}
+
+func (r *Resolver) List() ([]remote.ResolvedObject, error) {
+	obj, err := r.fetch()
+	if err != nil {
+		return nil, err
+	}
+	ro, err := remote.DescribeObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	return []remote.ResolvedObject{ro}, nil
+}
+
+func (r *Resolver) Get(kind, name string) (runtime.Object, error) {
+	obj, err := r.fetch()
+	if err != nil {
+		return nil, err
+	}
+	if err := remote.CheckObject(obj, kind, name); err != nil {
+		return nil, fmt.Errorf("%s in %s at %s: %w", r.path, r.url, r.revision, err)
+	}
+	return obj, nil
+}
+
+// fetch returns the object stored in the file, from the cache if it was read recently.
+func (r *Resolver) fetch() (runtime.Object, error) {
+	key := remote.CacheKey("", "", map[string]string{URLParam: r.url, RevisionParam: r.revision, PathParam: r.path})
+	if obj, ok := r.cache.Get(key); ok {
+		return obj, nil
+	}
+
+	contents, err := r.readFile()
+	if err != nil {
+		return nil, err
+	}
+	obj, err := remote.DecodeObject(contents)
+	if err != nil {
+		return nil, fmt.Errorf("%s in %s at %s: %w", r.path, r.url, r.revision, err)
+	}
+	r.cache.Set(key, obj)
+	return obj, nil
+}
+
+// readFile fetches the revision into a temporary repository and returns the contents of the file at the
+// requested path. A shallow fetch of the revision is tried first; as not every server allows fetching a commit
+// directly, the branches and tags are fetched instead if that fails.
+func (r *Resolver) readFile() ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	dir, err := ioutil.TempDir("", "git-resolver-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := run(ctx, dir, "init", "--quiet"); err != nil {
+		return nil, err
+	}
+	if _, err := run(ctx, dir, "fetch", "--quiet", "--depth=1", "--", r.url, r.revision); err == nil {
+		contents, err := run(ctx, dir, "show", "FETCH_HEAD:"+r.path)
+		if err != nil {
+			return nil, fmt.Errorf("could not find %s in %s at revision %s: %w", r.path, r.url, r.revision, err)
+		}
+		return contents, nil
+	}
+	if _, err := run(ctx, dir, "fetch", "--quiet", "--tags", "--", r.url, "+refs/heads/*:refs/remotes/origin/*"); err != nil {
+		return nil, fmt.Errorf("could not fetch %s: %w", r.url, err)
+	}
+	for _, rev := range []string{r.revision, "origin/" + r.revision} {
+		if contents, err := run(ctx, dir, "show", rev+":"+r.path); err == nil {
+			return contents, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find %s in %s at revision %s", r.path, r.url, r.revision)
+}
+
+func run(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	c := exec.CommandContext(ctx, "git", args...)
+	c.Dir = dir
+	// Never prompt for credentials, the controller has no terminal to answer them. Restrict the transports
+	// to the allowed schemes, including for redirects and submodules.
+	// HOME points to the temporary repository so that git neither needs a home directory for the user the
+	// controller runs as, nor reads any global configuration.
+	c.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ALLOW_PROTOCOL="+strings.Join(allowedSchemes, ":"), "HOME="+dir)
+	var stdout, stderr bytes.Buffer
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	if err := c.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("git %s timed out: %w", args[0], ctx.Err())
+		}
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("no git binary found in the image of the controller: %w", err)
+		}
+		err = fmt.Errorf("git %s failed: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+		if transientFailure.Match(stderr.Bytes()) {
+			return nil, &remote.TransientError{Err: err}
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2021 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/remote"
+	"github.com/tektoncd/pipeline/pkg/remote/git"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+const (
+	mainTask = `apiVersion: tekton.dev/v1beta1
+kind: Task
+metadata:
+  name: simple
+spec:
+  steps:
+  - image: main
+`
+	featureTask = `apiVersion: tekton.dev/v1beta1
+kind: Task
+metadata:
+  name: simple
+spec:
+  steps:
+  - image: feature
+`
+)
+
+// setupRepo creates a repository holding task.yaml in two revisions: the first commit on the default branch and
+// a second commit on the "feature" branch. It returns the path of the repository and the sha of the first commit.
+func setupRepo(t *testing.T) (string, string) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "git-resolver-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	gitCmd := func(args ...string) string {
+		t.Helper()
+		c := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		c.Dir = dir
+		out, err := c.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	writeTask := func(contents string) {
+		t.Helper()
+		if err := ioutil.WriteFile(filepath.Join(dir, "task.yaml"), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gitCmd("init", "--quiet")
+	writeTask(mainTask)
+	gitCmd("add", "task.yaml")
+	gitCmd("commit", "--quiet", "-m", "main")
+	sha := gitCmd("rev-parse", "HEAD")
+	gitCmd("checkout", "--quiet", "-b", "feature")
+	writeTask(featureTask)
+	gitCmd("commit", "--quiet", "-am", "feature")
+	gitCmd("checkout", "--quiet", "-")
+	return "file://" + dir, sha
+}
+
+func TestGitResolver(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	git.AllowFileURLs(t)
+	repo, sha := setupRepo(t)
+
+	testcases := []struct {
+		name      string
+		params    map[string]string
+		kind      string
+		taskName  string
+		wantImage string
+		wantErr   string
+	}{{
+		name:      "default-revision",
+		params:    map[string]string{git.URLParam: repo, git.PathParam: "task.yaml"},
+		kind:      "task",
+		wantImage: "main",
+	}, {
+		name:      "branch",
+		params:    map[string]string{git.URLParam: repo, git.PathParam: "task.yaml", git.RevisionParam: "feature"},
+		kind:      "task",
+		taskName:  "simple",
+		wantImage: "feature",
+	}, {
+		name:      "commit",
+		params:    map[string]string{git.URLParam: repo, git.PathParam: "task.yaml", git.RevisionParam: sha},
+		kind:      "task",
+		wantImage: "main",
+	}, {
+		name:    "wrong-kind",
+		params:  map[string]string{git.URLParam: repo, git.PathParam: "task.yaml"},
+		kind:    "pipeline",
+		wantErr: `resolved object has kind "task" but "pipeline" was requested`,
+	}, {
+		name:     "wrong-name",
+		params:   map[string]string{git.URLParam: repo, git.PathParam: "task.yaml"},
+		kind:     "task",
+		taskName: "other",
+		wantErr:  `resolved object has name "simple" but "other" was requested`,
+	}, {
+		name:    "missing-file",
+		params:  map[string]string{git.URLParam: repo, git.PathParam: "missing.yaml"},
+		kind:    "task",
+		wantErr: "could not find missing.yaml",
+	}, {
+		name:    "missing-repo",
+		params:  map[string]string{git.URLParam: repo + "/missing", git.PathParam: "task.yaml"},
+		kind:    "task",
+		wantErr: "could not fetch",
+	}}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver, err := git.NewResolver(tc.params, remote.Options{})
+			if err != nil {
+				t.Fatalf("unexpected error building resolver: %v", err)
+			}
+			obj, err := resolver.Get(tc.kind, tc.taskName)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q but got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			task, ok := obj.(*v1beta1.Task)
+			if !ok {
+				t.Fatalf("expected a Task but got %T", obj)
+			}
+			if d := cmp.Diff(tc.wantImage, task.Spec.Steps[0].Image); d != "" {
+				t.Errorf("unexpected step image %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGitResolver_List(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	git.AllowFileURLs(t)
+	repo, _ := setupRepo(t)
+
+	resolver, err := git.NewResolver(map[string]string{git.URLParam: repo, git.PathParam: "task.yaml", git.RevisionParam: "feature"}, remote.Options{})
+	if err != nil {
+		t.Fatalf("unexpected error building resolver: %v", err)
+	}
+	got, err := resolver.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []remote.ResolvedObject{{Kind: "task", APIVersion: "v1beta1", Name: "simple"}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
+
+func TestNewGitResolver_MissingParams(t *testing.T) {
+	for _, params := range []map[string]string{
+		{git.PathParam: "task.yaml"},
+		{git.URLParam: "https://example.com/repo.git"},
+	} {
+		if _, err := git.NewResolver(params, remote.Options{}); err == nil {
+			t.Errorf("expected an error for params %v", params)
+		}
+	}
+}
+
+func TestNewGitResolver_InvalidParams(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		params  map[string]string
+		wantErr string
+	}{{
+		name:    "url starting with a dash",
+		params:  map[string]string{git.URLParam: "--upload-pack=touch /tmp/pwned", git.PathParam: "task.yaml"},
+		wantErr: `invalid param "url": must not start with "-"`,
+	}, {
+		name:    "revision starting with a dash",
+		params:  map[string]string{git.URLParam: "https://example.com/repo.git", git.RevisionParam: "--upload-pack=touch /tmp/pwned", git.PathParam: "task.yaml"},
+		wantErr: `invalid param "revision": must not start with "-"`,
+	}, {
+		name:    "path starting with a dash",
+		params:  map[string]string{git.URLParam: "https://example.com/repo.git", git.PathParam: "--output=/tmp/pwned"},
+		wantErr: `invalid param "pathInRepo": must not start with "-"`,
+	}, {
+		name:    "local path",
+		params:  map[string]string{git.URLParam: "/var/run/repo", git.PathParam: "task.yaml"},
+		wantErr: "scheme must be one of https, ssh, git",
+	}, {
+		name:    "file url",
+		params:  map[string]string{git.URLParam: "file:///var/run/repo", git.PathParam: "task.yaml"},
+		wantErr: "scheme must be one of https, ssh, git",
+	}, {
+		name:    "remote helper",
+		params:  map[string]string{git.URLParam: "ext::sh -c touch% /tmp/pwned", git.PathParam: "task.yaml"},
+		wantErr: "scheme must be one of https, ssh, git",
+	}, {
+		name:    "plain http",
+		params:  map[string]string{git.URLParam: "http://example.com/repo.git", git.PathParam: "task.yaml"},
+		wantErr: "scheme must be one of https, ssh, git",
+	}, {
+		name:    "ssh host starting with a dash",
+		params:  map[string]string{git.URLParam: "ssh://-oProxyCommand=touch/repo.git", git.PathParam: "task.yaml"},
+		wantErr: `host must not start with "-"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := git.NewResolver(tc.params, remote.Options{})
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q but got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewGitResolver_AllowedURLs(t *testing.T) {
+	for _, u := range []string{
+		"https://github.com/tektoncd/catalog.git",
+		"ssh://git@github.com/tektoncd/catalog.git",
+		"git://github.com/tektoncd/catalog.git",
+		"git@github.com:tektoncd/catalog.git",
+	} {
+		if _, err := git.NewResolver(map[string]string{git.URLParam: u, git.PathParam: "task.yaml"}, remote.Options{}); err != nil {
+			t.Errorf("unexpected error for url %q: %v", u, err)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/remote"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// ResolverName is the name used to select this resolver in a TaskRef or PipelineRef.
+	ResolverName = "http"
+	// URLParam is the http or https url of the file holding the resource.
+	URLParam = "url"
+
+	// MaximumFileSize is the largest file, in bytes, that will be read from the url.
+	MaximumFileSize = 1 << 20
+
+	defaultTimeout = 30 * time.Second
+	cacheTTL       = 5 * time.Minute
+)
+
+var cache = remote.NewCache(cacheTTL)
+
+// Resolver implements the Resolver interface by downloading a single file over http.
+type Resolver struct {
+	url     string
+	client  *http.Client
+	timeout time.Duration
+	cache   *remote.Cache
+}
+
+// NewResolver returns an http resolver for the given params. It is a remote.Factory.
+func NewResolver(params map[string]string, _ remote.Options) (remote.Resolver, error) {
+	u := params[URLParam]
+	if u == "" {
+		return nil, fmt.Errorf("missing required param %q", URLParam)
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return nil, fmt.Errorf("invalid param %q: %w", URLParam, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("invalid param %q: scheme must be http or https", URLParam)
+	}
+	return &Resolver{url: u, client: http.DefaultClient, timeout: defaultTimeout, cache: cache}, nil
+}
+
+func (r *Resolver) List() ([]remote.ResolvedObject, error) {
+	obj, err := r.fetch()
+	if err != nil {
+		return nil, err
+	}
+	ro, err := remote.DescribeObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	return []remote.ResolvedObject{ro}, nil
+}
+
+func (r *Resolver) Get(kind, name string) (runtime.Object, error) {
+	obj, err := r.fetch()
+	if err != nil {
+		return nil, err
+	}
+	if err := remote.CheckObject(obj, kind, name); err != nil {
+		return nil, fmt.Errorf("%s: %w", r.url, err)
+	}
+	return obj, nil
+}
+
+// fetch returns the object stored at the url, from the cache if it was downloaded recently.
+func (r *Resolver) fetch() (runtime.Object, error) {
+	key := remote.CacheKey("", "", map[string]string{URLParam: r.url})
+	if obj, ok := r.cache.Get(key); ok {
+		return obj, nil
+	}
+
+	contents, err := r.download()
+	if err != nil {
+		return nil, err
+	}
+	obj, err := remote.DecodeObject(contents)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", r.url, err)
+	}
+	r.cache.Set(key, obj)
+	return obj, nil
+}
+
+func (r *Resolver) download() ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not download %s: %w", r.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		return nil, &remote.TransientError{Err: fmt.Errorf("could not download %s: unexpected status %s", r.url, resp.Status)}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not download %s: unexpected status %s", r.url, resp.Status)
+	}
+	contents, err := ioutil.ReadAll(io.LimitReader(resp.Body, MaximumFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", r.url, err)
+	}
+	if len(contents) > MaximumFileSize {
+		return nil, fmt.Errorf("%s is larger than the maximum of %d bytes", r.url, MaximumFileSize)
+	}
+	return contents, nil
+}
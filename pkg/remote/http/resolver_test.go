@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/remote"
+	resolverhttp "github.com/tektoncd/pipeline/pkg/remote/http"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+const pipelineYAML = `apiVersion: tekton.dev/v1beta1
+kind: Pipeline
+metadata:
+  name: simple
+spec:
+  tasks:
+  - name: build
+    taskRef:
+      name: build
+`
+
+func TestHTTPResolver(t *testing.T) {
+	var requests int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		switch r.URL.Path {
+		case "/pipeline.yaml":
+			fmt.Fprint(w, pipelineYAML)
+		case "/large.yaml":
+			fmt.Fprint(w, strings.Repeat("#", resolverhttp.MaximumFileSize+1))
+		case "/garbage.yaml":
+			fmt.Fprint(w, "not a tekton resource")
+		case "/unavailable.yaml":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer s.Close()
+
+	testcases := []struct {
+		name          string
+		path          string
+		kind          string
+		wantErr       string
+		wantTransient bool
+	}{{
+		name: "pipeline",
+		path: "/pipeline.yaml",
+		kind: "pipeline",
+	}, {
+		name:    "wrong-kind",
+		path:    "/pipeline.yaml",
+		kind:    "task",
+		wantErr: `resolved object has kind "pipeline" but "task" was requested`,
+	}, {
+		name:    "not-found",
+		path:    "/missing.yaml",
+		kind:    "pipeline",
+		wantErr: "unexpected status 404 Not Found",
+	}, {
+		name:    "too-large",
+		path:    "/large.yaml",
+		kind:    "pipeline",
+		wantErr: "larger than the maximum",
+	}, {
+		name:    "not-a-resource",
+		path:    "/garbage.yaml",
+		kind:    "pipeline",
+		wantErr: "could not parse contents as a Tekton resource",
+	}, {
+		name:          "unavailable",
+		path:          "/unavailable.yaml",
+		kind:          "pipeline",
+		wantErr:       "unexpected status 503 Service Unavailable",
+		wantTransient: true,
+	}}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver, err := resolverhttp.NewResolver(map[string]string{resolverhttp.URLParam: s.URL + tc.path}, remote.Options{})
+			if err != nil {
+				t.Fatalf("unexpected error building resolver: %v", err)
+			}
+			obj, err := resolver.Get(tc.kind, "")
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q but got %v", tc.wantErr, err)
+				}
+				if remote.IsTransientError(err) != tc.wantTransient {
+					t.Errorf("expected the error to be transient: %t, got %v", tc.wantTransient, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			pipeline, ok := obj.(*v1beta1.Pipeline)
+			if !ok {
+				t.Fatalf("expected a Pipeline but got %T", obj)
+			}
+			if d := cmp.Diff("build", pipeline.Spec.Tasks[0].Name); d != "" {
+				t.Errorf("unexpected pipeline task name %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+
+	// The pipeline was downloaded successfully before, so it is served from the cache this time.
+	before := atomic.LoadInt32(&requests)
+	resolver, err := resolverhttp.NewResolver(map[string]string{resolverhttp.URLParam: s.URL + "/pipeline.yaml"}, remote.Options{})
+	if err != nil {
+		t.Fatalf("unexpected error building resolver: %v", err)
+	}
+	if _, err := resolver.Get("pipeline", "simple"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if after := atomic.LoadInt32(&requests); after != before {
+		t.Errorf("expected the pipeline to be served from the cache but %d requests were made", after-before)
+	}
+}
+
+func TestNewHTTPResolver_InvalidParams(t *testing.T) {
+	for _, params := range []map[string]string{
+		{},
+		{resolverhttp.URLParam: "ftp://example.com/task.yaml"},
+		{resolverhttp.URLParam: "://"},
+	} {
+		if _, err := resolverhttp.NewResolver(params, remote.Options{}); err == nil {
+			t.Errorf("expected an error for params %v", params)
+		}
+	}
+}
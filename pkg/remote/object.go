@@ -0,0 +1,63 @@
+/*
+Copyright 2021 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DecodeObject parses the YAML or JSON contents of a remote file as a Tekton resource.
+func DecodeObject(contents []byte) (runtime.Object, error) {
+	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(contents, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse contents as a Tekton resource: %w", err)
+	}
+	return obj, nil
+}
+
+// DescribeObject returns the kind, apiVersion and name of a resolved object, in the same form as the
+// OCI resolver reports them.
+func DescribeObject(obj runtime.Object) (ResolvedObject, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return ResolvedObject{}, err
+	}
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	return ResolvedObject{
+		Kind:       strings.ToLower(gvk.Kind),
+		APIVersion: gvk.Version,
+		Name:       accessor.GetName(),
+	}, nil
+}
+
+// CheckObject ensures that a resolved object has the requested kind and, when a name is requested, that it has
+// this name. Kinds are compared case-insensitively.
+func CheckObject(obj runtime.Object, kind, name string) error {
+	ro, err := DescribeObject(obj)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(ro.Kind, kind) {
+		return fmt.Errorf("resolved object has kind %q but %q was requested", ro.Kind, kind)
+	}
+	if name != "" && ro.Name != name {
+		return fmt.Errorf("resolved object has name %q but %q was requested", ro.Name, name)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Options holds the context of the run a reference is resolved for, which resolvers may use to locate and
+// authorize access to the referenced resource.
+type Options struct {
+	Namespace          string
+	ServiceAccountName string
+	KubeClient         kubernetes.Interface
+	TektonClient       clientset.Interface
+}
+
+// Factory builds a Resolver for a reference from the params given on that reference.
+type Factory func(params map[string]string, opts Options) (Resolver, error)
+
+// Registry holds the Factory of each resolver which can be named by a TaskRef or PipelineRef.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{factories: map[string]Factory{}}
+}
+
+// Register makes a resolver available under the given name, replacing any resolver previously registered with
+// that name.
+func (r *Registry) Register(name string, factory Factory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[name] = factory
+}
+
+// Names returns the sorted names of all registered resolvers.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolver builds the resolver registered with the given name. It returns a ResolutionError if no such resolver
+// is registered or if the params are rejected by the resolver.
+func (r *Registry) Resolver(name string, params map[string]string, opts Options) (Resolver, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, &ResolutionError{Resolver: name, Err: fmt.Errorf("no resolver registered with this name, known resolvers are %v", r.Names())}
+	}
+	resolver, err := factory(params, opts)
+	if err != nil {
+		return nil, &ResolutionError{Resolver: name, Err: err}
+	}
+	return resolver, nil
+}
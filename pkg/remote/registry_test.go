@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type fakeResolver struct{}
+
+func (fakeResolver) List() ([]ResolvedObject, error)               { return nil, nil }
+func (fakeResolver) Get(kind, name string) (runtime.Object, error) { return nil, nil }
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	r.Register("fake", func(params map[string]string, opts Options) (Resolver, error) {
+		if params["fail"] != "" {
+			return nil, errors.New("bad params")
+		}
+		return fakeResolver{}, nil
+	})
+
+	if d := cmp.Diff([]string{"fake"}, r.Names()); d != "" {
+		t.Errorf("unexpected names %s", diff.PrintWantGot(d))
+	}
+	if _, err := r.Resolver("fake", nil, Options{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"unknown", "fake"} {
+		_, err := r.Resolver(name, map[string]string{"fail": "true"}, Options{})
+		var re *ResolutionError
+		if !errors.As(err, &re) || re.Resolver != name {
+			t.Errorf("expected a ResolutionError for resolver %q but got %v", name, err)
+		}
+	}
+}
+
+func TestCache(t *testing.T) {
+	now := time.Now()
+	c := NewCache(time.Minute)
+	c.now = func() time.Time { return now }
+
+	task := &v1beta1.Task{ObjectMeta: metav1.ObjectMeta{Name: "simple"}}
+	key := CacheKey("task", "simple", map[string]string{"b": "2", "a": "1"})
+	c.Set(key, task)
+
+	// Modifying the stored object or the returned copy must not affect the cache.
+	task.Name = "changed"
+	got, ok := c.Get(CacheKey("task", "simple", map[string]string{"a": "1", "b": "2"}))
+	if !ok {
+		t.Fatal("expected the object to be cached")
+	}
+	if name := got.(*v1beta1.Task).Name; name != "simple" {
+		t.Errorf("expected cached object to be named simple but was %s", name)
+	}
+	got.(*v1beta1.Task).Name = "changed"
+	if got, _ := c.Get(key); got.(*v1beta1.Task).Name != "simple" {
+		t.Error("expected the cached object not to be modified")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := c.Get(key); ok {
+		t.Error("expected the cached object to have expired")
+	}
+}
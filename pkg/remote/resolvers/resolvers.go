@@ -0,0 +1,57 @@
+/*
+Copyright 2021 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resolvers holds the registry of resolvers that TaskRefs and PipelineRefs can name in their resolver field.
+package resolvers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/remote"
+	"github.com/tektoncd/pipeline/pkg/remote/cluster"
+	"github.com/tektoncd/pipeline/pkg/remote/git"
+	"github.com/tektoncd/pipeline/pkg/remote/http"
+)
+
+var registry = NewDefaultRegistry()
+
+// NewDefaultRegistry returns a new Registry holding the built-in git, cluster and http resolvers.
+func NewDefaultRegistry() *remote.Registry {
+	r := remote.NewRegistry()
+	r.Register(git.ResolverName, git.NewResolver)
+	r.Register(cluster.ResolverName, cluster.NewResolver)
+	r.Register(http.ResolverName, http.NewResolver)
+	return r
+}
+
+// Default returns the Registry used by the controllers to resolve references. Additional resolvers can be plugged
+// in by registering them on it before the controllers start.
+func Default() *remote.Registry {
+	return registry
+}
+
+// Resolver builds the named resolver from the Default registry. As they make the controller fetch the url given
+// by the reference, or read other namespaces with its own credentials, the git, http and cluster resolvers are only
+// available when the enable-git-and-http-resolvers feature flag is set.
+func Resolver(ctx context.Context, name string, params map[string]string, opts remote.Options) (remote.Resolver, error) {
+	if isGated(name) && !config.FromContextOrDefaults(ctx).FeatureFlags.EnableGitAndHTTPResolvers {
+		return nil, &remote.ResolutionError{Resolver: name, Err: errors.New(`resolver is disabled, set the "enable-git-and-http-resolvers" feature flag to "true" to enable it`)}
+	}
+	return registry.Resolver(name, params, opts)
+}
+
+func isGated(name string) bool {
+	return name == git.ResolverName || name == http.ResolverName || name == cluster.ResolverName
+}
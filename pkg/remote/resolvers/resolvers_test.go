@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolvers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/pipeline/pkg/remote"
+	"github.com/tektoncd/pipeline/pkg/remote/cluster"
+	"github.com/tektoncd/pipeline/pkg/remote/git"
+	"github.com/tektoncd/pipeline/pkg/remote/http"
+	"github.com/tektoncd/pipeline/pkg/remote/resolvers"
+)
+
+func TestResolver_FeatureFlag(t *testing.T) {
+	params := map[string]string{
+		git.URLParam:  "https://example.com/repo.git",
+		git.PathParam: "task.yaml",
+		"name":        "task",
+	}
+	enabled := config.ToContext(context.Background(), &config.Config{
+		FeatureFlags: &config.FeatureFlags{EnableGitAndHTTPResolvers: true},
+	})
+	for _, tc := range []struct {
+		name        string
+		ctx         context.Context
+		resolver    string
+		wantEnabled bool
+	}{{
+		name:     "git disabled by default",
+		ctx:      context.Background(),
+		resolver: git.ResolverName,
+	}, {
+		name:     "http disabled by default",
+		ctx:      context.Background(),
+		resolver: http.ResolverName,
+	}, {
+		name:     "cluster disabled by default",
+		ctx:      context.Background(),
+		resolver: cluster.ResolverName,
+	}, {
+		name:        "git enabled by the feature flag",
+		ctx:         enabled,
+		resolver:    git.ResolverName,
+		wantEnabled: true,
+	}, {
+		name:        "http enabled by the feature flag",
+		ctx:         enabled,
+		resolver:    http.ResolverName,
+		wantEnabled: true,
+	}, {
+		name:        "cluster enabled by the feature flag",
+		ctx:         enabled,
+		resolver:    cluster.ResolverName,
+		wantEnabled: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := resolvers.Resolver(tc.ctx, tc.resolver, params, remote.Options{TektonClient: fake.NewSimpleClientset()})
+			if tc.wantEnabled && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tc.wantEnabled && !remote.IsResolutionError(err) {
+				t.Fatalf("expected a resolution error but got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sidecarlogresults implements the transport of Task results through
+// the logs of a sidecar, which is not limited by the size of the steps'
+// termination messages.
+package sidecarlogresults
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// MaxResultsSize is the upper bound of the total size of the results a
+	// TaskRun can report through the sidecar logs.
+	MaxResultsSize = 512 * 1024
+
+	waitPollingInterval = 100 * time.Millisecond
+)
+
+// ErrInvalidResults indicates that the logs of the sidecar could not be
+// turned into Task results, either because they are malformed or because they
+// exceed MaxResultsSize.
+var ErrInvalidResults = errors.New("invalid sidecar log results")
+
+// LookForResults waits for the last step of the Task to write its post file
+// (waitFile) and then writes to w, one JSON object per line, the content of
+// each result found in resultsDir.
+func LookForResults(w io.Writer, waitFile, resultsDir string, resultNames []string) error {
+	if waitFile != "" {
+		waitForFile(waitFile)
+	}
+
+	enc := json.NewEncoder(w)
+	for _, name := range resultNames {
+		if name == "" {
+			continue
+		}
+		value, err := ioutil.ReadFile(filepath.Join(resultsDir, name))
+		if os.IsNotExist(err) {
+			// The result was not emitted by any step, skip it
+			continue
+		} else if err != nil {
+			return fmt.Errorf("error reading result %q: %w", name, err)
+		}
+		if err := enc.Encode(v1beta1.PipelineResourceResult{
+			Key:        name,
+			Value:      string(value),
+			ResultType: v1beta1.TaskRunResultType,
+		}); err != nil {
+			return fmt.Errorf("error writing result %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// waitForFile blocks until file, or its ".err" counterpart written when a
+// step fails, exists.
+func waitForFile(file string) {
+	for {
+		if _, err := os.Stat(file); err == nil {
+			return
+		}
+		if _, err := os.Stat(file + ".err"); err == nil {
+			return
+		}
+		time.Sleep(waitPollingInterval)
+	}
+}
+
+// ParseResults reads the results written by LookForResults from r.
+func ParseResults(r io.Reader) ([]v1beta1.PipelineResourceResult, error) {
+	var results []v1beta1.PipelineResourceResult
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), MaxResultsSize+1)
+	size := 0
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if size += len(line); size > MaxResultsSize {
+			return nil, fmt.Errorf("%w: results exceed the maximum allowed size of %d bytes", ErrInvalidResults, MaxResultsSize)
+		}
+		if len(line) == 0 {
+			continue
+		}
+		var result v1beta1.PipelineResourceResult
+		if err := json.Unmarshal(line, &result); err != nil {
+			return nil, fmt.Errorf("%w: could not parse %q: %v", ErrInvalidResults, truncate(string(line)), err)
+		}
+		results = append(results, result)
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, fmt.Errorf("%w: results exceed the maximum allowed size of %d bytes", ErrInvalidResults, MaxResultsSize)
+		}
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetResultsFromSidecarLogs fetches the logs of the given sidecar container and
+// parses them into results. Errors caused by the content of the logs wrap
+// ErrInvalidResults, any other error is transient.
+func GetResultsFromSidecarLogs(ctx context.Context, kubeclient kubernetes.Interface, namespace, podName, container string) ([]v1beta1.PipelineResourceResult, error) {
+	stream, err := kubeclient.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{Container: container}).Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting logs of container %q in Pod %q: %w", container, podName, err)
+	}
+	defer stream.Close()
+	return ParseResults(stream)
+}
+
+func truncate(s string) string {
+	const max = 64
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "..."
+}
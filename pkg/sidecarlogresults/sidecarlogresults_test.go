@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecarlogresults
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestLookForResults(t *testing.T) {
+	dir, err := ioutil.TempDir("", "results")
+	if err != nil {
+		t.Fatalf("unexpected error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	large := strings.Repeat("a", 100*1024)
+	for name, value := range map[string]string{
+		"digest": "sha256:1234",
+		"sbom":   large,
+		"multi":  "line one\nline two",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(value), 0644); err != nil {
+			t.Fatalf("unexpected error writing result: %v", err)
+		}
+	}
+	// The last step failed, so it wrote the ".err" post file.
+	waitFile := filepath.Join(dir, "2")
+	if err := ioutil.WriteFile(waitFile+".err", nil, 0644); err != nil {
+		t.Fatalf("unexpected error writing post file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := LookForResults(&buf, waitFile, dir, []string{"digest", "missing", "sbom", "multi"}); err != nil {
+		t.Fatalf("LookForResults() = %v", err)
+	}
+	got, err := ParseResults(&buf)
+	if err != nil {
+		t.Fatalf("ParseResults() = %v", err)
+	}
+	want := []v1beta1.PipelineResourceResult{{
+		Key:        "digest",
+		Value:      "sha256:1234",
+		ResultType: v1beta1.TaskRunResultType,
+	}, {
+		Key:        "sbom",
+		Value:      large,
+		ResultType: v1beta1.TaskRunResultType,
+	}, {
+		Key:        "multi",
+		Value:      "line one\nline two",
+		ResultType: v1beta1.TaskRunResultType,
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("results diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestParseResultsErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		logs string
+	}{{
+		name: "not json",
+		logs: "some log line\n",
+	}, {
+		name: "single result too large",
+		logs: `{"key":"sbom","value":"` + strings.Repeat("a", MaxResultsSize) + `","type":"TaskRunResult"}` + "\n",
+	}, {
+		name: "results too large together",
+		logs: strings.Repeat(`{"key":"sbom","value":"`+strings.Repeat("a", MaxResultsSize/4)+`","type":"TaskRunResult"}`+"\n", 5),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseResults(strings.NewReader(tc.logs))
+			if !errors.Is(err, ErrInvalidResults) {
+				t.Errorf("ParseResults() = %v, want an error wrapping %v", err, ErrInvalidResults)
+			}
+		})
+	}
+}
@@ -130,6 +130,41 @@ func ValidateVariableIsolatedP(value, prefix string, vars sets.String) *apis.Fie
 	return nil
 }
 
+// Verifies that variables referencing the objects in objectKeys use one of the keys declared for the object
+// (e.g. "$(params.foo.bar)"). If allowIsolated is true, an object may also be referenced as a whole
+// (e.g. "$(params.foo[*])") as long as the reference is completely isolated.
+func ValidateVariableObjectKeysP(value, prefix string, objectKeys map[string]sets.String, allowIsolated bool) *apis.FieldError {
+	vs, present := extractFullVariablesFromString(value, prefix)
+	if !present {
+		return nil
+	}
+	for _, v := range vs {
+		parts := strings.SplitN(strings.TrimSuffix(v, "[*]"), ".", 2)
+		keys, isObject := objectKeys[parts[0]]
+		if !isObject {
+			continue
+		}
+		if len(parts) == 1 {
+			if firstMatch, _ := extractExpressionFromString(value, prefix); allowIsolated && len(vs) == 1 && len(value) == len(firstMatch) {
+				continue
+			}
+			return &apis.FieldError{
+				Message: fmt.Sprintf("object variable must reference one of its keys in %q", value),
+				// Empty path is required to make the `ViaField`, … work
+				Paths: []string{""},
+			}
+		}
+		if !keys.Has(parts[1]) {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("non-existent object key in %q", value),
+				// Empty path is required to make the `ViaField`, … work
+				Paths: []string{""},
+			}
+		}
+	}
+	return nil
+}
+
 // Extract a the first full string expressions found (e.g "$(input.params.foo)"). Return
 // "" and false if nothing is found.
 func extractExpressionFromString(s, prefix string) (string, bool) {
@@ -160,6 +195,22 @@ func extractVariablesFromString(s, prefix string) ([]string, bool) {
 	return vars, true
 }
 
+// extractFullVariablesFromString returns the complete variables found in s, including any object keys
+// (e.g. "foo.bar" for "$(params.foo.bar)").
+func extractFullVariablesFromString(s, prefix string) ([]string, bool) {
+	pattern := fmt.Sprintf(braceMatchingRegex, prefix, parameterSubstitution)
+	re := regexp.MustCompile(pattern)
+	matches := re.FindAllStringSubmatch(s, -1)
+	if len(matches) == 0 {
+		return []string{}, false
+	}
+	vars := make([]string, len(matches))
+	for i, match := range matches {
+		vars[i] = matchGroups(match, re)["var"]
+	}
+	return vars, true
+}
+
 func matchGroups(matches []string, pattern *regexp.Regexp) map[string]string {
 	groups := make(map[string]string)
 	for i, name := range pattern.SubexpNames()[1:] {
@@ -202,3 +253,31 @@ func ApplyArrayReplacements(in string, stringReplacements map[string]string, arr
 	// Otherwise return a size-1 array containing the input string with standard stringReplacements applied.
 	return []string{ApplyReplacements(in, stringReplacements)}
 }
+
+// ApplyObjectReplacements returns the object which replaces the input string if the input string only
+// references an object of objectReplacements, either as "$(key)" or "$(key[*])". It returns false if the
+// input string is not such a reference.
+func ApplyObjectReplacements(in string, objectReplacements map[string]map[string]string) (map[string]string, bool) {
+	for k, v := range objectReplacements {
+		if in == fmt.Sprintf("$(%s)", k) || in == fmt.Sprintf("$(%s[*])", k) {
+			object := make(map[string]string, len(v))
+			for key, value := range v {
+				object[key] = value
+			}
+			return object, true
+		}
+	}
+	return nil, false
+}
+
+// ApplyWholeArrayReplacements returns the array which replaces the input string if the input string only
+// references an array of arrayReplacements, either as "$(key)" or "$(key[*])". It returns false if the
+// input string is not such a reference.
+func ApplyWholeArrayReplacements(in string, arrayReplacements map[string][]string) ([]string, bool) {
+	for k, v := range arrayReplacements {
+		if in == fmt.Sprintf("$(%s)", k) || in == fmt.Sprintf("$(%s[*])", k) {
+			return append([]string{}, v...), true
+		}
+	}
+	return nil, false
+}
@@ -254,3 +254,124 @@ func TestApplyArrayReplacements(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateVariableObjectKeysP(t *testing.T) {
+	objectKeys := map[string]sets.String{"git": sets.NewString("url", "revision")}
+	for _, tc := range []struct {
+		name          string
+		input         string
+		allowIsolated bool
+		expectedError *apis.FieldError
+	}{{
+		name:  "declared object key",
+		input: "--url=$(params.git.url) --revision=$(params.git.revision)",
+	}, {
+		name:  "variables which are not objects",
+		input: "--flag=$(params.foo) $(params.bar.baz)",
+	}, {
+		name:          "isolated whole object allowed",
+		input:         "$(params.git[*])",
+		allowIsolated: true,
+	}, {
+		name:  "undeclared object key",
+		input: "--depth=$(params.git.depth)",
+		expectedError: &apis.FieldError{
+			Message: `non-existent object key in "--depth=$(params.git.depth)"`,
+			Paths:   []string{""},
+		},
+	}, {
+		name:  "isolated whole object not allowed",
+		input: "$(params.git[*])",
+		expectedError: &apis.FieldError{
+			Message: `object variable must reference one of its keys in "$(params.git[*])"`,
+			Paths:   []string{""},
+		},
+	}, {
+		name:          "whole object not isolated",
+		input:         "--git=$(params.git[*])",
+		allowIsolated: true,
+		expectedError: &apis.FieldError{
+			Message: `object variable must reference one of its keys in "--git=$(params.git[*])"`,
+			Paths:   []string{""},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := substitution.ValidateVariableObjectKeysP(tc.input, "params", objectKeys, tc.allowIsolated)
+			if d := cmp.Diff(tc.expectedError, got, cmp.AllowUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("ValidateVariableObjectKeysP() error did not match expected error %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestApplyObjectReplacements(t *testing.T) {
+	objectReplacements := map[string]map[string]string{"params.git": {"url": "https://github.com/tektoncd/pipeline"}}
+	for _, tc := range []struct {
+		name           string
+		input          string
+		expectedOutput map[string]string
+		expectedOk     bool
+	}{{
+		name:           "object reference",
+		input:          "$(params.git)",
+		expectedOutput: map[string]string{"url": "https://github.com/tektoncd/pipeline"},
+		expectedOk:     true,
+	}, {
+		name:           "object star reference",
+		input:          "$(params.git[*])",
+		expectedOutput: map[string]string{"url": "https://github.com/tektoncd/pipeline"},
+		expectedOk:     true,
+	}, {
+		name:  "object key reference",
+		input: "$(params.git.url)",
+	}, {
+		name:  "object reference with other content",
+		input: "--git=$(params.git[*])",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			actualOutput, ok := substitution.ApplyObjectReplacements(tc.input, objectReplacements)
+			if ok != tc.expectedOk {
+				t.Errorf("ApplyObjectReplacements() expected ok to be %t but got %t", tc.expectedOk, ok)
+			}
+			if d := cmp.Diff(tc.expectedOutput, actualOutput); d != "" {
+				t.Errorf("ApplyObjectReplacements() output did not match expected value %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestApplyWholeArrayReplacements(t *testing.T) {
+	arrayReplacements := map[string][]string{"tasks.list.results.files": {"a.txt", "b.txt"}}
+	for _, tc := range []struct {
+		name           string
+		input          string
+		expectedOutput []string
+		expectedOk     bool
+	}{{
+		name:           "array reference",
+		input:          "$(tasks.list.results.files)",
+		expectedOutput: []string{"a.txt", "b.txt"},
+		expectedOk:     true,
+	}, {
+		name:           "array star reference",
+		input:          "$(tasks.list.results.files[*])",
+		expectedOutput: []string{"a.txt", "b.txt"},
+		expectedOk:     true,
+	}, {
+		name:  "array element reference",
+		input: "$(tasks.list.results.files[0])",
+	}, {
+		name:  "array reference with other content",
+		input: "--files=$(tasks.list.results.files[*])",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			actualOutput, ok := substitution.ApplyWholeArrayReplacements(tc.input, arrayReplacements)
+			if ok != tc.expectedOk {
+				t.Errorf("ApplyWholeArrayReplacements() expected ok to be %t but got %t", tc.expectedOk, ok)
+			}
+			if d := cmp.Diff(tc.expectedOutput, actualOutput); d != "" {
+				t.Errorf("ApplyWholeArrayReplacements() output did not match expected value %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -84,7 +84,8 @@ func getDeclaredWorkspace(name string, w []v1beta1.WorkspaceDeclaration) (*v1bet
 
 // Apply will update the StepTemplate and Volumes declaration in ts so that the workspaces
 // specified through wb combined with the declared workspaces in ts will be available for
-// all containers in the resulting pod.
+// all containers in the resulting pod. The workspaces used by some Steps or Sidecars are
+// isolated: they are only mounted in the Steps and Sidecars which use them.
 func Apply(ts v1beta1.TaskSpec, wb []v1beta1.WorkspaceBinding, v map[string]corev1.Volume) (*v1beta1.TaskSpec, error) {
 	// If there are no bound workspaces, we don't need to do anything
 	if len(wb) == 0 {
@@ -92,6 +93,12 @@ func Apply(ts v1beta1.TaskSpec, wb []v1beta1.WorkspaceBinding, v map[string]core
 	}
 
 	addedVolumes := sets.NewString()
+	isolatedWorkspaces := getIsolatedWorkspaces(ts)
+	if isolatedWorkspaces.Len() > 0 {
+		// The volume mounts of the Steps and Sidecars are modified, so they
+		// must not be shared with the given TaskSpec.
+		ts = *ts.DeepCopy()
+	}
 
 	// Initialize StepTemplate if it hasn't been already
 	if ts.StepTemplate == nil {
@@ -106,12 +113,26 @@ func Apply(ts v1beta1.TaskSpec, wb []v1beta1.WorkspaceBinding, v map[string]core
 		// Get the volume we should be using for this binding
 		vv := v[wb[i].Name]
 
-		ts.StepTemplate.VolumeMounts = append(ts.StepTemplate.VolumeMounts, corev1.VolumeMount{
+		volumeMount := corev1.VolumeMount{
 			Name:      vv.Name,
 			MountPath: w.GetMountPath(),
 			SubPath:   wb[i].SubPath,
 			ReadOnly:  w.ReadOnly,
-		})
+		}
+		if isolatedWorkspaces.Has(w.Name) {
+			for j := range ts.Steps {
+				if usesWorkspace(ts.Steps[j].Workspaces, w.Name) {
+					ts.Steps[j].VolumeMounts = append(ts.Steps[j].VolumeMounts, volumeMount)
+				}
+			}
+			for j := range ts.Sidecars {
+				if usesWorkspace(ts.Sidecars[j].Workspaces, w.Name) {
+					ts.Sidecars[j].VolumeMounts = append(ts.Sidecars[j].VolumeMounts, volumeMount)
+				}
+			}
+		} else {
+			ts.StepTemplate.VolumeMounts = append(ts.StepTemplate.VolumeMounts, volumeMount)
+		}
 
 		// Only add this volume if it hasn't already been added
 		if !addedVolumes.Has(vv.Name) {
@@ -121,3 +142,29 @@ func Apply(ts v1beta1.TaskSpec, wb []v1beta1.WorkspaceBinding, v map[string]core
 	}
 	return &ts, nil
 }
+
+// getIsolatedWorkspaces returns the names of the workspaces used by any Step or
+// Sidecar of ts.
+func getIsolatedWorkspaces(ts v1beta1.TaskSpec) sets.String {
+	isolated := sets.NewString()
+	for _, step := range ts.Steps {
+		for _, w := range step.Workspaces {
+			isolated.Insert(w.Name)
+		}
+	}
+	for _, sidecar := range ts.Sidecars {
+		for _, w := range sidecar.Workspaces {
+			isolated.Insert(w.Name)
+		}
+	}
+	return isolated
+}
+
+func usesWorkspace(usages []v1beta1.WorkspaceUsage, name string) bool {
+	for _, w := range usages {
+		if w.Name == name {
+			return true
+		}
+	}
+	return false
+}
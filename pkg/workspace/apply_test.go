@@ -509,6 +509,86 @@ func TestApply(t *testing.T) {
 				ReadOnly:  true,
 			}},
 		},
+	}, {
+		name: "isolated workspace only mounted in the steps and sidecars using it",
+		ts: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Name: "scan"},
+			}, {
+				Container:  corev1.Container{Name: "sign"},
+				Workspaces: []v1beta1.WorkspaceUsage{{Name: "signing-key"}},
+			}},
+			Sidecars: []v1beta1.Sidecar{{
+				Container:  corev1.Container{Name: "signer"},
+				Workspaces: []v1beta1.WorkspaceUsage{{Name: "signing-key"}},
+			}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{
+				Name: "source",
+			}, {
+				Name:     "signing-key",
+				ReadOnly: true,
+			}},
+		},
+		workspaces: []v1beta1.WorkspaceBinding{{
+			Name:     "source",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}, {
+			Name: "signing-key",
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "key",
+			},
+		}},
+		expectedTaskSpec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Container: corev1.Container{Name: "scan"},
+			}, {
+				Container: corev1.Container{
+					Name: "sign",
+					VolumeMounts: []corev1.VolumeMount{{
+						Name:      "ws-hvpvf",
+						MountPath: "/workspace/signing-key",
+						ReadOnly:  true,
+					}},
+				},
+				Workspaces: []v1beta1.WorkspaceUsage{{Name: "signing-key"}},
+			}},
+			Sidecars: []v1beta1.Sidecar{{
+				Container: corev1.Container{
+					Name: "signer",
+					VolumeMounts: []corev1.VolumeMount{{
+						Name:      "ws-hvpvf",
+						MountPath: "/workspace/signing-key",
+						ReadOnly:  true,
+					}},
+				},
+				Workspaces: []v1beta1.WorkspaceUsage{{Name: "signing-key"}},
+			}},
+			StepTemplate: &corev1.Container{
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "ws-mnq6l",
+					MountPath: "/workspace/source",
+				}},
+			},
+			Volumes: []corev1.Volume{{
+				Name: "ws-mnq6l",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			}, {
+				Name: "ws-hvpvf",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: "key",
+					},
+				},
+			}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{
+				Name: "source",
+			}, {
+				Name:     "signing-key",
+				ReadOnly: true,
+			}},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			vols := workspace.CreateVolumes(tc.workspaces)
@@ -70,6 +70,7 @@ type Data struct {
 	PipelineResources []*v1alpha1.PipelineResource
 	Conditions        []*v1alpha1.Condition
 	Runs              []*v1alpha1.Run
+	StepActions       []*v1alpha1.StepAction
 	Pods              []*corev1.Pod
 	Namespaces        []*corev1.Namespace
 	ConfigMaps        []*corev1.ConfigMap
@@ -238,6 +239,14 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 			t.Fatal(err)
 		}
 	}
+	// StepActions are fetched by the TaskRun reconciler with the client, so
+	// they are not added to an informer.
+	for _, sa := range d.StepActions {
+		sa := sa.DeepCopy()
+		if _, err := c.Pipeline.TektonV1alpha1().StepActions(sa.Namespace).Create(ctx, sa, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
 	c.Kube.PrependReactor("*", "pods", AddToInformer(t, i.Pod.Informer().GetIndexer()))
 	for _, p := range d.Pods {
 		p := p.DeepCopy() // Avoid assumptions that the informer's copy is modified.
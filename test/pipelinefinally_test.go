@@ -274,14 +274,18 @@ func TestPipelineLevelFinally_OneDAGTaskFailed_InvalidTaskResult_Failure(t *test
 	// finaltaskconsumingdagtask1 has a reference to a task result from failed task
 	// finaltaskconsumingdagtask4 has a reference to a task result from skipped task with when expression
 	expectedSkippedTasks := []v1beta1.SkippedTask{{
-		Name: "dagtask3",
+		Name:   "dagtask3",
+		Reason: v1beta1.ConditionsSkip,
 	}, {
 		Name:            "dagtask4",
+		Reason:          v1beta1.WhenExpressionsSkip,
 		WhenExpressions: we,
 	}, {
-		Name: "finaltaskconsumingdagtask1",
+		Name:   "finaltaskconsumingdagtask1",
+		Reason: v1beta1.MissingResultsSkip,
 	}, {
-		Name: "finaltaskconsumingdagtask4",
+		Name:   "finaltaskconsumingdagtask4",
+		Reason: v1beta1.MissingResultsSkip,
 	}}
 
 	if d := cmp.Diff(pr.Status.SkippedTasks, expectedSkippedTasks); d != "" {